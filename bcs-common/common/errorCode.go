@@ -176,12 +176,16 @@ const (
 	bcs mesos scheduler service module errno name is as a beginning to BcsErrMesosSched*/
 
 	//BcsErrMesosSchedCommon scheduler error code
-	BcsErrMesosSchedCommon           = AdditionErrorCode + 200
-	BcsErrMesosSchedCommonStr        = "scheduler common error"
-	BcsErrMesosSchedResourceExist    = AdditionErrorCode + 201
-	BcsErrMesosSchedResourceExistStr = "resource already exist"
-	BcsErrMesosSchedNotFound         = AdditionErrorCode + 202
-	BcsErrMesosSchedNotFoundStr      = "404 not found"
+	BcsErrMesosSchedCommon              = AdditionErrorCode + 200
+	BcsErrMesosSchedCommonStr           = "scheduler common error"
+	BcsErrMesosSchedResourceExist       = AdditionErrorCode + 201
+	BcsErrMesosSchedResourceExistStr    = "resource already exist"
+	BcsErrMesosSchedNotFound            = AdditionErrorCode + 202
+	BcsErrMesosSchedNotFoundStr         = "404 not found"
+	BcsErrMesosSchedQuotaExceeded       = AdditionErrorCode + 203
+	BcsErrMesosSchedQuotaExceededStr    = "namespace quota exceeded"
+	BcsErrMesosSchedDisruptionDenied    = AdditionErrorCode + 204
+	BcsErrMesosSchedDisruptionDeniedStr = "disruption denied by disruption budget"
 
 	/*Common error code 1401 230~1401 259
 	bcs mesos driver module errno name is as a beginning to BcsErrMesosDriver*/
@@ -207,6 +211,8 @@ const (
 	BcsErrDaemonStopProcessFailedStr    = "failed to stop process"
 	BcsErrDaemonDeleteProcessFailed     = AdditionErrorCode + 263
 	BcsErrDaemonDeleteProcessFailedStr  = "failed to delete process"
+	BcsErrDaemonHeartBeatFailed         = AdditionErrorCode + 264
+	BcsErrDaemonHeartBeatFailedStr      = "failed to heartbeat process"
 
 	/*Common error code 1401 290~1401 319*/
 	//bcs-netservice error code
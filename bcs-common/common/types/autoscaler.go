@@ -43,6 +43,69 @@ type BcsAutoscalerSpec struct {
 	MaxInstance uint
 	//autoscale target metric infos
 	MetricsTarget []*AutoscalerMetricTarget `json:"metrics"`
+	//scheduled min/max instance overrides, the first active schedule takes effect
+	Schedules []*AutoscalerSchedule `json:"schedules,omitempty"`
+	//scale up and scale down rate limits and stabilization windows
+	Behavior *AutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+//AutoscalerSchedule overrides min/max instance for Duration seconds every time Schedule fires
+type AutoscalerSchedule struct {
+	Name string `json:"name"`
+	//cron expression with five fields, such as "0 20 * * 5"
+	Schedule string `json:"schedule"`
+	//time zone of schedule, such as Asia/Shanghai, default local time zone of hpacontroller
+	TimeZone string `json:"timeZone,omitempty"`
+	//seconds the schedule keeps active after it fires
+	Duration int64 `json:"duration"`
+	//min instance when schedule is active, 0 means not override
+	MinInstance uint `json:"minInstance,omitempty"`
+	//max instance when schedule is active, 0 means not override
+	MaxInstance uint `json:"maxInstance,omitempty"`
+}
+
+//AutoscalerBehavior scaling behavior of scale up and scale down directions
+type AutoscalerBehavior struct {
+	ScaleUp   *AutoscalerScalingRules `json:"scaleUp,omitempty"`
+	ScaleDown *AutoscalerScalingRules `json:"scaleDown,omitempty"`
+}
+
+type AutoscalerPolicySelect string
+
+const (
+	//select the policy allows the biggest change, default
+	AutoscalerPolicySelectMax AutoscalerPolicySelect = "Max"
+	//select the policy allows the smallest change
+	AutoscalerPolicySelectMin AutoscalerPolicySelect = "Min"
+	//scaling in this direction is disabled
+	AutoscalerPolicySelectDisabled AutoscalerPolicySelect = "Disabled"
+)
+
+//AutoscalerScalingRules rules of one scaling direction
+type AutoscalerScalingRules struct {
+	//seconds of past recommendations considered, the safest recommendation in window is used:
+	//the lowest for scale up, the highest for scale down
+	StabilizationWindowSeconds int64 `json:"stabilizationWindowSeconds,omitempty"`
+	//which policy is used when there are multiple policies
+	SelectPolicy AutoscalerPolicySelect `json:"selectPolicy,omitempty"`
+	//rate limits of scaling, no limit if empty
+	Policies []*AutoscalerScalingPolicy `json:"policies,omitempty"`
+}
+
+type AutoscalerScalingPolicyType string
+
+const (
+	//change at most Value instances in period
+	AutoscalerScalingPolicyInstances AutoscalerScalingPolicyType = "Instances"
+	//change at most Value percent of instances in period
+	AutoscalerScalingPolicyPercent AutoscalerScalingPolicyType = "Percent"
+)
+
+//AutoscalerScalingPolicy limits the change of instances in PeriodSeconds
+type AutoscalerScalingPolicy struct {
+	Type          AutoscalerScalingPolicyType `json:"type"`
+	Value         uint                        `json:"value"`
+	PeriodSeconds int64                       `json:"periodSeconds"`
 }
 
 type AutoscalerMetricTarget struct {
@@ -53,6 +116,11 @@ type AutoscalerMetricTarget struct {
 	Description string
 	//autoscale metric target value
 	Target *AutoscalerMetricValue
+	//label matchers added to the metric query, only for Taskgroup and External metrics
+	Selector map[string]string `json:"selector,omitempty"`
+	//promql query used instead of the metric name and selector, only for Taskgroup and External metrics.
+	//results of Taskgroup metric query must keep the taskgroup label
+	Query string `json:"query,omitempty"`
 }
 
 type MetricSourceType string
@@ -117,6 +185,40 @@ type BcsAutoscalerStatus struct {
 	CurrentMetrics []*AutoscalerMetricCurrent
 	//target ref status
 	TargetRefStatus string
+	//name of active schedule, empty if no schedule is active
+	ActiveSchedule string `json:"activeSchedule,omitempty"`
+	//the last scaling decision and why it was made
+	LastDecision *AutoscalerDecision `json:"lastDecision,omitempty"`
+	//recommendations in stabilization window
+	Recommendations []*AutoscalerRecommendation `json:"recommendations,omitempty"`
+	//scale events in the longest policy period
+	ScaleEvents []*AutoscalerScaleEvent `json:"scaleEvents,omitempty"`
+}
+
+//AutoscalerDecision records a scaling decision
+type AutoscalerDecision struct {
+	Timestamp       time.Time              `json:"timestamp"`
+	Operator        AutoscalerOperatorType `json:"operator"`
+	CurrentInstance uint                   `json:"currentInstance"`
+	//instance recommended by metrics
+	RecommendInstance uint `json:"recommendInstance"`
+	//instance after schedules, stabilization windows and policies applied
+	DesiredInstance uint `json:"desiredInstance"`
+	//the reasons of each step which changes the instance
+	Reasons []string `json:"reasons"`
+}
+
+//AutoscalerRecommendation instance recommended by metrics at one time
+type AutoscalerRecommendation struct {
+	Timestamp time.Time `json:"timestamp"`
+	Instance  uint      `json:"instance"`
+}
+
+//AutoscalerScaleEvent instance change of one scaling
+type AutoscalerScaleEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	From      uint      `json:"from"`
+	To        uint      `json:"to"`
 }
 
 const (
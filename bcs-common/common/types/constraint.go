@@ -69,4 +69,34 @@ type ConstraintDataItem struct {
 type Constraint struct {
 	IntersectionItem []*ConstraintDataItem `json:"intersectionItem,omitempty"`
 	NodeSelector     map[string]string     `json:"nodeSelector,omitempty"`
+	//Preferred soft constraints, they never filter offers but affect offer scores
+	Preferred []*PreferredConstraint `json:"preferred,omitempty"`
+	//Scorers offer scorers with weight, fit offers are sorted by weighted sum of scores,
+	//default is scorer preferred when Preferred is set, otherwise offers are used by first-fit
+	Scorers []*ScorerPolicy `json:"scorers,omitempty"`
+}
+
+//PreferredConstraint offer fit Preference gets Weight when scoring by scorer preferred,
+//Preference is the same as ConstraintData of IntersectionItem, like LIKE/UNLIKE/GROUPBY/MAXPER
+type PreferredConstraint struct {
+	Weight     int32           `json:"weight"`
+	Preference *ConstraintData `json:"preference"`
+}
+
+const (
+	//OfferScorer_PREFERRED scores offer by weights of fit preferred constraints
+	OfferScorer_PREFERRED = "preferred"
+	//OfferScorer_BINPACK prefers offer with less free resources
+	OfferScorer_BINPACK = "binpack"
+	//OfferScorer_SPREAD prefers offer with more free resources
+	OfferScorer_SPREAD = "spread"
+
+	//PreferredWeight_MAX max weight of preferred constraint
+	PreferredWeight_MAX = 100
+)
+
+//ScorerPolicy offer scorer name and weight, weight is 1 when not set
+type ScorerPolicy struct {
+	Name   string `json:"name"`
+	Weight int32  `json:"weight"`
 }
@@ -35,6 +35,10 @@ type BcsDeploymentSpec struct {
 	// pause allows you to pause the rolling update process when you are in it.
 	// default value is false.
 	PauseDeployment bool `json:"pauseDeployment"`
+
+	// priority and preemption of taskgroups, same as ReplicaControllerSpec
+	Priority   int32             `json:"priority,omitempty"`
+	Preemption *PreemptionPolicy `json:"preemption,omitempty"`
 }
 
 type UpgradeStrategy struct {
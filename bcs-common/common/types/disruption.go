@@ -0,0 +1,161 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import "fmt"
+
+//BcsDisruptionBudget limits the number of taskgroups of selected applications which are voluntarily
+//disrupted at the same time, by taskgroup reschedule, agent drain and deployment rolling update
+type BcsDisruptionBudget struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata"`
+	Spec       DisruptionBudgetSpec `json:"spec"`
+	//Status is calculated when disruption budget is queried
+	Status *DisruptionBudgetStatus `json:"status,omitempty"`
+}
+
+//DisruptionBudgetSpec selector and limit of disruption budget, only one of MinAvailable and MaxUnavailable can be set
+type DisruptionBudgetSpec struct {
+	//Selector selects applications by labels in the namespace of disruption budget
+	Selector map[string]string `json:"selector"`
+	//MinAvailable the number of taskgroups must be still running after disruption
+	MinAvailable *int `json:"minAvailable,omitempty"`
+	//MaxUnavailable the number of taskgroups can be unavailable after disruption
+	MaxUnavailable *int `json:"maxUnavailable,omitempty"`
+}
+
+//DisruptionBudgetStatus current state of taskgroups selected by disruption budget
+type DisruptionBudgetStatus struct {
+	//ExpectedTaskgroups is the number of instances of selected applications
+	ExpectedTaskgroups int `json:"expectedTaskgroups"`
+	//HealthyTaskgroups is the number of running taskgroups of selected applications
+	HealthyTaskgroups int `json:"healthyTaskgroups"`
+	//DesiredHealthy is the minimum number of running taskgroups
+	DesiredHealthy int `json:"desiredHealthy"`
+	//DisruptionsAllowed is the number of taskgroups can be disrupted now
+	DisruptionsAllowed int `json:"disruptionsAllowed"`
+}
+
+//DrainAgentResult result of one agent drain request, drain is finished when no taskgroup is blocked
+type DrainAgentResult struct {
+	InnerIP string `json:"innerIP"`
+	//Evicted taskgroups rescheduled by this request
+	Evicted []string `json:"evicted"`
+	//Blocked taskgroups still on agent, they should be evicted by next drain request
+	Blocked []*DrainBlockedTaskgroup `json:"blocked"`
+}
+
+//DrainBlockedTaskgroup taskgroup not evicted by agent drain and the reason
+type DrainBlockedTaskgroup struct {
+	TaskgroupID string `json:"taskgroupID"`
+	Reason      string `json:"reason"`
+}
+
+//Validate check whether disruption budget definition is valid
+func (in *BcsDisruptionBudget) Validate() error {
+	if in.NameSpace == "" || in.Name == "" {
+		return fmt.Errorf("namespace or name of disruption budget is empty")
+	}
+	if len(in.Spec.Selector) == 0 {
+		return fmt.Errorf("selector of disruption budget is empty")
+	}
+	if (in.Spec.MinAvailable == nil) == (in.Spec.MaxUnavailable == nil) {
+		return fmt.Errorf("one and only one of minAvailable and maxUnavailable must be set")
+	}
+	if (in.Spec.MinAvailable != nil && *in.Spec.MinAvailable < 0) ||
+		(in.Spec.MaxUnavailable != nil && *in.Spec.MaxUnavailable < 0) {
+		return fmt.Errorf("minAvailable or maxUnavailable can not be negative")
+	}
+	return nil
+}
+
+//Selects check whether labels of application match selector of disruption budget
+func (in *BcsDisruptionBudget) Selects(labels map[string]string) bool {
+	if len(in.Spec.Selector) == 0 {
+		return false
+	}
+	for k, v := range in.Spec.Selector {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+//CalculateStatus calculate status of disruption budget by the number of expected and running taskgroups
+func (in *BcsDisruptionBudget) CalculateStatus(expected, healthy int) *DisruptionBudgetStatus {
+	status := &DisruptionBudgetStatus{
+		ExpectedTaskgroups: expected,
+		HealthyTaskgroups:  healthy,
+	}
+	if in.Spec.MinAvailable != nil {
+		status.DesiredHealthy = *in.Spec.MinAvailable
+	} else if in.Spec.MaxUnavailable != nil {
+		status.DesiredHealthy = expected - *in.Spec.MaxUnavailable
+	}
+	if status.DesiredHealthy < 0 {
+		status.DesiredHealthy = 0
+	}
+	if healthy > status.DesiredHealthy {
+		status.DisruptionsAllowed = healthy - status.DesiredHealthy
+	}
+	return status
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsDisruptionBudget) DeepCopyInto(out *BcsDisruptionBudget) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(DisruptionBudgetStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsDisruptionBudget.
+func (in *BcsDisruptionBudget) DeepCopy() *BcsDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionBudgetSpec) DeepCopyInto(out *DisruptionBudgetSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
@@ -0,0 +1,51 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import "testing"
+
+func TestDisruptionBudgetCalculateStatus(t *testing.T) {
+	intPtr := func(i int) *int {
+		return &i
+	}
+	cases := []struct {
+		name            string
+		spec            DisruptionBudgetSpec
+		expected        int
+		healthy         int
+		desiredHealthy  int
+		disruptsAllowed int
+	}{
+		{name: "min available", spec: DisruptionBudgetSpec{MinAvailable: intPtr(3)},
+			expected: 5, healthy: 5, desiredHealthy: 3, disruptsAllowed: 2},
+		{name: "min available not met", spec: DisruptionBudgetSpec{MinAvailable: intPtr(3)},
+			expected: 5, healthy: 2, desiredHealthy: 3, disruptsAllowed: 0},
+		{name: "max unavailable", spec: DisruptionBudgetSpec{MaxUnavailable: intPtr(1)},
+			expected: 5, healthy: 5, desiredHealthy: 4, disruptsAllowed: 1},
+		{name: "max unavailable used up", spec: DisruptionBudgetSpec{MaxUnavailable: intPtr(1)},
+			expected: 5, healthy: 4, desiredHealthy: 4, disruptsAllowed: 0},
+		{name: "max unavailable over expected", spec: DisruptionBudgetSpec{MaxUnavailable: intPtr(10)},
+			expected: 5, healthy: 3, desiredHealthy: 0, disruptsAllowed: 3},
+		{name: "no instances", spec: DisruptionBudgetSpec{MinAvailable: intPtr(1)},
+			expected: 0, healthy: 0, desiredHealthy: 1, disruptsAllowed: 0},
+	}
+	for _, cs := range cases {
+		budget := &BcsDisruptionBudget{Spec: cs.spec}
+		status := budget.CalculateStatus(cs.expected, cs.healthy)
+		if status.ExpectedTaskgroups != cs.expected || status.HealthyTaskgroups != cs.healthy ||
+			status.DesiredHealthy != cs.desiredHealthy || status.DisruptionsAllowed != cs.disruptsAllowed {
+			t.Errorf("%s: unexpected status %+v", cs.name, status)
+		}
+	}
+}
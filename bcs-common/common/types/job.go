@@ -0,0 +1,76 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+// ConcurrencyPolicy how cronjob treats concurrent executions of jobs
+type ConcurrencyPolicy string
+
+const (
+	//ConcurrencyPolicy_ALLOW allows jobs created by cronjob to run concurrently
+	ConcurrencyPolicy_ALLOW ConcurrencyPolicy = "Allow"
+	//ConcurrencyPolicy_FORBID skips new job if the previous one hasn't finished yet
+	ConcurrencyPolicy_FORBID ConcurrencyPolicy = "Forbid"
+	//ConcurrencyPolicy_REPLACE kills the running job and replaces it with the new one
+	ConcurrencyPolicy_REPLACE ConcurrencyPolicy = "Replace"
+)
+
+// BcsJob definition of run-to-completion job, taskgroups of job are never restarted
+type BcsJob struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata"`
+	Spec       JobSpec    `json:"spec"`
+	KillPolicy KillPolicy `json:"killPolicy,omitempty"`
+}
+
+// JobSpec specification of job
+type JobSpec struct {
+	//Completions is the number of taskgroups that should finish successfully, default 1
+	Completions int32 `json:"completions,omitempty"`
+	//Parallelism is the max number of taskgroups running at the same time, default 1
+	Parallelism int32 `json:"parallelism,omitempty"`
+	//BackoffLimit is the number of failed taskgroups before marking job failed, default 6
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	//ActiveDeadlineSeconds is the duration in seconds job may be active before it is terminated, 0 means no limit
+	ActiveDeadlineSeconds int64            `json:"activeDeadlineSeconds,omitempty"`
+	Template              *PodTemplateSpec `json:"template"`
+}
+
+// BcsCronJob definition of job created repeatedly on a cron schedule
+type BcsCronJob struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata"`
+	Spec       CronJobSpec `json:"spec"`
+}
+
+// CronJobSpec specification of cronjob
+type CronJobSpec struct {
+	//Schedule in standard cron format, such as "*/5 * * * *" or "@hourly"
+	Schedule string `json:"schedule"`
+	//ConcurrencyPolicy Allow | Forbid | Replace, default Allow
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	//Suspend stops creating new jobs, running jobs are not affected
+	Suspend bool `json:"suspend,omitempty"`
+	//SuccessfulJobsHistoryLimit number of finished jobs to keep, default 3
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+	//FailedJobsHistoryLimit number of failed jobs to keep, default 1
+	FailedJobsHistoryLimit *int32          `json:"failedJobsHistoryLimit,omitempty"`
+	JobTemplate            JobTemplateSpec `json:"jobTemplate"`
+}
+
+// JobTemplateSpec template of job created by cronjob
+type JobTemplateSpec struct {
+	ObjectMeta `json:"metadata,omitempty"`
+	Spec       JobSpec    `json:"spec"`
+	KillPolicy KillPolicy `json:"killPolicy,omitempty"`
+}
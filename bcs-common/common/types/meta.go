@@ -39,6 +39,10 @@ const (
 	BcsDataType_CRD              BcsDataType = "customresourcedefinition"
 	BcsDataType_PERMISSION       BcsDataType = "permission"
 	BcsDataType_Daemonset        BcsDataType = "daemonset"
+	BcsDataType_NamespaceQuota   BcsDataType = "namespacequota"
+	BcsDataType_Job              BcsDataType = "job"
+	BcsDataType_CronJob          BcsDataType = "cronjob"
+	BcsDataType_DisruptionBudget BcsDataType = "disruptionbudget"
 )
 
 //TypeMeta for bcs data type
@@ -0,0 +1,91 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import "fmt"
+
+//BcsNamespaceQuota resource quota of one mesos namespace, metadata.namespace is the namespace limited
+type BcsNamespaceQuota struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta `json:"metadata"`
+	//Hard is the limit of namespace
+	Hard NamespaceQuotaResource `json:"hard"`
+	//Used is the current usage of namespace, it is only filled when quota is queried
+	Used *NamespaceQuotaResource `json:"used,omitempty"`
+}
+
+//NamespaceQuotaResource resources limited by namespace quota, zero value means no limit
+type NamespaceQuotaResource struct {
+	//cpu cores
+	Cpu float64 `json:"cpu"`
+	//memory, MB
+	Mem float64 `json:"mem"`
+	//disk, MB
+	Disk float64 `json:"disk"`
+	//number of taskgroups
+	Taskgroups int `json:"taskgroups"`
+}
+
+//Validate check whether quota definition is valid
+func (in *BcsNamespaceQuota) Validate() error {
+	if in.NameSpace == "" {
+		return fmt.Errorf("namespace of quota is empty")
+	}
+	if in.Hard.Cpu < 0 || in.Hard.Mem < 0 || in.Hard.Disk < 0 || in.Hard.Taskgroups < 0 {
+		return fmt.Errorf("hard of quota can not be negative")
+	}
+	return nil
+}
+
+//Exceeded returns the names of resources in used exceeding hard limit
+func (in *NamespaceQuotaResource) Exceeded(used *NamespaceQuotaResource) []string {
+	var exceeded []string
+	if in.Cpu > 0 && used.Cpu > in.Cpu {
+		exceeded = append(exceeded, fmt.Sprintf("cpu(used %.2f, hard %.2f)", used.Cpu, in.Cpu))
+	}
+	if in.Mem > 0 && used.Mem > in.Mem {
+		exceeded = append(exceeded, fmt.Sprintf("mem(used %.2f, hard %.2f)", used.Mem, in.Mem))
+	}
+	if in.Disk > 0 && used.Disk > in.Disk {
+		exceeded = append(exceeded, fmt.Sprintf("disk(used %.2f, hard %.2f)", used.Disk, in.Disk))
+	}
+	if in.Taskgroups > 0 && used.Taskgroups > in.Taskgroups {
+		exceeded = append(exceeded, fmt.Sprintf("taskgroups(used %d, hard %d)", used.Taskgroups, in.Taskgroups))
+	}
+	return exceeded
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsNamespaceQuota) DeepCopyInto(out *BcsNamespaceQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Hard = in.Hard
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = new(NamespaceQuotaResource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsNamespaceQuota.
+func (in *BcsNamespaceQuota) DeepCopy() *BcsNamespaceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsNamespaceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
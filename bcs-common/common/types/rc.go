@@ -220,6 +220,8 @@ type Uri struct {
 	User      string //package registry user
 	Pwd       string //package registry password, example for curl -u 'user:pwd' -X GET "http://xxx.registry.xxx.com/xxx/v1/pack.tar.gz"
 	OutputDir string
+	Sha256    string //hex sha256 digest the package is pinned to
+	Signature string //base64 signature of package sha256 digest, verified by public key of process daemon
 }
 
 type PodSpec struct {
@@ -243,6 +245,16 @@ type ReplicaControllerSpec struct {
 	Instance int               `json:"instance"`
 	Selector map[string]string `json:"selector,omitempty"`
 	Template *PodTemplateSpec  `json:"template"`
+	// Priority of taskgroups, taskgroups with higher priority can preempt the lower ones when cluster is full
+	Priority   int32             `json:"priority,omitempty"`
+	Preemption *PreemptionPolicy `json:"preemption,omitempty"`
+}
+
+//PreemptionPolicy policy for preempting taskgroups with lower priority
+type PreemptionPolicy struct {
+	// MaxEvictions is the max number of taskgroups evicted on one host for launching one taskgroup,
+	// 0 means never preempt
+	MaxEvictions int32 `json:"maxEvictions"`
 }
 
 type ReplicaController struct {
@@ -32,6 +32,71 @@ type BcsStorageDynamicBatchDeleteIf struct {
 	UpdateTimeEnd   int64 `json:"updateTimeEnd"`
 }
 
+// BcsStorageDynamicAggregationIf define storage dynamic aggregation interface data interaction
+type BcsStorageDynamicAggregationIf struct {
+	// GroupBy fields to group by, e.g. ["namespace", "data.status.phase"], empty for counting all
+	GroupBy []string `json:"groupBy"`
+	// Unwind array fields to be unwound before grouping, e.g. ["data.spec.containers"]
+	Unwind []string `json:"unwind,omitempty"`
+	// Sum name of sum result -> numeric field to sum, e.g. {"cpu": "data.spec.containers.resources.limits.cpu"}
+	Sum map[string]string `json:"sum,omitempty"`
+	// Sort keys in priority order, groups with equal keys are ordered by group fields
+	Sort []BcsStorageDynamicAggregationSort `json:"sort,omitempty"`
+	// Limit max number of groups returned
+	Limit int64 `json:"limit,omitempty"`
+}
+
+const (
+	// BcsStorageBulkActionUpsert upsert resource in bulk request
+	BcsStorageBulkActionUpsert = "upsert"
+	// BcsStorageBulkActionDelete delete resource in bulk request
+	BcsStorageBulkActionDelete = "delete"
+)
+
+// BcsStorageDynamicBulkOperationIf define operation of storage dynamic bulk interface
+type BcsStorageDynamicBulkOperationIf struct {
+	// Action upsert or delete
+	Action string `json:"action"`
+	// Namespace namespace of resource, empty for cluster resource
+	Namespace    string      `json:"namespace,omitempty"`
+	ResourceType string      `json:"resourceType"`
+	ResourceName string      `json:"resourceName"`
+	Data         interface{} `json:"data,omitempty"`
+}
+
+// BcsStorageDynamicBulkIf define storage dynamic bulk interface data interaction
+type BcsStorageDynamicBulkIf struct {
+	Operations []BcsStorageDynamicBulkOperationIf `json:"operations"`
+}
+
+// BcsStorageDynamicBulkResultIf define result of each operation in storage dynamic bulk interface
+type BcsStorageDynamicBulkResultIf struct {
+	Result  bool   `json:"result"`
+	Message string `json:"message,omitempty"`
+}
+
+// BcsStorageRetentionPolicyIf define storage retention policy interface data interaction
+type BcsStorageRetentionPolicyIf struct {
+	// TTL seconds to keep documents, 0 for no limit
+	TTL int64 `json:"ttl"`
+	// MaxCount max number of documents to keep, 0 for no limit
+	MaxCount int64 `json:"maxCount"`
+	// KeepLastPerName max number of documents to keep for each resource name, 0 for no limit
+	KeepLastPerName int64 `json:"keepLastPerName"`
+	// TimeTag time field to decide which documents are older, default updateTime
+	TimeTag string `json:"timeTag,omitempty"`
+	// NameTag name field used by KeepLastPerName, default resourceName
+	NameTag string `json:"nameTag,omitempty"`
+}
+
+// BcsStorageDynamicAggregationSort define sort key of storage dynamic aggregation
+type BcsStorageDynamicAggregationSort struct {
+	// Key "count" or name of sum result
+	Key string `json:"key"`
+	// Order 1 for ascending and -1 for descending
+	Order int `json:"order"`
+}
+
 // BcsStorageWatchIf define storage watch interface data interaction
 type BcsStorageWatchIf struct {
 	Data interface{} `json:"data"`
@@ -84,7 +149,8 @@ const (
 type EventKind string
 
 const (
-	TaskEventKind EventKind = "task"
+	TaskEventKind      EventKind = "task"
+	TaskGroupEventKind EventKind = "taskgroup"
 )
 
 type EventLevel string
@@ -195,4 +261,13 @@ type WatchOptions struct {
 	// The value-change event will be checked if it's different from last status. If not then this event
 	// will be ignored. And it will not trigger timeout reset.
 	MustDiff string `json:"mustDiff"`
+
+	// Resume watch from the resourceVersion returned by the last event or list, events after it will be replayed.
+	// Watch will be ended with error if the change history of the resourceVersion has been trimmed,
+	// client should list again then. Empty for watching from now.
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+
+	// Receive bookmark events which only carry the latest resourceVersion, so that client can keep
+	// its resume point fresh even if no matched events happen.
+	AllowBookmarks bool `json:"allowBookmarks,omitempty"`
 }
@@ -0,0 +1,60 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	from := time.Date(2020, time.March, 14, 10, 7, 30, 0, time.UTC)
+	cases := []struct {
+		spec   string
+		expect time.Time
+	}{
+		{"* * * * *", time.Date(2020, time.March, 14, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2020, time.March, 14, 10, 15, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2020, time.March, 14, 10, 25, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2020, time.March, 15, 3, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2020, time.March, 14, 11, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		// 2020-03-14 is saturday
+		{"30 9 * * 1-5", time.Date(2020, time.March, 16, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2020, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		// day of month or day of week when both are restricted
+		{"0 0 20 * 1", time.Date(2020, time.March, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC)},
+		{"0,30 8-9 1,15 6 *", time.Date(2020, time.June, 1, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, cs := range cases {
+		schedule, err := ParseCronSchedule(cs.spec)
+		if err != nil {
+			t.Errorf("parse %q failed: %s", cs.spec, err.Error())
+			continue
+		}
+		if next := schedule.Next(from); !next.Equal(cs.expect) {
+			t.Errorf("next of %q expect %s, got %s", cs.spec, cs.expect, next)
+		}
+	}
+}
+
+func TestParseCronScheduleInvalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *",
+		"* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@every 5m"} {
+		if _, err := ParseCronSchedule(spec); err == nil {
+			t.Errorf("expect error when parse %q", spec)
+		}
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/micro/go-micro/v2/broker"
 	"github.com/micro/go-plugins/broker/rabbitmq/v2"
 	"github.com/micro/go-plugins/broker/stan/v2"
+	"strings"
 	"time"
 )
 
@@ -81,6 +82,29 @@ func natstreamingBroker(q *QueueOptions) (broker.Broker, error) {
 	return brokerNatstreaming, nil
 }
 
+// kafka broker init: kafka options/init/connect
+func kafkaBroker(q *QueueOptions) (broker.Broker, error) {
+	var brokerOpts []broker.Option
+	brokerOpts = append(brokerOpts, broker.Addrs(strings.Split(q.CommonOptions.Address, ",")...))
+
+	brokerKafka := newKBroker(q.KafkaOptions, brokerOpts...)
+
+	// init kafka broker
+	err := brokerKafka.Init()
+	if err != nil {
+		errMsg := fmt.Sprintf("brokerKafka init failed: %v", err)
+		return nil, errors.New(errMsg)
+	}
+
+	// create connect
+	if err = brokerKafka.Connect(); err != nil {
+		errMsg := fmt.Sprintf("can't connect to kafka broker: %v", err)
+		return nil, errors.New(errMsg)
+	}
+
+	return brokerKafka, nil
+}
+
 // NewQueueBroker connect queue instance by queue kind
 func NewQueueBroker(options *QueueOptions) (broker.Broker, error) {
 
@@ -116,6 +140,17 @@ func NewQueueBroker(options *QueueOptions) (broker.Broker, error) {
 		if err != nil {
 			return nil, err
 		}
+	case KAFKA:
+		// validate kafka configOptions
+		err = validateKafkaOptions(options)
+		if err != nil {
+			return nil, err
+		}
+		// init kafka broker
+		b, err = kafkaBroker(options)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.New("unSupported queue kind")
 	}
@@ -0,0 +1,338 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package msgqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	glog "github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/drivers"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DeadLetterTopicSuffix suffix of default dead-letter topic of subscription queue
+	DeadLetterTopicSuffix = ".deadletter"
+	// DefaultDeadLetterCapacity default max dead letters kept for every topic in memory store
+	DefaultDeadLetterCapacity = 1000
+	// DefaultDeadLetterTable default table of dead letters in db store
+	DefaultDeadLetterTable = "msgqueue_deadletter"
+
+	// headers of message published into dead-letter topic, headers of original message are kept
+	DeadLetterIDHeader          = "deadLetterId"
+	DeadLetterOriginTopicHeader = "deadLetterOriginTopic"
+	DeadLetterQueueHeader       = "deadLetterQueue"
+	DeadLetterHandlerHeader     = "deadLetterHandler"
+	DeadLetterReasonHeader      = "deadLetterReason"
+	DeadLetterRetriesHeader     = "deadLetterRetries"
+)
+
+var deadLetterDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "bkbcs_msgqueue",
+	Subsystem: "deadletter",
+	Name:      "dropped_total",
+	Help:      "The total number of dead letters dropped by full in-memory dead-letter store",
+}, []string{"topic"})
+
+func init() {
+	prometheus.MustRegister(deadLetterDropped)
+}
+
+// RetryOptions retry policy of subscription, message is published into dead-letter topic when retries are exhausted
+type RetryOptions struct {
+	// max retry times after the first failure of handler, 0 means no retry
+	MaxRetries int `json:"maxRetries"`
+	// backoff before the first retry, it is doubled for every next retry and limited by MaxBackoff
+	Backoff    time.Duration `json:"backoff"`
+	MaxBackoff time.Duration `json:"maxBackoff"`
+	// topic where exhausted messages are parked, default is queue name with DeadLetterTopicSuffix
+	DeadLetterTopic string `json:"deadLetterTopic"`
+}
+
+func (r *RetryOptions) validate() error {
+	if r.MaxRetries < 0 {
+		return errors.New("retry options maxRetries cannot be negative")
+	}
+	if r.Backoff < 0 || r.MaxBackoff < 0 {
+		return errors.New("retry options backoff cannot be negative")
+	}
+	return nil
+}
+
+// backoff returns waiting duration before the retry, retry starts from 1
+func (r *RetryOptions) backoff(retry int) time.Duration {
+	backoff := r.Backoff
+	for i := 1; i < retry; i++ {
+		backoff *= 2
+		if r.MaxBackoff > 0 && backoff >= r.MaxBackoff {
+			break
+		}
+	}
+	if r.MaxBackoff > 0 && backoff > r.MaxBackoff {
+		return r.MaxBackoff
+	}
+	return backoff
+}
+
+// deadLetterTopic returns dead-letter topic of subscription queue
+func (r *RetryOptions) deadLetterTopic(queueName string) string {
+	if len(r.DeadLetterTopic) != 0 {
+		return r.DeadLetterTopic
+	}
+	return queueName + DeadLetterTopicSuffix
+}
+
+// DeadLetter message parked in dead-letter topic
+type DeadLetter struct {
+	ID string `json:"id" bson:"id"`
+	// dead-letter topic
+	Topic string `json:"topic" bson:"topic"`
+	// original topic, queue and handler of the message
+	OriginTopic string `json:"originTopic" bson:"originTopic"`
+	QueueName   string `json:"queueName" bson:"queueName"`
+	HandlerName string `json:"handlerName" bson:"handlerName"`
+	// original message
+	Header map[string]string `json:"header" bson:"header"`
+	Body   []byte            `json:"body" bson:"body"`
+	// error of the last handling and retry times
+	Reason     string    `json:"reason" bson:"reason"`
+	Retries    int       `json:"retries" bson:"retries"`
+	CreateTime time.Time `json:"createTime" bson:"createTime"`
+}
+
+// message returns message published into dead-letter topic, it carries original body and headers,
+// so subscriber of dead-letter topic handles it like the original one
+func (d *DeadLetter) message() *broker.Message {
+	header := make(map[string]string, len(d.Header)+6)
+	for k, v := range d.Header {
+		header[k] = v
+	}
+	header[DeadLetterIDHeader] = d.ID
+	header[DeadLetterOriginTopicHeader] = d.OriginTopic
+	header[DeadLetterQueueHeader] = d.QueueName
+	header[DeadLetterHandlerHeader] = d.HandlerName
+	header[DeadLetterReasonHeader] = d.Reason
+	header[DeadLetterRetriesHeader] = strconv.Itoa(d.Retries)
+	return &broker.Message{
+		Header: header,
+		Body:   d.Body,
+	}
+}
+
+// DeadLetterStore storage of dead letters for listing and replaying, dead letters are published
+// into dead-letter topic of broker whether store is set or not
+type DeadLetterStore interface {
+	// Put parks dead letter into its topic
+	Put(ctx context.Context, letter *DeadLetter) error
+	// List lists dead letters of topic by create time
+	List(ctx context.Context, topic string, offset, limit int) ([]*DeadLetter, error)
+	// Get gets dead letters of topic by ids
+	Get(ctx context.Context, topic string, ids []string) ([]*DeadLetter, error)
+	// Delete deletes dead letters of topic by ids
+	Delete(ctx context.Context, topic string, ids []string) error
+}
+
+// memoryDeadLetterStore keeps dead letters in memory, the oldest ones are dropped when topic is full,
+// every dropped dead letter is logged and counted by metric bkbcs_msgqueue_deadletter_dropped_total.
+// Dead letters in memory are lost after restart and not shared by replicas, they can still be consumed
+// from dead-letter topic of broker
+type memoryDeadLetterStore struct {
+	sync.RWMutex
+	capacity int
+	topics   map[string][]*DeadLetter
+}
+
+// NewMemoryDeadLetterStore create in-memory dead-letter store keeping at most capacity letters for every topic
+func NewMemoryDeadLetterStore(capacity int) DeadLetterStore {
+	if capacity <= 0 {
+		capacity = DefaultDeadLetterCapacity
+	}
+	return &memoryDeadLetterStore{
+		capacity: capacity,
+		topics:   make(map[string][]*DeadLetter),
+	}
+}
+
+// Put parks dead letter into its topic
+func (m *memoryDeadLetterStore) Put(ctx context.Context, letter *DeadLetter) error {
+	m.Lock()
+	defer m.Unlock()
+
+	letters := append(m.topics[letter.Topic], letter)
+	if len(letters) > m.capacity {
+		dropped := letters[:len(letters)-m.capacity]
+		for _, d := range dropped {
+			glog.Warnf("dead-letter topic %s is full, drop dead letter %s of handler[%s] queue %s: %s",
+				d.Topic, d.ID, d.HandlerName, d.QueueName, d.Reason)
+		}
+		deadLetterDropped.WithLabelValues(letter.Topic).Add(float64(len(dropped)))
+		letters = letters[len(letters)-m.capacity:]
+	}
+	m.topics[letter.Topic] = letters
+	return nil
+}
+
+// List lists dead letters of topic by create time
+func (m *memoryDeadLetterStore) List(ctx context.Context, topic string, offset, limit int) ([]*DeadLetter, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	letters := m.topics[topic]
+	if offset >= len(letters) {
+		return nil, nil
+	}
+	letters = letters[offset:]
+	if limit > 0 && limit < len(letters) {
+		letters = letters[:limit]
+	}
+	return append([]*DeadLetter{}, letters...), nil
+}
+
+// Get gets dead letters of topic by ids
+func (m *memoryDeadLetterStore) Get(ctx context.Context, topic string, ids []string) ([]*DeadLetter, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	idSet := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		idSet[id] = true
+	}
+	var letters []*DeadLetter
+	for _, letter := range m.topics[topic] {
+		if idSet[letter.ID] {
+			letters = append(letters, letter)
+		}
+	}
+	return letters, nil
+}
+
+// Delete deletes dead letters of topic by ids
+func (m *memoryDeadLetterStore) Delete(ctx context.Context, topic string, ids []string) error {
+	m.Lock()
+	defer m.Unlock()
+
+	idSet := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		idSet[id] = true
+	}
+	var letters []*DeadLetter
+	for _, letter := range m.topics[topic] {
+		if !idSet[letter.ID] {
+			letters = append(letters, letter)
+		}
+	}
+	m.topics[topic] = letters
+	return nil
+}
+
+// dbDeadLetterStore keeps dead letters in database table
+type dbDeadLetterStore struct {
+	db        drivers.DB
+	tableName string
+}
+
+// NewDBDeadLetterStore create dead-letter store on database, dead letters of all topics are kept in table
+func NewDBDeadLetterStore(db drivers.DB, tableName string) (DeadLetterStore, error) {
+	if db == nil {
+		return nil, errors.New("db of dead-letter store cannot be empty")
+	}
+	if len(tableName) == 0 {
+		tableName = DefaultDeadLetterTable
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), HandleTimeout)
+	defer cancel()
+	exist, err := db.HasTable(ctx, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("check dead-letter table %s failed: %v", tableName, err)
+	}
+	if !exist {
+		if err = db.CreateTable(ctx, tableName); err != nil {
+			return nil, fmt.Errorf("create dead-letter table %s failed: %v", tableName, err)
+		}
+	}
+	index := drivers.Index{
+		Name: tableName + "_idx",
+		Key: map[string]int32{
+			"topic":      1,
+			"createTime": 1,
+		},
+	}
+	hasIndex, err := db.Table(tableName).HasIndex(ctx, index.Name)
+	if err != nil {
+		return nil, fmt.Errorf("check index of dead-letter table %s failed: %v", tableName, err)
+	}
+	if !hasIndex {
+		if err = db.Table(tableName).CreateIndex(ctx, index); err != nil {
+			return nil, fmt.Errorf("create index of dead-letter table %s failed: %v", tableName, err)
+		}
+	}
+
+	return &dbDeadLetterStore{
+		db:        db,
+		tableName: tableName,
+	}, nil
+}
+
+// Put parks dead letter into its topic
+func (d *dbDeadLetterStore) Put(ctx context.Context, letter *DeadLetter) error {
+	_, err := d.db.Table(d.tableName).Insert(ctx, []interface{}{letter})
+	return err
+}
+
+// List lists dead letters of topic by create time
+func (d *dbDeadLetterStore) List(ctx context.Context, topic string, offset, limit int) ([]*DeadLetter, error) {
+	find := d.db.Table(d.tableName).Find(topicCondition(topic, nil)).
+		WithSort(map[string]interface{}{"createTime": 1}).
+		WithStart(int64(offset))
+	if limit > 0 {
+		find = find.WithLimit(int64(limit))
+	}
+	var letters []*DeadLetter
+	if err := find.All(ctx, &letters); err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+// Get gets dead letters of topic by ids
+func (d *dbDeadLetterStore) Get(ctx context.Context, topic string, ids []string) ([]*DeadLetter, error) {
+	var letters []*DeadLetter
+	if err := d.db.Table(d.tableName).Find(topicCondition(topic, ids)).All(ctx, &letters); err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+// Delete deletes dead letters of topic by ids
+func (d *dbDeadLetterStore) Delete(ctx context.Context, topic string, ids []string) error {
+	_, err := d.db.Table(d.tableName).Delete(ctx, topicCondition(topic, ids))
+	return err
+}
+
+func topicCondition(topic string, ids []string) *operator.Condition {
+	cond := operator.NewLeafCondition(operator.Eq, operator.M{"topic": topic})
+	if ids == nil {
+		return cond
+	}
+	return operator.NewBranchCondition(operator.And, cond,
+		operator.NewLeafCondition(operator.In, operator.M{"id": ids}))
+}
@@ -0,0 +1,293 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package msgqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/broker"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRetryOptionsBackoff(t *testing.T) {
+	retry := &RetryOptions{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	expects := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, expect := range expects {
+		if backoff := retry.backoff(i + 1); backoff != expect {
+			t.Errorf("retry %d expect backoff %s, got %s", i+1, expect, backoff)
+		}
+	}
+	if topic := retry.deadLetterTopic("alert"); topic != "alert"+DeadLetterTopicSuffix {
+		t.Errorf("unexpected default dead-letter topic %s", topic)
+	}
+}
+
+func TestMemoryDeadLetterStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDeadLetterStore(3)
+	for i := 0; i < 4; i++ {
+		if err := store.Put(ctx, &DeadLetter{ID: fmt.Sprint(i), Topic: "dlq"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store.Put(ctx, &DeadLetter{ID: "other", Topic: "other"})
+
+	// the oldest one is dropped and counted when topic is full
+	letters, _ := store.List(ctx, "dlq", 0, 0)
+	if ids := deadLetterIDs(letters); fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("expect dead letters [1 2 3], got %v", ids)
+	}
+	if dropped := testutil.ToFloat64(deadLetterDropped.WithLabelValues("dlq")); dropped != 1 {
+		t.Errorf("expect 1 dropped dead letter, got %v", dropped)
+	}
+	letters, _ = store.List(ctx, "dlq", 1, 1)
+	if ids := deadLetterIDs(letters); fmt.Sprint(ids) != "[2]" {
+		t.Errorf("expect dead letters [2] of page, got %v", ids)
+	}
+	letters, _ = store.Get(ctx, "dlq", []string{"1", "3", "other"})
+	if ids := deadLetterIDs(letters); fmt.Sprint(ids) != "[1 3]" {
+		t.Errorf("expect dead letters [1 3] by ids, got %v", ids)
+	}
+	store.Delete(ctx, "dlq", []string{"1", "3"})
+	letters, _ = store.List(ctx, "dlq", 0, 0)
+	if ids := deadLetterIDs(letters); fmt.Sprint(ids) != "[2]" {
+		t.Errorf("expect dead letters [2] after deleting, got %v", ids)
+	}
+}
+
+func deadLetterIDs(letters []*DeadLetter) []string {
+	var ids []string
+	for _, letter := range letters {
+		ids = append(ids, letter.ID)
+	}
+	return ids
+}
+
+// flakyHandler fails the first failures calls
+type flakyHandler struct {
+	sync.Mutex
+	failures int
+	calls    int
+	handled  chan string
+}
+
+func (h *flakyHandler) Name() string {
+	return "flaky"
+}
+
+func (h *flakyHandler) Handle(ctx context.Context, data []byte) error {
+	h.Lock()
+	defer h.Unlock()
+
+	h.calls++
+	if h.calls <= h.failures {
+		return errors.New("handler unavailable")
+	}
+	handlerData := &HandlerData{}
+	if err := json.Unmarshal(data, handlerData); err != nil {
+		return err
+	}
+	h.handled <- string(handlerData.Body)
+	return nil
+}
+
+func (h *flakyHandler) setFailures(failures int) {
+	h.Lock()
+	defer h.Unlock()
+	h.failures = failures
+	h.calls = 0
+}
+
+func (h *flakyHandler) getCalls() int {
+	h.Lock()
+	defer h.Unlock()
+	return h.calls
+}
+
+func TestSubscribeWithRetryDeadLetter(t *testing.T) {
+	q := newKafkaQueue(t, newFakeKafka(), KafkaOffsetNewest, DeadLetterStoreOpts(NewMemoryDeadLetterStore(10)))
+	defer q.Stop()
+
+	handler := &flakyHandler{failures: 1, handled: make(chan string, 10)}
+	sub, err := q.SubscribeWithRetry(handler, nil, "alert", "Pod", &RetryOptions{
+		MaxRetries: 2,
+		Backoff:    time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// another queue of the same topic, it should not receive replayed data
+	other := &flakyHandler{handled: make(chan string, 10)}
+	otherSub, err := q.SubscribeWithQueueName(other, nil, "storage", "Pod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer otherSub.Unsubscribe()
+	deadLetterTopic := "alert" + DeadLetterTopicSuffix
+	// consumer of dead-letter topic in broker
+	deadLetterConsumer := &collectHandler{name: "deadletter", data: make(chan [2]string, 10)}
+	deadLetterSub, err := q.SubscribeWithQueueName(deadLetterConsumer, nil, "deadletter", deadLetterTopic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer deadLetterSub.Unsubscribe()
+
+	// succeed by retry
+	publishPods(t, q, []string{"pod1"}, 1)
+	select {
+	case body := <-handler.handled:
+		if body != "pod1-1" {
+			t.Errorf("unexpected handled data %s", body)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("data is not handled by retry")
+	}
+
+	// park into dead-letter topic when retries are exhausted
+	handler.setFailures(3)
+	publishPods(t, q, []string{"pod2"}, 1)
+	var letters []*DeadLetter
+	for i := 0; i < 300 && len(letters) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+		if letters, err = q.ListDeadLetters(deadLetterTopic, 0, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(letters) != 1 {
+		t.Fatalf("expect 1 dead letter, got %d", len(letters))
+	}
+	letter := letters[0]
+	if string(letter.Body) != "pod2-1" || letter.Reason != "handler unavailable" || letter.Retries != 2 ||
+		letter.OriginTopic != "Pod" || letter.QueueName != "alert" || letter.Header[string(ResourceName)] != "pod2" {
+		t.Errorf("unexpected dead letter %+v", letter)
+	}
+	if calls := handler.getCalls(); calls != 3 {
+		t.Errorf("expect handler called 3 times, got %d", calls)
+	}
+	if received := deadLetterConsumer.wait(t, 1); len(received["pod2"]) != 1 || received["pod2"][0] != "pod2-1" {
+		t.Errorf("expect dead letter of pod2 published into topic %s, got %v", deadLetterTopic, received)
+	}
+
+	// replay dead letter is kept when handler fails again
+	handler.setFailures(1)
+	replayed, err := q.ReplayDeadLetters(deadLetterTopic, []string{letter.ID})
+	if err == nil || replayed != 0 {
+		t.Fatalf("expect replay failed, got %d, err %v", replayed, err)
+	}
+
+	// replay dead letter after handler recovers
+	handler.setFailures(0)
+	replayed, err = q.ReplayDeadLetters(deadLetterTopic, []string{letter.ID})
+	if err != nil || replayed != 1 {
+		t.Fatalf("expect 1 dead letter replayed, got %d, err %v", replayed, err)
+	}
+	select {
+	case body := <-handler.handled:
+		if body != "pod2-1" {
+			t.Errorf("unexpected replayed data %s", body)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("replayed data is not handled")
+	}
+	if letters, _ = q.ListDeadLetters(deadLetterTopic, 0, 10); len(letters) != 0 {
+		t.Errorf("replayed dead letters should be removed, got %d", len(letters))
+	}
+	for i := 0; i < 2; i++ {
+		if body := <-other.handled; body != "pod1-1" && body != "pod2-1" {
+			t.Errorf("unexpected data %s of other queue", body)
+		}
+	}
+	select {
+	case body := <-other.handled:
+		t.Errorf("replayed data %s should not be received by other queue", body)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// dead letters can't be replayed without subscriber
+	handler.setFailures(3)
+	publishPods(t, q, []string{"pod3"}, 1)
+	for i := 0; i < 300 && len(letters) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+		letters, _ = q.ListDeadLetters(deadLetterTopic, 0, 10)
+	}
+	if len(letters) != 1 {
+		t.Fatalf("expect 1 dead letter, got %d", len(letters))
+	}
+	sub.Unsubscribe()
+	if _, err = q.ReplayDeadLetters(deadLetterTopic, []string{letters[0].ID}); err == nil {
+		t.Errorf("expect replay failed without subscriber")
+	}
+}
+
+// dead letters are published into topic without store, but they can't be listed or replayed
+func TestSubscribeWithRetryWithoutStore(t *testing.T) {
+	fake := newFakeKafka()
+	q := newKafkaQueue(t, fake, KafkaOffsetNewest)
+	defer q.Stop()
+
+	handler := &flakyHandler{failures: 3, handled: make(chan string, 10)}
+	sub, err := q.SubscribeWithRetry(handler, nil, "alert", "Pod", &RetryOptions{
+		MaxRetries:      1,
+		Backoff:         time.Millisecond,
+		DeadLetterTopic: "alert-failed",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+	deadLetterConsumer := &collectHandler{name: "deadletter", data: make(chan [2]string, 10)}
+	deadLetterSub, err := q.SubscribeWithQueueName(deadLetterConsumer, nil, "deadletter", "alert-failed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer deadLetterSub.Unsubscribe()
+
+	publishPods(t, q, []string{"pod1"}, 1)
+	if received := deadLetterConsumer.wait(t, 1); len(received["pod1"]) != 1 {
+		t.Errorf("expect dead letter of pod1 published into topic, got %v", received)
+	}
+	fake.Lock()
+	var record *kafkaRecord
+	for _, records := range fake.partitionsOf("alert-failed") {
+		if len(records) != 0 {
+			record = records[0]
+		}
+	}
+	fake.Unlock()
+	if record == nil {
+		t.Fatal("expect dead letter record in topic alert-failed")
+	}
+	message := &broker.Message{}
+	if err := json.Unmarshal(record.Value, message); err != nil {
+		t.Fatal(err)
+	}
+	if message.Header[DeadLetterOriginTopicHeader] != "Pod" || message.Header[DeadLetterQueueHeader] != "alert" ||
+		message.Header[DeadLetterReasonHeader] != "handler unavailable" || message.Header[DeadLetterRetriesHeader] != "1" ||
+		len(message.Header[DeadLetterIDHeader]) == 0 {
+		t.Errorf("unexpected dead letter headers %+v", message.Header)
+	}
+
+	if _, err := q.ListDeadLetters("alert-failed", 0, 10); err == nil {
+		t.Errorf("expect listing dead letters failed without store")
+	}
+	if _, err := q.ReplayDeadLetters("alert-failed", []string{message.Header[DeadLetterIDHeader]}); err == nil {
+		t.Errorf("expect replaying dead letters failed without store")
+	}
+}
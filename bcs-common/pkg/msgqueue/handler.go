@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	glog "github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/google/uuid"
 	"github.com/micro/go-micro/v2/broker"
 	"time"
 )
@@ -79,6 +80,14 @@ type objectHandler struct {
 	resourceType string
 	handler      Handler
 	filter       []Filter
+
+	// retry policy and dead-letter store, failed data is dropped when retry is nil
+	ctx         context.Context
+	queueName   string
+	retry       *RetryOptions
+	deadLetters DeadLetterStore
+	// publish data into dead-letter topic of broker
+	publish func(topic string, data *broker.Message) error
 }
 
 // HandlerData return data for external subscriber
@@ -115,26 +124,94 @@ func (object *objectHandler) selfHandler(b broker.Event) error {
 
 	glog.V(4).Infof("handler[%s] deal resourceType[%s] data", object.handler.Name(), object.resourceType)
 
+	dataByte, err := object.handlerData(headers, data)
+	if err != nil {
+		glog.Errorf("marshal dataObject failed: %v", err)
+		return err
+	}
+
+	err = object.handle(dataByte)
+	if err == nil {
+		return nil
+	}
+	glog.Errorf("external handler data failed: %v", err)
+	if object.retry == nil {
+		return err
+	}
+
+	retries := 0
+	for retries < object.retry.MaxRetries {
+		retries++
+		select {
+		case <-object.ctx.Done():
+			return err
+		case <-time.After(object.retry.backoff(retries)):
+		}
+		if err = object.handle(dataByte); err == nil {
+			return nil
+		}
+		glog.Errorf("external handler[%s] retry %d data failed: %v", object.handler.Name(), retries, err)
+	}
+
+	return object.parkDeadLetter(headers, data, err, retries)
+}
+
+func (object *objectHandler) handlerData(headers map[string]string, data []byte) ([]byte, error) {
 	dataObject := &HandlerData{
 		ResourceType: object.resourceType,
 		Meta:         headers,
 		Body:         data,
 	}
+	return json.Marshal(dataObject)
+}
 
-	dataByte, err := json.Marshal(dataObject)
+// replay handles dead letter by this subscriber only once, dead letter is kept by caller when it fails again
+func (object *objectHandler) replay(letter *DeadLetter) error {
+	dataByte, err := object.handlerData(letter.Header, letter.Body)
 	if err != nil {
-		glog.Errorf("marshal dataObject failed: %v", err)
 		return err
 	}
+	return object.handle(dataByte)
+}
 
+func (object *objectHandler) handle(data []byte) error {
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), HandleTimeout)
 	defer cancel()
 
-	err = object.handler.Handle(timeoutCtx, dataByte)
-	if err != nil {
-		glog.Errorf("external handler data failed: %v", err)
-		return err
+	return object.handler.Handle(timeoutCtx, data)
+}
+
+// parkDeadLetter publishes data into dead-letter topic when retries are exhausted, and keeps it in
+// dead-letter store for listing and replaying when store is set. handleErr is returned when data can't be published
+func (object *objectHandler) parkDeadLetter(headers map[string]string, data []byte, handleErr error, retries int) error {
+	letter := &DeadLetter{
+		ID:          uuid.New().String(),
+		Topic:       object.retry.deadLetterTopic(object.queueName),
+		OriginTopic: object.resourceType,
+		QueueName:   object.queueName,
+		HandlerName: object.handler.Name(),
+		Header:      headers,
+		Body:        data,
+		Reason:      handleErr.Error(),
+		Retries:     retries,
+		CreateTime:  time.Now(),
+	}
+
+	if err := object.publish(letter.Topic, letter.message()); err != nil {
+		glog.Errorf("publish data into dead-letter topic %s failed: %v", letter.Topic, err)
+		return handleErr
 	}
+	glog.Warnf("handler[%s] data parked into dead-letter topic %s[%s] after %d retries: %s",
+		letter.HandlerName, letter.Topic, letter.ID, retries, letter.Reason)
 
+	if object.deadLetters == nil {
+		return nil
+	}
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), HandleTimeout)
+	defer cancel()
+	if err := object.deadLetters.Put(timeoutCtx, letter); err != nil {
+		// dead letter is in topic already, only listing and replaying by store are affected
+		glog.Errorf("keep dead letter %s[%s] in store failed: %v", letter.Topic, letter.ID, err)
+	}
 	return nil
 }
@@ -0,0 +1,430 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package msgqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	glog "github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/broker"
+)
+
+const (
+	// DefaultKafkaVersion default kafka version, consumer group requires 0.10.2.0 at least
+	DefaultKafkaVersion = "1.0.0"
+
+	// retry interval when consumer group session exits with error
+	kafkaConsumeRetryInterval = 3 * time.Second
+)
+
+// kafkaRecord message consumed from kafka
+type kafkaRecord struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       string
+	Value     []byte
+	// mark marks the record as consumed, offset is committed by client later
+	mark func()
+}
+
+// kafkaRecordHandler handles records consumed by consumer group
+type kafkaRecordHandler func(record *kafkaRecord)
+
+// kafkaClient transport of kafka broker, it is replaced by an in-process fake in test
+type kafkaClient interface {
+	// SendMessage sends value into topic, values with the same key are sent to the same partition in order
+	SendMessage(topic, key string, value []byte) error
+	// Consume joins consumer group and consumes topic in background until the returned closer is closed
+	Consume(topic, group string, handler kafkaRecordHandler) (io.Closer, error)
+	// Close closes the client
+	Close() error
+}
+
+// newKafkaClient creates kafka client by brokers address and kafka options
+var newKafkaClient = newSaramaClient
+
+// kafkaPartitionKey returns partition key of message by cluster/namespace/resourceName headers,
+// messages of the same resource are sent to the same partition so that they are consumed in order
+func kafkaPartitionKey(header map[string]string) string {
+	return strings.Join([]string{
+		header[string(ClusterID)],
+		header[string(Namespace)],
+		header[string(ResourceName)],
+	}, "/")
+}
+
+type ackOnSuccessKey struct{}
+
+// kafkaAckOnSuccess marks message as consumed when handler succeeds even if auto ack is disabled
+func kafkaAckOnSuccess() broker.SubscribeOption {
+	return func(o *broker.SubscribeOptions) {
+		if o.Context == nil {
+			o.Context = context.Background()
+		}
+		o.Context = context.WithValue(o.Context, ackOnSuccessKey{}, true)
+	}
+}
+
+// kBroker kafka implementation of broker.Broker
+type kBroker struct {
+	sync.RWMutex
+	opts      broker.Options
+	kafkaOpts *KafkaOptions
+	client    kafkaClient
+}
+
+func newKBroker(kafkaOpts *KafkaOptions, opts ...broker.Option) *kBroker {
+	options := broker.Options{
+		Context: context.Background(),
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+	return &kBroker{
+		opts:      options,
+		kafkaOpts: kafkaOpts,
+	}
+}
+
+// Init set broker options
+func (k *kBroker) Init(opts ...broker.Option) error {
+	for _, o := range opts {
+		o(&k.opts)
+	}
+	return nil
+}
+
+// Options return broker options
+func (k *kBroker) Options() broker.Options {
+	return k.opts
+}
+
+// Address return comma separated brokers address
+func (k *kBroker) Address() string {
+	return strings.Join(k.opts.Addrs, ",")
+}
+
+// Connect create kafka client
+func (k *kBroker) Connect() error {
+	k.Lock()
+	defer k.Unlock()
+
+	if k.client != nil {
+		return nil
+	}
+	client, err := newKafkaClient(k.opts.Addrs, k.kafkaOpts)
+	if err != nil {
+		return err
+	}
+	k.client = client
+	return nil
+}
+
+// Disconnect close kafka client
+func (k *kBroker) Disconnect() error {
+	k.Lock()
+	defer k.Unlock()
+
+	if k.client == nil {
+		return nil
+	}
+	err := k.client.Close()
+	k.client = nil
+	return err
+}
+
+func (k *kBroker) getClient() (kafkaClient, error) {
+	k.RLock()
+	defer k.RUnlock()
+
+	if k.client == nil {
+		return nil, errors.New("kafka broker not connected")
+	}
+	return k.client, nil
+}
+
+// Publish send message into topic with partition key from message header
+func (k *kBroker) Publish(topic string, m *broker.Message, opts ...broker.PublishOption) error {
+	client, err := k.getClient()
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshal message failed: %v", err)
+	}
+	return client.SendMessage(topic, kafkaPartitionKey(m.Header), value)
+}
+
+// Subscribe consume topic by consumer group named by subscribe queue, a random group is used
+// when queue is empty so that every subscriber receives all messages
+func (k *kBroker) Subscribe(topic string, h broker.Handler, opts ...broker.SubscribeOption) (broker.Subscriber, error) {
+	client, err := k.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	options := broker.NewSubscribeOptions(opts...)
+	if len(options.Queue) == 0 {
+		options.Queue = uuid.New().String()
+	}
+	ackOnSuccess := options.AutoAck
+	if options.Context != nil {
+		if v, ok := options.Context.Value(ackOnSuccessKey{}).(bool); ok && v {
+			ackOnSuccess = true
+		}
+	}
+
+	closer, err := client.Consume(topic, options.Queue, func(record *kafkaRecord) {
+		msg := &broker.Message{}
+		if err := json.Unmarshal(record.Value, msg); err != nil {
+			// message can never be handled, skip it
+			glog.Errorf("unmarshal kafka message[%s/%d/%d] failed: %v",
+				record.Topic, record.Partition, record.Offset, err)
+			record.mark()
+			return
+		}
+		event := &kEvent{
+			topic:   topic,
+			message: msg,
+			record:  record,
+		}
+		event.err = h(event)
+		if event.err != nil {
+			glog.Errorf("handle kafka message[%s/%d/%d] failed: %v",
+				record.Topic, record.Partition, record.Offset, event.err)
+			return
+		}
+		if ackOnSuccess {
+			record.mark()
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("kafka consume topic %s by group %s failed: %v", topic, options.Queue, err)
+	}
+
+	return &kSubscriber{
+		topic:  topic,
+		opts:   options,
+		closer: closer,
+	}, nil
+}
+
+// String return broker name
+func (k *kBroker) String() string {
+	return string(KAFKA)
+}
+
+// kEvent kafka implementation of broker.Event
+type kEvent struct {
+	topic   string
+	message *broker.Message
+	record  *kafkaRecord
+	err     error
+}
+
+// Topic of event
+func (e *kEvent) Topic() string {
+	return e.topic
+}
+
+// Message of event
+func (e *kEvent) Message() *broker.Message {
+	return e.message
+}
+
+// Ack mark message as consumed
+func (e *kEvent) Ack() error {
+	e.record.mark()
+	return nil
+}
+
+// Error return handler error
+func (e *kEvent) Error() error {
+	return e.err
+}
+
+// kSubscriber kafka implementation of broker.Subscriber
+type kSubscriber struct {
+	topic  string
+	opts   broker.SubscribeOptions
+	closer io.Closer
+}
+
+// Options return subscribe options
+func (s *kSubscriber) Options() broker.SubscribeOptions {
+	return s.opts
+}
+
+// Topic return subscribed topic
+func (s *kSubscriber) Topic() string {
+	return s.topic
+}
+
+// Unsubscribe leave consumer group
+func (s *kSubscriber) Unsubscribe() error {
+	return s.closer.Close()
+}
+
+// saramaClient kafkaClient implementation by sarama
+type saramaClient struct {
+	client   sarama.Client
+	producer sarama.SyncProducer
+}
+
+func newSaramaClient(addrs []string, opts *KafkaOptions) (kafkaClient, error) {
+	var err error
+	config := sarama.NewConfig()
+	if len(opts.ClientID) != 0 {
+		config.ClientID = opts.ClientID
+	}
+	version := DefaultKafkaVersion
+	if len(opts.Version) != 0 {
+		version = opts.Version
+	}
+	config.Version, err = sarama.ParseKafkaVersion(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kafka version %s: %v", version, err)
+	}
+	// messages of the same key are sent to the same partition
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+	if opts.OffsetReset == KafkaOffsetOldest {
+		config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	} else {
+		config.Consumer.Offsets.Initial = sarama.OffsetNewest
+	}
+
+	client, err := sarama.NewClient(addrs, config)
+	if err != nil {
+		return nil, fmt.Errorf("create kafka client failed: %v", err)
+	}
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("create kafka producer failed: %v", err)
+	}
+	return &saramaClient{
+		client:   client,
+		producer: producer,
+	}, nil
+}
+
+// SendMessage sends value into topic by sync producer
+func (c *saramaClient) SendMessage(topic, key string, value []byte) error {
+	_, _, err := c.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.StringEncoder(key),
+		Value: sarama.ByteEncoder(value),
+	})
+	return err
+}
+
+// Consume starts consumer group session in background, session is rejoined when it exits by rebalance or error
+func (c *saramaClient) Consume(topic, group string, handler kafkaRecordHandler) (io.Closer, error) {
+	consumerGroup, err := sarama.NewConsumerGroupFromClient(group, c.client)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	consumer := &saramaConsumer{
+		group:  consumerGroup,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(consumer.done)
+		groupHandler := &saramaGroupHandler{handler: handler}
+		for {
+			if err := consumerGroup.Consume(ctx, []string{topic}, groupHandler); err != nil {
+				glog.Errorf("kafka consumer group %s consume topic %s failed: %v", group, topic, err)
+				select {
+				case <-ctx.Done():
+				case <-time.After(kafkaConsumeRetryInterval):
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+	return consumer, nil
+}
+
+// Close closes producer and client
+func (c *saramaClient) Close() error {
+	if err := c.producer.Close(); err != nil {
+		glog.Errorf("close kafka producer failed: %v", err)
+	}
+	return c.client.Close()
+}
+
+// saramaConsumer closer of consumer group
+type saramaConsumer struct {
+	group  sarama.ConsumerGroup
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Close stops consuming and leaves consumer group
+func (c *saramaConsumer) Close() error {
+	c.cancel()
+	<-c.done
+	return c.group.Close()
+}
+
+// saramaGroupHandler implementation of sarama.ConsumerGroupHandler
+type saramaGroupHandler struct {
+	handler kafkaRecordHandler
+}
+
+// Setup is run at the beginning of a new session
+func (h *saramaGroupHandler) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// Cleanup is run at the end of a session
+func (h *saramaGroupHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim handles messages of claimed partition one by one, so that messages are handled in order
+func (h *saramaGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		message := msg
+		h.handler(&kafkaRecord{
+			Topic:     message.Topic,
+			Partition: message.Partition,
+			Offset:    message.Offset,
+			Key:       string(message.Key),
+			Value:     message.Value,
+			mark: func() {
+				session.MarkMessage(message, "")
+			},
+		})
+	}
+	return nil
+}
@@ -0,0 +1,332 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package msgqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/broker"
+)
+
+const fakeKafkaPartitions = 3
+
+// fakeKafka in-process kafka, every consumer group has only one member which consumes all partitions
+type fakeKafka struct {
+	sync.Mutex
+	offsetReset string
+	// topic -> partition -> records
+	topics map[string][][]*kafkaRecord
+	// group -> topic -> committed offset of partitions
+	committed map[string]map[string][]int64
+}
+
+func newFakeKafka() *fakeKafka {
+	return &fakeKafka{
+		topics:    make(map[string][][]*kafkaRecord),
+		committed: make(map[string]map[string][]int64),
+	}
+}
+
+func (f *fakeKafka) partitionsOf(topic string) [][]*kafkaRecord {
+	if _, ok := f.topics[topic]; !ok {
+		f.topics[topic] = make([][]*kafkaRecord, fakeKafkaPartitions)
+	}
+	return f.topics[topic]
+}
+
+func (f *fakeKafka) SendMessage(topic, key string, value []byte) error {
+	f.Lock()
+	defer f.Unlock()
+
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	partition := int32(hash.Sum32() % fakeKafkaPartitions)
+	partitions := f.partitionsOf(topic)
+	partitions[partition] = append(partitions[partition], &kafkaRecord{
+		Topic:     topic,
+		Partition: partition,
+		Offset:    int64(len(partitions[partition])),
+		Key:       key,
+		Value:     value,
+	})
+	return nil
+}
+
+func (f *fakeKafka) Consume(topic, group string, handler kafkaRecordHandler) (io.Closer, error) {
+	f.Lock()
+	if _, ok := f.committed[group]; !ok {
+		f.committed[group] = make(map[string][]int64)
+	}
+	if _, ok := f.committed[group][topic]; !ok {
+		offsets := make([]int64, fakeKafkaPartitions)
+		if f.offsetReset != KafkaOffsetOldest {
+			for i, records := range f.partitionsOf(topic) {
+				offsets[i] = int64(len(records))
+			}
+		}
+		f.committed[group][topic] = offsets
+	}
+	position := append([]int64{}, f.committed[group][topic]...)
+	f.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for ctx.Err() == nil {
+			for partition := range position {
+				f.Lock()
+				records := f.partitionsOf(topic)[partition]
+				var record *kafkaRecord
+				if position[partition] < int64(len(records)) {
+					record = records[position[partition]]
+				}
+				f.Unlock()
+				if record == nil {
+					continue
+				}
+				position[partition]++
+				consumed := *record
+				consumed.mark = func() {
+					f.Lock()
+					defer f.Unlock()
+					f.committed[group][topic][consumed.Partition] = consumed.Offset + 1
+				}
+				handler(&consumed)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	return closerFunc(func() error {
+		cancel()
+		<-done
+		return nil
+	}), nil
+}
+
+func (f *fakeKafka) Close() error {
+	return nil
+}
+
+type closerFunc func() error
+
+func (c closerFunc) Close() error {
+	return c()
+}
+
+// collectHandler collects resourceName and body of handled messages
+type collectHandler struct {
+	name string
+	data chan [2]string
+}
+
+func (h *collectHandler) Name() string {
+	return h.name
+}
+
+func (h *collectHandler) Handle(ctx context.Context, data []byte) error {
+	handlerData := &HandlerData{}
+	if err := json.Unmarshal(data, handlerData); err != nil {
+		return err
+	}
+	h.data <- [2]string{handlerData.Meta[string(ResourceName)], string(handlerData.Body)}
+	return nil
+}
+
+func (h *collectHandler) wait(t *testing.T, count int) map[string][]string {
+	received := make(map[string][]string)
+	for i := 0; i < count; i++ {
+		select {
+		case d := <-h.data:
+			received[d[0]] = append(received[d[0]], d[1])
+		case <-time.After(3 * time.Second):
+			t.Fatalf("handler %s expect %d messages, got %d", h.name, count, i)
+		}
+	}
+	select {
+	case d := <-h.data:
+		t.Fatalf("handler %s got unexpected message %v", h.name, d)
+	case <-time.After(50 * time.Millisecond):
+	}
+	return received
+}
+
+func newKafkaQueue(t *testing.T, fake *fakeKafka, offsetReset string, opts ...QueueOption) MessageQueue {
+	newKafkaClient = func(addrs []string, opts *KafkaOptions) (kafkaClient, error) {
+		fake.offsetReset = opts.OffsetReset
+		return fake, nil
+	}
+	t.Cleanup(func() {
+		newKafkaClient = newSaramaClient
+	})
+
+	q, err := NewMsgQueue(append([]QueueOption{
+		CommonOpts(&CommonOptions{
+			QueueFlag:       true,
+			QueueKind:       KAFKA,
+			ResourceToQueue: map[string]string{"Pod": "Pod"},
+			Address:         "127.0.0.1:9092,127.0.0.2:9092",
+		}),
+		KafkaOpts(&KafkaOptions{
+			ClientID:    "bcs-test",
+			OffsetReset: offsetReset,
+		}),
+	}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func publishPods(t *testing.T, q MessageQueue, names []string, version int) {
+	for _, name := range names {
+		err := q.Publish(&broker.Message{
+			Header: map[string]string{
+				string(ClusterID):    "BCS-K8S-00000",
+				string(Namespace):    "default",
+				string(ResourceType): "Pod",
+				string(ResourceName): name,
+			},
+			Body: []byte(fmt.Sprintf("%s-%d", name, version)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestKafkaPublishPartitionKey(t *testing.T) {
+	fake := newFakeKafka()
+	q := newKafkaQueue(t, fake, KafkaOffsetOldest)
+	defer q.Stop()
+
+	publishPods(t, q, []string{"pod1", "pod2"}, 1)
+	publishPods(t, q, []string{"pod1", "pod2"}, 2)
+
+	partitionOfKey := make(map[string]int32)
+	for _, records := range fake.topics["Pod"] {
+		for _, record := range records {
+			if partition, ok := partitionOfKey[record.Key]; ok && partition != record.Partition {
+				t.Errorf("key %s is sent to partition %d and %d", record.Key, partition, record.Partition)
+			}
+			partitionOfKey[record.Key] = record.Partition
+		}
+	}
+	for _, key := range []string{"BCS-K8S-00000/default/pod1", "BCS-K8S-00000/default/pod2"} {
+		if _, ok := partitionOfKey[key]; !ok {
+			t.Errorf("no message with key %s", key)
+		}
+	}
+}
+
+func TestKafkaSubscribeConsumerGroup(t *testing.T) {
+	fake := newFakeKafka()
+	q := newKafkaQueue(t, fake, KafkaOffsetOldest)
+	defer q.Stop()
+
+	publishPods(t, q, []string{"pod1", "pod2"}, 1)
+
+	handler1 := &collectHandler{name: "group1", data: make(chan [2]string, 10)}
+	sub1, err := q.SubscribeWithQueueName(handler1, nil, "group1", "Pod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler2 := &collectHandler{name: "group2", data: make(chan [2]string, 10)}
+	sub2, err := q.SubscribeWithQueueName(handler2, nil, "group2", "Pod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub2.Unsubscribe()
+
+	publishPods(t, q, []string{"pod1", "pod2"}, 2)
+
+	// every group receives all messages, messages of the same resource are in order
+	expect := map[string][]string{
+		"pod1": {"pod1-1", "pod1-2"},
+		"pod2": {"pod2-1", "pod2-2"},
+	}
+	for _, handler := range []*collectHandler{handler1, handler2} {
+		received := handler.wait(t, 4)
+		for name, bodies := range expect {
+			if fmt.Sprint(received[name]) != fmt.Sprint(bodies) {
+				t.Errorf("handler %s expect %v of %s, got %v", handler.name, bodies, name, received[name])
+			}
+		}
+	}
+
+	// group resumes from committed offset after resubscribing
+	if err := sub1.Unsubscribe(); err != nil {
+		t.Fatal(err)
+	}
+	publishPods(t, q, []string{"pod1"}, 3)
+	sub1, err = q.SubscribeWithQueueName(handler1, nil, "group1", "Pod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub1.Unsubscribe()
+	received := handler1.wait(t, 1)
+	if fmt.Sprint(received["pod1"]) != fmt.Sprint([]string{"pod1-3"}) {
+		t.Errorf("resubscribed group1 expect [pod1-3], got %v", received)
+	}
+	handler2.wait(t, 1)
+}
+
+func TestKafkaSubscribeOffsetNewest(t *testing.T) {
+	fake := newFakeKafka()
+	q := newKafkaQueue(t, fake, KafkaOffsetNewest)
+	defer q.Stop()
+
+	publishPods(t, q, []string{"pod1"}, 1)
+
+	handler := &collectHandler{name: "newest", data: make(chan [2]string, 10)}
+	sub, err := q.SubscribeWithQueueName(handler, nil, "newest", "Pod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	publishPods(t, q, []string{"pod1"}, 2)
+	received := handler.wait(t, 1)
+	if fmt.Sprint(received["pod1"]) != fmt.Sprint([]string{"pod1-2"}) {
+		t.Errorf("expect only message published after subscribing, got %v", received)
+	}
+}
+
+func TestValidateKafkaOptions(t *testing.T) {
+	testCases := []struct {
+		options *QueueOptions
+		isValid bool
+	}{
+		{&QueueOptions{CommonOptions: &CommonOptions{Address: "127.0.0.1:9092"},
+			KafkaOptions: &KafkaOptions{}}, true},
+		{&QueueOptions{CommonOptions: &CommonOptions{Address: "127.0.0.1:9092"},
+			KafkaOptions: &KafkaOptions{OffsetReset: KafkaOffsetOldest}}, true},
+		{&QueueOptions{CommonOptions: &CommonOptions{Address: "127.0.0.1:9092"},
+			KafkaOptions: &KafkaOptions{OffsetReset: "earliest"}}, false},
+		{&QueueOptions{CommonOptions: &CommonOptions{Address: "127.0.0.1:9092"}}, false},
+		{&QueueOptions{CommonOptions: &CommonOptions{}, KafkaOptions: &KafkaOptions{}}, false},
+	}
+	for i, testCase := range testCases {
+		if err := validateKafkaOptions(testCase.options); (err == nil) != testCase.isValid {
+			t.Errorf("case %d: expect valid %v, got err %v", i, testCase.isValid, err)
+		}
+	}
+}
@@ -15,6 +15,7 @@ package msgqueue
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"time"
 )
@@ -27,6 +28,16 @@ const (
 	RABBITMQ QueueKind = "rabbitmq"
 	// NATSTREAMING queueType
 	NATSTREAMING QueueKind = "nats-streaming"
+	// KAFKA queueType
+	KAFKA QueueKind = "kafka"
+)
+
+// kafka offset reset for consumer group without committed offset
+const (
+	// KafkaOffsetNewest consume from the newest message
+	KafkaOffsetNewest = "newest"
+	// KafkaOffsetOldest consume from the oldest message
+	KafkaOffsetOldest = "oldest"
 )
 
 // DefaultQueue Options
@@ -94,13 +105,19 @@ type CommonOptions struct {
 	Address string `json:"address"`
 }
 
-// QueueOptions init options: rabbitmq or natstreaming
+// QueueOptions init options: rabbitmq, natstreaming or kafka
 type QueueOptions struct {
 	CommonOptions    *CommonOptions    `json:"commonOptions"`
 	Exchange         *ExchangeOptions  `json:"exchange"`
 	NatsOptions      *NatsOptions      `json:"natsOptions"`
+	KafkaOptions     *KafkaOptions     `json:"kafkaOptions"`
 	PublishOptions   *PublishOptions   `json:"publishOptions"`
 	SubscribeOptions *SubscribeOptions `json:"subscribeOptions"`
+	// default retry policy of subscriptions, nil means dropping data failed by handler
+	RetryOptions *RetryOptions `json:"retryOptions"`
+	// storage of dead letters for listing and replaying, dead letters are always published into dead-letter topic,
+	// nil means they can only be consumed from the topic
+	DeadLetterStore DeadLetterStore `json:"-"`
 }
 
 // NatsOptions initOptions for nats
@@ -111,6 +128,15 @@ type NatsOptions struct {
 	ConnectRetry   bool          `json:"connectRetry"`
 }
 
+// KafkaOptions initOptions for kafka, brokers are set by comma separated CommonOptions.Address
+type KafkaOptions struct {
+	// kafka version, consumer group requires 0.10.2.0 at least
+	Version  string `json:"version"`
+	ClientID string `json:"clientId"`
+	// offset to consume from when consumer group has no committed offset: newest or oldest
+	OffsetReset string `json:"offsetReset"`
+}
+
 // ExchangeOptions for rabbitmq exchange
 type ExchangeOptions struct {
 	Name    string `json:"name"`
@@ -189,6 +215,27 @@ func NatsOpts(natsOpts *NatsOptions) QueueOption {
 	}
 }
 
+// KafkaOpts connect options
+func KafkaOpts(kafkaOpts *KafkaOptions) QueueOption {
+	return func(q *QueueOptions) {
+		q.KafkaOptions = kafkaOpts
+	}
+}
+
+// RetryOpts set default retry policy of subscriptions
+func RetryOpts(retryOpts *RetryOptions) QueueOption {
+	return func(q *QueueOptions) {
+		q.RetryOptions = retryOpts
+	}
+}
+
+// DeadLetterStoreOpts set storage of dead letters for listing and replaying
+func DeadLetterStoreOpts(store DeadLetterStore) QueueOption {
+	return func(q *QueueOptions) {
+		q.DeadLetterStore = store
+	}
+}
+
 // MetaData meta dataInfo
 type MetaData struct {
 	ClusterID    string `json:"clusterId"`
@@ -254,3 +301,22 @@ func validateRabbitMqOptions(r *QueueOptions) error {
 
 	return nil
 }
+
+func validateKafkaOptions(k *QueueOptions) error {
+	if len(k.CommonOptions.Address) == 0 {
+		return errors.New("kafka options address is null")
+	}
+
+	if k.KafkaOptions == nil {
+		return errors.New("kafka options is null")
+	}
+
+	switch k.KafkaOptions.OffsetReset {
+	case "", KafkaOffsetNewest, KafkaOffsetOldest:
+	default:
+		return fmt.Errorf("kafka options offsetReset %s is invalid, must be %s or %s",
+			k.KafkaOptions.OffsetReset, KafkaOffsetNewest, KafkaOffsetOldest)
+	}
+
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	glog "github.com/Tencent/bk-bcs/bcs-common/common/blog"
 	"github.com/micro/go-micro/v2/broker"
@@ -34,6 +35,15 @@ type MessageQueue interface {
 	Subscribe(handler Handler, filters []Filter, resourceType string) (UnSub, error)
 	// SubscribeWithQueueName subscribe topic with custom quenename
 	SubscribeWithQueueName(handler Handler, filters []Filter, queuename, topic string) (UnSub, error)
+	// SubscribeWithRetry subscribe topic with custom queuename, data failed by handler is retried
+	// and parked into dead-letter topic when retries are exhausted
+	SubscribeWithRetry(handler Handler, filters []Filter, queuename, topic string, retry *RetryOptions) (UnSub, error)
+
+	// ListDeadLetters list data of dead-letter topic kept in dead-letter store
+	ListDeadLetters(deadLetterTopic string, offset, limit int) ([]*DeadLetter, error)
+	// ReplayDeadLetters hand dead letters in store to the subscriber which failed them, replayed dead letters
+	// are removed from store
+	ReplayDeadLetters(deadLetterTopic string, ids []string) (int, error)
 
 	// String return queue name
 	String() (string, error)
@@ -41,6 +51,9 @@ type MessageQueue interface {
 	Stop()
 }
 
+// errDeadLetterStoreNotSet dead letters can only be consumed from dead-letter topic without store
+var errDeadLetterStoreNotSet = errors.New("dead-letter store is not set")
+
 // UnSub for unSubscribe topic
 type UnSub interface {
 	Unsubscribe() error
@@ -52,9 +65,26 @@ type MsgQueue struct {
 	broker       broker.Broker
 	ctx          context.Context
 	cancel       context.CancelFunc
+
+	// subscribers in this process by queue name and handler name, dead letters are replayed to them
+	subscriberLock sync.RWMutex
+	subscribers    map[string][]*objectHandler
+}
+
+// subscription unsubscribes topic and removes subscriber from queue
+type subscription struct {
+	broker.Subscriber
+	mq     *MsgQueue
+	object *objectHandler
 }
 
-// NewMsgQueue init queue for rabbitmq/nats
+// Unsubscribe topic
+func (s *subscription) Unsubscribe() error {
+	s.mq.removeSubscriber(s.object)
+	return s.Subscriber.Unsubscribe()
+}
+
+// NewMsgQueue init queue for rabbitmq/nats/kafka
 func NewMsgQueue(opts ...QueueOption) (MessageQueue, error) {
 	var (
 		queueOptions = GetDefaultOptions()
@@ -64,9 +94,15 @@ func NewMsgQueue(opts ...QueueOption) (MessageQueue, error) {
 	for _, o := range opts {
 		o(queueOptions)
 	}
+	if queueOptions.RetryOptions != nil {
+		if err = queueOptions.RetryOptions.validate(); err != nil {
+			return nil, err
+		}
+	}
 
 	messageQueue := &MsgQueue{
 		queueOptions: queueOptions,
+		subscribers:  make(map[string][]*objectHandler),
 	}
 
 	messageQueue.broker, err = NewQueueBroker(queueOptions)
@@ -99,14 +135,7 @@ func (mq *MsgQueue) Publish(data *broker.Message) error {
 		return errMsg
 	}
 
-	switch mq.queueOptions.CommonOptions.QueueKind {
-	case RABBITMQ:
-		err = mq.broker.Publish(queueName, data, rabbitmq.DeliveryMode(mq.queueOptions.PublishOptions.DeliveryMode))
-	case NATSTREAMING:
-		err = mq.broker.Publish(queueName, data)
-	default:
-		return errors.New("unsupported queue kind")
-	}
+	err = mq.publish(queueName, data)
 	if err != nil {
 		errMsg := fmt.Errorf("[pub] message failed: [messageType: %s], [messageQueue: %s], [cluster_id: %s], [namespace: %s], [resourceName: %s]",
 			data.Header["resourceType"], queueName, data.Header["id"], data.Header["namespace"], data.Header["resourceName"])
@@ -119,6 +148,18 @@ func (mq *MsgQueue) Publish(data *broker.Message) error {
 	return nil
 }
 
+func (mq *MsgQueue) publish(topic string, data *broker.Message) error {
+	switch mq.queueOptions.CommonOptions.QueueKind {
+	case RABBITMQ:
+		return mq.broker.Publish(topic, data, rabbitmq.DeliveryMode(mq.queueOptions.PublishOptions.DeliveryMode))
+	case NATSTREAMING, KAFKA:
+		// kafka partition key is derived from message headers by broker
+		return mq.broker.Publish(topic, data)
+	default:
+		return errors.New("unsupported queue kind")
+	}
+}
+
 // Subscribe subscribe resourceType data with specific handler and filters
 func (mq *MsgQueue) Subscribe(handler Handler, filters []Filter, resourceType string) (UnSub, error) {
 	if !mq.queueOptions.CommonOptions.QueueFlag {
@@ -128,11 +169,24 @@ func (mq *MsgQueue) Subscribe(handler Handler, filters []Filter, resourceType st
 	return mq.SubscribeWithQueueName(handler, filters, resourceType, resourceType)
 }
 
-// SubscribeWithQueueName subscribe resourceType data with specific handler and filters
+// SubscribeWithQueueName subscribe resourceType data with specific handler and filters,
+// failed data is retried by queue RetryOptions
 func (mq *MsgQueue) SubscribeWithQueueName(handler Handler, filters []Filter, queueName, resourceType string) (UnSub, error) {
+	return mq.SubscribeWithRetry(handler, filters, queueName, resourceType, mq.queueOptions.RetryOptions)
+}
+
+// SubscribeWithRetry subscribe resourceType data with specific handler and filters, failed data is retried
+// with backoff and published into dead-letter topic when retries are exhausted, nil retry means dropping failed data
+func (mq *MsgQueue) SubscribeWithRetry(handler Handler, filters []Filter, queueName, resourceType string,
+	retry *RetryOptions) (UnSub, error) {
 	if !mq.queueOptions.CommonOptions.QueueFlag {
 		return nil, errors.New("queue flag is off")
 	}
+	if retry != nil {
+		if err := retry.validate(); err != nil {
+			return nil, err
+		}
+	}
 
 	var (
 		topic      = resourceType
@@ -140,6 +194,11 @@ func (mq *MsgQueue) SubscribeWithQueueName(handler Handler, filters []Filter, qu
 			resourceType: resourceType,
 			handler:      handler,
 			filter:       filters,
+			ctx:          mq.ctx,
+			queueName:    queueName,
+			retry:        retry,
+			deadLetters:  mq.queueOptions.DeadLetterStore,
+			publish:      mq.publish,
 		}
 	)
 	subscribeOptions, err := mq.getSubOptions(queueName)
@@ -153,9 +212,112 @@ func (mq *MsgQueue) SubscribeWithQueueName(handler Handler, filters []Filter, qu
 		return nil, err
 	}
 
+	mq.addSubscriber(podHandler)
+
 	glog.V(4).Infof("subscribe [%s:%s] successful", subscribe.Options().Queue, subscribe.Topic())
 
-	return subscribe, nil
+	return &subscription{Subscriber: subscribe, mq: mq, object: podHandler}, nil
+}
+
+func subscriberKey(queueName, handlerName string) string {
+	return queueName + "/" + handlerName
+}
+
+func (mq *MsgQueue) addSubscriber(object *objectHandler) {
+	mq.subscriberLock.Lock()
+	defer mq.subscriberLock.Unlock()
+
+	key := subscriberKey(object.queueName, object.handler.Name())
+	mq.subscribers[key] = append(mq.subscribers[key], object)
+}
+
+func (mq *MsgQueue) removeSubscriber(object *objectHandler) {
+	mq.subscriberLock.Lock()
+	defer mq.subscriberLock.Unlock()
+
+	key := subscriberKey(object.queueName, object.handler.Name())
+	var objects []*objectHandler
+	for _, o := range mq.subscribers[key] {
+		if o != object {
+			objects = append(objects, o)
+		}
+	}
+	if len(objects) == 0 {
+		delete(mq.subscribers, key)
+		return
+	}
+	mq.subscribers[key] = objects
+}
+
+func (mq *MsgQueue) getSubscriber(queueName, handlerName string) *objectHandler {
+	mq.subscriberLock.RLock()
+	defer mq.subscriberLock.RUnlock()
+
+	objects := mq.subscribers[subscriberKey(queueName, handlerName)]
+	if len(objects) == 0 {
+		return nil
+	}
+	return objects[0]
+}
+
+// ListDeadLetters list data of dead-letter topic kept in dead-letter store by park time
+func (mq *MsgQueue) ListDeadLetters(deadLetterTopic string, offset, limit int) ([]*DeadLetter, error) {
+	if mq.queueOptions.DeadLetterStore == nil {
+		return nil, errDeadLetterStoreNotSet
+	}
+	timeoutCtx, cancel := context.WithTimeout(mq.ctx, HandleTimeout)
+	defer cancel()
+
+	return mq.queueOptions.DeadLetterStore.List(timeoutCtx, deadLetterTopic, offset, limit)
+}
+
+// ReplayDeadLetters hand dead letters to the subscriber which failed them instead of republishing into original topic,
+// so other subscribers and queues of the topic don't receive them again. The subscriber must be subscribed in this
+// process. It returns count of replayed dead letters, replayed dead letters are removed and the ones failed are kept
+func (mq *MsgQueue) ReplayDeadLetters(deadLetterTopic string, ids []string) (int, error) {
+	if !mq.queueOptions.CommonOptions.QueueFlag {
+		return 0, errors.New("queue flag is off")
+	}
+	if mq.queueOptions.DeadLetterStore == nil {
+		return 0, errDeadLetterStoreNotSet
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(mq.ctx, HandleTimeout)
+	defer cancel()
+	letters, err := mq.queueOptions.DeadLetterStore.Get(timeoutCtx, deadLetterTopic, ids)
+	if err != nil {
+		return 0, fmt.Errorf("get dead letters from %s failed: %v", deadLetterTopic, err)
+	}
+
+	var replayed []string
+	for _, letter := range letters {
+		object := mq.getSubscriber(letter.QueueName, letter.HandlerName)
+		if object == nil {
+			err = fmt.Errorf("handler %s of queue %s is not subscribed", letter.HandlerName, letter.QueueName)
+			glog.Errorf("replay dead letter %s[%s] failed: %v", deadLetterTopic, letter.ID, err)
+			break
+		}
+		if err = object.replay(letter); err != nil {
+			glog.Errorf("replay dead letter %s[%s] to handler %s of queue %s failed: %v",
+				deadLetterTopic, letter.ID, letter.HandlerName, letter.QueueName, err)
+			break
+		}
+		replayed = append(replayed, letter.ID)
+	}
+	if len(replayed) != 0 {
+		if delErr := mq.queueOptions.DeadLetterStore.Delete(timeoutCtx, deadLetterTopic, replayed); delErr != nil {
+			return len(replayed), fmt.Errorf("remove replayed dead letters from %s failed: %v", deadLetterTopic, delErr)
+		}
+	}
+	if err != nil {
+		return len(replayed), fmt.Errorf("replay dead letters from %s failed: %v", deadLetterTopic, err)
+	}
+
+	glog.V(4).Infof("replay %d dead letters from %s successful", len(replayed), deadLetterTopic)
+	return len(replayed), nil
 }
 
 // Handlers of all topics
@@ -222,6 +384,11 @@ func (mq *MsgQueue) getSubOptions(queueName string) ([]broker.SubscribeOption, e
 			natsopts = append(natsopts, natstan.MaxInflight(mq.queueOptions.SubscribeOptions.MaxInFlight))
 		}
 		subOptions = append(subOptions, stan.SubscribeOption(natsopts...))
+	case KAFKA:
+		// queueName is used as consumer group, offset reset is set by KafkaOptions
+		if mq.queueOptions.SubscribeOptions.AckOnSuccess {
+			subOptions = append(subOptions, kafkaAckOnSuccess())
+		}
 	default:
 		return nil, errors.New("unsupported queue kind")
 	}
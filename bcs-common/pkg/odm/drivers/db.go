@@ -27,6 +27,36 @@ type Index struct {
 	Background bool             `json:"background" bson:"background"`
 }
 
+// BulkOperationType type of operation in bulk write
+type BulkOperationType string
+
+const (
+	// BulkUpsert update one matched document, or insert it when no document matches
+	BulkUpsert BulkOperationType = "upsert"
+	// BulkUpdateMany update all matched documents
+	BulkUpdateMany BulkOperationType = "updateMany"
+	// BulkDeleteMany delete all matched documents
+	BulkDeleteMany BulkOperationType = "deleteMany"
+)
+
+// BulkOperation operation in bulk write
+type BulkOperation struct {
+	Type      BulkOperationType
+	Condition *operator.Condition
+	// Data update document, not used by delete operation
+	Data interface{}
+}
+
+// BulkWriteResult result of bulk write
+type BulkWriteResult struct {
+	MatchedCount  int64
+	ModifiedCount int64
+	UpsertedCount int64
+	DeletedCount  int64
+	// Errors errors of failed operations, the key is index of operation
+	Errors map[int]error
+}
+
 // DB interface for database
 type DB interface {
 	// DataBase() get database name
@@ -35,6 +65,10 @@ type DB interface {
 	// Ping ping the database server
 	Ping() error
 
+	// CurrentTimestamp get current operation timestamp of database, changes after it can be watched
+	// by WithStartTimestamp
+	CurrentTimestamp(ctx context.Context) (Timestamp, error)
+
 	// Close close database client
 	Close() error
 
@@ -111,6 +145,9 @@ type WatchEvent struct {
 	UpdatedFields  map[string]interface{}
 	RemovedFields  []string
 	Data           operator.M
+	// OperationTime operation timestamp of event, unlike ClusterTime it keeps the ordinal
+	// within a second, so it can be used as resume point of watch
+	OperationTime Timestamp
 }
 
 // Watch interface for watch action
@@ -135,6 +172,10 @@ type Watch interface {
 	// the least significant 32 bits are an incrementing ordinal for operations within a given second.
 	WithStartTimestamp(uint32, uint32) Watch
 
+	// WithCloseEvent set if watch sends close event when stream ends without cancellation,
+	// and error event when stream fails, so that caller can restart watch
+	WithCloseEvent(notify bool) Watch
+
 	// DoWatch do watch action
 	DoWatch(ctx context.Context) (chan *WatchEvent, error)
 }
@@ -156,7 +197,8 @@ type Table interface {
 	// Find get find object
 	Find(condition *operator.Condition) Find
 
-	// Aggregation aggregation operation
+	// Aggregation aggregation operation, stage in pipeline can be *operator.Condition,
+	// e.g. operator.Mat condition for $match stage
 	Aggregation(ctx context.Context, pipeline interface{}, result interface{}) error
 
 	// Insert insert many data
@@ -174,6 +216,10 @@ type Table interface {
 	// Delete delete data
 	Delete(ctx context.Context, condition *operator.Condition) (int64, error)
 
+	// BulkWrite execute operations in one request without order, failure of one operation
+	// does not stop the others, errors of failed operations are returned in result
+	BulkWrite(ctx context.Context, operations []*BulkOperation) (*BulkWriteResult, error)
+
 	// Watch watch data
 	Watch(condition []*operator.Condition) Watch
 }
@@ -21,4 +21,6 @@ var (
 	ErrTableRecordNotFound = errors.New("record not found")
 	// ErrTableRecordDuplicateKey database index key is duplicate
 	ErrTableRecordDuplicateKey = errors.New("duplicate key error")
+	// ErrWatchHistoryLost start point of watch is no longer kept in database
+	ErrWatchHistoryLost = errors.New("watch history lost")
 )
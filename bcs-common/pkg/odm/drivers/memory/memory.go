@@ -90,6 +90,11 @@ func (db *DB) Ping() error {
 	return nil
 }
 
+// CurrentTimestamp current time
+func (db *DB) CurrentTimestamp(ctx context.Context) (drivers.Timestamp, error) {
+	return drivers.Timestamp{Second: uint32(time.Now().Unix())}, nil
+}
+
 // CreateTable create table
 func (db *DB) CreateTable(ctx context.Context, tableName string) error {
 	_, ok := db.data[tableName]
@@ -277,6 +282,11 @@ func (w *DBTableWatcher) WithStartTimestamp(timeSec uint32, index uint32) driver
 	return w
 }
 
+// WithCloseEvent set if watch sends close or error event when stream ends
+func (w *DBTableWatcher) WithCloseEvent(notify bool) drivers.Watch {
+	return w
+}
+
 // DoWatch do watch action
 func (w *DBTableWatcher) DoWatch(ctx context.Context) (chan *drivers.WatchEvent, error) {
 	return nil, nil
@@ -113,6 +113,34 @@ func (db *DB) Ping() error {
 	return err
 }
 
+// CurrentTimestamp get current cluster time of mongodb replica set
+func (db *DB) CurrentTimestamp(ctx context.Context) (drivers.Timestamp, error) {
+	var err error
+	startTime := time.Now()
+	defer func() {
+		reportMongdbMetrics("currentTimestamp", err, startTime)
+	}()
+	result := struct {
+		OperationTime primitive.Timestamp `bson:"operationTime"`
+		ClusterTime   struct {
+			ClusterTime primitive.Timestamp `bson:"clusterTime"`
+		} `bson:"$clusterTime"`
+	}{}
+	err = db.mCli.Database(db.dbName).RunCommand(ctx, bson.D{{Key: "ping", Value: 1}}).Decode(&result)
+	if err != nil {
+		return drivers.Timestamp{}, err
+	}
+	ts := result.ClusterTime.ClusterTime
+	if ts.T == 0 {
+		ts = result.OperationTime
+	}
+	if ts.T == 0 {
+		err = fmt.Errorf("no cluster time in response, mongodb is not a replica set")
+		return drivers.Timestamp{}, err
+	}
+	return drivers.Timestamp{Second: ts.T, Index: ts.I}, nil
+}
+
 // HasTable if table exists
 func (db *DB) HasTable(ctx context.Context, tableName string) (bool, error) {
 	var err error
@@ -287,6 +315,18 @@ func (c *Collection) Aggregation(ctx context.Context, pipeline interface{}, resu
 	defer func() {
 		reportMongdbMetrics("aggregation", err, startTime)
 	}()
+	// convert condition stages in pipeline, e.g. $match stage built by operator.Mat condition
+	if stages, ok := pipeline.([]interface{}); ok {
+		convertedStages := make([]interface{}, 0, len(stages))
+		for _, stage := range stages {
+			if condition, isCondition := stage.(*operator.Condition); isCondition {
+				convertedStages = append(convertedStages, condition.Combine(leafNodeProcessor, branchNodeProcessor))
+				continue
+			}
+			convertedStages = append(convertedStages, stage)
+		}
+		pipeline = convertedStages
+	}
 	cursor, err = c.mCli.Database(c.dbName).
 		Collection(c.collectionName).
 		Aggregate(ctx, pipeline, &mopt.AggregateOptions{})
@@ -381,6 +421,60 @@ func (c *Collection) Delete(ctx context.Context, condition *operator.Condition)
 	return ret.DeletedCount, nil
 }
 
+// BulkWrite execute operations in one request without order
+func (c *Collection) BulkWrite(ctx context.Context, operations []*drivers.BulkOperation) (
+	*drivers.BulkWriteResult, error) {
+	var ret *mongo.BulkWriteResult
+	var err error
+	startTime := time.Now()
+	defer func() {
+		reportMongdbMetrics("bulkWrite", err, startTime)
+	}()
+	result := &drivers.BulkWriteResult{
+		Errors: make(map[int]error),
+	}
+	if len(operations) == 0 {
+		return result, nil
+	}
+	models := make([]mongo.WriteModel, 0, len(operations))
+	for _, op := range operations {
+		// convert condition to filter
+		filter := op.Condition.Combine(leafNodeProcessor, branchNodeProcessor)
+		switch op.Type {
+		case drivers.BulkUpsert:
+			models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(op.Data).SetUpsert(true))
+		case drivers.BulkUpdateMany:
+			models = append(models, mongo.NewUpdateManyModel().SetFilter(filter).SetUpdate(op.Data))
+		case drivers.BulkDeleteMany:
+			models = append(models, mongo.NewDeleteManyModel().SetFilter(filter))
+		default:
+			err = fmt.Errorf("unknown bulk operation type %s", op.Type)
+			return nil, err
+		}
+	}
+	ordered := false
+	ret, err = c.mCli.Database(c.dbName).Collection(c.collectionName).
+		BulkWrite(ctx, models, &mopt.BulkWriteOptions{Ordered: &ordered})
+	if err != nil {
+		bulkErr, ok := err.(mongo.BulkWriteException)
+		if !ok || bulkErr.WriteConcernError != nil {
+			return nil, err
+		}
+		for _, writeErr := range bulkErr.WriteErrors {
+			result.Errors[writeErr.Index] = fmt.Errorf("%s", writeErr.Message)
+		}
+		// errors of operations are returned in result, the request itself succeeded
+		err = nil
+	}
+	if ret != nil {
+		result.MatchedCount = ret.MatchedCount
+		result.ModifiedCount = ret.ModifiedCount
+		result.UpsertedCount = ret.UpsertedCount
+		result.DeletedCount = ret.DeletedCount
+	}
+	return result, nil
+}
+
 // Watch watch data
 func (c *Collection) Watch(conditions []*operator.Condition) drivers.Watch {
 	return &Watcher{
@@ -511,6 +605,7 @@ type Watcher struct {
 	isFull           bool
 	maxAwaitDuration time.Duration
 	startTimestamp   *primitive.Timestamp
+	closeEvent       bool
 	conditions       []*operator.Condition
 	*Collection
 }
@@ -542,6 +637,12 @@ func (w *Watcher) WithStartTimestamp(timeSec uint32, index uint32) drivers.Watch
 	return w
 }
 
+// WithCloseEvent set if watch sends close or error event when stream ends without cancellation
+func (w *Watcher) WithCloseEvent(notify bool) drivers.Watch {
+	w.closeEvent = notify
+	return w
+}
+
 // DoWatch do watch action
 func (w *Watcher) DoWatch(ctx context.Context) (chan *drivers.WatchEvent, error) {
 	changeStreamOpt := &mopt.ChangeStreamOptions{}
@@ -576,6 +677,9 @@ func (w *Watcher) DoWatch(ctx context.Context) (chan *drivers.WatchEvent, error)
 	}()
 	changeStream, err = w.mCli.Database(w.dbName).Collection(w.collectionName).Watch(ctx, filters, changeStreamOpt)
 	if err != nil {
+		if w.startTimestamp != nil && isChangeStreamHistoryLost(err) {
+			return nil, drivers.ErrWatchHistoryLost
+		}
 		return nil, err
 	}
 
@@ -603,7 +707,32 @@ func (w *Watcher) DoWatch(ctx context.Context) (chan *drivers.WatchEvent, error)
 			}
 			eventChannel <- newEvent
 		}
+		// stream is stopped by caller, or caller does not care about end of stream
+		if ctx.Err() != nil || !w.closeEvent {
+			return
+		}
+		if err := changeStream.Err(); err != nil {
+			blog.Errorf("change stream of %s.%s failed, err %s", w.dbName, w.collectionName, err.Error())
+			eventChannel <- errEvent
+			return
+		}
+		eventChannel <- &drivers.WatchEvent{
+			Type:           drivers.EventClose,
+			DBName:         w.dbName,
+			CollectionName: w.collectionName,
+		}
 	}()
 
 	return eventChannel, nil
 }
+
+// isChangeStreamHistoryLost check if start point of change stream has been trimmed from oplog
+func isChangeStreamHistoryLost(err error) bool {
+	if cmdErr, ok := err.(mongo.CommandError); ok {
+		// 286: ChangeStreamHistoryLost, 280: ChangeStreamFatalError before mongodb 4.2
+		if cmdErr.Code == 286 || (cmdErr.Code == 280 && strings.Contains(cmdErr.Message, "oplog")) {
+			return true
+		}
+	}
+	return strings.Contains(err.Error(), "resume point may no longer be in the oplog")
+}
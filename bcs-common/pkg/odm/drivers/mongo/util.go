@@ -61,6 +61,7 @@ func convertMongoEvent(data Event) (*drivers.WatchEvent, error) {
 			CollectionName: data.Ns.ColName,
 			Data:           operator.M(data.FullDocument),
 			ClusterTime:    time.Unix(int64(data.ClusterTime.T), 0),
+			OperationTime:  drivers.Timestamp{Second: data.ClusterTime.T, Index: data.ClusterTime.I},
 			TxnNumber:      data.TxnNumber,
 		}
 	case operationTypeUpdate:
@@ -70,6 +71,7 @@ func convertMongoEvent(data Event) (*drivers.WatchEvent, error) {
 			CollectionName: data.Ns.ColName,
 			Data:           operator.M(data.FullDocument),
 			ClusterTime:    time.Unix(int64(data.ClusterTime.T), 0),
+			OperationTime:  drivers.Timestamp{Second: data.ClusterTime.T, Index: data.ClusterTime.I},
 			TxnNumber:      data.TxnNumber,
 		}
 		if data.UpdateDesc != nil {
@@ -84,6 +86,7 @@ func convertMongoEvent(data Event) (*drivers.WatchEvent, error) {
 			CollectionName: data.Ns.ColName,
 			Data:           operator.M(data.FullDocument),
 			ClusterTime:    time.Unix(int64(data.ClusterTime.T), 0),
+			OperationTime:  drivers.Timestamp{Second: data.ClusterTime.T, Index: data.ClusterTime.I},
 			TxnNumber:      data.TxnNumber,
 		}
 
@@ -94,6 +97,7 @@ func convertMongoEvent(data Event) (*drivers.WatchEvent, error) {
 			CollectionName: data.Ns.ColName,
 			Data:           operator.M(data.FullDocument),
 			ClusterTime:    time.Unix(int64(data.ClusterTime.T), 0),
+			OperationTime:  drivers.Timestamp{Second: data.ClusterTime.T, Index: data.ClusterTime.I},
 			TxnNumber:      data.TxnNumber,
 		}
 
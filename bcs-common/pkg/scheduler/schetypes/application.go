@@ -173,6 +173,9 @@ type Version struct {
 	Kind commtypes.BcsDataType
 	// commtypes.ReplicaController json
 	RawJson *commtypes.ReplicaController `json:"raw_json,omitempty"`
+	// priority of taskgroups and policy for preempting taskgroups with lower priority
+	Priority   int32                       `json:"priority,omitempty"`
+	Preemption *commtypes.PreemptionPolicy `json:"preemption,omitempty"`
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -250,7 +253,7 @@ func (version *Version) CheckAndDefaultResource() error {
 			}
 		}
 		return nil
-	case commtypes.BcsDataType_APP, "":
+	case commtypes.BcsDataType_APP, "", commtypes.BcsDataType_Job:
 		for index, container := range version.Container {
 			if nil == container.DataClass {
 				version.Container[index].DataClass = &DataClass{}
@@ -283,25 +286,53 @@ func (version *Version) CheckConstraints() bool {
 			if oneData == nil {
 				continue
 			}
-			if oneData.Type == commtypes.ConstValueType_Scalar && oneData.Scalar == nil {
+			if !checkConstraintData(oneData) {
 				return false
 			}
-			if oneData.Type == commtypes.ConstValueType_Text && oneData.Text == nil {
-				return false
-			}
-			if oneData.Type == commtypes.ConstValueType_Set && oneData.Set == nil {
+		}
+	}
+
+	for _, preferred := range version.Constraints.Preferred {
+		if preferred == nil {
+			continue
+		}
+		if preferred.Preference == nil || !checkConstraintData(preferred.Preference) {
+			return false
+		}
+		if preferred.Weight <= 0 || preferred.Weight > commtypes.PreferredWeight_MAX {
+			return false
+		}
+	}
+
+	for _, scorer := range version.Constraints.Scorers {
+		if scorer == nil {
+			continue
+		}
+		if scorer.Name == "" || scorer.Weight < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func checkConstraintData(oneData *commtypes.ConstraintData) bool {
+	if oneData.Type == commtypes.ConstValueType_Scalar && oneData.Scalar == nil {
+		return false
+	}
+	if oneData.Type == commtypes.ConstValueType_Text && oneData.Text == nil {
+		return false
+	}
+	if oneData.Type == commtypes.ConstValueType_Set && oneData.Set == nil {
+		return false
+	}
+	if oneData.Type == commtypes.ConstValueType_Range {
+		for _, oneRange := range oneData.Ranges {
+			if oneRange == nil {
 				return false
 			}
-			if oneData.Type == commtypes.ConstValueType_Range {
-				for _, oneRange := range oneData.Ranges {
-					if oneRange == nil {
-						return false
-					}
-				}
-			}
 		}
 	}
-
 	return true
 }
 
@@ -317,7 +348,7 @@ func (version *Version) AllCpus() float64 {
 			cpu, _ := strconv.ParseFloat(process.Resources.Limits.Cpu, 64)
 			allCpus = allCpus + cpu
 		}
-	case commtypes.BcsDataType_APP, "":
+	case commtypes.BcsDataType_APP, "", commtypes.BcsDataType_Job:
 		for _, container := range version.Container {
 			allCpus = allCpus + container.DataClass.Resources.Cpus
 		}
@@ -336,7 +367,7 @@ func (version *Version) AllMems() float64 {
 			mem, _ := strconv.ParseFloat(process.Resources.Limits.Mem, 64)
 			allMem = allMem + mem
 		}
-	case commtypes.BcsDataType_APP, "":
+	case commtypes.BcsDataType_APP, "", commtypes.BcsDataType_Job:
 		for _, container := range version.Container {
 			allMem = allMem + container.DataClass.Resources.Mem
 		}
@@ -355,7 +386,7 @@ func (version *Version) AllDisk() float64 {
 			disk, _ := strconv.ParseFloat(process.Resources.Limits.Storage, 64)
 			allDisk = allDisk + disk
 		}
-	case commtypes.BcsDataType_APP, "":
+	case commtypes.BcsDataType_APP, "", commtypes.BcsDataType_Job:
 		for _, container := range version.Container {
 			allDisk = allDisk + container.DataClass.Resources.Disk
 		}
@@ -689,6 +720,8 @@ type TaskGroup struct {
 	Message        string
 	LaunchResource *Resource
 	CurrResource   *Resource
+	// priority inherited from version, used for preemption
+	Priority int32
 	//BcsMessages map[int64]*BcsMessage
 	BcsEventMsg *BcsMessage
 	// Populated by the system.
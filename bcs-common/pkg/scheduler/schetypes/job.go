@@ -0,0 +1,147 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+import (
+	"fmt"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+)
+
+// job status
+const (
+	Job_Status_Staging  = "Staging"
+	Job_Status_Running  = "Running"
+	Job_Status_Complete = "Complete"
+	Job_Status_Failed   = "Failed"
+	Job_Status_Deleting = "Deleting"
+)
+
+// JobParameter parameters controlling how job taskgroups run to completion
+type JobParameter struct {
+	//number of taskgroups that should finish successfully
+	Completions int32 `json:"completions"`
+	//max number of taskgroups running at the same time
+	Parallelism int32 `json:"parallelism"`
+	//number of failed taskgroups before marking job failed
+	BackoffLimit int32 `json:"backoffLimit"`
+	//duration in seconds job may be active before it is terminated, 0 means no limit
+	ActiveDeadlineSeconds int64 `json:"activeDeadlineSeconds"`
+}
+
+// BcsJob run-to-completion job, taskgroups of job belong to the application with the same namespace and name
+type BcsJob struct {
+	commtypes.ObjectMeta `json:",inline"`
+	JobParameter         `json:",inline"`
+	//version of the taskgroups, the kind is job and restart policy is never
+	Version *Version `json:"version"`
+	//name of cronjob which creates the job, empty if job is created directly
+	CronJob string `json:"cronJob,omitempty"`
+
+	Status  string
+	Message string
+	//number of running, succeeded and failed taskgroups
+	Active         int32
+	Succeeded      int32
+	Failed         int32
+	Created        int64
+	StartTime      int64
+	CompletionTime int64
+	LastUpdateTime int64
+	// Populated by the system.
+	// Read-only.
+	// Value must be treated as opaque by clients and .
+	ResourceVersion string `json:"-"`
+}
+
+// GetUuid get job the unique uuid
+func (j *BcsJob) GetUuid() string {
+	return fmt.Sprintf("%s.%s", j.NameSpace, j.Name)
+}
+
+// IsFinished whether job is complete or failed
+func (j *BcsJob) IsFinished() bool {
+	return j.Status == Job_Status_Complete || j.Status == Job_Status_Failed
+}
+
+// BcsCronJob creates jobs on a cron schedule
+type BcsCronJob struct {
+	commtypes.ObjectMeta `json:",inline"`
+	//cron expression, such as "*/5 * * * *" or "@hourly"
+	Schedule          string                      `json:"schedule"`
+	ConcurrencyPolicy commtypes.ConcurrencyPolicy `json:"concurrencyPolicy"`
+	Suspend           bool                        `json:"suspend"`
+	//number of complete and failed jobs to keep
+	SuccessfulJobsHistoryLimit int32 `json:"successfulJobsHistoryLimit"`
+	FailedJobsHistoryLimit     int32 `json:"failedJobsHistoryLimit"`
+	//template of jobs, name of the job created is cronjob name with scheduled time suffix
+	JobTemplate *BcsJob `json:"jobTemplate"`
+
+	//last time a job is created, unix seconds
+	LastScheduleTime int64
+	//names of jobs created by cronjob and not finished yet
+	Active         []string
+	Created        int64
+	LastUpdateTime int64
+	// Populated by the system.
+	// Read-only.
+	// Value must be treated as opaque by clients and .
+	ResourceVersion string `json:"-"`
+}
+
+// GetUuid get cronjob the unique uuid
+func (c *BcsCronJob) GetUuid() string {
+	return fmt.Sprintf("%s.%s", c.NameSpace, c.Name)
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsJob) DeepCopyInto(out *BcsJob) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Version = in.Version.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsJob.
+func (in *BcsJob) DeepCopy() *BcsJob {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsCronJob) DeepCopyInto(out *BcsCronJob) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.JobTemplate = in.JobTemplate.DeepCopy()
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsCronJob.
+func (in *BcsCronJob) DeepCopy() *BcsCronJob {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsCronJob)
+	in.DeepCopyInto(out)
+	return out
+}
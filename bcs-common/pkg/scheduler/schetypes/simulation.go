@@ -0,0 +1,51 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package types
+
+// SimulationResult result of scheduling simulation, which runs taskgroups of a version
+// through constraint and resource fitting against current offers without launching anything
+type SimulationResult struct {
+	RunAs string `json:"namespace"`
+	ID    string `json:"name"`
+	//number of taskgroups requested to place
+	Replicas int `json:"replicas"`
+	//number of taskgroups which would be placed
+	Placed int `json:"placed"`
+	//resource needed by one taskgroup
+	NeedResource *Resource `json:"needResource"`
+	//hosts and the number of taskgroups placed on each
+	Hosts []*SimulationHost `json:"hosts"`
+	//hosts rejected when no more taskgroup can be placed, empty if all replicas are placed
+	Rejections []*SimulationRejection `json:"rejections,omitempty"`
+}
+
+// SimulationHost taskgroups placed on host in simulation
+type SimulationHost struct {
+	Hostname string `json:"hostname"`
+	Placed   int    `json:"placed"`
+}
+
+// SimulationRejection reason why host can not hold one more taskgroup
+type SimulationRejection struct {
+	Hostname string `json:"hostname"`
+	Reason   string `json:"reason"`
+	Message  string `json:"message"`
+}
+
+// simulation rejection reasons
+const (
+	SimulationReasonResource         = "InsufficientResource"
+	SimulationReasonExtendedResource = "InsufficientExtendedResource"
+	SimulationReasonConstraints      = "ConstraintsNotFit"
+)
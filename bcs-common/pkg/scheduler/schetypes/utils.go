@@ -41,7 +41,7 @@ func GetTaskgroupIP(t *TaskGroup) string {
 		switch oneTask.Kind {
 		case commtypes.BcsDataType_PROCESS:
 			return oneTask.AgentIPAddress
-		case commtypes.BcsDataType_APP, "":
+		case commtypes.BcsDataType_APP, "", commtypes.BcsDataType_Job:
 			if len(oneTask.StatusData) == 0 {
 				continue
 			}
@@ -0,0 +1,484 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package mongo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/meta"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/drivers"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/storage"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/watch"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	//defaultDeletedTTL time for keeping deleted data, watchers get deleted object from it
+	defaultDeletedTTL = 10 * time.Minute
+	//purgeInterval interval for purging deleted data
+	purgeInterval = time.Minute
+)
+
+//Config mongo storage config
+type Config struct {
+	DB         drivers.DB       //database accessor, it's shared and not closed by storage
+	Table      string           //table holding data, all keys are under table like etcd path prefix
+	NewFunc    meta.ObjectNewFn //func for object creation
+	Codec      meta.Codec       //Codec for encoder & decoder
+	DeletedTTL time.Duration    //time for keeping deleted data for watchers, default 10 minutes
+}
+
+//NewStorage create mongo accessor implemented storage interface
+func NewStorage(config *Config) (storage.Storage, error) {
+	if config == nil || config.DB == nil || len(config.Table) == 0 {
+		return nil, fmt.Errorf("lost mongo database or table")
+	}
+	if config.NewFunc == nil || config.Codec == nil {
+		return nil, fmt.Errorf("lost object NewFunc or Codec for table %s", config.Table)
+	}
+	cxt, stopFn := context.WithCancel(context.Background())
+	s := &Storage{
+		db:          config.DB,
+		table:       config.Table,
+		objectNewFn: config.NewFunc,
+		codec:       config.Codec,
+		deletedTTL:  config.DeletedTTL,
+		stopFn:      stopFn,
+	}
+	if s.deletedTTL <= 0 {
+		s.deletedTTL = defaultDeletedTTL
+	}
+	if err := s.ensureTable(cxt); err != nil {
+		stopFn()
+		blog.V(3).Infof("mongo storage ensure table %s failed, %s", config.Table, err)
+		return nil, err
+	}
+	go s.purge(cxt)
+	return s, nil
+}
+
+//Storage implementation storage interface with odm mongo driver.
+//Deletion only marks data deleted, so watchers can get the deleted object from
+//change stream, deleted data is purged after deletedTTL.
+type Storage struct {
+	db          drivers.DB         //database accessor
+	table       string             //table holding data
+	objectNewFn meta.ObjectNewFn   //create new object for codec.decode
+	codec       meta.Codec         //json Codec for object
+	deletedTTL  time.Duration      //time for keeping deleted data
+	stopFn      context.CancelFunc //stop purging
+}
+
+func (s *Storage) ensureTable(cxt context.Context) error {
+	hasTable, err := s.db.HasTable(cxt, s.table)
+	if err != nil {
+		return err
+	}
+	if !hasTable {
+		if err := s.db.CreateTable(cxt, s.table); err != nil {
+			return err
+		}
+	}
+	indexes := []drivers.Index{
+		{Name: s.table + "_key_idx", Key: map[string]int32{keyField: 1}, Unique: true},
+		{Name: s.table + "_paths_idx", Key: map[string]int32{pathsField: 1}},
+	}
+	for _, index := range indexes {
+		hasIndex, err := s.db.Table(s.table).HasIndex(cxt, index.Name)
+		if err != nil {
+			return err
+		}
+		if hasIndex {
+			continue
+		}
+		if err := s.db.Table(s.table).CreateIndex(cxt, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Create implements storage interface
+//param key: full key of object, such as namespace/name
+func (s *Storage) Create(cxt context.Context, key string, obj meta.Object, ttl int) (out meta.Object, err error) {
+	fullKey := cleanKey(key)
+	if len(fullKey) == 0 {
+		return nil, fmt.Errorf("lost object key")
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("lost object for %s", fullKey)
+	}
+	if ttl > 0 {
+		var cancelFn context.CancelFunc
+		cxt, cancelFn = context.WithTimeout(cxt, time.Second*time.Duration(ttl))
+		defer cancelFn()
+	}
+	//serialize object
+	data, err := s.codec.Encode(obj)
+	if err != nil {
+		blog.V(5).Infof("mongo storage %s encode %s/%s failed, %s", s.table, obj.GetNamespace(), obj.GetName(), err)
+		return nil, fmt.Errorf("encode %s/%s: %s", obj.GetNamespace(), obj.GetName(), err)
+	}
+	//previous data is returned for update
+	prev, err := s.getDocument(cxt, fullKey)
+	if err != nil && err != storage.ErrNotFound {
+		blog.V(3).Infof("mongo storage %s get previous %s failed, %s", s.table, fullKey, err)
+		return nil, err
+	}
+	doc := &document{
+		Key:        fullKey,
+		Paths:      keyPaths(fullKey),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Data:       string(data),
+		Deleted:    false,
+		UpdateTime: time.Now(),
+	}
+	if err := s.db.Table(s.table).Upsert(cxt, keyCondition(fullKey), operator.M{"$set": doc}); err != nil {
+		blog.V(3).Infof("mongo storage %s Create %s/%s failed, %s", s.table, obj.GetNamespace(), obj.GetName(), err)
+		return nil, err
+	}
+	if prev == nil {
+		blog.V(3).Infof("mongo storage %s create %s success", s.table, fullKey)
+		return nil, nil
+	}
+	target := s.objectNewFn()
+	if err := s.codec.Decode([]byte(prev.Data), target); err != nil {
+		blog.V(3).Infof("mongo storage %s decode %s previous value failed, %s", s.table, fullKey, err)
+		//even got previous data failed, we still consider Create successfully
+		return nil, nil
+	}
+	blog.V(3).Infof("mongo storage %s update %s & got previous data success", s.table, fullKey)
+	return target, nil
+}
+
+//Delete implements storage interface
+//* if key is empty, delete all data in table
+//* if key is not empty, delete data of key and all data under key
+//object is returned only when data of key exists
+func (s *Storage) Delete(cxt context.Context, key string) (obj meta.Object, err error) {
+	fullKey := cleanKey(key)
+	var prev *document
+	if len(fullKey) != 0 {
+		if prev, err = s.getDocument(cxt, fullKey); err != nil && err != storage.ErrNotFound {
+			blog.V(3).Infof("mongo storage %s get %s before deletion failed, %s", s.table, fullKey, err)
+			return nil, err
+		}
+	}
+	counter, err := s.db.Table(s.table).UpdateMany(cxt, existCondition(pathCondition(fullKey)), operator.M{
+		"$set": operator.M{
+			deletedField:    true,
+			updateTimeField: time.Now(),
+		},
+	})
+	if err != nil {
+		blog.V(3).Infof("mongo storage %s delete %s failed, %s", s.table, fullKey, err)
+		return nil, err
+	}
+	blog.V(3).Infof("mongo storage %s clean data under %s success, num: %d", s.table, fullKey, counter)
+	if prev == nil {
+		return nil, nil
+	}
+	target := s.objectNewFn()
+	if err := s.codec.Decode([]byte(prev.Data), target); err != nil {
+		blog.V(3).Infof("mongo storage %s decode deleted %s failed, %s", s.table, fullKey, err)
+		return nil, nil
+	}
+	return target, nil
+}
+
+//Watch implements storage interface
+//* if key empty, watch all data
+//* if key is namespace, watch all data under namespace
+//* if key is namespace/name, watch detail data
+//version is not supported, watch always starts from now. watch is stopped when any error occurs
+func (s *Storage) Watch(cxt context.Context, key, version string, selector storage.Selector) (watch.Interface, error) {
+	fullKey := cleanKey(key)
+	proxy := newMongoProxyWatch(cxt, s.codec, selector)
+	//objects matching selector before watch, so update making them unmatched can be sent as deletion
+	if selector != nil {
+		docs, err := s.listDocuments(cxt, fullKey)
+		if err != nil {
+			proxy.Stop()
+			return nil, err
+		}
+		for _, doc := range docs {
+			target := s.objectNewFn()
+			if err := s.codec.Decode([]byte(doc.Data), target); err != nil {
+				continue
+			}
+			if ok, _ := selector.Matchs(target); ok {
+				proxy.matched[doc.Key] = struct{}{}
+			}
+		}
+	}
+	stages := []*operator.Condition{
+		operator.NewBranchCondition(operator.Mat, watchCondition(fullKey)),
+	}
+	mongoCh, err := s.db.Table(s.table).Watch(stages).WithFullContent(true).WithCloseEvent(true).DoWatch(proxy.cxt)
+	if err != nil {
+		proxy.Stop()
+		blog.V(3).Infof("mongo storage %s watch %s failed, %s", s.table, fullKey, err)
+		return nil, err
+	}
+	go proxy.eventProxy(mongoCh, s.objectNewFn)
+	blog.V(3).Infof("mongo storage %s is ready to watch %s", s.table, fullKey)
+	return proxy, nil
+}
+
+//WatchList implements storage interface
+//Watch & WatchList are the same for mongo storage
+func (s *Storage) WatchList(cxt context.Context, key, version string, selector storage.Selector) (watch.Interface, error) {
+	return s.Watch(cxt, key, version, selector)
+}
+
+//Get implements storage interface
+//get exactly data object by key, version is not supported
+func (s *Storage) Get(cxt context.Context, key, version string, ignoreNotFound bool) (obj meta.Object, err error) {
+	fullKey := cleanKey(key)
+	if len(fullKey) == 0 {
+		return nil, fmt.Errorf("lost object key")
+	}
+	doc, err := s.getDocument(cxt, fullKey)
+	if err == storage.ErrNotFound && ignoreNotFound {
+		blog.V(5).Infof("mongo storage %s got nothing for %s", s.table, fullKey)
+		return nil, nil
+	}
+	if err != nil {
+		blog.V(3).Infof("mongo storage %s exact get %s failed, %s", s.table, fullKey, err)
+		return nil, err
+	}
+	target := s.objectNewFn()
+	if err := s.codec.Decode([]byte(doc.Data), target); err != nil {
+		blog.V(3).Infof("mongo storage %s decode data object %s failed, %s", s.table, fullKey, err)
+		return nil, fmt.Errorf("%s decode: %s", s.table, err)
+	}
+	blog.V(3).Infof("mongo storage %s got %s success", s.table, fullKey)
+	return target, nil
+}
+
+//List implements storage interface
+//list namespace-based data or all data
+func (s *Storage) List(cxt context.Context, key string, selector storage.Selector) (objs []meta.Object, err error) {
+	fullKey := cleanKey(key)
+	docs, err := s.listDocuments(cxt, fullKey)
+	if err != nil {
+		blog.V(3).Infof("mongo storage %s list %s failed, %s", s.table, fullKey, err)
+		return nil, err
+	}
+	if len(docs) == 0 {
+		blog.V(5).Infof("mongo storage %s list nothing under %s", s.table, fullKey)
+		return nil, nil
+	}
+	for _, doc := range docs {
+		target := s.objectNewFn()
+		if err := s.codec.Decode([]byte(doc.Data), target); err != nil {
+			blog.V(3).Infof("mongo storage %s decode data object %s failed, %s", s.table, doc.Key, err)
+			continue
+		}
+		if selector == nil {
+			objs = append(objs, target)
+			continue
+		}
+		if ok, _ := selector.Matchs(target); ok {
+			objs = append(objs, target)
+		}
+	}
+	blog.V(3).Infof("mongo storage %s list %s success, got %d objects", s.table, fullKey, len(objs))
+	return objs, nil
+}
+
+//Close stop purging deleted data, database is not closed because it's shared
+func (s *Storage) Close() {
+	blog.V(3).Infof("mongo storage %s exit.", s.table)
+	s.stopFn()
+}
+
+//getDocument get document of key which is not deleted
+func (s *Storage) getDocument(cxt context.Context, fullKey string) (*document, error) {
+	doc := &document{}
+	err := s.db.Table(s.table).Find(existCondition(keyCondition(fullKey))).One(cxt, doc)
+	if err == drivers.ErrTableRecordNotFound {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+//listDocuments list documents under key which are not deleted
+func (s *Storage) listDocuments(cxt context.Context, fullKey string) ([]*document, error) {
+	docs := make([]*document, 0)
+	if err := s.db.Table(s.table).Find(existCondition(pathCondition(fullKey))).All(cxt, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+//purge clean deleted data which is kept longer than deletedTTL
+func (s *Storage) purge(cxt context.Context) {
+	tick := time.NewTicker(purgeInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-cxt.Done():
+			return
+		case <-tick.C:
+			cond := operator.NewBranchCondition(operator.And,
+				operator.NewLeafCondition(operator.Eq, operator.M{deletedField: true}),
+				operator.NewLeafCondition(operator.Lt, operator.M{updateTimeField: time.Now().Add(-s.deletedTTL)}),
+			)
+			counter, err := s.db.Table(s.table).Delete(cxt, cond)
+			if err != nil {
+				blog.V(3).Infof("mongo storage %s purge deleted data failed, %s", s.table, err)
+				continue
+			}
+			if counter != 0 {
+				blog.V(3).Infof("mongo storage %s purge %d deleted data", s.table, counter)
+			}
+		}
+	}
+}
+
+//newMongoProxyWatch create mongo proxy watch
+func newMongoProxyWatch(cxt context.Context, codec meta.Codec, s storage.Selector) *mongoProxyWatch {
+	localCxt, canceler := context.WithCancel(cxt)
+	proxy := &mongoProxyWatch{
+		selector:      s,
+		codec:         codec,
+		matched:       make(map[string]struct{}),
+		filterChannel: make(chan watch.Event, watch.DefaultChannelBuffer),
+		cxt:           localCxt,
+		stopFn:        canceler,
+	}
+	return proxy
+}
+
+//mongoProxyWatch wrapper for mongo change stream, filter data by selector if needed.
+//* decodes documents in change stream to object
+//* constructs event and dispatches to user channel
+//* records objects matching selector, object which becomes unmatched is sent as deleted
+type mongoProxyWatch struct {
+	selector      storage.Selector
+	codec         meta.Codec
+	matched       map[string]struct{} //keys of objects matching selector
+	filterChannel chan watch.Event
+	cxt           context.Context //context from storage.Watch
+	stopFn        context.CancelFunc
+}
+
+//Stop watch channel
+func (e *mongoProxyWatch) Stop() {
+	e.stopFn()
+}
+
+//WatchEvent get watch events, if watch stopped/error, watch must close
+// channel and exit, watch user must read channel like
+// e, ok := <-channel
+func (e *mongoProxyWatch) WatchEvent() <-chan watch.Event {
+	return e.filterChannel
+}
+
+func (e *mongoProxyWatch) eventProxy(mongoCh chan *drivers.WatchEvent, objectNewFn meta.ObjectNewFn) {
+	defer func() {
+		close(e.filterChannel)
+	}()
+	for {
+		select {
+		case <-e.cxt.Done():
+			blog.V(3).Infof("mongoProxyWatch is stopped by user")
+			return
+		case event := <-mongoCh:
+			if event.Type == drivers.EventError || event.Type == drivers.EventClose {
+				blog.V(3).Infof("mongo proxy watch got change stream %s of %s", event.Type, event.CollectionName)
+				return
+			}
+			targetEvent := e.eventConstruct(event, objectNewFn)
+			if targetEvent == nil {
+				continue
+			}
+			select {
+			case e.filterChannel <- *targetEvent:
+			case <-e.cxt.Done():
+				return
+			}
+		}
+	}
+}
+
+func (e *mongoProxyWatch) eventConstruct(event *drivers.WatchEvent, objectNewFn meta.ObjectNewFn) *watch.Event {
+	if len(event.Data) == 0 {
+		//document is purged before it's looked up
+		return nil
+	}
+	key, _ := event.Data[keyField].(string)
+	data, _ := event.Data[dataField].(string)
+	obj := objectNewFn()
+	if err := e.codec.Decode([]byte(data), obj); err != nil {
+		blog.V(3).Infof("mongoProxyWatch decode %s event of %s failed, %s", event.Type, key, err)
+		return nil
+	}
+	deleted, _ := event.Data[deletedField].(bool)
+	_, wasMatched := e.matched[key]
+	cur := true
+	if e.selector != nil {
+		cur, _ = e.selector.Matchs(obj)
+	}
+
+	targetEvent := &watch.Event{Data: obj}
+	switch {
+	case deleted:
+		//object is not sent to watcher if it's never matched
+		if e.selector != nil && !cur && !wasMatched {
+			return nil
+		}
+		delete(e.matched, key)
+		targetEvent.Type = watch.EventDeleted
+		return targetEvent
+	case event.Type == drivers.EventAdd:
+		targetEvent.Type = watch.EventAdded
+	case event.Type == drivers.EventUpdate:
+		targetEvent.Type = watch.EventUpdated
+		//deleted data is created again
+		if revived, ok := event.UpdatedFields[deletedField].(bool); ok && !revived {
+			targetEvent.Type = watch.EventAdded
+		}
+	default:
+		blog.V(3).Infof("mongoProxyWatch got unexpect event: %v", event.Type)
+		return nil
+	}
+	if e.selector == nil {
+		return targetEvent
+	}
+	switch {
+	case cur && !wasMatched:
+		targetEvent.Type = watch.EventAdded
+	case !cur && wasMatched:
+		targetEvent.Type = watch.EventDeleted
+	case !cur && !wasMatched:
+		blog.V(5).Infof("mongoProxyWatch filter block data, filter: %s", e.selector.String())
+		return nil
+	}
+	if cur {
+		e.matched[key] = struct{}{}
+	} else {
+		delete(e.matched, key)
+	}
+	return targetEvent
+}
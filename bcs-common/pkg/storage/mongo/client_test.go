@@ -0,0 +1,270 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package mongo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/meta"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/drivers"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/storage"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/watch"
+
+	"golang.org/x/net/context"
+)
+
+//fakeDB database with one table, documents listed are kept in docs,
+//and change stream events are sent by test through events
+type fakeDB struct {
+	drivers.DB
+	table *fakeTable
+}
+
+func (db *fakeDB) HasTable(cxt context.Context, name string) (bool, error) {
+	return true, nil
+}
+
+func (db *fakeDB) Table(name string) drivers.Table {
+	return db.table
+}
+
+type fakeTable struct {
+	drivers.Table
+	docs       []*document
+	events     chan *drivers.WatchEvent
+	closeEvent bool
+}
+
+func (t *fakeTable) HasIndex(cxt context.Context, name string) (bool, error) {
+	return true, nil
+}
+
+func (t *fakeTable) Find(condition *operator.Condition) drivers.Find {
+	return &fakeFinder{table: t}
+}
+
+func (t *fakeTable) Delete(cxt context.Context, condition *operator.Condition) (int64, error) {
+	return 0, nil
+}
+
+func (t *fakeTable) Watch(conditions []*operator.Condition) drivers.Watch {
+	return &fakeWatch{table: t}
+}
+
+type fakeFinder struct {
+	drivers.Find
+	table *fakeTable
+}
+
+func (f *fakeFinder) All(cxt context.Context, result interface{}) error {
+	*(result.(*[]*document)) = f.table.docs
+	return nil
+}
+
+type fakeWatch struct {
+	drivers.Watch
+	table *fakeTable
+}
+
+func (w *fakeWatch) WithFullContent(isFull bool) drivers.Watch {
+	return w
+}
+
+func (w *fakeWatch) WithCloseEvent(notify bool) drivers.Watch {
+	w.table.closeEvent = notify
+	return w
+}
+
+func (w *fakeWatch) DoWatch(cxt context.Context) (chan *drivers.WatchEvent, error) {
+	return w.table.events, nil
+}
+
+func newObject(name string, labels map[string]string) meta.Object {
+	return &meta.ObjectMeta{Namespace: "ns", Name: name, Labels: labels}
+}
+
+func objectNewFn() meta.Object {
+	return &meta.ObjectMeta{}
+}
+
+//newDocument document of object, data is encoded as storage does
+func newDocument(t *testing.T, obj meta.Object, deleted bool) operator.M {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("encode object failed, %s", err)
+	}
+	return operator.M{
+		keyField:     obj.GetNamespace() + "/" + obj.GetName(),
+		dataField:    string(data),
+		deletedField: deleted,
+	}
+}
+
+func TestEventConstruct(t *testing.T) {
+	selector := storage.LabelAsSelector(meta.Labels{"app": "nginx"})
+	matchedLabels := map[string]string{"app": "nginx"}
+	otherLabels := map[string]string{"app": "redis"}
+	tests := []struct {
+		name       string
+		selector   storage.Selector
+		wasMatched bool
+		event      *drivers.WatchEvent
+		object     meta.Object
+		deleted    bool
+		//empty type means no event is sent to watcher
+		expect       watch.EventType
+		expectMatch  bool
+		withoutData  bool
+		revivedField bool
+	}{
+		{name: "add", event: &drivers.WatchEvent{Type: drivers.EventAdd},
+			object: newObject("a", nil), expect: watch.EventAdded},
+		{name: "update", event: &drivers.WatchEvent{Type: drivers.EventUpdate},
+			object: newObject("a", nil), expect: watch.EventUpdated},
+		{name: "soft deletion", event: &drivers.WatchEvent{Type: drivers.EventUpdate},
+			object: newObject("a", nil), deleted: true, expect: watch.EventDeleted},
+		{name: "revived after soft deletion", event: &drivers.WatchEvent{Type: drivers.EventUpdate},
+			object: newObject("a", nil), revivedField: true, expect: watch.EventAdded},
+		{name: "purged before lookup", event: &drivers.WatchEvent{Type: drivers.EventUpdate},
+			object: newObject("a", nil), withoutData: true},
+		{name: "unknown event", event: &drivers.WatchEvent{Type: drivers.EventDelete},
+			object: newObject("a", nil)},
+		{name: "update moves object into selector", selector: selector,
+			event:  &drivers.WatchEvent{Type: drivers.EventUpdate},
+			object: newObject("a", matchedLabels), expect: watch.EventAdded, expectMatch: true},
+		{name: "update moves object out of selector", selector: selector, wasMatched: true,
+			event:  &drivers.WatchEvent{Type: drivers.EventUpdate},
+			object: newObject("a", otherLabels), expect: watch.EventDeleted},
+		{name: "update of matched object", selector: selector, wasMatched: true,
+			event:  &drivers.WatchEvent{Type: drivers.EventUpdate},
+			object: newObject("a", matchedLabels), expect: watch.EventUpdated, expectMatch: true},
+		{name: "update of unmatched object", selector: selector,
+			event:  &drivers.WatchEvent{Type: drivers.EventUpdate},
+			object: newObject("a", otherLabels)},
+		{name: "deletion of object never matched", selector: selector,
+			event:  &drivers.WatchEvent{Type: drivers.EventUpdate},
+			object: newObject("a", otherLabels), deleted: true},
+		{name: "deletion of object matched before", selector: selector, wasMatched: true,
+			event:  &drivers.WatchEvent{Type: drivers.EventUpdate},
+			object: newObject("a", otherLabels), deleted: true, expect: watch.EventDeleted},
+		{name: "revived object matching selector", selector: selector,
+			event:  &drivers.WatchEvent{Type: drivers.EventUpdate},
+			object: newObject("a", matchedLabels), revivedField: true, expect: watch.EventAdded, expectMatch: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proxy := newMongoProxyWatch(context.Background(), &meta.JsonCodec{}, test.selector)
+			defer proxy.Stop()
+			if test.wasMatched {
+				proxy.matched["ns/a"] = struct{}{}
+			}
+			if !test.withoutData {
+				test.event.Data = newDocument(t, test.object, test.deleted)
+			}
+			if test.revivedField {
+				test.event.UpdatedFields = map[string]interface{}{deletedField: false}
+			}
+			event := proxy.eventConstruct(test.event, objectNewFn)
+			switch {
+			case len(test.expect) == 0 && event != nil:
+				t.Errorf("expect no event, got %+v", event)
+			case len(test.expect) != 0 && event == nil:
+				t.Errorf("expect %s event, got nothing", test.expect)
+			case event != nil && event.Type != test.expect:
+				t.Errorf("expect %s event, got %s", test.expect, event.Type)
+			case event != nil && event.Data.GetName() != "a":
+				t.Errorf("expect object a in event, got %+v", event.Data)
+			}
+			if _, matched := proxy.matched["ns/a"]; test.selector != nil && matched != test.expectMatch {
+				t.Errorf("expect matched %v, got %v", test.expectMatch, matched)
+			}
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	table := &fakeTable{
+		docs: []*document{
+			{Key: "ns/a", Data: `{"name":"a","namespace":"ns","labels":{"app":"nginx"}}`},
+			{Key: "ns/b", Data: `{"name":"b","namespace":"ns","labels":{"app":"redis"}}`},
+		},
+		events: make(chan *drivers.WatchEvent, 10),
+	}
+	s, err := NewStorage(&Config{
+		DB:      &fakeDB{table: table},
+		Table:   "test",
+		NewFunc: objectNewFn,
+		Codec:   &meta.JsonCodec{},
+	})
+	if err != nil {
+		t.Fatalf("create storage failed, %s", err)
+	}
+	defer s.Close()
+
+	selector := storage.LabelAsSelector(meta.Labels{"app": "nginx"})
+	w, err := s.Watch(context.Background(), "ns", "", selector)
+	if err != nil {
+		t.Fatalf("watch failed, %s", err)
+	}
+	defer w.Stop()
+	if !table.closeEvent {
+		t.Errorf("expect storage watch asking for close event")
+	}
+
+	//a matched before watch becomes unmatched, b becomes matched, c never matches
+	table.events <- &drivers.WatchEvent{Type: drivers.EventUpdate,
+		Data: newDocument(t, newObject("a", map[string]string{"app": "redis"}), false)}
+	table.events <- &drivers.WatchEvent{Type: drivers.EventUpdate,
+		Data: newDocument(t, newObject("b", map[string]string{"app": "nginx"}), false)}
+	table.events <- &drivers.WatchEvent{Type: drivers.EventAdd,
+		Data: newDocument(t, newObject("c", nil), false)}
+	table.events <- &drivers.WatchEvent{Type: drivers.EventUpdate,
+		Data: newDocument(t, newObject("b", map[string]string{"app": "nginx"}), true)}
+	table.events <- &drivers.WatchEvent{Type: drivers.EventClose, CollectionName: "test"}
+
+	expects := []struct {
+		eventType watch.EventType
+		name      string
+	}{
+		{eventType: watch.EventDeleted, name: "a"},
+		{eventType: watch.EventAdded, name: "b"},
+		{eventType: watch.EventDeleted, name: "b"},
+	}
+	for _, expect := range expects {
+		select {
+		case event, ok := <-w.WatchEvent():
+			if !ok {
+				t.Fatalf("expect %s event of %s, got channel closed", expect.eventType, expect.name)
+			}
+			if event.Type != expect.eventType || event.Data.GetName() != expect.name {
+				t.Errorf("expect %s event of %s, got %s event of %s",
+					expect.eventType, expect.name, event.Type, event.Data.GetName())
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expect %s event of %s, got nothing", expect.eventType, expect.name)
+		}
+	}
+	//close event of change stream stops watch
+	select {
+	case event, ok := <-w.WatchEvent():
+		if ok {
+			t.Errorf("expect watch channel closed, got %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("expect watch channel closed after change stream closed")
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package mongo
+
+//util is tools collection for mongo storage
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+)
+
+const (
+	keyField        = "key"
+	pathsField      = "paths"
+	dataField       = "data"
+	deletedField    = "deleted"
+	updateTimeField = "updateTime"
+
+	//fields in change stream event
+	fullDocumentField  = "fullDocument"
+	operationTypeField = "operationType"
+)
+
+//document data object stored in mongo
+type document struct {
+	Key        string    `bson:"key"`        //full key of object
+	Paths      []string  `bson:"paths"`      //key and all its parent keys, for listing and watching under key
+	Namespace  string    `bson:"namespace"`  //namespace of object
+	Name       string    `bson:"name"`       //name of object
+	Data       string    `bson:"data"`       //object encoded by codec
+	Deleted    bool      `bson:"deleted"`    //object is deleted, it's kept for watchers until purged
+	UpdateTime time.Time `bson:"updateTime"` //time of last change
+}
+
+//cleanKey clean key as path, leading and trailing slashes are removed
+func cleanKey(key string) string {
+	return strings.Trim(path.Clean("/"+key), "/")
+}
+
+//keyPaths return key and all its parent keys, for example,
+//a/b/c returns [a, a/b, a/b/c]
+func keyPaths(fullKey string) []string {
+	var paths []string
+	segments := strings.Split(fullKey, "/")
+	for i := range segments {
+		paths = append(paths, strings.Join(segments[:i+1], "/"))
+	}
+	return paths
+}
+
+//keyCondition condition for data of key
+func keyCondition(fullKey string) *operator.Condition {
+	return operator.NewLeafCondition(operator.Eq, operator.M{keyField: fullKey})
+}
+
+//pathCondition condition for data of key and under key, empty key means all data
+func pathCondition(fullKey string) *operator.Condition {
+	if len(fullKey) == 0 {
+		return operator.EmptyCondition
+	}
+	return operator.NewLeafCondition(operator.Eq, operator.M{pathsField: fullKey})
+}
+
+//existCondition condition for data which is not deleted
+func existCondition(cond *operator.Condition) *operator.Condition {
+	return operator.NewBranchCondition(operator.And, cond,
+		operator.NewLeafCondition(operator.Ne, operator.M{deletedField: true}))
+}
+
+//watchCondition condition for change stream events of data under key,
+//physical deletion of purged data is ignored
+func watchCondition(fullKey string) *operator.Condition {
+	conds := []*operator.Condition{
+		operator.NewLeafCondition(operator.In, operator.M{
+			operationTypeField: []string{"insert", "update", "replace"},
+		}),
+	}
+	if len(fullKey) != 0 {
+		conds = append(conds, operator.NewLeafCondition(operator.Eq, operator.M{
+			fullDocumentField + "." + pathsField: fullKey,
+		}))
+	}
+	return operator.NewBranchCondition(operator.And, conds...)
+}
@@ -0,0 +1,53 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package mongo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyPaths(t *testing.T) {
+	tests := []struct {
+		key    string
+		expect []string
+	}{
+		{key: "ns/name", expect: []string{"ns", "ns/name"}},
+		{key: "/a/b/c/", expect: []string{"a", "a/b", "a/b/c"}},
+		{key: "a//b/../c", expect: []string{"a", "a/c"}},
+		{key: "name", expect: []string{"name"}},
+	}
+	for _, test := range tests {
+		paths := keyPaths(cleanKey(test.key))
+		if !reflect.DeepEqual(paths, test.expect) {
+			t.Errorf("key %s expect paths %v, got %v", test.key, test.expect, paths)
+		}
+	}
+}
+
+func TestCleanKey(t *testing.T) {
+	tests := map[string]string{
+		"":          "",
+		"/":         "",
+		"ns":        "ns",
+		"/ns/name/": "ns/name",
+		"ns//name":  "ns/name",
+		"ns/./name": "ns/name",
+	}
+	for key, expect := range tests {
+		if got := cleanKey(key); got != expect {
+			t.Errorf("key %q expect %q, got %q", key, expect, got)
+		}
+	}
+}
@@ -64,6 +64,8 @@ type BCSConfig struct {
 	WriterQueueLen int64 `json:"writerQueueLen"`
 	// PodQueueNum run many queue to distribute Pod event in due to increase storage qps
 	PodQueueNum int `json:"podQueueNum"`
+	// BulkSize max number of resource changes written to storage in one bulk request, bulk is disabled when less than 2
+	BulkSize int `json:"bulkSize"`
 }
 
 // K8sConfig for installation out of cluster
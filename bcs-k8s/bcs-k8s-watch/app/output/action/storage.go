@@ -50,6 +50,93 @@ func (act *StorageAction) Update(syncData *SyncData) {
 	act.request("PUT", syncData)
 }
 
+// Batch writes resource data in one bulk request, data which could not be written
+// by bulk request is written one by one.
+func (act *StorageAction) Batch(syncDatas []*SyncData) {
+	ops := make([]http.StorageBulkOperation, 0, len(syncDatas))
+	bulkDatas := make([]*SyncData, 0, len(syncDatas))
+	for _, syncData := range syncDatas {
+		if !http.IsBulkSupported(syncData.Kind) {
+			act.handle(syncData)
+			continue
+		}
+		op := http.StorageBulkOperation{
+			Action:       http.BulkActionUpsert,
+			Namespace:    syncData.Namespace,
+			ResourceType: syncData.Kind,
+			ResourceName: syncData.Name,
+			Data:         syncData.Data,
+		}
+		if syncData.Action == SyncDataActionDelete {
+			op.Action = http.BulkActionDelete
+		}
+		ops = append(ops, op)
+		bulkDatas = append(bulkDatas, syncData)
+	}
+	if len(ops) == 0 {
+		return
+	}
+	if len(ops) == 1 {
+		act.handle(bulkDatas[0])
+		return
+	}
+
+	glog.Infof("calling bulk request: %s %d operations", act.name, len(ops))
+	targets := act.storageService.Servers()
+	if len(targets) == 0 {
+		glog.Errorf("storage server list is empty, got no address yet!")
+		return
+	}
+
+	var resp http.StorageBulkResponse
+	var err error
+	for _, httpClientConfig := range targets {
+		client := http.StorageClient{
+			HTTPClientConfig: httpClientConfig,
+			ClusterID:        act.clusterID,
+			ResourceType:     act.name,
+		}
+		resp, err = client.BulkWrite(ops)
+		if err != nil {
+			glog.Errorf("bulk %s FAIL %s retry: [%d operations]", act.name, err.Error(), len(ops))
+			continue
+		}
+		break
+	}
+
+	// storage may not support bulk request, write one by one instead
+	if !resp.Result || len(resp.Data) != len(ops) {
+		glog.Errorf("bulk %s ERROR[%s], fall back to write one by one: [%d operations]", act.name, resp.Message, len(ops))
+		for _, syncData := range bulkDatas {
+			act.handle(syncData)
+		}
+		return
+	}
+	for i, result := range resp.Data {
+		if !result.Result {
+			glog.Errorf("bulk %s %s ERROR[%s]: [%s/%s]", ops[i].Action, ops[i].ResourceType, result.Message,
+				ops[i].Namespace, ops[i].ResourceName)
+			continue
+		}
+		glog.V(2).Infof("bulk %s %s SUCCESS: [%s/%s]", ops[i].Action, ops[i].ResourceType,
+			ops[i].Namespace, ops[i].ResourceName)
+	}
+}
+
+// handle writes resource data by its action.
+func (act *StorageAction) handle(syncData *SyncData) {
+	switch syncData.Action {
+	case SyncDataActionAdd:
+		act.Add(syncData)
+	case SyncDataActionDelete:
+		act.Delete(syncData)
+	case SyncDataActionUpdate:
+		act.Update(syncData)
+	default:
+		glog.Errorf("can't handle metadata, unknown action type[%+v]", syncData.Action)
+	}
+}
+
 func (act *StorageAction) request(method string, syncData *SyncData) {
 	glog.Infof("calling request: %s %s %s/%s", method, syncData.Kind, syncData.Namespace, syncData.Name)
 
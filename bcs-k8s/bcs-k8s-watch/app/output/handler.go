@@ -46,6 +46,12 @@ type Action interface {
 	Update(syncData *action.SyncData)
 }
 
+// BatchAction handles multiple metadata in one batch.
+type BatchAction interface {
+	// Batch adds, updates or deletes multiple resource metadata.
+	Batch(syncDatas []*action.SyncData)
+}
+
 // Handler is resource handler, consumes metadata distributed from
 // Writer, and handles data with the action.
 type Handler struct {
@@ -59,6 +65,9 @@ type Handler struct {
 
 	// metadata action.
 	act Action
+
+	// max number of metadata handled in one batch, batch is disabled when it's less than 2.
+	batchSize int
 }
 
 // NewHandler creates a new resource Handler instance with the action.
@@ -72,6 +81,12 @@ func NewHandler(clusterID string, dataType string, act Action) *Handler {
 	return h
 }
 
+// SetBatchSize sets max number of metadata handled in one batch, it takes effect
+// only when the action supports batch.
+func (h *Handler) SetBatchSize(size int) {
+	h.batchSize = size
+}
+
 // Handle sends the metadata into handler queue.
 func (h *Handler) Handle(data *action.SyncData) {
 	h.queue <- data
@@ -104,6 +119,11 @@ func (h *Handler) reportHandlerQueueLength() {
 // handle func is invoked by wait.NonSlidingUntil with a stop channel, do not block
 // to recv the queue here in order to make it have runtime to handle the stop channel.
 func (h *Handler) handle() {
+	if batchAct, ok := h.act.(BatchAction); ok && h.batchSize > 1 {
+		h.handleBatch(batchAct)
+		return
+	}
+
 	// try to keep reading from queue until there is no more data every period.
 	for {
 		select {
@@ -130,6 +150,50 @@ func (h *Handler) handle() {
 	}
 }
 
+// handleBatch coalesces metadata in queue and handles them in batches.
+func (h *Handler) handleBatch(batchAct BatchAction) {
+	for {
+		select {
+		case data := <-h.queue:
+			metrics.ReportK8sWatchHandlerQueueLengthDec(h.clusterID, h.dataType)
+			batch := []*action.SyncData{data}
+
+			// take all queued metadata until the batch is full.
+		DrainLoop:
+			for len(batch) < h.batchSize {
+				select {
+				case data := <-h.queue:
+					metrics.ReportK8sWatchHandlerQueueLengthDec(h.clusterID, h.dataType)
+					batch = append(batch, data)
+				default:
+					break DrainLoop
+				}
+			}
+			batchAct.Batch(coalesceSyncData(batch))
+
+		case <-time.After(defaultQueueTimeout):
+			// no more data, break loop.
+			return
+		}
+	}
+}
+
+// coalesceSyncData keeps only the latest metadata of each resource, the latest
+// state is all that matters to storage.
+func coalesceSyncData(batch []*action.SyncData) []*action.SyncData {
+	latest := make(map[string]int, len(batch))
+	for i, data := range batch {
+		latest[data.Kind+"/"+data.Namespace+"/"+data.Name] = i
+	}
+	coalesced := make([]*action.SyncData, 0, len(latest))
+	for i, data := range batch {
+		if latest[data.Kind+"/"+data.Namespace+"/"+data.Name] == i {
+			coalesced = append(coalesced, data)
+		}
+	}
+	return coalesced
+}
+
 // Run starts the handler.
 func (h *Handler) Run(stopCh <-chan struct{}) {
 	glog.Infof("%+v resource handler is starting now", h.dataType)
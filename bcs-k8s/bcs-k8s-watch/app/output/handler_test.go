@@ -0,0 +1,40 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package output
+
+import (
+	"testing"
+
+	"github.com/Tencent/bk-bcs/bcs-k8s/bcs-k8s-watch/app/output/action"
+)
+
+func TestCoalesceSyncData(t *testing.T) {
+	batch := []*action.SyncData{
+		{Kind: "Pod", Namespace: "default", Name: "a", Action: "Add"},
+		{Kind: "Pod", Namespace: "default", Name: "b", Action: "Add"},
+		{Kind: "Pod", Namespace: "default", Name: "a", Action: "Update"},
+		{Kind: "Pod", Namespace: "kube-system", Name: "a", Action: "Add"},
+		{Kind: "Pod", Namespace: "default", Name: "b", Action: "Delete"},
+	}
+	coalesced := coalesceSyncData(batch)
+	expect := []*action.SyncData{batch[2], batch[3], batch[4]}
+	if len(coalesced) != len(expect) {
+		t.Fatalf("expect %d sync data, got %d", len(expect), len(coalesced))
+	}
+	for i := range expect {
+		if coalesced[i] != expect[i] {
+			t.Errorf("index %d: expect %+v, got %+v", i, expect[i], coalesced[i])
+		}
+	}
+}
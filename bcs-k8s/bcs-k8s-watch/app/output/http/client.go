@@ -74,6 +74,13 @@ const (
 
 	// request timeout
 	StorageRequestTimeoutSeconds = 2
+	// request timeout of bulk request increases by StorageRequestTimeoutSeconds for every bulkTimeoutStep operations
+	bulkTimeoutStep = 100
+
+	// bcsstorage/v1/k8s/dynamic/bulk/clusters/{clusterId}
+	BulkURLFmt = "%s/bcsstorage/v1/k8s/dynamic/bulk/clusters/%s"
+	// handler bulk write
+	HandlerBulkName = "k8s_cluster_bulk"
 
 	// bcsstorage/v1/k8s/watch/clusters/{clusterId}/namespaces/{namespace}/{resourceType}/{resourceName}
 	NamespaceScopeWatchURLFmt = "%s/bcsstorage/v1/k8s/watch/clusters/%s/namespaces/%s/%s/%s"
@@ -85,6 +92,13 @@ var WatchKindSet = map[string]struct{}{
 	"ExportService": {},
 }
 
+const (
+	// BulkActionUpsert upsert action in bulk request
+	BulkActionUpsert = "upsert"
+	// BulkActionDelete delete action in bulk request
+	BulkActionDelete = "delete"
+)
+
 type StorageClient struct {
 	HTTPClientConfig *bcs.HTTPClientConfig
 	ClusterID        string
@@ -104,6 +118,43 @@ type StorageRequestBody struct {
 	Data interface{} `json:"data"`
 }
 
+// StorageBulkOperation is operation in bulk request
+type StorageBulkOperation struct {
+	Action       string      `json:"action"`
+	Namespace    string      `json:"namespace,omitempty"`
+	ResourceType string      `json:"resourceType"`
+	ResourceName string      `json:"resourceName"`
+	Data         interface{} `json:"data,omitempty"`
+}
+
+// StorageBulkRequestBody is body of bulk request
+type StorageBulkRequestBody struct {
+	Operations []StorageBulkOperation `json:"operations"`
+}
+
+// StorageBulkResult is result of each operation in bulk request
+type StorageBulkResult struct {
+	Result  bool   `json:"result"`
+	Message string `json:"message"`
+}
+
+// StorageBulkResponse is response of bulk request
+type StorageBulkResponse struct {
+	Result  bool                `json:"result"`
+	Code    int64               `json:"code"`
+	Message string              `json:"message"`
+	Data    []StorageBulkResult `json:"data"`
+}
+
+// IsBulkSupported returns whether the resource type could be written by bulk request
+func IsBulkSupported(resourceType string) bool {
+	if resourceType == "Event" {
+		return false
+	}
+	_, ok := WatchKindSet[resourceType]
+	return !ok
+}
+
 func (client *StorageClient) GetURL() (string, string) {
 	// Event
 	if client.ResourceType == "Event" {
@@ -299,6 +350,44 @@ func (client *StorageClient) PUT(data interface{}) (storageResp StorageResponse,
 	return
 }
 
+// BulkWrite upserts or deletes multiple resources of the cluster in one request.
+func (client *StorageClient) BulkWrite(ops []StorageBulkOperation) (storageResp StorageBulkResponse, err error) {
+	start := time.Now()
+	status := metrics.SucStatus
+	url := fmt.Sprintf(BulkURLFmt, client.HTTPClientConfig.URL, client.ClusterID)
+
+	defer func() {
+		metrics.ReportK8sWatchAPIMetrics(client.ClusterID, HandlerBulkName, client.Namespace, client.ResourceType,
+			http.MethodPost, status, start)
+	}()
+
+	request, err := client.NewRequest()
+	if err != nil {
+		status = metrics.ErrStatus
+		return
+	}
+
+	// larger batch takes longer time
+	timeout := time.Duration(StorageRequestTimeoutSeconds*(len(ops)/bulkTimeoutStep+1)) * time.Second
+	resp, _, errs := request.
+		Timeout(timeout).
+		Post(url).
+		Send(StorageBulkRequestBody{Operations: ops}).
+		EndStruct(&storageResp)
+
+	if !storageResp.Result {
+		glog.Debug(fmt.Sprintf("method=POST url=%s, operations=%d, storageResp=%v", url, len(ops), storageResp))
+		status = metrics.ErrStatus
+	}
+
+	if errs != nil {
+		glog.Errorf("POST fail: [url=%s, operations=%d, resp=%v, errors=%s]", url, len(ops), resp, errs)
+		err = errors.New("HTTP error")
+		status = metrics.ErrStatus
+	}
+	return
+}
+
 func (client *StorageClient) listResource(url string, handlerName string) (data []interface{}, err error) {
 	var (
 		start       = time.Now()
@@ -106,6 +106,8 @@ type Writer struct {
 	getResourceName func(data *action.SyncData) string
 	// resourceQueueNum for resource queueNum
 	resourceQueueNum resourceQueueDistributeNum
+	// bulkSize max number of metadata written to storage in one request
+	bulkSize int
 	// goroutine stop channel.
 	stopCh <-chan struct{}
 }
@@ -125,6 +127,7 @@ func NewWriter(clusterID string, storageService *bcs.InnerService, bcsConfig opt
 			podChanQueueNum: bcsConfig.PodQueueNum,
 		},
 		getResourceName: getResourceDataName,
+		bulkSize:        bcsConfig.BulkSize,
 	}
 
 	if err := w.init(clusterID, storageService); err != nil {
@@ -159,9 +162,18 @@ func (w *Writer) init(clusterID string, storageService *bcs.InnerService) error
 		action := action.NewStorageAction(clusterID, resource, storageService)
 		w.Handlers[resource] = NewHandler(clusterID, resource, action)
 	}
+
+	for _, handler := range w.Handlers {
+		handler.SetBatchSize(w.bulkSize)
+	}
 	return nil
 }
 
+// BulkSize returns max number of metadata written to storage in one request.
+func (w *Writer) BulkSize() int {
+	return w.bulkSize
+}
+
 // Sync syncs normal metadata by sending into queue.
 func (w *Writer) Sync(data *action.SyncData) {
 	if data == nil {
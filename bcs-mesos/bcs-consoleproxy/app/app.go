@@ -69,6 +69,11 @@ func setConfig(op *options.ConsoleProxyOption) {
 	op.Conf.Ips = op.Ips
 	op.Conf.IsAuth = op.IsAuth
 	op.Conf.IsOneSeesion = op.IsOneSession
+	op.Conf.ClusterID = op.ClusterID
+	op.Conf.RecordDir = op.RecordDir
+	op.Conf.RecordRetention = op.RecordRetention
+	op.Conf.CommandBlocklist = op.CommandBlocklist
+	op.Conf.TrustedProxies = op.TrustedProxies
 
 	//server cert directoty
 	if op.CertConfig.ServerCertFile != "" && op.CertConfig.CAFile != "" &&
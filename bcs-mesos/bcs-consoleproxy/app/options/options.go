@@ -35,6 +35,12 @@ type ConsoleProxyOption struct {
 	IsAuth         bool     `json:"is-auth" value:"" usage:"is auth"`
 	IsOneSession   bool     `json:"is-one-session" value:"" usage:"support just one session for an container"`
 
+	ClusterID        string   `json:"cluster-id" value:"" usage:"cluster id recorded in console sessions when request does not carry BCS-ClusterID"`
+	RecordDir        string   `json:"record-dir" value:"" usage:"directory of console session records, empty means recording disabled"`
+	RecordRetention  int      `json:"record-retention-days" value:"0" usage:"days to keep console session records, 0 means keeping forever"`
+	CommandBlocklist []string `json:"command-blocklist" value:"" usage:"regular expressions of commands blocked in console input"`
+	TrustedProxies   []string `json:"trusted-proxies" value:"" usage:"IPs or CIDRs of authenticated gateways, such as bcs-api, trusted to set BCS-Operator, BCS-ClusterID and X-Forwarded-For"`
+
 	Conf config.ConsoleProxyConfig
 }
 
@@ -16,8 +16,12 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
 	"github.com/Tencent/bk-bcs/bcs-common/common/ssl"
@@ -31,6 +35,8 @@ type Router struct {
 	sync.RWMutex
 	conf    *config.ConsoleProxyConfig
 	backend manager.Manager
+	// trustedProxies are networks of authenticated gateways
+	trustedProxies []*net.IPNet
 }
 
 // CreateExecReq is createExec request struct
@@ -40,6 +46,15 @@ type CreateExecReq struct {
 	User        string   `json:"user,omitempty"`
 }
 
+const (
+	// operatorHeader is header of the user who operates the console
+	operatorHeader = "BCS-Operator"
+	// clusterHeader is header of cluster id
+	clusterHeader = "BCS-ClusterID"
+	// sessionsPath is path prefix of console session api
+	sessionsPath = "/bcsapi/v1/consoleproxy/sessions"
+)
+
 // ResizeExecReq is resizeExec request struct
 type ResizeExecReq struct {
 	ExecID string `json:"exec_id,omitempty"`
@@ -50,8 +65,9 @@ type ResizeExecReq struct {
 // NewRouter return api router
 func NewRouter(b manager.Manager, conf *config.ConsoleProxyConfig) *Router {
 	r := &Router{
-		backend: b,
-		conf:    conf,
+		backend:        b,
+		conf:           conf,
+		trustedProxies: parseTrustedProxies(conf.TrustedProxies),
 	}
 
 	r.initRoutes()
@@ -65,6 +81,9 @@ func (r *Router) initRoutes() {
 	mux.HandleFunc("/bcsapi/v1/consoleproxy/create_exec", r.createExec)
 	mux.HandleFunc("/bcsapi/v1/consoleproxy/start_exec", r.startExec)
 	mux.HandleFunc("/bcsapi/v1/consoleproxy/resize_exec", r.resizeExec)
+	//console session audit
+	mux.HandleFunc(sessionsPath, r.requireAuth(r.listSessions))
+	mux.HandleFunc(sessionsPath+"/", r.requireAuth(r.getSession))
 	s := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", r.conf.Address, r.conf.Port),
 		Handler: mux,
@@ -115,6 +134,9 @@ func (r *Router) createExec(w http.ResponseWriter, req *http.Request) {
 		ContainerID: createExecReq.ContainerID,
 		User:        createExecReq.User,
 		Cmd:         createExecReq.Cmd,
+		Operator:    r.getOperator(req),
+		ClusterID:   r.getClusterID(req),
+		SourceIP:    r.getSourceIP(req),
 	}
 
 	r.backend.CreateExec(w, req, webconsole)
@@ -129,10 +151,18 @@ func (r *Router) startExec(w http.ResponseWriter, req *http.Request) {
 	execID := req.FormValue("exec_id")
 	containerID := req.FormValue("container_id")
 
+	width, _ := strconv.Atoi(req.FormValue("width"))
+	height, _ := strconv.Atoi(req.FormValue("height"))
+
 	webconsole := &types.WebSocketConfig{
 		ExecID:      execID,
 		ContainerID: containerID,
 		Origin:      req.Header.Get("Origin"),
+		Width:       width,
+		Height:      height,
+		Operator:    r.getOperator(req),
+		ClusterID:   r.getClusterID(req),
+		SourceIP:    r.getSourceIP(req),
 	}
 
 	// handler container web console
@@ -161,3 +191,154 @@ func (r *Router) resizeExec(w http.ResponseWriter, req *http.Request) {
 
 	r.backend.ResizeExec(w, req, webconsole)
 }
+
+func (r *Router) listSessions(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := req.URL.Query()
+	filter := &types.ConsoleSessionFilter{
+		Operator:    query.Get("operator"),
+		ClusterID:   query.Get("cluster_id"),
+		ContainerID: query.Get("container_id"),
+	}
+	var err error
+	if since := query.Get("since"); since != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			http.Error(w, fmt.Sprintf("since %s is not RFC3339 time", since), http.StatusBadRequest)
+			return
+		}
+	}
+	if until := query.Get("until"); until != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, until); err != nil {
+			http.Error(w, fmt.Sprintf("until %s is not RFC3339 time", until), http.StatusBadRequest)
+			return
+		}
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			http.Error(w, fmt.Sprintf("limit %s is not number", limit), http.StatusBadRequest)
+			return
+		}
+	}
+
+	r.backend.ListSessions(w, req, filter)
+}
+
+// getSession handles /sessions/{id} and /sessions/{id}/record
+func (r *Router) getSession(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	path := strings.Trim(strings.TrimPrefix(req.URL.Path, sessionsPath), "/")
+	items := strings.Split(path, "/")
+	switch {
+	case len(items) == 1 && items[0] != "":
+		r.backend.GetSession(w, req, items[0])
+	case len(items) == 2 && items[0] != "" && items[1] == "record":
+		r.backend.GetSessionRecord(w, req, items[0])
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// requireAuth only allows requests with operator authenticated by trusted gateway
+func (r *Router) requireAuth(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if r.getOperator(req) == "" {
+			blog.Warnf("reject unauthenticated request %s from %s", req.URL.Path, req.RemoteAddr)
+			http.Error(w, "request must be authenticated by trusted gateway", http.StatusUnauthorized)
+			return
+		}
+		handler(w, req)
+	}
+}
+
+// getOperator returns operator set by trusted gateway, empty if request is not from trusted gateway
+func (r *Router) getOperator(req *http.Request) string {
+	if !r.isTrustedProxy(remoteIP(req)) {
+		return ""
+	}
+	return req.Header.Get(operatorHeader)
+}
+
+// getClusterID returns cluster id set by trusted gateway, default is the configured one
+func (r *Router) getClusterID(req *http.Request) string {
+	if r.isTrustedProxy(remoteIP(req)) {
+		if cluster := req.Header.Get(clusterHeader); cluster != "" {
+			return cluster
+		}
+	}
+	return r.conf.ClusterID
+}
+
+// getSourceIP returns client ip, X-Forwarded-For is only trusted when request is from trusted proxies,
+// the address closest to the proxies which is not a trusted proxy is the client
+func (r *Router) getSourceIP(req *http.Request) string {
+	ip := remoteIP(req)
+	if !r.isTrustedProxy(ip) {
+		return ip
+	}
+	forwarded := strings.Split(req.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr := strings.TrimSpace(forwarded[i])
+		if addr == "" {
+			continue
+		}
+		ip = addr
+		if !r.isTrustedProxy(addr) {
+			break
+		}
+	}
+	return ip
+}
+
+// isTrustedProxy returns true if ip is in networks of trusted proxies
+func (r *Router) isTrustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range r.trustedProxies {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP returns ip of the peer connected to consoleproxy
+func remoteIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// parseTrustedProxies parses IPs or CIDRs of trusted proxies
+func parseTrustedProxies(proxies []string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			blog.Errorf("trusted proxy %s is invalid, err %s", proxy, err.Error())
+			continue
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,112 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-consoleproxy/console-proxy/config"
+)
+
+func newTestRouter() *Router {
+	return &Router{
+		conf:           &config.ConsoleProxyConfig{ClusterID: "BCS-MESOS-10001"},
+		trustedProxies: parseTrustedProxies([]string{"10.0.0.1", "192.168.0.0/16", "invalid"}),
+	}
+}
+
+func TestRequestIdentity(t *testing.T) {
+	r := newTestRouter()
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		forwarded     string
+		operator      string
+		expectOp      string
+		expectCluster string
+		expectIP      string
+	}{
+		{
+			name:          "trusted gateway",
+			remoteAddr:    "10.0.0.1:5000",
+			forwarded:     "1.1.1.1, 2.2.2.2",
+			operator:      "admin",
+			expectOp:      "admin",
+			expectCluster: "BCS-MESOS-10002",
+			expectIP:      "2.2.2.2",
+		},
+		{
+			name:          "trusted proxies chain",
+			remoteAddr:    "10.0.0.1:5000",
+			forwarded:     "3.3.3.3, 192.168.1.1",
+			operator:      "admin",
+			expectOp:      "admin",
+			expectCluster: "BCS-MESOS-10002",
+			expectIP:      "3.3.3.3",
+		},
+		{
+			name:          "untrusted client",
+			remoteAddr:    "8.8.8.8:5000",
+			forwarded:     "1.1.1.1",
+			operator:      "admin",
+			expectCluster: "BCS-MESOS-10001",
+			expectIP:      "8.8.8.8",
+		},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/bcsapi/v1/consoleproxy/start_exec?operator=someone", nil)
+		req.RemoteAddr = test.remoteAddr
+		req.Header.Set("X-Forwarded-For", test.forwarded)
+		req.Header.Set(operatorHeader, test.operator)
+		req.Header.Set(clusterHeader, "BCS-MESOS-10002")
+		if op := r.getOperator(req); op != test.expectOp {
+			t.Errorf("%s: expect operator %q, got %q", test.name, test.expectOp, op)
+		}
+		if cluster := r.getClusterID(req); cluster != test.expectCluster {
+			t.Errorf("%s: expect cluster %s, got %s", test.name, test.expectCluster, cluster)
+		}
+		if ip := r.getSourceIP(req); ip != test.expectIP {
+			t.Errorf("%s: expect source ip %s, got %s", test.name, test.expectIP, ip)
+		}
+	}
+}
+
+func TestRequireAuth(t *testing.T) {
+	r := newTestRouter()
+	handler := r.requireAuth(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	tests := []struct {
+		name       string
+		remoteAddr string
+		operator   string
+		expectCode int
+	}{
+		{name: "authenticated", remoteAddr: "192.168.3.4:5000", operator: "admin", expectCode: http.StatusOK},
+		{name: "no operator", remoteAddr: "192.168.3.4:5000", expectCode: http.StatusUnauthorized},
+		{name: "untrusted", remoteAddr: "8.8.8.8:5000", operator: "admin", expectCode: http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, sessionsPath, nil)
+		req.RemoteAddr = test.remoteAddr
+		req.Header.Set(operatorHeader, test.operator)
+		w := httptest.NewRecorder()
+		handler(w, req)
+		if w.Code != test.expectCode {
+			t.Errorf("%s: expect code %d, got %d", test.name, test.expectCode, w.Code)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-consoleproxy/console-proxy/types"
+)
+
+func readEvents(t *testing.T, path string) (*castHeader, [][]interface{}) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read record failed: %s", err.Error())
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	header := &castHeader{}
+	var events [][]interface{}
+	for i := 0; scanner.Scan(); i++ {
+		if i == 0 {
+			if err := json.Unmarshal(scanner.Bytes(), header); err != nil {
+				t.Fatalf("decode header failed: %s", err.Error())
+			}
+			continue
+		}
+		var event []interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("decode event %s failed: %s", scanner.Text(), err.Error())
+		}
+		events = append(events, event)
+	}
+	return header, events
+}
+
+func TestRecorder(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "audit")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "session.cast")
+	recorder, err := NewRecorder(path, 0, 0, "test", time.Now())
+	if err != nil {
+		t.Fatalf("create recorder failed: %s", err.Error())
+	}
+	recorder.Input([]byte("ls\r"))
+	//utf8 character split into two writes
+	word := []byte("中")
+	recorder.Output(word[:1])
+	recorder.Output(append(word[1:], '\n'))
+	recorder.Resize(120, 40)
+	recorder.Close()
+	recorder.Output([]byte("after close"))
+
+	header, events := readEvents(t, path)
+	if header.Version != 2 || header.Width != defaultWidth || header.Height != defaultHeight || header.Title != "test" {
+		t.Errorf("header %+v is not expected", header)
+	}
+	expected := [][]string{{EventInput, "ls\r"}, {EventOutput, "中\n"}, {EventResize, "120x40"}}
+	if len(events) != len(expected) {
+		t.Fatalf("events %v is not expected", events)
+	}
+	for i, event := range events {
+		if event[1] != expected[i][0] || event[2] != expected[i][1] {
+			t.Errorf("event %d %v is not expected %v", i, event, expected[i])
+		}
+	}
+}
+
+func TestInputStream(t *testing.T) {
+	filter, err := NewCommandFilter([]string{`^rm\s+-rf\s+/`, "", `^reboot`})
+	if err != nil {
+		t.Fatalf("create filter failed: %s", err.Error())
+	}
+	if _, err := NewCommandFilter([]string{"("}); err == nil {
+		t.Errorf("invalid blocklist should fail")
+	}
+	if empty, _ := NewCommandFilter(nil); empty != nil {
+		t.Errorf("empty blocklist should return nil filter")
+	}
+
+	tests := []struct {
+		name    string
+		tty     bool
+		input   string
+		forward string
+		blocked []string
+	}{
+		{"tty allowed", true, "ls -l\r", "ls -l\r", nil},
+		{"tty blocked", true, "rm -rf /data\r", "rm -rf /data\x15", []string{"rm -rf /data"}},
+		{"tty backspace", true, "rebooX\x7ft\rreboot\r", "rebooX\x7ft\x15reboot\x15", []string{"reboot", "reboot"}},
+		{"tty ctrl-c reset", true, "rm -rf \x03ls /\r", "rm -rf \x03ls /\r", nil},
+		{"no tty allowed", false, "ls\nid\n", "ls\nid\n", nil},
+		{"no tty blocked", false, "reboot now\nls\n", "ls\n", []string{"reboot now"}},
+	}
+	for _, test := range tests {
+		var blocked []string
+		output := &bytes.Buffer{}
+		stream := NewInputStream(strings.NewReader(test.input), output, nil, filter, test.tty,
+			func(command string) { blocked = append(blocked, command) })
+		forward, _ := ioutil.ReadAll(stream)
+		if string(forward) != test.forward {
+			t.Errorf("%s: forward %q, expected %q", test.name, string(forward), test.forward)
+		}
+		if strings.Join(blocked, ",") != strings.Join(test.blocked, ",") {
+			t.Errorf("%s: blocked %v, expected %v", test.name, blocked, test.blocked)
+		}
+		if len(test.blocked) != 0 && !strings.Contains(output.String(), "blocked") {
+			t.Errorf("%s: tips of blocked command not written", test.name)
+		}
+	}
+}
+
+func TestSessionStore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "audit")
+	defer os.RemoveAll(dir)
+	store, err := NewSessionStore(dir)
+	if err != nil {
+		t.Fatalf("create store failed: %s", err.Error())
+	}
+	now := time.Now()
+	sessions := []*types.ConsoleSession{
+		{Operator: "alice", ClusterID: "BCS-MESOS-10001", ContainerID: "abc123", StartTime: now.Add(-3 * time.Hour), EndTime: now.Add(-2 * time.Hour)},
+		{Operator: "bob", ClusterID: "BCS-MESOS-10001", ContainerID: "def456", StartTime: now.Add(-time.Hour)},
+		{Operator: "alice", ClusterID: "BCS-MESOS-10002", ContainerID: "abc789", StartTime: now},
+	}
+	for _, session := range sessions {
+		if err := store.Create(session); err != nil {
+			t.Fatalf("create session failed: %s", err.Error())
+		}
+	}
+	ioutil.WriteFile(store.RecordPath(sessions[0].ID), []byte("{}\n"), 0640)
+
+	if list := store.List(&types.ConsoleSessionFilter{Operator: "alice"}); len(list) != 2 || list[0].ID != sessions[2].ID {
+		t.Errorf("list by operator %v is not expected", list)
+	}
+	if list := store.List(&types.ConsoleSessionFilter{ContainerID: "abc", ClusterID: "BCS-MESOS-10001"}); len(list) != 1 {
+		t.Errorf("list by container and cluster %v is not expected", list)
+	}
+	if list := store.List(&types.ConsoleSessionFilter{Since: now.Add(-90 * time.Minute), Limit: 1}); len(list) != 1 || list[0].ID != sessions[2].ID {
+		t.Errorf("list by since and limit %v is not expected", list)
+	}
+
+	err = store.Update(sessions[1].ID, func(s *types.ConsoleSession) {
+		s.BlockedCommands = append(s.BlockedCommands, "reboot")
+	})
+	if err != nil {
+		t.Fatalf("update session failed: %s", err.Error())
+	}
+
+	//reload from dir, session without end time is closed
+	reloaded, err := NewSessionStore(dir)
+	if err != nil {
+		t.Fatalf("reload store failed: %s", err.Error())
+	}
+	session, err := reloaded.Get(sessions[1].ID)
+	if err != nil {
+		t.Fatalf("get session failed: %s", err.Error())
+	}
+	if len(session.BlockedCommands) != 1 || session.EndTime.IsZero() || session.Operator != "bob" {
+		t.Errorf("reloaded session %+v is not expected", session)
+	}
+
+	if count := reloaded.Clean(now.Add(-90 * time.Minute)); count != 1 {
+		t.Errorf("clean %d sessions, expected 1", count)
+	}
+	if _, err := reloaded.Get(sessions[0].ID); err == nil {
+		t.Errorf("cleaned session should not be found")
+	}
+	if _, err := os.Stat(reloaded.RecordPath(sessions[0].ID)); !os.IsNotExist(err) {
+		t.Errorf("record of cleaned session should be removed")
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// EventOutput is event of output stream
+	EventOutput = "o"
+	// EventInput is event of input stream
+	EventInput = "i"
+	// EventResize is event of terminal resize
+	EventResize = "r"
+
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// castHeader is header line of asciinema v2 file
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder records console streams into file in asciinema v2 format,
+// every event is a json array line: [elapsed seconds, event type, data]
+type Recorder struct {
+	sync.Mutex
+	file  *os.File
+	start time.Time
+	//incomplete utf8 bytes at the end of last event, keyed by event type
+	partial map[string][]byte
+}
+
+// NewRecorder create a Recorder writing to path
+func NewRecorder(path string, width, height int, title string, start time.Time) (*Recorder, error) {
+	if width <= 0 || height <= 0 {
+		width, height = defaultWidth, defaultHeight
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0640)
+	if err != nil {
+		return nil, err
+	}
+	header := castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: start.Unix(),
+		Title:     title,
+		Env:       map[string]string{"TERM": "xterm"},
+	}
+	data, _ := json.Marshal(header)
+	if _, err = file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &Recorder{
+		file:    file,
+		start:   start,
+		partial: make(map[string][]byte),
+	}, nil
+}
+
+// Input records data of input stream
+func (r *Recorder) Input(data []byte) {
+	r.event(EventInput, data)
+}
+
+// Output records data of output stream
+func (r *Recorder) Output(data []byte) {
+	r.event(EventOutput, data)
+}
+
+// Resize records terminal resize
+func (r *Recorder) Resize(width, height int) {
+	r.event(EventResize, []byte(fmt.Sprintf("%dx%d", width, height)))
+}
+
+func (r *Recorder) event(kind string, data []byte) {
+	if r == nil {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+	if r.file == nil {
+		return
+	}
+	//stream may be split in the middle of utf8 character, keep the incomplete
+	//bytes until the rest of character comes
+	data = append(r.partial[kind], data...)
+	data, r.partial[kind] = splitIncompleteRune(data)
+	if len(data) == 0 {
+		return
+	}
+	elapsed := float64(time.Since(r.start).Microseconds()) / 1e6
+	line, _ := json.Marshal([]interface{}{elapsed, kind, string(data)})
+	r.file.Write(append(line, '\n'))
+}
+
+// Close flushes pending data and closes record file
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.Lock()
+	defer r.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+//splitIncompleteRune split data into complete utf8 characters and trailing incomplete bytes
+func splitIncompleteRune(data []byte) ([]byte, []byte) {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(data[i]) {
+			continue
+		}
+		if !utf8.FullRune(data[i:]) {
+			return data[:i], append([]byte(nil), data[i:]...)
+		}
+		break
+	}
+	return data, nil
+}
@@ -0,0 +1,207 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package audit
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-consoleproxy/console-proxy/types"
+)
+
+const (
+	sessionFileSuffix = ".json"
+	recordFileSuffix  = ".cast"
+)
+
+// SessionStore keeps console session metadata and records in local directory,
+// metadata is saved as <id>.json and record is saved as <id>.cast
+type SessionStore struct {
+	sync.RWMutex
+	dir      string
+	sessions map[string]*types.ConsoleSession
+}
+
+// NewSessionStore create SessionStore and load sessions in dir
+func NewSessionStore(dir string) (*SessionStore, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	store := &SessionStore{
+		dir:      dir,
+		sessions: make(map[string]*types.ConsoleSession),
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), sessionFileSuffix) {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			blog.Errorf("read console session %s failed: %s", f.Name(), err.Error())
+			continue
+		}
+		session := &types.ConsoleSession{}
+		if err := json.Unmarshal(data, session); err != nil {
+			blog.Errorf("decode console session %s failed: %s", f.Name(), err.Error())
+			continue
+		}
+		//session is interrupted by restart, the last record time is the end
+		if session.EndTime.IsZero() {
+			session.EndTime = f.ModTime()
+			if info, err := os.Stat(store.RecordPath(session.ID)); err == nil {
+				session.EndTime = info.ModTime()
+			}
+			store.save(session)
+		}
+		store.sessions[session.ID] = session
+	}
+	blog.Infof("load %d console sessions from %s", len(store.sessions), dir)
+	return store, nil
+}
+
+// Create saves new session, id is generated if empty
+func (s *SessionStore) Create(session *types.ConsoleSession) error {
+	if session.ID == "" {
+		session.ID = newSessionID(session.StartTime)
+	}
+	s.Lock()
+	defer s.Unlock()
+	if err := s.save(session); err != nil {
+		return err
+	}
+	copied := *session
+	s.sessions[session.ID] = &copied
+	return nil
+}
+
+// Update changes session by update func and saves it
+func (s *SessionStore) Update(id string, update func(session *types.ConsoleSession)) error {
+	s.Lock()
+	defer s.Unlock()
+	session, ok := s.sessions[id]
+	if !ok {
+		return fmt.Errorf("console session %s not found", id)
+	}
+	update(session)
+	return s.save(session)
+}
+
+// Get returns copy of session by id
+func (s *SessionStore) Get(id string) (*types.ConsoleSession, error) {
+	s.RLock()
+	defer s.RUnlock()
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("console session %s not found", id)
+	}
+	copied := *session
+	return &copied, nil
+}
+
+// List returns copy of sessions matched filter, the latest first
+func (s *SessionStore) List(filter *types.ConsoleSessionFilter) []*types.ConsoleSession {
+	s.RLock()
+	var sessions []*types.ConsoleSession
+	for _, session := range s.sessions {
+		if filter.Operator != "" && session.Operator != filter.Operator {
+			continue
+		}
+		if filter.ClusterID != "" && session.ClusterID != filter.ClusterID {
+			continue
+		}
+		if filter.ContainerID != "" && !strings.HasPrefix(session.ContainerID, filter.ContainerID) {
+			continue
+		}
+		if !filter.Since.IsZero() && session.StartTime.Before(filter.Since) {
+			continue
+		}
+		if !filter.Until.IsZero() && session.StartTime.After(filter.Until) {
+			continue
+		}
+		copied := *session
+		sessions = append(sessions, &copied)
+	}
+	s.RUnlock()
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.After(sessions[j].StartTime)
+	})
+	if filter.Limit > 0 && len(sessions) > filter.Limit {
+		sessions = sessions[:filter.Limit]
+	}
+	return sessions
+}
+
+// RecordPath returns record file path of session
+func (s *SessionStore) RecordPath(id string) string {
+	return filepath.Join(s.dir, id+recordFileSuffix)
+}
+
+// Clean removes finished sessions ended before deadline, return number of removed sessions
+func (s *SessionStore) Clean(deadline time.Time) int {
+	s.Lock()
+	defer s.Unlock()
+	count := 0
+	for id, session := range s.sessions {
+		if session.EndTime.IsZero() || session.EndTime.After(deadline) {
+			continue
+		}
+		if err := os.Remove(s.RecordPath(id)); err != nil && !os.IsNotExist(err) {
+			blog.Errorf("remove console session record %s failed: %s", id, err.Error())
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, id+sessionFileSuffix)); err != nil && !os.IsNotExist(err) {
+			blog.Errorf("remove console session %s failed: %s", id, err.Error())
+			continue
+		}
+		delete(s.sessions, id)
+		count++
+	}
+	return count
+}
+
+//save writes session metadata to file, write to temporary file first to avoid broken file
+func (s *SessionStore) save(session *types.ConsoleSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(s.dir, session.ID+sessionFileSuffix)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+//newSessionID create session id with start time and random suffix
+func newSessionID(start time.Time) string {
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return fmt.Sprintf("%s-%s", start.Format("20060102150405"), hex.EncodeToString(suffix))
+}
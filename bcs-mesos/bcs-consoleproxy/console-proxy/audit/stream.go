@@ -0,0 +1,194 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package audit
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	keyCtrlC     = 0x03
+	keyBackspace = 0x08
+	keyCtrlU     = 0x15
+	keyDelete    = 0x7f
+)
+
+// CommandFilter checks commands against blocklist of regular expressions
+type CommandFilter struct {
+	patterns []*regexp.Regexp
+}
+
+// NewCommandFilter create CommandFilter, nil is returned if blocklist is empty
+func NewCommandFilter(blocklist []string) (*CommandFilter, error) {
+	filter := &CommandFilter{}
+	for _, item := range blocklist {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		pattern, err := regexp.Compile(item)
+		if err != nil {
+			return nil, fmt.Errorf("command blocklist %s is invalid: %s", item, err.Error())
+		}
+		filter.patterns = append(filter.patterns, pattern)
+	}
+	if len(filter.patterns) == 0 {
+		return nil, nil
+	}
+	return filter, nil
+}
+
+// Blocked checks whether command matches the blocklist, return the matched pattern
+func (f *CommandFilter) Blocked(command string) (string, bool) {
+	if f == nil {
+		return "", false
+	}
+	for _, pattern := range f.patterns {
+		if pattern.MatchString(command) {
+			return pattern.String(), true
+		}
+	}
+	return "", false
+}
+
+// OutputStream records console output and serializes writes to client
+type OutputStream struct {
+	sync.Mutex
+	writer   io.Writer
+	recorder *Recorder
+}
+
+// NewOutputStream create OutputStream writing to writer
+func NewOutputStream(writer io.Writer, recorder *Recorder) *OutputStream {
+	return &OutputStream{
+		writer:   writer,
+		recorder: recorder,
+	}
+}
+
+// Write writes data to client and records it
+func (s *OutputStream) Write(p []byte) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+	s.recorder.Output(p)
+	return s.writer.Write(p)
+}
+
+// InputStream records console input and enforces the command blocklist.
+// typed characters are tracked as a command line, when enter comes with a
+// blocked command, the enter is replaced by ctrl-u to discard the line in tty,
+// and the whole line is dropped without tty.
+// commands from shell history or completion can not be seen by InputStream.
+type InputStream struct {
+	reader    io.Reader
+	output    io.Writer
+	recorder  *Recorder
+	filter    *CommandFilter
+	tty       bool
+	onBlocked func(command string)
+	line      []byte
+	pending   []byte
+	buf       []byte
+}
+
+// NewInputStream create InputStream reading from reader, tips of blocked command are written to output
+func NewInputStream(reader io.Reader, output io.Writer, recorder *Recorder, filter *CommandFilter,
+	tty bool, onBlocked func(command string)) *InputStream {
+	return &InputStream{
+		reader:    reader,
+		output:    output,
+		recorder:  recorder,
+		filter:    filter,
+		tty:       tty,
+		onBlocked: onBlocked,
+		buf:       make([]byte, 32*1024),
+	}
+}
+
+// Read reads input from client
+func (s *InputStream) Read(p []byte) (int, error) {
+	for len(s.pending) == 0 {
+		n, err := s.reader.Read(s.buf)
+		if n > 0 {
+			s.recorder.Input(s.buf[:n])
+			s.pending = s.process(s.buf[:n])
+		}
+		if err != nil {
+			if len(s.pending) != 0 {
+				break
+			}
+			return 0, err
+		}
+	}
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
+
+//process tracks command line and returns the bytes forwarded to container
+func (s *InputStream) process(data []byte) []byte {
+	if s.filter == nil {
+		return append([]byte(nil), data...)
+	}
+	var forward []byte
+	for _, b := range data {
+		switch b {
+		case '\r', '\n':
+			line := s.line
+			s.line = s.line[:0]
+			command := strings.TrimSpace(string(line))
+			if pattern, blocked := s.filter.Blocked(command); blocked && command != "" {
+				s.block(command, pattern)
+				if s.tty {
+					forward = append(forward, keyCtrlU)
+				}
+				continue
+			}
+			if !s.tty {
+				forward = append(forward, line...)
+			}
+			forward = append(forward, b)
+			continue
+		case keyDelete, keyBackspace:
+			if len(s.line) > 0 {
+				_, size := utf8.DecodeLastRune(s.line)
+				s.line = s.line[:len(s.line)-size]
+			}
+		case keyCtrlC, keyCtrlU:
+			s.line = s.line[:0]
+		default:
+			s.line = append(s.line, b)
+			//without tty, line is forwarded after checked
+			if !s.tty {
+				continue
+			}
+		}
+		if s.tty || b == keyCtrlC {
+			forward = append(forward, b)
+		}
+	}
+	return forward
+}
+
+func (s *InputStream) block(command, pattern string) {
+	tips := fmt.Sprintf("\r\ncommand [%s] is blocked by console policy [%s]\r\n", command, pattern)
+	s.output.Write([]byte(tips))
+	if s.onBlocked != nil {
+		s.onBlocked(command)
+	}
+}
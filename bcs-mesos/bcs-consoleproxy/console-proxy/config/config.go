@@ -38,6 +38,15 @@ type ConsoleProxyConfig struct {
 	Ips            []string
 	IsAuth         bool
 	IsOneSeesion   bool
+
+	//console session audit
+	ClusterID        string
+	RecordDir        string
+	RecordRetention  int
+	CommandBlocklist []string
+	//IPs or CIDRs of gateways which authenticate users, only requests from them are trusted
+	//to carry operator, cluster id and X-Forwarded-For headers
+	TrustedProxies []string
 }
 
 // NewConsoleProxyConfig create a config object
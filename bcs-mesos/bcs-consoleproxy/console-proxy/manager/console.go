@@ -187,17 +187,21 @@ func (m *manager) CreateExec(w http.ResponseWriter, r *http.Request, conf *types
 		ResponseJSON(w, http.StatusBadRequest, errMsg{err.Error()})
 		return
 	}
+	m.addCreatedExec(exec.ID, conf)
 
 	ResponseJSON(w, http.StatusOK, exec)
 }
 
 func (m *manager) startExec(ws io.ReadWriter, conf *types.WebSocketConfig) error {
 	fmt.Println("start exec")
+	// 记录会话并检查命令
+	input, output, finish := m.auditExec(ws, conf)
+	defer finish()
 	// 执行连接
 	err := m.dockerClient.StartExec(conf.ExecID, docker.StartExecOptions{
-		InputStream:  ws,
-		OutputStream: ws,
-		ErrorStream:  ws,
+		InputStream:  input,
+		OutputStream: output,
+		ErrorStream:  output,
 		Detach:       false,
 		Tty:          m.conf.Tty,
 		RawTerminal:  true,
@@ -213,6 +217,10 @@ func (m *manager) ResizeExec(w http.ResponseWriter, r *http.Request, conf *types
 		ResponseJSON(w, http.StatusBadRequest, errMsg{err.Error()})
 		return
 	}
+	m.RLock()
+	recorder := m.recorders[conf.ExecID]
+	m.RUnlock()
+	recorder.Resize(conf.Width, conf.Height)
 
 	ResponseJSON(w, http.StatusOK, nil)
 }
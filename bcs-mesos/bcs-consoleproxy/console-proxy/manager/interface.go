@@ -27,4 +27,9 @@ type Manager interface {
 	StartExec(http.ResponseWriter, *http.Request, *types.WebSocketConfig)
 	CreateExec(http.ResponseWriter, *http.Request, *types.WebSocketConfig)
 	ResizeExec(http.ResponseWriter, *http.Request, *types.WebSocketConfig)
+
+	//console session audit
+	ListSessions(http.ResponseWriter, *http.Request, *types.ConsoleSessionFilter)
+	GetSession(http.ResponseWriter, *http.Request, string)
+	GetSessionRecord(http.ResponseWriter, *http.Request, string)
 }
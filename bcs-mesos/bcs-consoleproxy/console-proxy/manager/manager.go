@@ -14,9 +14,21 @@
 package manager
 
 import (
+	"sync"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-consoleproxy/console-proxy/audit"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-consoleproxy/console-proxy/config"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-consoleproxy/console-proxy/types"
 	"github.com/fsouza/go-dockerclient"
-	"sync"
+)
+
+const (
+	//created exec not started in execExpiration is dropped
+	execExpiration = 10 * time.Minute
+	//interval of cleaning expired session records
+	recordCleanInterval = time.Hour
 )
 
 type manager struct {
@@ -24,6 +36,20 @@ type manager struct {
 	conf                *config.ConsoleProxyConfig
 	dockerClient        *docker.Client
 	connectedContainers map[string]bool
+
+	//console session audit
+	sessionStore  *audit.SessionStore
+	commandFilter *audit.CommandFilter
+	//created execs waiting for start, key is exec id
+	createdExecs map[string]*createdExec
+	//recorders of running sessions, key is exec id
+	recorders map[string]*audit.Recorder
+}
+
+//createdExec is exec info from CreateExec
+type createdExec struct {
+	conf    *types.WebSocketConfig
+	created time.Time
 }
 
 // NewManager create a Manager object
@@ -31,12 +57,46 @@ func NewManager(conf *config.ConsoleProxyConfig) Manager {
 	return &manager{
 		conf:                conf,
 		connectedContainers: make(map[string]bool),
+		createdExecs:        make(map[string]*createdExec),
+		recorders:           make(map[string]*audit.Recorder),
 	}
 }
 
-// Start create docker client
+// Start create docker client and console session audit
 func (m *manager) Start() error {
 	var err error
 	m.dockerClient, err = docker.NewClient(m.conf.DockerEndpoint)
-	return err
+	if err != nil {
+		return err
+	}
+
+	m.commandFilter, err = audit.NewCommandFilter(m.conf.CommandBlocklist)
+	if err != nil {
+		return err
+	}
+	if m.conf.RecordDir == "" {
+		blog.Infof("console session record dir is empty, recording is disabled")
+		return nil
+	}
+	m.sessionStore, err = audit.NewSessionStore(m.conf.RecordDir)
+	if err != nil {
+		return err
+	}
+	if m.conf.RecordRetention > 0 {
+		go m.cleanRecords()
+	}
+	return nil
+}
+
+//cleanRecords removes session records older than retention days
+func (m *manager) cleanRecords() {
+	ticker := time.NewTicker(recordCleanInterval)
+	defer ticker.Stop()
+	for {
+		deadline := time.Now().AddDate(0, 0, -m.conf.RecordRetention)
+		if count := m.sessionStore.Clean(deadline); count > 0 {
+			blog.Infof("clean %d console session records ended before %s", count, deadline.String())
+		}
+		<-ticker.C
+	}
 }
@@ -0,0 +1,189 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package manager
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-consoleproxy/console-proxy/audit"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-consoleproxy/console-proxy/types"
+)
+
+//addCreatedExec keeps exec info for session of StartExec, expired ones are dropped
+func (m *manager) addCreatedExec(execID string, conf *types.WebSocketConfig) {
+	now := time.Now()
+	m.Lock()
+	defer m.Unlock()
+	for id, exec := range m.createdExecs {
+		if now.Sub(exec.created) > execExpiration {
+			delete(m.createdExecs, id)
+		}
+	}
+	m.createdExecs[execID] = &createdExec{
+		conf:    conf,
+		created: now,
+	}
+}
+
+//takeCreatedExec gets and removes exec info created by CreateExec
+func (m *manager) takeCreatedExec(execID string) *types.WebSocketConfig {
+	m.Lock()
+	defer m.Unlock()
+	exec, ok := m.createdExecs[execID]
+	if !ok {
+		return nil
+	}
+	delete(m.createdExecs, execID)
+	return exec.conf
+}
+
+//auditExec records console session and wraps console streams with recorder and command blocklist,
+//finish must be called when exec is done
+func (m *manager) auditExec(ws io.ReadWriter, conf *types.WebSocketConfig) (io.Reader, io.Writer, func()) {
+	session := &types.ConsoleSession{
+		Operator:    conf.Operator,
+		ClusterID:   conf.ClusterID,
+		ContainerID: conf.ContainerID,
+		ExecID:      conf.ExecID,
+		SourceIP:    conf.SourceIP,
+		StartTime:   time.Now(),
+	}
+	//container, user and cmd of exec comes from CreateExec
+	if created := m.takeCreatedExec(conf.ExecID); created != nil {
+		session.ContainerID = created.ContainerID
+		session.User = created.User
+		session.Cmd = created.Cmd
+		if session.Operator == "" {
+			session.Operator = created.Operator
+		}
+		if session.ClusterID == "" {
+			session.ClusterID = created.ClusterID
+		}
+	}
+
+	var recorder *audit.Recorder
+	recorded := false
+	if m.sessionStore != nil {
+		if err := m.sessionStore.Create(session); err != nil {
+			blog.Errorf("create console session for exec %s failed: %s", conf.ExecID, err.Error())
+		} else {
+			recorded = true
+			title := fmt.Sprintf("%s exec in container %s", session.Operator, session.ContainerID)
+			recorder, err = audit.NewRecorder(m.sessionStore.RecordPath(session.ID), conf.Width, conf.Height,
+				title, session.StartTime)
+			if err != nil {
+				blog.Errorf("create console session %s record failed: %s", session.ID, err.Error())
+			}
+		}
+	}
+	if recorder != nil {
+		m.Lock()
+		m.recorders[conf.ExecID] = recorder
+		m.Unlock()
+	}
+	blog.Infof("console session %s start, operator %s, cluster %s, container %s, exec %s, source %s",
+		session.ID, session.Operator, session.ClusterID, session.ContainerID, session.ExecID, session.SourceIP)
+
+	onBlocked := func(command string) {
+		blog.Warnf("console session %s operator %s container %s, command %s is blocked",
+			session.ID, session.Operator, session.ContainerID, command)
+		if !recorded {
+			return
+		}
+		err := m.sessionStore.Update(session.ID, func(s *types.ConsoleSession) {
+			s.BlockedCommands = append(s.BlockedCommands, command)
+		})
+		if err != nil {
+			blog.Errorf("update console session %s failed: %s", session.ID, err.Error())
+		}
+	}
+	output := audit.NewOutputStream(ws, recorder)
+	input := audit.NewInputStream(ws, output, recorder, m.commandFilter, m.conf.Tty, onBlocked)
+
+	finish := func() {
+		m.Lock()
+		delete(m.recorders, conf.ExecID)
+		m.Unlock()
+		recorder.Close()
+		blog.Infof("console session %s of container %s finish", session.ID, session.ContainerID)
+		if !recorded {
+			return
+		}
+		err := m.sessionStore.Update(session.ID, func(s *types.ConsoleSession) {
+			s.EndTime = time.Now()
+		})
+		if err != nil {
+			blog.Errorf("update console session %s failed: %s", session.ID, err.Error())
+		}
+	}
+	return input, output, finish
+}
+
+// ListSessions list recorded console sessions
+func (m *manager) ListSessions(w http.ResponseWriter, r *http.Request, filter *types.ConsoleSessionFilter) {
+	if m.sessionStore == nil {
+		ResponseJSON(w, http.StatusNotFound, errMsg{"console session recording is disabled"})
+		return
+	}
+	sessions := m.sessionStore.List(filter)
+	if sessions == nil {
+		sessions = make([]*types.ConsoleSession, 0)
+	}
+	ResponseJSON(w, http.StatusOK, sessions)
+}
+
+// GetSession get recorded console session by id
+func (m *manager) GetSession(w http.ResponseWriter, r *http.Request, id string) {
+	if m.sessionStore == nil {
+		ResponseJSON(w, http.StatusNotFound, errMsg{"console session recording is disabled"})
+		return
+	}
+	session, err := m.sessionStore.Get(id)
+	if err != nil {
+		ResponseJSON(w, http.StatusNotFound, errMsg{err.Error()})
+		return
+	}
+	ResponseJSON(w, http.StatusOK, session)
+}
+
+// GetSessionRecord download record of console session in asciinema v2 format
+func (m *manager) GetSessionRecord(w http.ResponseWriter, r *http.Request, id string) {
+	if m.sessionStore == nil {
+		ResponseJSON(w, http.StatusNotFound, errMsg{"console session recording is disabled"})
+		return
+	}
+	if _, err := m.sessionStore.Get(id); err != nil {
+		ResponseJSON(w, http.StatusNotFound, errMsg{err.Error()})
+		return
+	}
+	file, err := os.Open(m.sessionStore.RecordPath(id))
+	if err != nil {
+		ResponseJSON(w, http.StatusNotFound, errMsg{err.Error()})
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		ResponseJSON(w, http.StatusInternalServerError, errMsg{err.Error()})
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-asciicast")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.cast", id))
+	http.ServeContent(w, r, id+".cast", info.ModTime(), file)
+}
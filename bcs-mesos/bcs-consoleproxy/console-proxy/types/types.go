@@ -13,6 +13,10 @@
 
 package types
 
+import (
+	"time"
+)
+
 // WebSocketConfig is config
 type WebSocketConfig struct {
 	Height      int
@@ -25,4 +29,32 @@ type WebSocketConfig struct {
 	Origin      string
 	User        string
 	ExecID      string
+	Operator    string
+	ClusterID   string
+	SourceIP    string
+}
+
+// ConsoleSession is metadata of a recorded console session
+type ConsoleSession struct {
+	ID              string    `json:"id"`
+	Operator        string    `json:"operator"`
+	ClusterID       string    `json:"cluster_id"`
+	ContainerID     string    `json:"container_id"`
+	ExecID          string    `json:"exec_id"`
+	User            string    `json:"user"`
+	Cmd             []string  `json:"cmd"`
+	SourceIP        string    `json:"source_ip"`
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+	BlockedCommands []string  `json:"blocked_commands,omitempty"`
+}
+
+// ConsoleSessionFilter is query condition of console sessions
+type ConsoleSessionFilter struct {
+	Operator    string
+	ClusterID   string
+	ContainerID string
+	Since       time.Time
+	Until       time.Time
+	Limit       int
 }
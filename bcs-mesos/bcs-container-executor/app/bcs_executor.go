@@ -67,9 +67,18 @@ const (
 //NewBcsExecutor create Executor instance
 func NewBcsExecutor(flag *CommandFlags) exec.Executor {
 	//create container runtime client
-	docker := container.NewDockerContainer(flag.DockerSocket, flag.User, flag.Passwd)
-	if docker == nil {
-		logs.Errorln("BcsExecutor create docker client failed")
+	var runtime container.Container
+	switch flag.ContainerRuntime {
+	case ContainerRuntimeCRI:
+		runtime = container.NewCRIContainer(flag.CRIEndpoint, flag.User, flag.Passwd, os.Getenv("MESOS_SANDBOX"))
+	case ContainerRuntimeDocker, "":
+		runtime = container.NewDockerContainer(flag.DockerSocket, flag.User, flag.Passwd)
+	default:
+		logs.Errorf("BcsExecutor container runtime %s is not supported", flag.ContainerRuntime)
+		return nil
+	}
+	if runtime == nil {
+		logs.Errorf("BcsExecutor create %s client failed", flag.ContainerRuntime)
 		return nil
 	}
 	//create stop channel
@@ -80,7 +89,7 @@ func NewBcsExecutor(flag *CommandFlags) exec.Executor {
 		exeCxt:    eCxt,
 		exeCancel: eCancel,
 		podStatus: container.PodStatus_UNKNOWN,
-		container: docker,
+		container: runtime,
 		launched:  false,
 		tasks: &BcsTaskInfo{
 			ContainerRef:  make(map[string]string),
@@ -16,6 +16,7 @@ package app
 import (
 	"github.com/Tencent/bk-bcs/bcs-common/common/encrypt"
 	"github.com/Tencent/bk-bcs/bcs-common/common/util"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-container-executor/container"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-container-executor/logs"
 
 	"github.com/spf13/pflag"
@@ -24,6 +25,10 @@ import (
 const (
 	//DefaultCNIDirectory default cni directory
 	DefaultCNIDirectory = "/data/bcs/bcs-cni"
+	//ContainerRuntimeDocker docker runtime
+	ContainerRuntimeDocker = "docker"
+	//ContainerRuntimeCRI cri runtime, such as containerd
+	ContainerRuntimeCRI = "cri"
 )
 
 // CommandFlags hold all command line flags from mesos-slave
@@ -36,6 +41,8 @@ type CommandFlags struct {
 	CNIPluginDir        string // cni plugin directory, $CNIPluginDir/bin for binary, $CNIPluginDir/conf for configuration
 	NetworkImage        string // cni network images
 	ExtendedResourceDir string // dir for extended resources
+	ContainerRuntime    string // container runtime: docker or cri
+	CRIEndpoint         string // cri runtime endpoint
 }
 
 // NewCommandFlags return new DockerFalgs with default value
@@ -48,6 +55,8 @@ func NewCommandFlags() *CommandFlags {
 		NetworkMode:         "",
 		CNIPluginDir:        DefaultCNIDirectory,
 		ExtendedResourceDir: "/data/bcs/extended-resources",
+		ContainerRuntime:    ContainerRuntimeDocker,
+		CRIEndpoint:         container.DefaultCRIEndpoint,
 	}
 }
 
@@ -68,6 +77,10 @@ func ParseCmdFlags() *CommandFlags {
 	flag.StringVar(&cmdFlag.NetworkImage, "network-image", cmdFlag.NetworkImage, "container network image")
 	flag.StringVar(&cmdFlag.ExtendedResourceDir, "extended-resource-directory", cmdFlag.ExtendedResourceDir,
 		"the directory where all executor records extended resource allocation")
+	flag.StringVar(&cmdFlag.ContainerRuntime, "container-runtime", cmdFlag.ContainerRuntime,
+		"container runtime for running containers: docker or cri")
+	flag.StringVar(&cmdFlag.CRIEndpoint, "cri-endpoint", cmdFlag.CRIEndpoint,
+		"cri runtime endpoint, only used when container-runtime is cri")
 	util.InitFlags()
 	// parse base64 uuid to password, skip if uuid empty
 	if len(cmdFlag.Passwd) != 0 {
@@ -448,6 +448,9 @@ func (p *CNIPod) Start() error {
 		task.RuntimeConf.IPAddress = p.cniIPAddr
 		task.RuntimeConf.Status = container.ContainerStatus_CREATED
 		task.RuntimeConf.Message = "container created"
+		if createInst.Message != "" {
+			task.RuntimeConf.Message = fmt.Sprintf("container created, %s", createInst.Message)
+		}
 		//crate success, event callback before start
 		if p.events != nil && p.events.PreStart != nil {
 			preErr := p.events.PreStart(task)
@@ -395,6 +395,9 @@ func (p *DockerPod) Start() error {
 		task.RuntimeConf.IPAddress = p.cnmIPAddr
 		task.RuntimeConf.Status = container.ContainerStatus_CREATED
 		task.RuntimeConf.Message = "container created"
+		if createdInst.Message != "" {
+			task.RuntimeConf.Message = fmt.Sprintf("container created, %s", createdInst.Message)
+		}
 		task.RuntimeConf.Resource = task.Resource
 
 		logs.Infof("task %s cpu %f mem %f", task.TaskId, task.RuntimeConf.Resource.Cpus, task.RuntimeConf.Resource.Mem)
@@ -0,0 +1,767 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package container
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	schedTypes "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-container-executor/logs"
+
+	"google.golang.org/grpc"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+)
+
+const (
+	//DefaultCRIEndpoint default containerd cri endpoint
+	DefaultCRIEndpoint = "unix:///run/containerd/containerd.sock"
+	//criConnectTimeout timeout for connecting cri endpoint
+	criConnectTimeout = time.Second * 10
+	//criRequestTimeout timeout for normal cri request
+	criRequestTimeout = time.Minute * 2
+	//criPullImageTimeout timeout for pulling image
+	criPullImageTimeout = time.Minute * 10
+	//criExecTimeout timeout in seconds for exec command in container
+	criExecTimeout = 60
+	//criUploadChunkSize max bytes of file content uploaded by one exec
+	criUploadChunkSize = 48 * 1024
+	//criSandboxNamespace namespace of sandbox metadata
+	criSandboxNamespace = "bcs"
+	//criNetworkAnnotation keeps network name of BcsContainerTask
+	criNetworkAnnotation = "io.tencent.bcs.network"
+	//criHostnameAnnotation keeps hostname of BcsContainerTask
+	criHostnameAnnotation = "io.tencent.bcs.hostname"
+	//criDroppedAnnotation keeps settings of BcsContainerTask not supported by cri
+	criDroppedAnnotation = "io.tencent.bcs.dropped"
+)
+
+//CRIContainer implement container interface
+//to handle all operator with cri runtime, such as containerd.
+//every container runs in a pod sandbox of its own, except the container
+//with network container:<id>, which joins the pod sandbox of container <id>.
+//the pod sandbox is removed with the last container in it.
+type CRIContainer struct {
+	user          string                          //login registry user name
+	passwd        string                          //login registry user password
+	endpoint      string                          //cri runtime endpoint
+	logDir        string                          //directory of container logs
+	conn          *grpc.ClientConn                //grpc connection to cri endpoint
+	runtimeClient runtimeapi.RuntimeServiceClient //cri runtime service client
+	imageClient   runtimeapi.ImageServiceClient   //cri image service client
+}
+
+//NewCRIContainer create CRIContainer manager tool
+//endpoint can be unix domain sock or tcp address, logDir is directory for container stdout/stderr
+func NewCRIContainer(endpoint, user, passwd, logDir string) Container {
+	if endpoint == "" {
+		endpoint = DefaultCRIEndpoint
+	}
+	protocol, addr, err := parseCRIEndpoint(endpoint)
+	if err != nil {
+		logs.Errorf("Create CRIContainer Manager failed: %s", err.Error())
+		return nil
+	}
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, protocol, address)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), criConnectTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithBlock(), grpc.WithContextDialer(dialer))
+	if err != nil {
+		logs.Errorf("Create CRIContainer Manager failed, connect %s err: %s", endpoint, err.Error())
+		return nil
+	}
+	cri := &CRIContainer{
+		user:          user,
+		passwd:        passwd,
+		endpoint:      endpoint,
+		logDir:        logDir,
+		conn:          conn,
+		runtimeClient: runtimeapi.NewRuntimeServiceClient(conn),
+		imageClient:   runtimeapi.NewImageServiceClient(conn),
+	}
+	//check cri api version of runtime
+	vCtx, vCancel := context.WithTimeout(context.Background(), criConnectTimeout)
+	defer vCancel()
+	version, err := cri.runtimeClient.Version(vCtx, &runtimeapi.VersionRequest{})
+	if err != nil {
+		logs.Errorf("Create CRIContainer Manager failed, get runtime version err: %s", err.Error())
+		conn.Close()
+		return nil
+	}
+	logs.Infof("CRIContainer connect %s success, runtime %s %s, api version %s", endpoint,
+		version.RuntimeName, version.RuntimeVersion, version.RuntimeApiVersion)
+	return cri
+}
+
+//parseCRIEndpoint parse endpoint to protocol & address for dial
+func parseCRIEndpoint(endpoint string) (string, string, error) {
+	switch {
+	case strings.HasPrefix(endpoint, "unix://"):
+		return "unix", strings.TrimPrefix(endpoint, "unix://"), nil
+	case strings.HasPrefix(endpoint, "tcp://"):
+		return "tcp", strings.TrimPrefix(endpoint, "tcp://"), nil
+	case strings.HasPrefix(endpoint, "/"):
+		return "unix", endpoint, nil
+	}
+	return "", "", fmt.Errorf("cri endpoint %s is not supported, only unix & tcp available", endpoint)
+}
+
+//findContainer find container by id, id prefix or name
+func (cri *CRIContainer) findContainer(ref string) (*runtimeapi.Container, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), criRequestTimeout)
+	defer cancel()
+	resp, err := cri.runtimeClient.ListContainers(ctx, &runtimeapi.ListContainersRequest{})
+	if err != nil {
+		return nil, err
+	}
+	ref = strings.TrimPrefix(ref, "/")
+	var found *runtimeapi.Container
+	for _, c := range resp.Containers {
+		if c.Id == ref || (c.Metadata != nil && c.Metadata.Name == ref) {
+			return c, nil
+		}
+		if strings.HasPrefix(c.Id, ref) {
+			found = c
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("container %s not found", ref)
+	}
+	return found, nil
+}
+
+//RunCommand running command in Container
+func (cri *CRIContainer) RunCommand(containerID string, command []string) error {
+	c, err := cri.findContainer(containerID)
+	if err != nil {
+		logs.Errorf("cri exec in container %s failed: %s", containerID, err.Error())
+		return err
+	}
+	//docker exec is detached, do not wait for command either
+	go func() {
+		resp, err := cri.execSync(c.Id, command)
+		if err != nil {
+			logs.Errorf("cri exec %v in container %s failed: %s", command, c.Id, err.Error())
+			return
+		}
+		logs.Infof("cri exec %v in container %s done, exit code %d", command, c.Id, resp.ExitCode)
+	}()
+	return nil
+}
+
+//execSync run command in container and wait for result
+func (cri *CRIContainer) execSync(containerID string, command []string) (*runtimeapi.ExecSyncResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*(criExecTimeout+10))
+	defer cancel()
+	req := &runtimeapi.ExecSyncRequest{
+		ContainerId: containerID,
+		Cmd:         command,
+		Timeout:     criExecTimeout,
+	}
+	return cri.runtimeClient.ExecSync(ctx, req)
+}
+
+// RunCommandV2 v2 version run command
+func (cri *CRIContainer) RunCommandV2(ops *schedTypes.RequestCommandTask) (*schedTypes.ResponseCommandTask, error) {
+	by, _ := json.Marshal(ops)
+	logs.Infof("cri run command %s", string(by))
+	resp := &schedTypes.ResponseCommandTask{
+		ID:          ops.ID,
+		TaskId:      ops.TaskId,
+		ContainerId: ops.ContainerId,
+	}
+	//cri exec can not setting user & environments, user is the one container running with
+	if ops.User != "" && ops.User != "root" {
+		logs.Infof("cri run command in container %s ignore user %s", ops.ContainerId, ops.User)
+	}
+	command := ops.Cmd
+	if len(ops.Env) != 0 {
+		command = append(append([]string{"env"}, ops.Env...), ops.Cmd...)
+	}
+	c, err := cri.findContainer(ops.ContainerId)
+	if err != nil {
+		logs.Errorf("cri exec error %s", err.Error())
+		resp.Status = commtypes.TaskCommandStatusFailed
+		resp.Message = err.Error()
+		return resp, nil
+	}
+	execResp, err := cri.execSync(c.Id, command)
+	if err != nil {
+		logs.Errorf("cri exec error %s", err.Error())
+		resp.Status = commtypes.TaskCommandStatusFailed
+		resp.Message = err.Error()
+		return resp, nil
+	}
+	logs.Infof("cri container %s run command success", ops.ContainerId)
+
+	out, errB := execResp.Stdout, execResp.Stderr
+	if len(out) > 256 {
+		out = out[:256]
+	}
+	if len(errB) > 256 {
+		errB = errB[:256]
+	}
+	resp.Status = commtypes.TaskCommandStatusFinish
+	resp.CommInspect = &commtypes.CommandInspectInfo{}
+	resp.CommInspect.ExitCode = int(execResp.ExitCode)
+	resp.CommInspect.Stderr = string(errB)
+	resp.CommInspect.Stdout = string(out)
+	return resp, nil
+}
+
+//UploadToContainer upload file from host to Container.
+//cri has no copy api, file content is written by exec with base64 chunks,
+//so image must contain sh & base64 command
+func (cri *CRIContainer) UploadToContainer(containerID string, source, dest string) error {
+	content, err := ioutil.ReadFile(source)
+	if err != nil {
+		logs.Errorf("Read source %s failed: %s", source, err.Error())
+		return err
+	}
+	c, err := cri.findContainer(containerID)
+	if err != nil {
+		return err
+	}
+	//keep the same destination with docker, file named as source in directory of dest
+	target := filepath.Join(filepath.Dir(dest), filepath.Base(source))
+	quoted := criShellQuote(target)
+	commands := []string{fmt.Sprintf("mkdir -p %s && : > %s", criShellQuote(filepath.Dir(target)), quoted)}
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > 0 {
+		size := criUploadChunkSize
+		if len(encoded) < size {
+			size = len(encoded)
+		}
+		commands = append(commands, fmt.Sprintf("echo '%s' | base64 -d >> %s", encoded[:size], quoted))
+		encoded = encoded[size:]
+	}
+	for _, command := range commands {
+		resp, err := cri.execSync(c.Id, []string{"sh", "-c", command})
+		if err != nil {
+			logs.Errorf("Upload %s to container %s failed: %s", source, containerID, err.Error())
+			return err
+		}
+		if resp.ExitCode != 0 {
+			return fmt.Errorf("upload %s to container %s failed, exit code %d: %s",
+				source, containerID, resp.ExitCode, string(resp.Stderr))
+		}
+	}
+	return nil
+}
+
+//criShellQuote quote s as one word of sh command
+func criShellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+//ListContainer list all running containner info
+func (cri *CRIContainer) ListContainer() {
+
+}
+
+//CreateContainer create pod sandbox & container with cri runtime.
+func (cri *CRIContainer) CreateContainer(containerName string, containerTask *BcsContainerTask) (*BcsContainerInfo, error) {
+	//ip address of pod sandbox is allocated by network plugin of cri runtime
+	if containerTask.NetworkIPAddr != "" {
+		logs.Errorf("Create Container %s failed, cri can not request ip address %s",
+			containerName, containerTask.NetworkIPAddr)
+		return nil, fmt.Errorf("container %s requests ip address %s, but it is not supported by cri runtime %s",
+			containerName, containerTask.NetworkIPAddr, cri.endpoint)
+	}
+	//settings without cri api are dropped, keep them visible in container message and annotation
+	dropped := criDroppedSettings(containerTask)
+	if len(dropped) != 0 {
+		logs.Errorf("cri container %s drops settings not supported by cri: %s", containerName, strings.Join(dropped, ", "))
+	}
+	//check images
+	imageList, _ := cri.ListImage(containerTask.Image)
+	//ready to pull if user setting pullImageForce
+	if len(imageList) == 0 || containerTask.ForcePullImage {
+		if pullErr := cri.PullImage(containerTask.Image); pullErr != nil {
+			return nil, pullErr
+		}
+	}
+	//join pod sandbox of other container or create new one
+	var sandboxID string
+	var sandboxConfig *runtimeapi.PodSandboxConfig
+	var err error
+	newSandbox := false
+	if strings.HasPrefix(containerTask.NetworkName, "container:") {
+		sandboxID, sandboxConfig, err = cri.joinSandbox(strings.TrimPrefix(containerTask.NetworkName, "container:"))
+	} else {
+		sandboxID, sandboxConfig, err = cri.runSandbox(containerName, containerTask)
+		newSandbox = true
+	}
+	if err != nil {
+		logs.Errorf("Create Container %s failed, prepare pod sandbox err: %s", containerName, err.Error())
+		return nil, err
+	}
+
+	config := &runtimeapi.ContainerConfig{
+		Metadata: &runtimeapi.ContainerMetadata{Name: containerName},
+		Image:    &runtimeapi.ImageSpec{Image: containerTask.Image},
+		Labels:   make(map[string]string),
+		Annotations: map[string]string{
+			criNetworkAnnotation:  containerTask.NetworkName,
+			criHostnameAnnotation: containerTask.HostName,
+		},
+		Linux: &runtimeapi.LinuxContainerConfig{
+			Resources: criLinuxResources(containerTask),
+			SecurityContext: &runtimeapi.LinuxContainerSecurityContext{
+				Capabilities:     &runtimeapi.Capability{AddCapabilities: []string{"SYS_PTRACE"}},
+				Privileged:       containerTask.Privileged,
+				NamespaceOptions: sandboxConfig.GetLinux().GetSecurityContext().GetNamespaceOptions(),
+			},
+		},
+	}
+	if len(dropped) != 0 {
+		config.Annotations[criDroppedAnnotation] = strings.Join(dropped, ", ")
+	}
+	if cri.logDir != "" {
+		config.LogPath = containerName + ".log"
+	}
+	//docker keeps image entrypoint and takes command with arguments as cmd,
+	//cri args do the same
+	if containerTask.Command != "" {
+		config.Args = append(config.Args, containerTask.Command)
+	}
+	config.Args = append(config.Args, containerTask.Args...)
+	for _, env := range containerTask.Env {
+		config.Envs = append(config.Envs, &runtimeapi.KeyValue{Key: env.Key, Value: env.Value})
+	}
+	for _, volumn := range containerTask.Volums {
+		config.Mounts = append(config.Mounts, &runtimeapi.Mount{
+			HostPath:      volumn.HostPath,
+			ContainerPath: volumn.ContainerPath,
+			Readonly:      volumn.ReadOnly,
+		})
+	}
+	for _, kv := range containerTask.Labels {
+		config.Labels[kv.Key] = kv.Value
+	}
+
+	by, _ := json.Marshal(config)
+	logs.Infof("create container %s in sandbox %s data %s", containerName, sandboxID, string(by))
+	ctx, cancel := context.WithTimeout(context.Background(), criRequestTimeout)
+	defer cancel()
+	resp, err := cri.runtimeClient.CreateContainer(ctx, &runtimeapi.CreateContainerRequest{
+		PodSandboxId:  sandboxID,
+		Config:        config,
+		SandboxConfig: sandboxConfig,
+	})
+	if err != nil {
+		logs.Errorf("Create Container %s failed: %s", containerName, err.Error())
+		if newSandbox {
+			cri.removeSandbox(sandboxID)
+		}
+		return nil, err
+	}
+	logs.Infof("Success to create container(ID:%s)", resp.ContainerId)
+	info := &BcsContainerInfo{
+		ID:   resp.ContainerId,
+		Name: containerName,
+	}
+	if len(dropped) != 0 {
+		info.Message = fmt.Sprintf("settings not supported by cri are dropped: %s", strings.Join(dropped, ", "))
+	}
+	return info, nil
+}
+
+//criDroppedSettings describe settings of task which have no cri api
+func criDroppedSettings(containerTask *BcsContainerTask) []string {
+	var dropped []string
+	if len(containerTask.Hosts) != 0 {
+		dropped = append(dropped, fmt.Sprintf("hosts %s", strings.Join(containerTask.Hosts, " ")))
+	}
+	for _, ulimit := range containerTask.Ulimits {
+		dropped = append(dropped, fmt.Sprintf("ulimit %s=%s", ulimit.Key, ulimit.Value))
+	}
+	if containerTask.ShmSize != 0 {
+		dropped = append(dropped, fmt.Sprintf("shm size %d", containerTask.ShmSize))
+	}
+	return dropped
+}
+
+//runSandbox create pod sandbox for container
+func (cri *CRIContainer) runSandbox(containerName string, containerTask *BcsContainerTask) (string, *runtimeapi.PodSandboxConfig, error) {
+	namespaces := &runtimeapi.NamespaceOption{
+		Network: runtimeapi.NamespaceMode_POD,
+		Pid:     runtimeapi.NamespaceMode_CONTAINER,
+		Ipc:     runtimeapi.NamespaceMode_POD,
+	}
+	if containerTask.NetworkName == "host" {
+		namespaces.Network = runtimeapi.NamespaceMode_NODE
+	}
+	if containerTask.Ipc == "host" {
+		namespaces.Ipc = runtimeapi.NamespaceMode_NODE
+	}
+	config := &runtimeapi.PodSandboxConfig{
+		Metadata: &runtimeapi.PodSandboxMetadata{
+			Name:      containerName,
+			Uid:       containerName,
+			Namespace: criSandboxNamespace,
+		},
+		Hostname:     containerTask.HostName,
+		LogDirectory: cri.logDir,
+		Labels:       make(map[string]string),
+		Linux: &runtimeapi.LinuxPodSandboxConfig{
+			SecurityContext: &runtimeapi.LinuxSandboxSecurityContext{
+				NamespaceOptions: namespaces,
+				Privileged:       containerTask.Privileged,
+			},
+		},
+	}
+	for _, kv := range containerTask.Labels {
+		config.Labels[kv.Key] = kv.Value
+	}
+	//port mapping only works with runtime network, like docker bridge
+	if namespaces.Network == runtimeapi.NamespaceMode_POD {
+		for _, port := range containerTask.PortBindings {
+			containerPort, _ := strconv.Atoi(port.ContainerPort)
+			hostPort, _ := strconv.Atoi(port.HostPort)
+			mapping := &runtimeapi.PortMapping{
+				Protocol:      runtimeapi.Protocol_TCP,
+				ContainerPort: int32(containerPort),
+				HostPort:      int32(hostPort),
+				HostIp:        port.HostIP,
+			}
+			if strings.ToLower(port.Protocol) == "udp" {
+				mapping.Protocol = runtimeapi.Protocol_UDP
+			}
+			config.PortMappings = append(config.PortMappings, mapping)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), criRequestTimeout)
+	defer cancel()
+	resp, err := cri.runtimeClient.RunPodSandbox(ctx, &runtimeapi.RunPodSandboxRequest{Config: config})
+	if err != nil {
+		return "", nil, err
+	}
+	logs.Infof("Success to run pod sandbox(ID:%s) for container %s", resp.PodSandboxId, containerName)
+	return resp.PodSandboxId, config, nil
+}
+
+//joinSandbox get pod sandbox of container ref
+func (cri *CRIContainer) joinSandbox(ref string) (string, *runtimeapi.PodSandboxConfig, error) {
+	c, err := cri.findContainer(ref)
+	if err != nil {
+		return "", nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), criRequestTimeout)
+	defer cancel()
+	resp, err := cri.runtimeClient.PodSandboxStatus(ctx, &runtimeapi.PodSandboxStatusRequest{PodSandboxId: c.PodSandboxId})
+	if err != nil {
+		return "", nil, err
+	}
+	status := resp.Status
+	config := &runtimeapi.PodSandboxConfig{
+		Metadata:     status.Metadata,
+		LogDirectory: cri.logDir,
+		Labels:       status.Labels,
+		Annotations:  status.Annotations,
+	}
+	if status.Linux != nil && status.Linux.Namespaces != nil {
+		config.Linux = &runtimeapi.LinuxPodSandboxConfig{
+			SecurityContext: &runtimeapi.LinuxSandboxSecurityContext{
+				NamespaceOptions: status.Linux.Namespaces.Options,
+			},
+		}
+	}
+	return c.PodSandboxId, config, nil
+}
+
+//removeSandbox stop & remove pod sandbox if no container left in it
+func (cri *CRIContainer) removeSandbox(sandboxID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), criRequestTimeout)
+	defer cancel()
+	resp, err := cri.runtimeClient.ListContainers(ctx, &runtimeapi.ListContainersRequest{
+		Filter: &runtimeapi.ContainerFilter{PodSandboxId: sandboxID},
+	})
+	if err != nil {
+		logs.Errorf("list containers in pod sandbox %s failed: %s", sandboxID, err.Error())
+		return
+	}
+	if len(resp.Containers) != 0 {
+		return
+	}
+	if _, err := cri.runtimeClient.StopPodSandbox(ctx, &runtimeapi.StopPodSandboxRequest{PodSandboxId: sandboxID}); err != nil {
+		logs.Errorf("stop pod sandbox %s failed: %s", sandboxID, err.Error())
+		return
+	}
+	if _, err := cri.runtimeClient.RemovePodSandbox(ctx, &runtimeapi.RemovePodSandboxRequest{PodSandboxId: sandboxID}); err != nil {
+		logs.Errorf("remove pod sandbox %s failed: %s", sandboxID, err.Error())
+		return
+	}
+	logs.Infof("Success to remove pod sandbox %s", sandboxID)
+}
+
+//criLinuxResources convert task resource to cri linux resources, same as docker
+func criLinuxResources(containerTask *BcsContainerTask) *runtimeapi.LinuxContainerResources {
+	resources := &runtimeapi.LinuxContainerResources{CpuShares: 1024}
+	if containerTask.Resource != nil {
+		if containerTask.Resource.Cpus > 0 {
+			resources.CpuShares = int64(containerTask.Resource.Cpus * 1024)
+		}
+		if containerTask.Resource.Mem >= 4 {
+			resources.MemoryLimitInBytes = int64(containerTask.Resource.Mem * 1024 * 1024)
+		}
+	}
+	if containerTask.LimitResource != nil && containerTask.LimitResource.Cpus > 0 {
+		resources.CpuPeriod = DefaultDockerCPUPeriod
+		resources.CpuQuota = int64(containerTask.LimitResource.Cpus * DefaultDockerCPUPeriod)
+	}
+	if containerTask.LimitResource != nil && containerTask.LimitResource.Mem >= 4 {
+		resources.MemoryLimitInBytes = int64(containerTask.LimitResource.Mem * 1024 * 1024)
+	}
+	return resources
+}
+
+//StartContainer starting container with container id return by CreateContainer
+func (cri *CRIContainer) StartContainer(containerID string) error {
+	logs.Infof("begin to start container(ID:%s)", containerID)
+	c, err := cri.findContainer(containerID)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), criRequestTimeout)
+	defer cancel()
+	if _, err := cri.runtimeClient.StartContainer(ctx, &runtimeapi.StartContainerRequest{ContainerId: c.Id}); err != nil {
+		logs.Errorf("Start Container(ID:%s) failed: %s", containerID, err.Error())
+		return err
+	}
+	logs.Infof("Success to start container(ID:%s)", containerID)
+	return nil
+}
+
+//StopContainer with container name. container will be killed when timeout
+func (cri *CRIContainer) StopContainer(containerName string, timeout int) error {
+	c, err := cri.findContainer(containerName)
+	if err != nil {
+		return err
+	}
+	logs.Infof("start stop container %s", containerName)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(timeout+StopContainerGraceTime))
+	defer cancel()
+	_, err = cri.runtimeClient.StopContainer(ctx, &runtimeapi.StopContainerRequest{
+		ContainerId: c.Id,
+		Timeout:     int64(timeout),
+	})
+	if ctx.Err() == context.DeadlineExceeded {
+		logs.Infof("stop container %s timeout", containerName)
+		return fmt.Errorf("stop container %s timeout", containerName)
+	}
+	logs.Infof("stop container %s done", containerName)
+	return err
+}
+
+//RemoveContainer remove container by name, pod sandbox is removed with the last container in it
+func (cri *CRIContainer) RemoveContainer(containerName string, force bool) error {
+	c, err := cri.findContainer(containerName)
+	if err != nil {
+		return err
+	}
+	if c.State == runtimeapi.ContainerState_CONTAINER_RUNNING && !force {
+		return fmt.Errorf("container %s is running, stop it before remove", containerName)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), criRequestTimeout)
+	defer cancel()
+	if _, err := cri.runtimeClient.RemoveContainer(ctx, &runtimeapi.RemoveContainerRequest{ContainerId: c.Id}); err != nil {
+		logs.Errorf("Remove Container %s failed, err %s", containerName, err.Error())
+		return err
+	}
+	logs.Infof("Success to remove container %s", containerName)
+	cri.removeSandbox(c.PodSandboxId)
+	return nil
+}
+
+//KillContainer kill container by name, cri only supports SIGKILL by stopping without timeout
+func (cri *CRIContainer) KillContainer(containerName string, signal int) error {
+	if syscall.Signal(signal) != syscall.SIGKILL {
+		return fmt.Errorf("kill container %s with signal %d is not supported by cri runtime %s, only SIGKILL",
+			containerName, signal, cri.endpoint)
+	}
+	return cri.StopContainer(containerName, 0)
+}
+
+//InspectContainer inspect container by name
+func (cri *CRIContainer) InspectContainer(containerName string) (*BcsContainerInfo, error) {
+	c, err := cri.findContainer(containerName)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), criRequestTimeout)
+	defer cancel()
+	resp, err := cri.runtimeClient.ContainerStatus(ctx, &runtimeapi.ContainerStatusRequest{
+		ContainerId: c.Id,
+		Verbose:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	status := resp.Status
+	bcsContainer := &BcsContainerInfo{
+		ID:          status.Id,
+		Name:        status.GetMetadata().GetName(),
+		Pid:         criContainerPid(resp.Info),
+		Status:      criContainerStatus(status.State),
+		ExitCode:    int(status.ExitCode),
+		Hostname:    status.Annotations[criHostnameAnnotation],
+		NetworkMode: status.Annotations[criNetworkAnnotation],
+		OOMKilled:   status.Reason == "OOMKilled",
+		Message:     status.Message,
+	}
+	if status.StartedAt != 0 {
+		bcsContainer.StartAt = time.Unix(0, status.StartedAt)
+	}
+	if status.FinishedAt != 0 {
+		bcsContainer.FinishAt = time.Unix(0, status.FinishedAt)
+	}
+	if bcsContainer.NetworkMode == "default" {
+		bcsContainer.NetworkMode = "bridge"
+	}
+	//ip address is in pod sandbox
+	sandbox, err := cri.runtimeClient.PodSandboxStatus(ctx, &runtimeapi.PodSandboxStatusRequest{PodSandboxId: c.PodSandboxId})
+	if err != nil {
+		logs.Errorf("inspect pod sandbox %s of container %s failed: %s", c.PodSandboxId, containerName, err.Error())
+		return bcsContainer, nil
+	}
+	if sandbox.Status != nil && sandbox.Status.Network != nil {
+		bcsContainer.IPAddress = sandbox.Status.Network.Ip
+	}
+	return bcsContainer, nil
+}
+
+//criContainerStatus convert cri container state to docker status
+func criContainerStatus(state runtimeapi.ContainerState) string {
+	switch state {
+	case runtimeapi.ContainerState_CONTAINER_CREATED:
+		return ContainerStatus_CREATED
+	case runtimeapi.ContainerState_CONTAINER_RUNNING:
+		return ContainerStatus_RUNNING
+	case runtimeapi.ContainerState_CONTAINER_EXITED:
+		return ContainerStatus_EXITED
+	}
+	return ContainerStatus_DEAD
+}
+
+//criContainerPid get pid from verbose info of container status, containerd
+//keeps it in info as {"pid": 1234, ...}
+func criContainerPid(info map[string]string) int {
+	raw, ok := info["info"]
+	if !ok {
+		return 0
+	}
+	verbose := struct {
+		Pid int `json:"pid"`
+	}{}
+	if err := json.Unmarshal([]byte(raw), &verbose); err != nil {
+		logs.Errorf("decode cri container verbose info failed: %s", err.Error())
+		return 0
+	}
+	return verbose.Pid
+}
+
+//PullImage pull image from hub
+func (cri *CRIContainer) PullImage(image string) error {
+	req := &runtimeapi.PullImageRequest{
+		Image: &runtimeapi.ImageSpec{Image: image},
+	}
+	if cri.user != "" {
+		req.Auth = &runtimeapi.AuthConfig{
+			Username: cri.user,
+			Password: cri.passwd,
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), criPullImageTimeout)
+	defer cancel()
+	resp, err := cri.imageClient.PullImage(ctx, req)
+	if err != nil {
+		logs.Errorf("cri pull image %s failed: %s", image, err.Error())
+		return err
+	}
+	logs.Infof("cri pull image %s success, image ref %s", image, resp.ImageRef)
+	return nil
+}
+
+//ListImage list all image from local
+func (cri *CRIContainer) ListImage(filter string) ([]*BcsImage, error) {
+	req := &runtimeapi.ListImagesRequest{}
+	if filter != "" {
+		req.Filter = &runtimeapi.ImageFilter{Image: &runtimeapi.ImageSpec{Image: filter}}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), criRequestTimeout)
+	defer cancel()
+	var bcsImages []*BcsImage
+	resp, err := cri.imageClient.ListImages(ctx, req)
+	if err != nil {
+		return bcsImages, err
+	}
+	for _, image := range resp.Images {
+		bcs := &BcsImage{
+			ID:         image.Id,
+			Repository: image.RepoTags,
+			Size:       int64(image.Size_),
+		}
+		bcsImages = append(bcsImages, bcs)
+	}
+	return bcsImages, nil
+}
+
+// UpdateResources update container resource in runtime
+func (cri *CRIContainer) UpdateResources(id string, resource *schedTypes.TaskResources) error {
+	if resource == nil {
+		return fmt.Errorf("container resource to update cannot be empty")
+	}
+	if *resource.Cpu < 0 || *resource.Mem < 4 || *resource.ReqCpu < 0 || *resource.ReqMem < 4 {
+		return fmt.Errorf("container resource reqCpu %f reqMem %f cpu %f memory %f is invalid",
+			*resource.ReqCpu, *resource.ReqMem, *resource.Cpu, *resource.Mem)
+	}
+	c, err := cri.findContainer(id)
+	if err != nil {
+		return err
+	}
+
+	logs.Infof("update container %s resources cpu %f mem %f", id, *resource.Cpu, *resource.Mem)
+
+	ctx, cancel := context.WithTimeout(context.Background(), criRequestTimeout)
+	defer cancel()
+	_, err = cri.runtimeClient.UpdateContainerResources(ctx, &runtimeapi.UpdateContainerResourcesRequest{
+		ContainerId: c.Id,
+		Linux: &runtimeapi.LinuxContainerResources{
+			CpuShares:          int64(*resource.ReqCpu * 1024),
+			CpuPeriod:          DefaultDockerCPUPeriod,
+			CpuQuota:           int64(*resource.Cpu * DefaultDockerCPUPeriod),
+			MemoryLimitInBytes: int64(*resource.Mem * 1024 * 1024),
+		},
+	})
+	return err
+}
+
+// CommitImage create image from running container, cri does not support it
+func (cri *CRIContainer) CommitImage(id, image string) error {
+	return fmt.Errorf("commit image is not supported by cri runtime %s", cri.endpoint)
+}
@@ -0,0 +1,467 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package container
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	schedTypes "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+
+	"google.golang.org/grpc"
+	runtimeapi "k8s.io/kubernetes/pkg/kubelet/apis/cri/runtime/v1alpha2"
+)
+
+//fakeCRIServer in memory cri runtime & image service for testing,
+//unimplemented methods of embedded interfaces panic when called
+type fakeCRIServer struct {
+	runtimeapi.RuntimeServiceServer
+	runtimeapi.ImageServiceServer
+
+	lock       sync.Mutex
+	index      int
+	images     map[string]bool
+	pulled     []string
+	sandboxes  map[string]*runtimeapi.PodSandboxConfig
+	containers map[string]*fakeCRIContainer
+	execs      [][]string
+}
+
+type fakeCRIContainer struct {
+	sandboxID string
+	config    *runtimeapi.ContainerConfig
+	state     runtimeapi.ContainerState
+	startedAt int64
+	resources *runtimeapi.LinuxContainerResources
+}
+
+func newFakeCRIServer(images ...string) *fakeCRIServer {
+	f := &fakeCRIServer{
+		images:     make(map[string]bool),
+		sandboxes:  make(map[string]*runtimeapi.PodSandboxConfig),
+		containers: make(map[string]*fakeCRIContainer),
+	}
+	for _, image := range images {
+		f.images[image] = true
+	}
+	return f
+}
+
+func (f *fakeCRIServer) nextID(prefix string) string {
+	f.index++
+	return fmt.Sprintf("%s%08d", prefix, f.index)
+}
+
+func (f *fakeCRIServer) Version(ctx context.Context, req *runtimeapi.VersionRequest) (*runtimeapi.VersionResponse, error) {
+	return &runtimeapi.VersionResponse{Version: "0.1.0", RuntimeName: "fake", RuntimeVersion: "0.0.1", RuntimeApiVersion: "v1alpha2"}, nil
+}
+
+func (f *fakeCRIServer) RunPodSandbox(ctx context.Context, req *runtimeapi.RunPodSandboxRequest) (*runtimeapi.RunPodSandboxResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	id := f.nextID("sandbox")
+	f.sandboxes[id] = req.Config
+	return &runtimeapi.RunPodSandboxResponse{PodSandboxId: id}, nil
+}
+
+func (f *fakeCRIServer) StopPodSandbox(ctx context.Context, req *runtimeapi.StopPodSandboxRequest) (*runtimeapi.StopPodSandboxResponse, error) {
+	return &runtimeapi.StopPodSandboxResponse{}, nil
+}
+
+func (f *fakeCRIServer) RemovePodSandbox(ctx context.Context, req *runtimeapi.RemovePodSandboxRequest) (*runtimeapi.RemovePodSandboxResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.sandboxes, req.PodSandboxId)
+	return &runtimeapi.RemovePodSandboxResponse{}, nil
+}
+
+func (f *fakeCRIServer) PodSandboxStatus(ctx context.Context, req *runtimeapi.PodSandboxStatusRequest) (*runtimeapi.PodSandboxStatusResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	config, ok := f.sandboxes[req.PodSandboxId]
+	if !ok {
+		return nil, fmt.Errorf("sandbox %s not found", req.PodSandboxId)
+	}
+	return &runtimeapi.PodSandboxStatusResponse{
+		Status: &runtimeapi.PodSandboxStatus{
+			Id:       req.PodSandboxId,
+			Metadata: config.Metadata,
+			Network:  &runtimeapi.PodSandboxNetworkStatus{Ip: "10.0.0.2"},
+			Linux: &runtimeapi.LinuxPodSandboxStatus{
+				Namespaces: &runtimeapi.Namespace{Options: config.Linux.SecurityContext.NamespaceOptions},
+			},
+			Labels: config.Labels,
+		},
+	}, nil
+}
+
+func (f *fakeCRIServer) CreateContainer(ctx context.Context, req *runtimeapi.CreateContainerRequest) (*runtimeapi.CreateContainerResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if _, ok := f.sandboxes[req.PodSandboxId]; !ok {
+		return nil, fmt.Errorf("sandbox %s not found", req.PodSandboxId)
+	}
+	if !f.images[req.Config.Image.Image] {
+		return nil, fmt.Errorf("image %s not found", req.Config.Image.Image)
+	}
+	id := f.nextID("container")
+	f.containers[id] = &fakeCRIContainer{
+		sandboxID: req.PodSandboxId,
+		config:    req.Config,
+		state:     runtimeapi.ContainerState_CONTAINER_CREATED,
+		resources: req.Config.Linux.Resources,
+	}
+	return &runtimeapi.CreateContainerResponse{ContainerId: id}, nil
+}
+
+func (f *fakeCRIServer) StartContainer(ctx context.Context, req *runtimeapi.StartContainerRequest) (*runtimeapi.StartContainerResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	c, ok := f.containers[req.ContainerId]
+	if !ok {
+		return nil, fmt.Errorf("container %s not found", req.ContainerId)
+	}
+	c.state = runtimeapi.ContainerState_CONTAINER_RUNNING
+	c.startedAt = time.Now().UnixNano()
+	return &runtimeapi.StartContainerResponse{}, nil
+}
+
+func (f *fakeCRIServer) StopContainer(ctx context.Context, req *runtimeapi.StopContainerRequest) (*runtimeapi.StopContainerResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	c, ok := f.containers[req.ContainerId]
+	if !ok {
+		return nil, fmt.Errorf("container %s not found", req.ContainerId)
+	}
+	c.state = runtimeapi.ContainerState_CONTAINER_EXITED
+	return &runtimeapi.StopContainerResponse{}, nil
+}
+
+func (f *fakeCRIServer) RemoveContainer(ctx context.Context, req *runtimeapi.RemoveContainerRequest) (*runtimeapi.RemoveContainerResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.containers, req.ContainerId)
+	return &runtimeapi.RemoveContainerResponse{}, nil
+}
+
+func (f *fakeCRIServer) ListContainers(ctx context.Context, req *runtimeapi.ListContainersRequest) (*runtimeapi.ListContainersResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	resp := &runtimeapi.ListContainersResponse{}
+	for id, c := range f.containers {
+		if req.Filter != nil && req.Filter.PodSandboxId != "" && req.Filter.PodSandboxId != c.sandboxID {
+			continue
+		}
+		resp.Containers = append(resp.Containers, &runtimeapi.Container{
+			Id:           id,
+			PodSandboxId: c.sandboxID,
+			Metadata:     c.config.Metadata,
+			State:        c.state,
+		})
+	}
+	return resp, nil
+}
+
+func (f *fakeCRIServer) ContainerStatus(ctx context.Context, req *runtimeapi.ContainerStatusRequest) (*runtimeapi.ContainerStatusResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	c, ok := f.containers[req.ContainerId]
+	if !ok {
+		return nil, fmt.Errorf("container %s not found", req.ContainerId)
+	}
+	return &runtimeapi.ContainerStatusResponse{
+		Status: &runtimeapi.ContainerStatus{
+			Id:          req.ContainerId,
+			Metadata:    c.config.Metadata,
+			State:       c.state,
+			StartedAt:   c.startedAt,
+			Annotations: c.config.Annotations,
+		},
+		Info: map[string]string{"info": `{"pid": 4321, "sandboxID": "` + c.sandboxID + `"}`},
+	}, nil
+}
+
+func (f *fakeCRIServer) UpdateContainerResources(ctx context.Context, req *runtimeapi.UpdateContainerResourcesRequest) (*runtimeapi.UpdateContainerResourcesResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	c, ok := f.containers[req.ContainerId]
+	if !ok {
+		return nil, fmt.Errorf("container %s not found", req.ContainerId)
+	}
+	c.resources = req.Linux
+	return &runtimeapi.UpdateContainerResourcesResponse{}, nil
+}
+
+func (f *fakeCRIServer) ExecSync(ctx context.Context, req *runtimeapi.ExecSyncRequest) (*runtimeapi.ExecSyncResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if _, ok := f.containers[req.ContainerId]; !ok {
+		return nil, fmt.Errorf("container %s not found", req.ContainerId)
+	}
+	f.execs = append(f.execs, req.Cmd)
+	return &runtimeapi.ExecSyncResponse{Stdout: []byte(strings.Join(req.Cmd, " ")), ExitCode: 0}, nil
+}
+
+func (f *fakeCRIServer) ListImages(ctx context.Context, req *runtimeapi.ListImagesRequest) (*runtimeapi.ListImagesResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	resp := &runtimeapi.ListImagesResponse{}
+	for image := range f.images {
+		if req.Filter != nil && req.Filter.Image != nil && req.Filter.Image.Image != image {
+			continue
+		}
+		resp.Images = append(resp.Images, &runtimeapi.Image{Id: "sha256:" + image, RepoTags: []string{image}, Size_: 1024})
+	}
+	return resp, nil
+}
+
+func (f *fakeCRIServer) PullImage(ctx context.Context, req *runtimeapi.PullImageRequest) (*runtimeapi.PullImageResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.images[req.Image.Image] = true
+	f.pulled = append(f.pulled, req.Image.Image)
+	return &runtimeapi.PullImageResponse{ImageRef: "sha256:" + req.Image.Image}, nil
+}
+
+//startFakeCRI serve fake cri server on unix socket, return CRIContainer connected to it
+func startFakeCRI(t *testing.T, fake *fakeCRIServer) (*CRIContainer, func()) {
+	dir, err := ioutil.TempDir("", "fake-cri")
+	if err != nil {
+		t.Fatalf("create temp dir failed: %s", err.Error())
+	}
+	sock := filepath.Join(dir, "cri.sock")
+	listener, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatalf("listen %s failed: %s", sock, err.Error())
+	}
+	server := grpc.NewServer()
+	runtimeapi.RegisterRuntimeServiceServer(server, fake)
+	runtimeapi.RegisterImageServiceServer(server, fake)
+	go server.Serve(listener)
+
+	cri := NewCRIContainer("unix://"+sock, "user", "passwd", dir)
+	if cri == nil {
+		server.Stop()
+		os.RemoveAll(dir)
+		t.Fatalf("create cri container with fake server failed")
+	}
+	return cri.(*CRIContainer), func() {
+		cri.(*CRIContainer).conn.Close()
+		server.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestCRIContainerLifecycle(t *testing.T) {
+	fake := newFakeCRIServer()
+	cri, stop := startFakeCRI(t, fake)
+	defer stop()
+
+	task := &BcsContainerTask{
+		Image:       "nginx:latest",
+		HostName:    "nginx-host",
+		Command:     "nginx",
+		Args:        []string{"-g", "daemon off;"},
+		Env:         []BcsKV{{Key: "PORT", Value: "80"}},
+		Volums:      []BcsVolume{{HostPath: "/data", ContainerPath: "/data", ReadOnly: true}},
+		NetworkName: "bridge",
+		PortBindings: map[string]BcsPort{
+			"80/tcp": {ContainerPort: "80", HostPort: "31000", Protocol: "tcp"},
+		},
+		Resource:      &schedTypes.Resource{Cpus: 1, Mem: 256},
+		LimitResource: &schedTypes.Resource{Cpus: 2, Mem: 512},
+	}
+	info, err := cri.CreateContainer("nginx-0", task)
+	if err != nil {
+		t.Fatalf("create container failed: %s", err.Error())
+	}
+	if len(fake.pulled) != 1 || fake.pulled[0] != "nginx:latest" {
+		t.Errorf("image should be pulled when missing, pulled %v", fake.pulled)
+	}
+	c := fake.containers[info.ID]
+	if c == nil || info.Name != "nginx-0" {
+		t.Fatalf("container info %+v not created in fake server", info)
+	}
+	if strings.Join(c.config.Args, " ") != "nginx -g daemon off;" || len(c.config.Command) != 0 {
+		t.Errorf("command & args should be container args, got command %v args %v", c.config.Command, c.config.Args)
+	}
+	if c.resources.CpuShares != 1024 || c.resources.CpuQuota != 2*DefaultDockerCPUPeriod ||
+		c.resources.MemoryLimitInBytes != 512*1024*1024 {
+		t.Errorf("container resources %+v is not expected", c.resources)
+	}
+	sandbox := fake.sandboxes[c.sandboxID]
+	if len(sandbox.PortMappings) != 1 || sandbox.PortMappings[0].HostPort != 31000 ||
+		sandbox.Linux.SecurityContext.NamespaceOptions.Network != runtimeapi.NamespaceMode_POD {
+		t.Errorf("sandbox config %+v is not expected", sandbox)
+	}
+
+	//start by name like pods do
+	if err := cri.StartContainer("nginx-0"); err != nil {
+		t.Fatalf("start container failed: %s", err.Error())
+	}
+	inspect, err := cri.InspectContainer(info.ID)
+	if err != nil {
+		t.Fatalf("inspect container failed: %s", err.Error())
+	}
+	if inspect.Status != ContainerStatus_RUNNING || inspect.Pid != 4321 || inspect.IPAddress != "10.0.0.2" ||
+		inspect.NetworkMode != "bridge" || inspect.Hostname != "nginx-host" || inspect.StartAt.IsZero() {
+		t.Errorf("inspect info %+v is not expected", inspect)
+	}
+
+	//sidecar joins sandbox of nginx
+	sidecar := &BcsContainerTask{Image: "nginx:latest", NetworkName: "container:" + info.ID}
+	sidecarInfo, err := cri.CreateContainer("sidecar-0", sidecar)
+	if err != nil {
+		t.Fatalf("create sidecar failed: %s", err.Error())
+	}
+	if fake.containers[sidecarInfo.ID].sandboxID != c.sandboxID || len(fake.sandboxes) != 1 {
+		t.Errorf("sidecar should join sandbox %s", c.sandboxID)
+	}
+
+	resource := &schedTypes.TaskResources{}
+	cpu, mem := 4.0, 1024.0
+	resource.Cpu, resource.Mem, resource.ReqCpu, resource.ReqMem = &cpu, &mem, &cpu, &mem
+	if err := cri.UpdateResources(info.ID, resource); err != nil {
+		t.Fatalf("update resources failed: %s", err.Error())
+	}
+	if c.resources.CpuQuota != 4*DefaultDockerCPUPeriod || c.resources.MemoryLimitInBytes != 1024*1024*1024 {
+		t.Errorf("updated resources %+v is not expected", c.resources)
+	}
+
+	if err := cri.StopContainer("nginx-0", 1); err != nil {
+		t.Fatalf("stop container failed: %s", err.Error())
+	}
+	if err := cri.KillContainer("sidecar-0", 15); err == nil {
+		t.Errorf("kill container with SIGTERM should not be supported")
+	}
+	if err := cri.KillContainer("sidecar-0", 9); err != nil {
+		t.Fatalf("kill container failed: %s", err.Error())
+	}
+	if err := cri.RemoveContainer("nginx-0", true); err != nil {
+		t.Fatalf("remove container failed: %s", err.Error())
+	}
+	if len(fake.sandboxes) != 1 {
+		t.Errorf("sandbox should be kept when sidecar is still in it")
+	}
+	if err := cri.RemoveContainer("sidecar-0", true); err != nil {
+		t.Fatalf("remove sidecar failed: %s", err.Error())
+	}
+	if len(fake.sandboxes) != 0 {
+		t.Errorf("sandbox should be removed with the last container")
+	}
+	if _, err := cri.InspectContainer("nginx-0"); err == nil {
+		t.Errorf("inspect removed container should fail")
+	}
+}
+
+func TestCRIContainerExec(t *testing.T) {
+	fake := newFakeCRIServer("busybox:latest")
+	cri, stop := startFakeCRI(t, fake)
+	defer stop()
+
+	task := &BcsContainerTask{Image: "busybox:latest", NetworkName: "host"}
+	info, err := cri.CreateContainer("busybox-0", task)
+	if err != nil {
+		t.Fatalf("create container failed: %s", err.Error())
+	}
+	if len(fake.pulled) != 0 {
+		t.Errorf("existing image should not be pulled")
+	}
+	if fake.sandboxes[fake.containers[info.ID].sandboxID].Linux.SecurityContext.NamespaceOptions.Network != runtimeapi.NamespaceMode_NODE {
+		t.Errorf("host network should use node network namespace")
+	}
+
+	resp, _ := cri.RunCommandV2(&schedTypes.RequestCommandTask{
+		ContainerId: info.ID,
+		Cmd:         []string{"ls", "/"},
+		Env:         []string{"A=B"},
+	})
+	if resp.CommInspect == nil || resp.CommInspect.Stdout != "env A=B ls /" {
+		t.Errorf("run command response %+v is not expected", resp)
+	}
+	resp, _ = cri.RunCommandV2(&schedTypes.RequestCommandTask{ContainerId: "not-exist", Cmd: []string{"ls"}})
+	if resp.CommInspect != nil || resp.Message == "" {
+		t.Errorf("run command in not existing container should fail, %+v", resp)
+	}
+
+	source := filepath.Join(cri.logDir, "app.conf")
+	if err := ioutil.WriteFile(source, []byte("key=value\n"), 0644); err != nil {
+		t.Fatalf("write source file failed: %s", err.Error())
+	}
+	if err := cri.UploadToContainer(info.ID, source, "/etc/app/app.conf"); err != nil {
+		t.Fatalf("upload file failed: %s", err.Error())
+	}
+	last := fake.execs[len(fake.execs)-1]
+	if len(last) != 3 || !strings.Contains(last[2], "a2V5PXZhbHVlCg==") || !strings.Contains(last[2], "/etc/app/app.conf") {
+		t.Errorf("upload command %v is not expected", last)
+	}
+	//destination is one word of sh command, whatever it contains
+	if err := cri.UploadToContainer(info.ID, source, "/etc/it's $(reboot)/app.conf"); err != nil {
+		t.Fatalf("upload file failed: %s", err.Error())
+	}
+	quoted := `'/etc/it'\''s $(reboot)/app.conf'`
+	for _, exec := range fake.execs[len(fake.execs)-2:] {
+		if !strings.HasSuffix(exec[2], " "+quoted) {
+			t.Errorf("upload command %v does not quote destination", exec)
+		}
+	}
+	if first := fake.execs[len(fake.execs)-2]; !strings.HasPrefix(first[2], `mkdir -p '/etc/it'\''s $(reboot)' && `) {
+		t.Errorf("upload command %v does not quote destination directory", first)
+	}
+
+	if err := cri.CommitImage(info.ID, "busybox:commit"); err == nil {
+		t.Errorf("commit image should not be supported")
+	}
+}
+
+func TestCRIContainerUnsupportedSettings(t *testing.T) {
+	fake := newFakeCRIServer("busybox:latest")
+	cri, stop := startFakeCRI(t, fake)
+	defer stop()
+
+	task := &BcsContainerTask{Image: "busybox:latest", NetworkName: "bridge", NetworkIPAddr: "10.0.0.8"}
+	if _, err := cri.CreateContainer("busybox-0", task); err == nil {
+		t.Fatalf("create container requesting ip address should fail")
+	}
+	if len(fake.sandboxes) != 0 || len(fake.containers) != 0 {
+		t.Errorf("nothing should be created when ip address is requested")
+	}
+
+	task = &BcsContainerTask{
+		Image:       "busybox:latest",
+		NetworkName: "bridge",
+		Hosts:       []string{"db:10.0.0.9"},
+		Ulimits:     []BcsKV{{Key: "nofile", Value: "65535"}},
+		ShmSize:     1024,
+	}
+	info, err := cri.CreateContainer("busybox-1", task)
+	if err != nil {
+		t.Fatalf("create container failed: %s", err.Error())
+	}
+	expected := "hosts db:10.0.0.9, ulimit nofile=65535, shm size 1024"
+	if !strings.Contains(info.Message, expected) {
+		t.Errorf("dropped settings should be in message, got %q", info.Message)
+	}
+	if dropped := fake.containers[info.ID].config.Annotations[criDroppedAnnotation]; dropped != expected {
+		t.Errorf("dropped settings should be in annotation, got %q", dropped)
+	}
+}
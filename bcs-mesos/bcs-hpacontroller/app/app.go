@@ -20,6 +20,8 @@ import (
 	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-hpacontroller/app/options"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-hpacontroller/hpacontroller/controller"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-hpacontroller/hpacontroller/metrics"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-hpacontroller/hpacontroller/metrics/prometheus"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-hpacontroller/hpacontroller/metrics/resources"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-hpacontroller/hpacontroller/reflector"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-hpacontroller/hpacontroller/scaler"
@@ -59,7 +61,14 @@ func Run(op *options.HpaControllerOption) error {
 	resoucesCollector := resources.NewResourceMetrics(op.Conf, store)
 	blog.Infof("init cluster resouces metrics collector success")
 
-	hpaController := controller.NewAutoscaler(op.Conf, store, resoucesCollector, nil, scaleController)
+	//init taskgroup and external metrics from prometheus
+	var externalCollector metrics.MetricsController
+	if op.Conf.PrometheusAddr != "" {
+		externalCollector = prometheus.NewPrometheusMetrics(op.Conf, store)
+		blog.Infof("init prometheus %s metrics collector success", op.Conf.PrometheusAddr)
+	}
+
+	hpaController := controller.NewAutoscaler(op.Conf, store, resoucesCollector, externalCollector, scaleController)
 	hpaController.Start()
 	blog.Infof("hpa controller start work...")
 
@@ -72,6 +81,8 @@ func setConfig(op *options.HpaControllerOption) {
 	op.Conf.CadvisorPort = op.CadvisorPort
 	op.Conf.BcsZkAddr = op.BCSZk
 	op.Conf.ClusterID = op.ClusterID
+	op.Conf.PrometheusAddr = op.PrometheusAddr
+	op.Conf.PrometheusTaskgroupLabel = op.PrometheusTaskgroupLabel
 
 	//client cert directoty
 	if op.CertConfig.ClientCertFile != "" && op.CertConfig.CAFile != "" &&
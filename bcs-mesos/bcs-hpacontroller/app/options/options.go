@@ -34,6 +34,9 @@ type HpaControllerOption struct {
 	CadvisorPort  int    `json:"cadvisor_port" value:"" usage:"container cadvisor port"`
 	ClusterID     string `json:"clusterid" value:"" usage:"bcs mesos cluster id"`
 
+	PrometheusAddr           string `json:"prometheus_addr" value:"" usage:"prometheus compatible query api address for Taskgroup and External metrics"`
+	PrometheusTaskgroupLabel string `json:"prometheus_taskgroup_label" value:"taskgroup" usage:"label of prometheus metrics which holds the taskgroup id"`
+
 	Conf *config.Config
 }
 
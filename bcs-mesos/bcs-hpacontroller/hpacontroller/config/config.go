@@ -44,6 +44,13 @@ type Config struct {
 	//container resources cadvisor port
 	CadvisorPort int
 
+	//prometheus compatible query api address, to collect Taskgroup and External metrics
+	//example: http://127.0.0.1:9090
+	PrometheusAddr string
+
+	//label of prometheus metrics which holds the taskgroup id, default taskgroup
+	PrometheusTaskgroupLabel string
+
 	//client https certs
 	ClientCert *CertConfig `json:"-"`
 
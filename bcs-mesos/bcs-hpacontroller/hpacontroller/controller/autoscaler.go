@@ -143,6 +143,9 @@ func (auto *Autoscaler) tickerSyncAutoscalerQueue() {
 			//start collect autoscaler ref metrics
 			blog.Infof("start collect scaler %s metrics", scaler.GetUuid())
 			auto.resourceMetrics.StartScalerMetrics(scaler)
+			if auto.externalMetrics != nil {
+				auto.externalMetrics.StartScalerMetrics(scaler)
+			}
 
 			//add scaler into workqueue
 			blog.Infof("add scaler %s into workqueue", scaler.GetUuid())
@@ -153,6 +156,9 @@ func (auto *Autoscaler) tickerSyncAutoscalerQueue() {
 		for k, scaler := range currentQueue {
 			blog.Infof("delete scaler %s", scaler.GetUuid())
 			auto.resourceMetrics.StopScalerMetrics(scaler)
+			if auto.externalMetrics != nil {
+				auto.externalMetrics.StopScalerMetrics(scaler)
+			}
 			delete(auto.workQueue, k)
 		}
 		auto.Unlock()
@@ -249,16 +255,16 @@ func (auto *Autoscaler) tickerHandlerAutoscaler() {
 				blog.Errorf("update scaler %s current metrics error %s", uuid, err.Error())
 				continue
 			}
-			err = auto.store.UpdateAutoscaler(scaler)
+
+			//compute scaler desired Instance, with schedules and scaling behavior applied
+			desiredInstance, operator, err := auto.decideScalerInstance(scaler, time.Now())
 			if err != nil {
-				blog.Errorf("store scaler %s error %s", scaler.GetUuid(), err.Error())
+				blog.Errorf("compute scaler %s desired instance error %s", scaler.GetUuid(), err.Error())
 				continue
 			}
-
-			//compute scaler desired Instance
-			desiredInstance, operator, err := auto.computeScalerDesiredInstance(scaler)
+			err = auto.store.UpdateAutoscaler(scaler)
 			if err != nil {
-				blog.Errorf("compute scaler %s desired instance error %s", scaler.GetUuid(), err.Error())
+				blog.Errorf("store scaler %s error %s", scaler.GetUuid(), err.Error())
 				continue
 			}
 
@@ -267,25 +273,6 @@ func (auto *Autoscaler) tickerHandlerAutoscaler() {
 				continue
 			}
 
-			// The period for which autoscaler will look backwards and
-			// not scale up below any recommendation it made during that period
-			if operator == commtypes.AutoscalerOperatorScaleUp &&
-				scaler.Status.LastScaleOPeratorType == commtypes.AutoscalerOperatorScaleUp &&
-				(time.Now().Unix()-scaler.Status.LastScaleTime.Unix()) < auto.config.UpscaleStabilization {
-				blog.Infof("scaler %s at time %s last operator %s, then continue", scaler.GetUuid(),
-					scaler.Status.LastScaleTime.Format("2006-01-02 15:04:05"), scaler.Status.LastScaleOPeratorType)
-				continue
-			}
-
-			// The period for which autoscaler will look backwards and
-			// not scale down below any recommendation it made during that period
-			if operator == commtypes.AutoscalerOperatorScaleDown &&
-				(time.Now().Unix()-scaler.Status.LastScaleTime.Unix()) < auto.config.DownscaleStabilization {
-				blog.Infof("scaler %s at time %s last operator %s, then continue", scaler.GetUuid(),
-					scaler.Status.LastScaleTime.Format("2006-01-02 15:04:05"), scaler.Status.LastScaleOPeratorType)
-				continue
-			}
-
 			blog.Infof("scaler %s %s target ref(%s:%s) to instance %d", scaler.GetUuid(),
 				operator, targetRef.Namespace, targetRef.Name, desiredInstance)
 			switch operator {
@@ -303,6 +290,7 @@ func (auto *Autoscaler) tickerHandlerAutoscaler() {
 
 			blog.Infof("autoscale scaler %s operator %s desired instance %d success", scaler.GetUuid(), operator, desiredInstance)
 			//update scaler status info
+			recordScaleEvent(scaler, scaler.Status.CurrentInstance, desiredInstance, time.Now())
 			scaler.Status.LastScaleOPeratorType = operator
 			scaler.Status.DesiredInstance = desiredInstance
 			scaler.Status.LastScaleTime = time.Now()
@@ -347,5 +335,40 @@ func (auto *Autoscaler) checkScalerIsValid(scaler *commtypes.BcsAutoscaler) erro
 		}
 	}
 
+	//check schedules
+	for _, schedule := range scaler.Spec.Schedules {
+		if _, err := scheduleActive(schedule, time.Now()); err != nil {
+			return fmt.Errorf("scaler %s schedule %s is invalid, %s", scaler.GetUuid(), schedule.Name, err.Error())
+		}
+	}
+
+	//check scaling behavior
+	if scaler.Spec.Behavior != nil {
+		for _, rules := range []*commtypes.AutoscalerScalingRules{
+			scaler.Spec.Behavior.ScaleUp, scaler.Spec.Behavior.ScaleDown} {
+			if rules == nil {
+				continue
+			}
+			if rules.SelectPolicy == "" {
+				rules.SelectPolicy = commtypes.AutoscalerPolicySelectMax
+			}
+			if rules.SelectPolicy != commtypes.AutoscalerPolicySelectMax &&
+				rules.SelectPolicy != commtypes.AutoscalerPolicySelectMin &&
+				rules.SelectPolicy != commtypes.AutoscalerPolicySelectDisabled {
+				return fmt.Errorf("scaler %s behavior selectPolicy %s is invalid", scaler.GetUuid(), rules.SelectPolicy)
+			}
+			for _, policy := range rules.Policies {
+				if policy.Type != commtypes.AutoscalerScalingPolicyInstances &&
+					policy.Type != commtypes.AutoscalerScalingPolicyPercent {
+					return fmt.Errorf("scaler %s behavior policy type %s is invalid", scaler.GetUuid(), policy.Type)
+				}
+				if policy.Value == 0 || policy.PeriodSeconds <= 0 {
+					return fmt.Errorf("scaler %s behavior policy %s value and periodSeconds must be positive",
+						scaler.GetUuid(), policy.Type)
+				}
+			}
+		}
+	}
+
 	return nil
 }
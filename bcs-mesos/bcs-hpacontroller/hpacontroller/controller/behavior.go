@@ -0,0 +1,331 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package controller
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/common/util"
+)
+
+//decide scaler desired instance: the instance recommended by metrics within the min and max instance of
+//active schedule, then stabilized by stabilization windows and limited by scaling policies.
+//the decision and its reasons are recorded in scaler status
+func (auto *Autoscaler) decideScalerInstance(scaler *commtypes.BcsAutoscaler, now time.Time) (
+	uint, commtypes.AutoscalerOperatorType, error) {
+
+	current := scaler.Status.CurrentInstance
+	minInstance, maxInstance, schedule := scheduledInstanceRange(scaler, now)
+	if schedule != scaler.Status.ActiveSchedule {
+		blog.Infof("scaler %s active schedule changes from %q to %q, instance range [%d, %d]",
+			scaler.GetUuid(), scaler.Status.ActiveSchedule, schedule, minInstance, maxInstance)
+	}
+	scaler.Status.ActiveSchedule = schedule
+
+	decision := &commtypes.AutoscalerDecision{
+		Timestamp:       now,
+		Operator:        commtypes.AutoscalerOperatorNone,
+		CurrentInstance: current,
+		Reasons:         make([]string, 0),
+	}
+	if schedule != "" {
+		decision.Reasons = append(decision.Reasons,
+			fmt.Sprintf("schedule %s is active, instance range [%d, %d]", schedule, minInstance, maxInstance))
+	}
+
+	recommend, operator, reasons, err := auto.computeScalerDesiredInstance(scaler, minInstance, maxInstance)
+	if err != nil {
+		return current, commtypes.AutoscalerOperatorNone, err
+	}
+	decision.RecommendInstance = recommend
+	decision.Reasons = append(decision.Reasons, reasons...)
+	desired := recommend
+
+	//instance out of range is corrected at once, stabilization windows and policies are not applied
+	if current >= minInstance && current <= maxInstance {
+		behavior := scaler.Spec.Behavior
+		var reason string
+		if behavior != nil {
+			desired, reason = stabilizeRecommendation(scaler.Status, behavior, current, recommend, now)
+			decision.Reasons = appendReason(decision.Reasons, reason)
+		}
+
+		if desired > current {
+			if behavior == nil || behavior.ScaleUp == nil {
+				desired, reason = auto.upscaleStabilization(scaler, current, desired, now)
+			} else {
+				desired, reason = limitScaling(scaler.Status, behavior.ScaleUp, current, desired, now)
+			}
+			decision.Reasons = appendReason(decision.Reasons, reason)
+		} else if desired < current {
+			if behavior == nil || behavior.ScaleDown == nil {
+				desired, reason = auto.downscaleStabilization(scaler, current, desired, now)
+			} else {
+				desired, reason = limitScaling(scaler.Status, behavior.ScaleDown, current, desired, now)
+			}
+			decision.Reasons = appendReason(decision.Reasons, reason)
+		}
+	}
+
+	if desired > current {
+		operator = commtypes.AutoscalerOperatorScaleUp
+	} else if desired < current {
+		operator = commtypes.AutoscalerOperatorScaleDown
+	} else {
+		operator = commtypes.AutoscalerOperatorNone
+	}
+	decision.Operator = operator
+	decision.DesiredInstance = desired
+	scaler.Status.LastDecision = decision
+	blog.Infof("scaler %s decision %s %d->%d, reasons: %v", scaler.GetUuid(), operator, current, desired, decision.Reasons)
+
+	return desired, operator, nil
+}
+
+// The period for which autoscaler will look backwards and
+// not scale up below any recommendation it made during that period
+func (auto *Autoscaler) upscaleStabilization(scaler *commtypes.BcsAutoscaler, current, desired uint,
+	now time.Time) (uint, string) {
+
+	if scaler.Status.LastScaleOPeratorType == commtypes.AutoscalerOperatorScaleUp &&
+		(now.Unix()-scaler.Status.LastScaleTime.Unix()) < auto.config.UpscaleStabilization {
+		return current, fmt.Sprintf("last scale up at %s is within upscale stabilization %ds",
+			scaler.Status.LastScaleTime.Format("2006-01-02 15:04:05"), auto.config.UpscaleStabilization)
+	}
+	return desired, ""
+}
+
+// The period for which autoscaler will look backwards and
+// not scale down below any recommendation it made during that period
+func (auto *Autoscaler) downscaleStabilization(scaler *commtypes.BcsAutoscaler, current, desired uint,
+	now time.Time) (uint, string) {
+
+	if (now.Unix() - scaler.Status.LastScaleTime.Unix()) < auto.config.DownscaleStabilization {
+		return current, fmt.Sprintf("last scale at %s is within downscale stabilization %ds",
+			scaler.Status.LastScaleTime.Format("2006-01-02 15:04:05"), auto.config.DownscaleStabilization)
+	}
+	return desired, ""
+}
+
+//scheduledInstanceRange return min and max instance of scaler, overridden by the first active schedule
+func scheduledInstanceRange(scaler *commtypes.BcsAutoscaler, now time.Time) (uint, uint, string) {
+	minInstance := scaler.Spec.MinInstance
+	maxInstance := scaler.Spec.MaxInstance
+	for _, schedule := range scaler.Spec.Schedules {
+		active, err := scheduleActive(schedule, now)
+		if err != nil {
+			blog.Errorf("scaler %s schedule %s is invalid, %s", scaler.GetUuid(), schedule.Name, err.Error())
+			continue
+		}
+		if !active {
+			continue
+		}
+
+		if schedule.MinInstance > 0 {
+			minInstance = schedule.MinInstance
+		}
+		if schedule.MaxInstance > 0 {
+			maxInstance = schedule.MaxInstance
+		}
+		if maxInstance < minInstance {
+			maxInstance = minInstance
+		}
+		return minInstance, maxInstance, schedule.Name
+	}
+	return minInstance, maxInstance, ""
+}
+
+//scheduleActive check whether schedule fired within its duration before now
+func scheduleActive(schedule *commtypes.AutoscalerSchedule, now time.Time) (bool, error) {
+	cron, err := util.ParseCronSchedule(schedule.Schedule)
+	if err != nil {
+		return false, err
+	}
+	if schedule.TimeZone != "" {
+		loc, err := time.LoadLocation(schedule.TimeZone)
+		if err != nil {
+			return false, err
+		}
+		now = now.In(loc)
+	}
+	if schedule.Duration <= 0 {
+		return false, fmt.Errorf("duration %d must be positive", schedule.Duration)
+	}
+
+	fired := cron.Next(now.Add(-time.Duration(schedule.Duration) * time.Second))
+	return !fired.IsZero() && !fired.After(now), nil
+}
+
+//stabilizeRecommendation record recommend in scaler status, and return the safest recommendation in
+//stabilization windows: the lowest in scale up window, the highest in scale down window
+func stabilizeRecommendation(status *commtypes.BcsAutoscalerStatus, behavior *commtypes.AutoscalerBehavior,
+	current, recommend uint, now time.Time) (uint, string) {
+
+	var upWindow, downWindow int64
+	if behavior.ScaleUp != nil {
+		upWindow = behavior.ScaleUp.StabilizationWindowSeconds
+	}
+	if behavior.ScaleDown != nil {
+		downWindow = behavior.ScaleDown.StabilizationWindowSeconds
+	}
+	window := upWindow
+	if downWindow > window {
+		window = downWindow
+	}
+
+	recommendations := make([]*commtypes.AutoscalerRecommendation, 0, len(status.Recommendations)+1)
+	for _, r := range status.Recommendations {
+		if now.Unix()-r.Timestamp.Unix() < window {
+			recommendations = append(recommendations, r)
+		}
+	}
+	recommendations = append(recommendations, &commtypes.AutoscalerRecommendation{Timestamp: now, Instance: recommend})
+	status.Recommendations = recommendations
+
+	upRecommend := recommend
+	downRecommend := recommend
+	for _, r := range recommendations {
+		age := now.Unix() - r.Timestamp.Unix()
+		if age < upWindow && r.Instance < upRecommend {
+			upRecommend = r.Instance
+		}
+		if age < downWindow && r.Instance > downRecommend {
+			downRecommend = r.Instance
+		}
+	}
+
+	stabilized := current
+	if upRecommend > current {
+		stabilized = upRecommend
+	} else if downRecommend < current {
+		stabilized = downRecommend
+	}
+	if stabilized == recommend {
+		return stabilized, ""
+	}
+	return stabilized, fmt.Sprintf("recommendation %d is stabilized to %d by stabilization window", recommend, stabilized)
+}
+
+//limitScaling limit the instance change by scaling policies, scale events in policy periods are counted
+func limitScaling(status *commtypes.BcsAutoscalerStatus, rules *commtypes.AutoscalerScalingRules,
+	current, desired uint, now time.Time) (uint, string) {
+
+	scaleUp := desired > current
+	direction := "down"
+	if scaleUp {
+		direction = "up"
+	}
+	if rules.SelectPolicy == commtypes.AutoscalerPolicySelectDisabled {
+		return current, fmt.Sprintf("scale %s is disabled", direction)
+	}
+	if len(rules.Policies) == 0 {
+		return desired, ""
+	}
+
+	var limit uint
+	var limitPolicy *commtypes.AutoscalerScalingPolicy
+	for _, policy := range rules.Policies {
+		//instances when period starts, changes in this direction within period are reverted
+		var changed uint
+		for _, event := range status.ScaleEvents {
+			if now.Unix()-event.Timestamp.Unix() >= policy.PeriodSeconds {
+				continue
+			}
+			if scaleUp && event.To > event.From {
+				changed += event.To - event.From
+			}
+			if !scaleUp && event.To < event.From {
+				changed += event.From - event.To
+			}
+		}
+
+		var policyLimit uint
+		if scaleUp {
+			periodStart := uint(0)
+			if current > changed {
+				periodStart = current - changed
+			}
+			if policy.Type == commtypes.AutoscalerScalingPolicyPercent {
+				policyLimit = uint(math.Ceil(float64(periodStart) * (1 + float64(policy.Value)/100)))
+			} else {
+				policyLimit = periodStart + policy.Value
+			}
+		} else {
+			periodStart := current + changed
+			if policy.Type == commtypes.AutoscalerScalingPolicyPercent {
+				if policy.Value < 100 {
+					policyLimit = uint(math.Floor(float64(periodStart) * (1 - float64(policy.Value)/100)))
+				}
+			} else if periodStart > policy.Value {
+				policyLimit = periodStart - policy.Value
+			}
+		}
+
+		//policy allows bigger change: higher limit for scale up, lower limit for scale down
+		bigger := (scaleUp && policyLimit > limit) || (!scaleUp && policyLimit < limit)
+		if limitPolicy == nil || (rules.SelectPolicy == commtypes.AutoscalerPolicySelectMin) != bigger {
+			limit = policyLimit
+			limitPolicy = policy
+		}
+	}
+
+	if (scaleUp && desired <= limit) || (!scaleUp && desired >= limit) {
+		return desired, ""
+	}
+	if (scaleUp && limit < current) || (!scaleUp && limit > current) {
+		limit = current
+	}
+	return limit, fmt.Sprintf("scale %s from %d to %d is limited to %d by policy %s %d per %ds",
+		direction, current, desired, limit, limitPolicy.Type, limitPolicy.Value, limitPolicy.PeriodSeconds)
+}
+
+//recordScaleEvent record scaling in scaler status, events older than the longest policy period are dropped
+func recordScaleEvent(scaler *commtypes.BcsAutoscaler, from, to uint, now time.Time) {
+	var period int64
+	if scaler.Spec.Behavior != nil {
+		for _, rules := range []*commtypes.AutoscalerScalingRules{
+			scaler.Spec.Behavior.ScaleUp, scaler.Spec.Behavior.ScaleDown} {
+			if rules == nil {
+				continue
+			}
+			for _, policy := range rules.Policies {
+				if policy.PeriodSeconds > period {
+					period = policy.PeriodSeconds
+				}
+			}
+		}
+	}
+
+	events := make([]*commtypes.AutoscalerScaleEvent, 0, len(scaler.Status.ScaleEvents)+1)
+	for _, event := range scaler.Status.ScaleEvents {
+		if now.Unix()-event.Timestamp.Unix() < period {
+			events = append(events, event)
+		}
+	}
+	if period > 0 {
+		events = append(events, &commtypes.AutoscalerScaleEvent{Timestamp: now, From: from, To: to})
+	}
+	scaler.Status.ScaleEvents = events
+}
+
+func appendReason(reasons []string, reason string) []string {
+	if reason == "" {
+		return reasons
+	}
+	return append(reasons, reason)
+}
@@ -0,0 +1,620 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-hpacontroller/hpacontroller/config"
+)
+
+// testNow friday 2021-06-04 12:00:00 UTC
+var testNow = time.Date(2021, 6, 4, 12, 0, 0, 0, time.UTC)
+
+func secondsAgo(now time.Time, seconds int64) time.Time {
+	return now.Add(-time.Duration(seconds) * time.Second)
+}
+
+func instancesPolicy(value uint, period int64) *commtypes.AutoscalerScalingPolicy {
+	return &commtypes.AutoscalerScalingPolicy{
+		Type:          commtypes.AutoscalerScalingPolicyInstances,
+		Value:         value,
+		PeriodSeconds: period,
+	}
+}
+
+func percentPolicy(value uint, period int64) *commtypes.AutoscalerScalingPolicy {
+	return &commtypes.AutoscalerScalingPolicy{
+		Type:          commtypes.AutoscalerScalingPolicyPercent,
+		Value:         value,
+		PeriodSeconds: period,
+	}
+}
+
+func TestScheduleActive(t *testing.T) {
+	// friday 22:00 for 4 hours, crossing midnight into saturday
+	weekend := &commtypes.AutoscalerSchedule{Name: "weekend", Schedule: "0 22 * * 5", Duration: 4 * 3600}
+	tests := []struct {
+		name     string
+		schedule *commtypes.AutoscalerSchedule
+		now      time.Time
+		active   bool
+		wantErr  bool
+	}{
+		{name: "before fired", schedule: weekend, now: time.Date(2021, 6, 4, 21, 59, 0, 0, time.UTC)},
+		{name: "fired", schedule: weekend, now: time.Date(2021, 6, 4, 22, 0, 0, 0, time.UTC), active: true},
+		{name: "before midnight", schedule: weekend, now: time.Date(2021, 6, 4, 23, 30, 0, 0, time.UTC), active: true},
+		{name: "after midnight", schedule: weekend, now: time.Date(2021, 6, 5, 1, 59, 0, 0, time.UTC), active: true},
+		{name: "duration passed", schedule: weekend, now: time.Date(2021, 6, 5, 2, 1, 0, 0, time.UTC)},
+		{name: "not the day of week", schedule: weekend, now: time.Date(2021, 6, 3, 23, 0, 0, 0, time.UTC)},
+		{
+			name: "time zone",
+			schedule: &commtypes.AutoscalerSchedule{
+				Name: "weekend", Schedule: "0 22 * * 5", TimeZone: "Asia/Shanghai", Duration: 4 * 3600},
+			// saturday 00:30 in Asia/Shanghai
+			now:    time.Date(2021, 6, 4, 16, 30, 0, 0, time.UTC),
+			active: true,
+		},
+		{
+			name:     "invalid cron",
+			schedule: &commtypes.AutoscalerSchedule{Name: "invalid", Schedule: "0 25 * * *", Duration: 3600},
+			now:      testNow,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid time zone",
+			schedule: &commtypes.AutoscalerSchedule{Name: "invalid", Schedule: "0 22 * * *", TimeZone: "Mars/Base", Duration: 3600},
+			now:      testNow,
+			wantErr:  true,
+		},
+		{
+			name:     "zero duration",
+			schedule: &commtypes.AutoscalerSchedule{Name: "invalid", Schedule: "0 22 * * *"},
+			now:      testNow,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		active, err := scheduleActive(test.schedule, test.now)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: expect error %v, got %v", test.name, test.wantErr, err)
+			continue
+		}
+		if active != test.active {
+			t.Errorf("%s: expect active %v, got %v", test.name, test.active, active)
+		}
+	}
+}
+
+func TestStabilizeRecommendation(t *testing.T) {
+	upWindow := &commtypes.AutoscalerBehavior{
+		ScaleUp: &commtypes.AutoscalerScalingRules{StabilizationWindowSeconds: 60},
+	}
+	downWindow := &commtypes.AutoscalerBehavior{
+		ScaleDown: &commtypes.AutoscalerScalingRules{StabilizationWindowSeconds: 300},
+	}
+	tests := []struct {
+		name            string
+		behavior        *commtypes.AutoscalerBehavior
+		history         []*commtypes.AutoscalerRecommendation
+		current         uint
+		recommend       uint
+		expect          uint
+		recommendations int
+	}{
+		{
+			name:            "no window",
+			behavior:        &commtypes.AutoscalerBehavior{},
+			history:         []*commtypes.AutoscalerRecommendation{{Timestamp: secondsAgo(testNow, 10), Instance: 4}},
+			current:         3,
+			recommend:       6,
+			expect:          6,
+			recommendations: 1,
+		},
+		{
+			name:            "lowest in scale up window",
+			behavior:        upWindow,
+			history:         []*commtypes.AutoscalerRecommendation{{Timestamp: secondsAgo(testNow, 30), Instance: 4}},
+			current:         3,
+			recommend:       6,
+			expect:          4,
+			recommendations: 2,
+		},
+		{
+			name:     "lower than current in scale up window",
+			behavior: upWindow,
+			history: []*commtypes.AutoscalerRecommendation{
+				{Timestamp: secondsAgo(testNow, 50), Instance: 2},
+				{Timestamp: secondsAgo(testNow, 30), Instance: 5},
+			},
+			current:         3,
+			recommend:       6,
+			expect:          3,
+			recommendations: 3,
+		},
+		{
+			name:            "out of scale up window",
+			behavior:        upWindow,
+			history:         []*commtypes.AutoscalerRecommendation{{Timestamp: secondsAgo(testNow, 90), Instance: 4}},
+			current:         3,
+			recommend:       6,
+			expect:          6,
+			recommendations: 1,
+		},
+		{
+			name:            "highest in scale down window",
+			behavior:        downWindow,
+			history:         []*commtypes.AutoscalerRecommendation{{Timestamp: secondsAgo(testNow, 100), Instance: 5}},
+			current:         6,
+			recommend:       3,
+			expect:          5,
+			recommendations: 2,
+		},
+		{
+			name:            "higher than current in scale down window",
+			behavior:        downWindow,
+			history:         []*commtypes.AutoscalerRecommendation{{Timestamp: secondsAgo(testNow, 299), Instance: 8}},
+			current:         6,
+			recommend:       3,
+			expect:          6,
+			recommendations: 2,
+		},
+		{
+			name:            "out of scale down window",
+			behavior:        downWindow,
+			history:         []*commtypes.AutoscalerRecommendation{{Timestamp: secondsAgo(testNow, 300), Instance: 8}},
+			current:         6,
+			recommend:       3,
+			expect:          3,
+			recommendations: 1,
+		},
+		{
+			name:            "scale up is not stabilized by scale down window",
+			behavior:        downWindow,
+			history:         []*commtypes.AutoscalerRecommendation{{Timestamp: secondsAgo(testNow, 100), Instance: 2}},
+			current:         3,
+			recommend:       6,
+			expect:          6,
+			recommendations: 2,
+		},
+	}
+	for _, test := range tests {
+		status := &commtypes.BcsAutoscalerStatus{Recommendations: test.history}
+		stabilized, reason := stabilizeRecommendation(status, test.behavior, test.current, test.recommend, testNow)
+		if stabilized != test.expect {
+			t.Errorf("%s: expect %d, got %d", test.name, test.expect, stabilized)
+		}
+		if (stabilized != test.recommend) != (reason != "") {
+			t.Errorf("%s: unexpected reason %q of %d stabilized to %d", test.name, reason, test.recommend, stabilized)
+		}
+		if len(status.Recommendations) != test.recommendations {
+			t.Errorf("%s: expect %d recommendations kept, got %d",
+				test.name, test.recommendations, len(status.Recommendations))
+		}
+		last := status.Recommendations[len(status.Recommendations)-1]
+		if last.Instance != test.recommend || !last.Timestamp.Equal(testNow) {
+			t.Errorf("%s: recommendation %d is not recorded", test.name, test.recommend)
+		}
+	}
+}
+
+func TestLimitScaling(t *testing.T) {
+	upEvent := &commtypes.AutoscalerScaleEvent{Timestamp: secondsAgo(testNow, 30), From: 10, To: 13}
+	oldUpEvent := &commtypes.AutoscalerScaleEvent{Timestamp: secondsAgo(testNow, 90), From: 10, To: 13}
+	downEvent := &commtypes.AutoscalerScaleEvent{Timestamp: secondsAgo(testNow, 30), From: 10, To: 8}
+	tests := []struct {
+		name    string
+		rules   *commtypes.AutoscalerScalingRules
+		events  []*commtypes.AutoscalerScaleEvent
+		current uint
+		desired uint
+		expect  uint
+	}{
+		{
+			name:    "disabled",
+			rules:   &commtypes.AutoscalerScalingRules{SelectPolicy: commtypes.AutoscalerPolicySelectDisabled},
+			current: 10,
+			desired: 20,
+			expect:  10,
+		},
+		{
+			name:    "no policy",
+			rules:   &commtypes.AutoscalerScalingRules{},
+			current: 10,
+			desired: 20,
+			expect:  20,
+		},
+		{
+			name: "instances policy scale up",
+			rules: &commtypes.AutoscalerScalingRules{
+				Policies: []*commtypes.AutoscalerScalingPolicy{instancesPolicy(4, 60)},
+			},
+			current: 10,
+			desired: 20,
+			expect:  14,
+		},
+		{
+			name: "within instances policy",
+			rules: &commtypes.AutoscalerScalingRules{
+				Policies: []*commtypes.AutoscalerScalingPolicy{instancesPolicy(4, 60)},
+			},
+			current: 10,
+			desired: 12,
+			expect:  12,
+		},
+		{
+			name: "percent policy scale up",
+			rules: &commtypes.AutoscalerScalingRules{
+				Policies: []*commtypes.AutoscalerScalingPolicy{percentPolicy(100, 60)},
+			},
+			current: 10,
+			desired: 30,
+			expect:  20,
+		},
+		{
+			name: "percent policy scale up rounds up",
+			rules: &commtypes.AutoscalerScalingRules{
+				Policies: []*commtypes.AutoscalerScalingPolicy{percentPolicy(10, 60)},
+			},
+			current: 3,
+			desired: 10,
+			expect:  4,
+		},
+		{
+			name: "select max scale up",
+			rules: &commtypes.AutoscalerScalingRules{
+				SelectPolicy: commtypes.AutoscalerPolicySelectMax,
+				Policies:     []*commtypes.AutoscalerScalingPolicy{instancesPolicy(4, 60), percentPolicy(100, 60)},
+			},
+			current: 10,
+			desired: 30,
+			expect:  20,
+		},
+		{
+			name: "select max by default",
+			rules: &commtypes.AutoscalerScalingRules{
+				Policies: []*commtypes.AutoscalerScalingPolicy{percentPolicy(100, 60), instancesPolicy(4, 60)},
+			},
+			current: 10,
+			desired: 30,
+			expect:  20,
+		},
+		{
+			name: "select min scale up",
+			rules: &commtypes.AutoscalerScalingRules{
+				SelectPolicy: commtypes.AutoscalerPolicySelectMin,
+				Policies:     []*commtypes.AutoscalerScalingPolicy{percentPolicy(100, 60), instancesPolicy(4, 60)},
+			},
+			current: 10,
+			desired: 30,
+			expect:  14,
+		},
+		{
+			name: "scale up in period counted",
+			rules: &commtypes.AutoscalerScalingRules{
+				Policies: []*commtypes.AutoscalerScalingPolicy{instancesPolicy(4, 60)},
+			},
+			events:  []*commtypes.AutoscalerScaleEvent{upEvent, downEvent},
+			current: 13,
+			desired: 20,
+			expect:  14,
+		},
+		{
+			name: "scale up used up in period",
+			rules: &commtypes.AutoscalerScalingRules{
+				Policies: []*commtypes.AutoscalerScalingPolicy{instancesPolicy(2, 60)},
+			},
+			events:  []*commtypes.AutoscalerScaleEvent{upEvent},
+			current: 13,
+			desired: 20,
+			expect:  13,
+		},
+		{
+			name: "scale up out of period not counted",
+			rules: &commtypes.AutoscalerScalingRules{
+				Policies: []*commtypes.AutoscalerScalingPolicy{instancesPolicy(4, 60)},
+			},
+			events:  []*commtypes.AutoscalerScaleEvent{oldUpEvent},
+			current: 13,
+			desired: 20,
+			expect:  17,
+		},
+		{
+			name: "instances policy scale down",
+			rules: &commtypes.AutoscalerScalingRules{
+				Policies: []*commtypes.AutoscalerScalingPolicy{instancesPolicy(2, 60)},
+			},
+			current: 10,
+			desired: 2,
+			expect:  8,
+		},
+		{
+			name: "percent policy scale down",
+			rules: &commtypes.AutoscalerScalingRules{
+				Policies: []*commtypes.AutoscalerScalingPolicy{percentPolicy(50, 60)},
+			},
+			current: 10,
+			desired: 2,
+			expect:  5,
+		},
+		{
+			name: "percent policy scale down all",
+			rules: &commtypes.AutoscalerScalingRules{
+				Policies: []*commtypes.AutoscalerScalingPolicy{percentPolicy(100, 60)},
+			},
+			current: 10,
+			desired: 0,
+			expect:  0,
+		},
+		{
+			name: "select max scale down",
+			rules: &commtypes.AutoscalerScalingRules{
+				SelectPolicy: commtypes.AutoscalerPolicySelectMax,
+				Policies:     []*commtypes.AutoscalerScalingPolicy{instancesPolicy(2, 60), percentPolicy(50, 60)},
+			},
+			current: 10,
+			desired: 2,
+			expect:  5,
+		},
+		{
+			name: "select min scale down",
+			rules: &commtypes.AutoscalerScalingRules{
+				SelectPolicy: commtypes.AutoscalerPolicySelectMin,
+				Policies:     []*commtypes.AutoscalerScalingPolicy{instancesPolicy(2, 60), percentPolicy(50, 60)},
+			},
+			current: 10,
+			desired: 2,
+			expect:  8,
+		},
+		{
+			name: "scale down in period counted",
+			rules: &commtypes.AutoscalerScalingRules{
+				Policies: []*commtypes.AutoscalerScalingPolicy{instancesPolicy(3, 60)},
+			},
+			events:  []*commtypes.AutoscalerScaleEvent{downEvent, upEvent},
+			current: 8,
+			desired: 2,
+			expect:  7,
+		},
+	}
+	for _, test := range tests {
+		status := &commtypes.BcsAutoscalerStatus{ScaleEvents: test.events}
+		limited, reason := limitScaling(status, test.rules, test.current, test.desired, testNow)
+		if limited != test.expect {
+			t.Errorf("%s: expect %d, got %d", test.name, test.expect, limited)
+		}
+		if (limited != test.desired) != (reason != "") {
+			t.Errorf("%s: unexpected reason %q of %d limited to %d", test.name, reason, test.desired, limited)
+		}
+	}
+}
+
+func TestRecordScaleEvent(t *testing.T) {
+	behavior := &commtypes.AutoscalerBehavior{
+		ScaleUp: &commtypes.AutoscalerScalingRules{
+			Policies: []*commtypes.AutoscalerScalingPolicy{instancesPolicy(4, 60)},
+		},
+		ScaleDown: &commtypes.AutoscalerScalingRules{
+			Policies: []*commtypes.AutoscalerScalingPolicy{percentPolicy(50, 300)},
+		},
+	}
+	history := []*commtypes.AutoscalerScaleEvent{
+		{Timestamp: secondsAgo(testNow, 400), From: 2, To: 4},
+		{Timestamp: secondsAgo(testNow, 100), From: 4, To: 6},
+	}
+	tests := []struct {
+		name     string
+		behavior *commtypes.AutoscalerBehavior
+		expect   []int64
+	}{
+		{name: "no behavior", expect: []int64{}},
+		{name: "no policy", behavior: &commtypes.AutoscalerBehavior{ScaleUp: &commtypes.AutoscalerScalingRules{}},
+			expect: []int64{}},
+		{name: "longest period", behavior: behavior, expect: []int64{100, 0}},
+	}
+	for _, test := range tests {
+		scaler := &commtypes.BcsAutoscaler{
+			Spec:   &commtypes.BcsAutoscalerSpec{Behavior: test.behavior},
+			Status: &commtypes.BcsAutoscalerStatus{ScaleEvents: history},
+		}
+		recordScaleEvent(scaler, 6, 9, testNow)
+		events := scaler.Status.ScaleEvents
+		if len(events) != len(test.expect) {
+			t.Errorf("%s: expect %d events, got %d", test.name, len(test.expect), len(events))
+			continue
+		}
+		for i, age := range test.expect {
+			if testNow.Unix()-events[i].Timestamp.Unix() != age {
+				t.Errorf("%s: expect event %d at %ds ago, got %s", test.name, i, age, events[i].Timestamp)
+			}
+		}
+		if len(events) > 0 && (events[len(events)-1].From != 6 || events[len(events)-1].To != 9) {
+			t.Errorf("%s: scaling 6->9 is not recorded", test.name)
+		}
+	}
+}
+
+func TestDecideScalerInstance(t *testing.T) {
+	auto := &Autoscaler{config: config.NewConfig()}
+	//metrics are valid for a while before now
+	now := time.Now()
+
+	newScaler := func(current uint, value float32) *commtypes.BcsAutoscaler {
+		return &commtypes.BcsAutoscaler{
+			Spec: &commtypes.BcsAutoscalerSpec{
+				MinInstance: 2,
+				MaxInstance: 10,
+				MetricsTarget: []*commtypes.AutoscalerMetricTarget{{
+					Type: commtypes.ExternalMetricSourceType,
+					Name: "queue",
+					Target: &commtypes.AutoscalerMetricValue{
+						Type:  commtypes.AutoscalerMetricTargetValue,
+						Value: 100,
+					},
+				}},
+			},
+			Status: &commtypes.BcsAutoscalerStatus{
+				CurrentInstance: current,
+				LastScaleTime:   secondsAgo(now, 3600),
+				CurrentMetrics: []*commtypes.AutoscalerMetricCurrent{{
+					Type: commtypes.ExternalMetricSourceType,
+					Name: "queue",
+					Current: &commtypes.AutoscalerMetricValue{
+						Type:  commtypes.AutoscalerMetricTargetValue,
+						Value: value,
+					},
+					Timestamp: now,
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		scaler    *commtypes.BcsAutoscaler
+		setup     func(scaler *commtypes.BcsAutoscaler)
+		recommend uint
+		desired   uint
+		operator  commtypes.AutoscalerOperatorType
+		schedule  string
+		reason    string
+	}{
+		{
+			name:      "scale up",
+			scaler:    newScaler(4, 200),
+			recommend: 8,
+			desired:   8,
+			operator:  commtypes.AutoscalerOperatorScaleUp,
+		},
+		{
+			name:   "within upscale stabilization",
+			scaler: newScaler(4, 200),
+			setup: func(scaler *commtypes.BcsAutoscaler) {
+				scaler.Status.LastScaleOPeratorType = commtypes.AutoscalerOperatorScaleUp
+				scaler.Status.LastScaleTime = secondsAgo(now, 60)
+			},
+			recommend: 8,
+			desired:   4,
+			operator:  commtypes.AutoscalerOperatorNone,
+			reason:    "upscale stabilization",
+		},
+		{
+			name:   "limited by scale up policy",
+			scaler: newScaler(4, 200),
+			setup: func(scaler *commtypes.BcsAutoscaler) {
+				scaler.Spec.Behavior = &commtypes.AutoscalerBehavior{
+					ScaleUp: &commtypes.AutoscalerScalingRules{
+						Policies: []*commtypes.AutoscalerScalingPolicy{instancesPolicy(2, 60)},
+					},
+				}
+				//behavior replaces the default upscale stabilization
+				scaler.Status.LastScaleOPeratorType = commtypes.AutoscalerOperatorScaleUp
+				scaler.Status.LastScaleTime = secondsAgo(now, 60)
+			},
+			recommend: 8,
+			desired:   6,
+			operator:  commtypes.AutoscalerOperatorScaleUp,
+			reason:    "limited to 6",
+		},
+		{
+			name:   "stabilized by scale up window",
+			scaler: newScaler(4, 200),
+			setup: func(scaler *commtypes.BcsAutoscaler) {
+				scaler.Spec.Behavior = &commtypes.AutoscalerBehavior{
+					ScaleUp: &commtypes.AutoscalerScalingRules{StabilizationWindowSeconds: 120},
+				}
+				scaler.Status.Recommendations = []*commtypes.AutoscalerRecommendation{
+					{Timestamp: secondsAgo(now, 60), Instance: 5},
+				}
+			},
+			recommend: 8,
+			desired:   5,
+			operator:  commtypes.AutoscalerOperatorScaleUp,
+			reason:    "stabilization window",
+		},
+		{
+			name:   "scale down disabled",
+			scaler: newScaler(6, 50),
+			setup: func(scaler *commtypes.BcsAutoscaler) {
+				scaler.Spec.Behavior = &commtypes.AutoscalerBehavior{
+					ScaleDown: &commtypes.AutoscalerScalingRules{
+						SelectPolicy: commtypes.AutoscalerPolicySelectDisabled,
+					},
+				}
+			},
+			recommend: 3,
+			desired:   6,
+			operator:  commtypes.AutoscalerOperatorNone,
+			reason:    "scale down is disabled",
+		},
+		{
+			name:   "out of range corrected at once",
+			scaler: newScaler(12, 100),
+			setup: func(scaler *commtypes.BcsAutoscaler) {
+				scaler.Spec.Behavior = &commtypes.AutoscalerBehavior{
+					ScaleDown: &commtypes.AutoscalerScalingRules{
+						SelectPolicy: commtypes.AutoscalerPolicySelectDisabled,
+					},
+				}
+			},
+			recommend: 10,
+			desired:   10,
+			operator:  commtypes.AutoscalerOperatorScaleDown,
+			reason:    "more than max instance",
+		},
+		{
+			name:   "min instance raised by schedule",
+			scaler: newScaler(4, 100),
+			setup: func(scaler *commtypes.BcsAutoscaler) {
+				scaler.Spec.Schedules = []*commtypes.AutoscalerSchedule{
+					{Name: "always", Schedule: "* * * * *", Duration: 3600, MinInstance: 6},
+				}
+			},
+			recommend: 6,
+			desired:   6,
+			operator:  commtypes.AutoscalerOperatorScaleUp,
+			schedule:  "always",
+			reason:    "schedule always is active",
+		},
+	}
+	for _, test := range tests {
+		if test.setup != nil {
+			test.setup(test.scaler)
+		}
+		desired, operator, err := auto.decideScalerInstance(test.scaler, now)
+		if err != nil {
+			t.Errorf("%s: decide instance error %s", test.name, err.Error())
+			continue
+		}
+		if desired != test.desired || operator != test.operator {
+			t.Errorf("%s: expect %s to %d, got %s to %d", test.name, test.operator, test.desired, operator, desired)
+		}
+		status := test.scaler.Status
+		if status.ActiveSchedule != test.schedule {
+			t.Errorf("%s: expect active schedule %q, got %q", test.name, test.schedule, status.ActiveSchedule)
+		}
+		decision := status.LastDecision
+		if decision == nil || decision.RecommendInstance != test.recommend || decision.DesiredInstance != desired ||
+			decision.Operator != operator {
+			t.Errorf("%s: decision %+v is not recorded", test.name, decision)
+			continue
+		}
+		if test.reason != "" && !strings.Contains(strings.Join(decision.Reasons, "; "), test.reason) {
+			t.Errorf("%s: expect reason %q, got %v", test.name, test.reason, decision.Reasons)
+		}
+	}
+}
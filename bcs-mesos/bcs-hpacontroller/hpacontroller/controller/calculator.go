@@ -61,8 +61,56 @@ func (auto *Autoscaler) updateScalerCurrentMetrics(scaler *commtypes.BcsAutoscal
 				current.Timestamp = time.Now()
 			}
 
+		case commtypes.TaskgroupsMetricSourceType:
+			if auto.externalMetrics == nil {
+				blog.Errorf("scaler %s taskgroup metric %s, but prometheus is not configured", scaler.GetUuid(), current.Name)
+				break
+			}
+			metrics, err := auto.externalMetrics.GetTaskgroupMetric(current.Name, scaler.GetUuid())
+			if err != nil {
+				blog.Errorf("scaler %s ref(%s:%s:%s) get taskgroup %s metrics error %s", scaler.GetUuid(),
+					refKind, refNs, refName, current.Name, err.Error())
+				break
+			}
+
+			var totalValue float32
+			var num float32
+			for _, metric := range metrics {
+				num++
+				totalValue += metric.Value
+			}
+
+			if num > 0 {
+				value, _ := strconv.ParseFloat(fmt.Sprintf("%.2f", totalValue/num), 32)
+				current.Current.AverageValue = float32(value)
+				current.Timestamp = time.Now()
+			}
+
 		case commtypes.ExternalMetricSourceType:
-			//todo
+			if auto.externalMetrics == nil {
+				blog.Errorf("scaler %s external metric %s, but prometheus is not configured", scaler.GetUuid(), current.Name)
+				break
+			}
+			metric, err := auto.externalMetrics.GetExternalMetric(current.Name, scaler.GetUuid())
+			if err != nil {
+				blog.Errorf("scaler %s ref(%s:%s:%s) get external %s metric error %s", scaler.GetUuid(),
+					refKind, refNs, refName, current.Name, err.Error())
+				break
+			}
+
+			//external metric with AverageValue target is divided by current instance
+			if current.Current.Type == commtypes.AutoscalerMetricTargetAverageValue {
+				if scaler.Status.CurrentInstance == 0 {
+					break
+				}
+				value, _ := strconv.ParseFloat(
+					fmt.Sprintf("%.2f", metric.Value/float32(scaler.Status.CurrentInstance)), 32)
+				current.Current.AverageValue = float32(value)
+			} else {
+				value, _ := strconv.ParseFloat(fmt.Sprintf("%.2f", metric.Value), 32)
+				current.Current.Value = float32(value)
+			}
+			current.Timestamp = metric.Timestamp
 
 		default:
 			blog.Errorf("scaler %s metrics %s type %s is invalid", scaler.GetUuid(), current.Name, current.Type)
@@ -72,24 +120,29 @@ func (auto *Autoscaler) updateScalerCurrentMetrics(scaler *commtypes.BcsAutoscal
 	return nil
 }
 
-//compute scaler DesiredInstance, and return it
-func (auto *Autoscaler) computeScalerDesiredInstance(scaler *commtypes.BcsAutoscaler) (uint, commtypes.AutoscalerOperatorType, error) {
+//compute scaler DesiredInstance within minInstance and maxInstance, and return it with the reasons
+func (auto *Autoscaler) computeScalerDesiredInstance(scaler *commtypes.BcsAutoscaler, minInstance, maxInstance uint) (
+	uint, commtypes.AutoscalerOperatorType, []string, error) {
 	var (
 		describedInstance uint
 		scaleUpNumber     int
 		scaleDownNumber   int
 		scalerOperator    commtypes.AutoscalerOperatorType = commtypes.AutoscalerOperatorNone
+		upReasons         []string
+		downReasons       []string
 	)
 
 	currentInstance := float32(scaler.Status.CurrentInstance)
 	//if current instance > max instance
-	if uint(currentInstance) > scaler.Spec.MaxInstance {
-		return scaler.Spec.MaxInstance, commtypes.AutoscalerOperatorScaleDown, nil
+	if uint(currentInstance) > maxInstance {
+		return maxInstance, commtypes.AutoscalerOperatorScaleDown,
+			[]string{fmt.Sprintf("current instance %d is more than max instance %d", uint(currentInstance), maxInstance)}, nil
 	}
 
 	//if current instance < min instance
-	if uint(currentInstance) < scaler.Spec.MinInstance {
-		return scaler.Spec.MinInstance, commtypes.AutoscalerOperatorScaleUp, nil
+	if uint(currentInstance) < minInstance {
+		return minInstance, commtypes.AutoscalerOperatorScaleUp,
+			[]string{fmt.Sprintf("current instance %d is less than min instance %d", uint(currentInstance), minInstance)}, nil
 	}
 
 	for _, target := range scaler.Spec.MetricsTarget {
@@ -182,7 +235,7 @@ func (auto *Autoscaler) computeScalerDesiredInstance(scaler *commtypes.BcsAutosc
 				continue
 			}
 			//if cuttent instance == max instance, then don't scale it
-			if scaler.Status.CurrentInstance == scaler.Spec.MaxInstance {
+			if scaler.Status.CurrentInstance == maxInstance {
 				continue
 			}
 
@@ -190,9 +243,11 @@ func (auto *Autoscaler) computeScalerDesiredInstance(scaler *commtypes.BcsAutosc
 			if ceil > describedInstance {
 				describedInstance = ceil
 			}
-			if describedInstance > scaler.Spec.MaxInstance {
-				describedInstance = scaler.Spec.MaxInstance
+			if describedInstance > maxInstance {
+				describedInstance = maxInstance
 			}
+			upReasons = append(upReasons, fmt.Sprintf("metric %s tolerance %.2f recommends scale up to %d",
+				current.Name, tolerance, ceil))
 
 			blog.Infof("scaler %s metrics %s current instance %f tolerance %.2f described instance %d", scaler.GetUuid(),
 				current.Name, currentInstance, tolerance, describedInstance)
@@ -212,7 +267,7 @@ func (auto *Autoscaler) computeScalerDesiredInstance(scaler *commtypes.BcsAutosc
 				continue
 			}
 			//if cuttent instance == min instance, then don't scale it
-			if scaler.Status.CurrentInstance == scaler.Spec.MinInstance {
+			if scaler.Status.CurrentInstance == minInstance {
 				continue
 			}
 
@@ -223,9 +278,11 @@ func (auto *Autoscaler) computeScalerDesiredInstance(scaler *commtypes.BcsAutosc
 			if describedInstance < ceil {
 				describedInstance = ceil
 			}
-			if describedInstance < scaler.Spec.MinInstance {
-				describedInstance = scaler.Spec.MinInstance
+			if describedInstance < minInstance {
+				describedInstance = minInstance
 			}
+			downReasons = append(downReasons, fmt.Sprintf("metric %s tolerance %.2f recommends scale down to %d",
+				current.Name, tolerance, ceil))
 
 			blog.Infof("scaler %s metrics %s tolerance %.2f described instance %d", scaler.GetUuid(),
 				current.Name, tolerance, describedInstance)
@@ -235,15 +292,18 @@ func (auto *Autoscaler) computeScalerDesiredInstance(scaler *commtypes.BcsAutosc
 	//if scale up number>0, then scale up it
 	if scaleUpNumber > 0 {
 		scalerOperator = commtypes.AutoscalerOperatorScaleUp
-		return describedInstance, scalerOperator, nil
+		return describedInstance, scalerOperator, upReasons, nil
 	}
 
 	//if scale down number==len(scaler.Spec.MetricsTarget), then scale down it
 	if scaleDownNumber == len(scaler.Spec.MetricsTarget) {
 		scalerOperator = commtypes.AutoscalerOperatorScaleDown
-		return describedInstance, scalerOperator, nil
+		return describedInstance, scalerOperator, downReasons, nil
 	}
 
 	//finally don't scale it
-	return scaler.Status.CurrentInstance, scalerOperator, nil
+	if scaleDownNumber > 0 {
+		downReasons = append(downReasons, "not all metrics recommend scale down")
+	}
+	return scaler.Status.CurrentInstance, scalerOperator, downReasons, nil
 }
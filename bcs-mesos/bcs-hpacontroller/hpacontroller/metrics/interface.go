@@ -34,6 +34,12 @@ const (
 // TaskgroupMetricsInfo contains taskgroup metrics as a map from pod names to TaskgroupMetricsInfo
 type TaskgroupMetricsInfo map[string]TaskgroupMetric
 
+// ExternalMetric contains the value of a global metric which is not associated with any taskgroup
+type ExternalMetric struct {
+	Timestamp time.Time
+	Value     float32
+}
+
 // MetricsController collect external metrics or taskgroup resource metrics
 type MetricsController interface {
 	//start to collect scaler metrics
@@ -45,4 +51,10 @@ type MetricsController interface {
 	// GetResourceMetric gets the given resource metric (and an associated oldest timestamp)
 	// for all taskgroup matching the specified uuid
 	GetResourceMetric(resourceName, uuid string) (TaskgroupMetricsInfo, error)
+
+	// GetTaskgroupMetric gets the given custom metric for all taskgroup matching the specified uuid
+	GetTaskgroupMetric(metricName, uuid string) (TaskgroupMetricsInfo, error)
+
+	// GetExternalMetric gets the given global metric collected for the specified uuid
+	GetExternalMetric(metricName, uuid string) (*ExternalMetric, error)
 }
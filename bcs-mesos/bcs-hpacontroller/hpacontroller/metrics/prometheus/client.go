@@ -0,0 +1,110 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+const (
+	queryAPIPath = "/api/v1/query"
+
+	// DefaultQueryTimeout timeout of one prometheus query
+	DefaultQueryTimeout = 10 * time.Second
+)
+
+// queryResponse response of prometheus instant query api
+type queryResponse struct {
+	Status    string     `json:"status"`
+	ErrorType string     `json:"errorType,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	Data      *queryData `json:"data,omitempty"`
+}
+
+type queryData struct {
+	ResultType model.ValueType `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// queryClient queries prometheus compatible http api
+type queryClient struct {
+	address string
+	client  *http.Client
+}
+
+func newQueryClient(address string, timeout time.Duration) *queryClient {
+	return &queryClient{
+		address: strings.TrimSuffix(address, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Query evaluates an instant query at time ts, scalar result is returned as a vector with one sample
+func (c *queryClient) Query(ctx context.Context, query string, ts time.Time) (model.Vector, error) {
+	values := url.Values{}
+	values.Set("query", query)
+	values.Set("time", strconv.FormatFloat(float64(ts.UnixNano())/1e9, 'f', 3, 64))
+
+	req, err := http.NewRequest(http.MethodPost, c.address+queryAPIPath, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	result := &queryResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, fmt.Errorf("decode query response(status code %d) failed, %s", resp.StatusCode, err.Error())
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("query %s failed, %s: %s", query, result.ErrorType, result.Error)
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("query %s response has no data", query)
+	}
+
+	switch result.Data.ResultType {
+	case model.ValVector:
+		var vector model.Vector
+		if err := json.Unmarshal(result.Data.Result, &vector); err != nil {
+			return nil, fmt.Errorf("decode query %s vector result failed, %s", query, err.Error())
+		}
+		return vector, nil
+	case model.ValScalar:
+		scalar := &model.Scalar{}
+		if err := json.Unmarshal(result.Data.Result, scalar); err != nil {
+			return nil, fmt.Errorf("decode query %s scalar result failed, %s", query, err.Error())
+		}
+		return model.Vector{&model.Sample{Metric: model.Metric{}, Value: scalar.Value, Timestamp: scalar.Timestamp}}, nil
+	}
+	return nil, fmt.Errorf("query %s result type %s is not supported", query, result.Data.ResultType)
+}
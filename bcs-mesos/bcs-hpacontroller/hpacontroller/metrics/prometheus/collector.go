@@ -0,0 +1,212 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	schedtypes "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-hpacontroller/hpacontroller/metrics"
+)
+
+type prometheusCollector struct {
+	sync.RWMutex
+	controller *prometheusMetrics
+
+	scaler *commtypes.BcsAutoscaler
+
+	//key = metric name
+	//value = TaskgroupMetricsInfo of running taskgroups
+	taskgroupMetricsInfo map[string]metrics.TaskgroupMetricsInfo
+	//key = metric name
+	externalMetrics map[string]*metrics.ExternalMetric
+
+	// seconds, default 30s
+	collectMetricsInterval int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newPrometheusCollector(controller *prometheusMetrics, scaler *commtypes.BcsAutoscaler) *prometheusCollector {
+	collector := &prometheusCollector{
+		controller:             controller,
+		scaler:                 scaler,
+		collectMetricsInterval: controller.config.CollectMetricsInterval,
+		taskgroupMetricsInfo:   make(map[string]metrics.TaskgroupMetricsInfo),
+		externalMetrics:        make(map[string]*metrics.ExternalMetric),
+	}
+
+	collector.ctx, collector.cancel = context.WithCancel(context.Background())
+
+	return collector
+}
+
+func (collector *prometheusCollector) start() {
+	//start ticker collector metrics
+	go collector.tickerCollectorMetrics()
+}
+
+func (collector *prometheusCollector) stop() {
+	collector.cancel()
+}
+
+func (collector *prometheusCollector) getTaskgroupMetricsInfo(metricName string) (metrics.TaskgroupMetricsInfo, error) {
+	collector.RLock()
+	defer collector.RUnlock()
+
+	info, ok := collector.taskgroupMetricsInfo[metricName]
+	if !ok {
+		return nil, fmt.Errorf("taskgroup metric %s is not collected", metricName)
+	}
+	return info, nil
+}
+
+func (collector *prometheusCollector) getExternalMetric(metricName string) (*metrics.ExternalMetric, error) {
+	collector.RLock()
+	defer collector.RUnlock()
+
+	metric, ok := collector.externalMetrics[metricName]
+	if !ok {
+		return nil, fmt.Errorf("external metric %s is not collected", metricName)
+	}
+	return metric, nil
+}
+
+func (collector *prometheusCollector) tickerCollectorMetrics() {
+	ticker := time.NewTicker(time.Second * time.Duration(collector.collectMetricsInterval))
+	defer ticker.Stop()
+
+	collector.collectorMetrics()
+	for {
+		select {
+		case <-collector.ctx.Done():
+			blog.Infof("stop ticker collector scaler %s prometheus metrics", collector.scaler.GetUuid())
+			return
+
+		case <-ticker.C:
+			blog.V(3).Infof("ticker collector scaler %s prometheus metrics", collector.scaler.GetUuid())
+			collector.collectorMetrics()
+		}
+	}
+}
+
+func (collector *prometheusCollector) collectorMetrics() {
+	var taskgroups []string
+	for _, target := range collector.scaler.Spec.MetricsTarget {
+		if target.Type != commtypes.TaskgroupsMetricSourceType {
+			continue
+		}
+		var err error
+		taskgroups, err = collector.runningTaskgroups()
+		if err != nil {
+			blog.Errorf("list scaler %s target ref taskgroups error %s", collector.scaler.GetUuid(), err.Error())
+			return
+		}
+		break
+	}
+
+	for _, target := range collector.scaler.Spec.MetricsTarget {
+		switch target.Type {
+		case commtypes.TaskgroupsMetricSourceType:
+			collector.collectTaskgroupMetric(target, taskgroups)
+
+		case commtypes.ExternalMetricSourceType:
+			collector.collectExternalMetric(target)
+		}
+	}
+}
+
+func (collector *prometheusCollector) collectTaskgroupMetric(target *commtypes.AutoscalerMetricTarget, taskgroups []string) {
+	if len(taskgroups) == 0 {
+		blog.Warnf("scaler %s has no running taskgroup, skip taskgroup metric %s", collector.scaler.GetUuid(), target.Name)
+		collector.Lock()
+		delete(collector.taskgroupMetricsInfo, target.Name)
+		collector.Unlock()
+		return
+	}
+
+	label := collector.controller.config.PrometheusTaskgroupLabel
+	query := taskgroupQuery(target, label, taskgroups)
+	now := time.Now()
+	ctx, cancel := context.WithTimeout(collector.ctx, DefaultQueryTimeout)
+	defer cancel()
+	vector, err := collector.controller.client.Query(ctx, query, now)
+	if err != nil {
+		blog.Errorf("scaler %s query taskgroup metric %s error %s", collector.scaler.GetUuid(), target.Name, err.Error())
+		return
+	}
+
+	info := taskgroupMetrics(vector, label, taskgroups, now, collector.collectMetricsInterval)
+	blog.Infof("scaler %s collect taskgroup metric %s of %d/%d taskgroups",
+		collector.scaler.GetUuid(), target.Name, len(info), len(taskgroups))
+	collector.Lock()
+	collector.taskgroupMetricsInfo[target.Name] = info
+	collector.Unlock()
+}
+
+func (collector *prometheusCollector) collectExternalMetric(target *commtypes.AutoscalerMetricTarget) {
+	query := externalQuery(target)
+	now := time.Now()
+	ctx, cancel := context.WithTimeout(collector.ctx, DefaultQueryTimeout)
+	defer cancel()
+	vector, err := collector.controller.client.Query(ctx, query, now)
+	if err != nil {
+		blog.Errorf("scaler %s query external metric %s error %s", collector.scaler.GetUuid(), target.Name, err.Error())
+		return
+	}
+
+	metric, err := externalMetric(vector, now)
+	if err != nil {
+		blog.Errorf("scaler %s external metric %s query(%s) error %s",
+			collector.scaler.GetUuid(), target.Name, query, err.Error())
+		return
+	}
+	blog.Infof("scaler %s collect external metric %s %.2f", collector.scaler.GetUuid(), target.Name, metric.Value)
+	collector.Lock()
+	collector.externalMetrics[target.Name] = metric
+	collector.Unlock()
+}
+
+// runningTaskgroups list the running taskgroups of scaler target ref
+func (collector *prometheusCollector) runningTaskgroups() ([]string, error) {
+	var taskgroups []*schedtypes.TaskGroup
+	var err error
+
+	targetRef := collector.scaler.Spec.ScaleTargetRef
+	switch targetRef.Kind {
+	case commtypes.AutoscalerTargetRefDeployment:
+		taskgroups, err = collector.controller.store.ListTaskgroupRefDeployment(targetRef.Namespace, targetRef.Name)
+
+	case commtypes.AutoscalerTargetRefApplication:
+		taskgroups, err = collector.controller.store.ListTaskgroupRefApplication(targetRef.Namespace, targetRef.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(taskgroups))
+	for _, taskgroup := range taskgroups {
+		if taskgroup.Status != schedtypes.TASKGROUP_STATUS_RUNNING {
+			continue
+		}
+		ids = append(ids, taskgroup.ID)
+	}
+	return ids, nil
+}
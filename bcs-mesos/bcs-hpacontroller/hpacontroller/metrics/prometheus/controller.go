@@ -0,0 +1,124 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package prometheus
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-hpacontroller/hpacontroller/config"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-hpacontroller/hpacontroller/metrics"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-hpacontroller/hpacontroller/reflector"
+)
+
+type prometheusMetrics struct {
+	sync.RWMutex
+	//hpa controller config
+	config *config.Config
+
+	// Reflector watches a specified resource and causes all changes to be reflected in the given store
+	store reflector.Reflector
+
+	//prometheus query api client
+	client *queryClient
+
+	//hpa autoscaler work queue, key = BcsAutoscaler.GetUuid()
+	workQueue map[string]*prometheusCollector
+}
+
+// NewPrometheusMetrics create MetricsController which collects Taskgroup and External metrics from prometheus
+func NewPrometheusMetrics(conf *config.Config, store reflector.Reflector) metrics.MetricsController {
+	prom := &prometheusMetrics{
+		config:    conf,
+		store:     store,
+		client:    newQueryClient(conf.PrometheusAddr, DefaultQueryTimeout),
+		workQueue: make(map[string]*prometheusCollector),
+	}
+
+	return prom
+}
+
+//start to collect scaler metrics
+func (prom *prometheusMetrics) StartScalerMetrics(scaler *commtypes.BcsAutoscaler) {
+	prom.Lock()
+	defer prom.Unlock()
+
+	_, ok := prom.workQueue[scaler.GetUuid()]
+	if ok {
+		return
+	}
+	if !hasPrometheusMetrics(scaler) {
+		return
+	}
+
+	//start collector scaler taskgroup and external metrics
+	prom.workQueue[scaler.GetUuid()] = newPrometheusCollector(prom, scaler)
+	prom.workQueue[scaler.GetUuid()].start()
+	blog.Infof("start collector scaler %s prometheus metrics", scaler.GetUuid())
+}
+
+//stop to collect scaler metrics
+func (prom *prometheusMetrics) StopScalerMetrics(scaler *commtypes.BcsAutoscaler) {
+	prom.Lock()
+	defer prom.Unlock()
+
+	collector, ok := prom.workQueue[scaler.GetUuid()]
+	if !ok {
+		return
+	}
+	collector.stop()
+	delete(prom.workQueue, scaler.GetUuid())
+	blog.Infof("stop collector scaler %s prometheus metrics", scaler.GetUuid())
+}
+
+// GetResourceMetric resource metrics are not collected from prometheus
+func (prom *prometheusMetrics) GetResourceMetric(resourceName, uuid string) (metrics.TaskgroupMetricsInfo, error) {
+	return nil, fmt.Errorf("resource metric %s is not supported by prometheus metrics", resourceName)
+}
+
+// GetTaskgroupMetric gets the given custom metric for all taskgroup matching the specified scaler uuid
+func (prom *prometheusMetrics) GetTaskgroupMetric(metricName, uuid string) (metrics.TaskgroupMetricsInfo, error) {
+	prom.RLock()
+	defer prom.RUnlock()
+
+	collector, ok := prom.workQueue[uuid]
+	if !ok {
+		return nil, fmt.Errorf("scaler %s not found", uuid)
+	}
+	return collector.getTaskgroupMetricsInfo(metricName)
+}
+
+// GetExternalMetric gets the given global metric collected for the specified scaler uuid
+func (prom *prometheusMetrics) GetExternalMetric(metricName, uuid string) (*metrics.ExternalMetric, error) {
+	prom.RLock()
+	defer prom.RUnlock()
+
+	collector, ok := prom.workQueue[uuid]
+	if !ok {
+		return nil, fmt.Errorf("scaler %s not found", uuid)
+	}
+	return collector.getExternalMetric(metricName)
+}
+
+// hasPrometheusMetrics check whether scaler has Taskgroup or External metrics
+func hasPrometheusMetrics(scaler *commtypes.BcsAutoscaler) bool {
+	for _, target := range scaler.Spec.MetricsTarget {
+		if target.Type == commtypes.TaskgroupsMetricSourceType || target.Type == commtypes.ExternalMetricSourceType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+)
+
+// fakePrometheus serves instant query api with fixed responses by query
+func fakePrometheus(responses map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != queryAPIPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query := r.FormValue("query")
+		resp, ok := responses[query]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"unexpected query"}`)
+			return
+		}
+		fmt.Fprint(w, resp)
+	}))
+}
+
+func TestTaskgroupMetric(t *testing.T) {
+	taskgroups := []string{"0.app.ns.10001.1", "1.app.ns.10001.2"}
+	target := &commtypes.AutoscalerMetricTarget{
+		Type:     commtypes.TaskgroupsMetricSourceType,
+		Name:     "http_requests",
+		Selector: map[string]string{"code": "200"},
+	}
+	query := taskgroupQuery(target, "taskgroup", taskgroups)
+	expected := `sum by (taskgroup) (http_requests{taskgroup=~"0\\.app\\.ns\\.10001\\.1|1\\.app\\.ns\\.10001\\.2",code="200"})`
+	if query != expected {
+		t.Fatalf("expected query %s, got %s", expected, query)
+	}
+
+	server := fakePrometheus(map[string]string{
+		query: `{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"taskgroup":"0.app.ns.10001.1"},"value":[1600000000,"10"]},
+			{"metric":{"taskgroup":"1.app.ns.10001.2"},"value":[1600000000,"30.5"]},
+			{"metric":{"taskgroup":"0.other.ns.10001.1"},"value":[1600000000,"100"]}]}}`,
+	})
+	defer server.Close()
+
+	client := newQueryClient(server.URL, time.Second)
+	vector, err := client.Query(context.Background(), query, time.Now())
+	if err != nil {
+		t.Fatalf("query failed, %s", err.Error())
+	}
+	info := taskgroupMetrics(vector, "taskgroup", taskgroups, time.Now(), 30)
+	if len(info) != 2 {
+		t.Fatalf("expected metrics of 2 taskgroups, got %d", len(info))
+	}
+	if info[taskgroups[0]].Value != 10 || info[taskgroups[1]].Value != 30.5 {
+		t.Errorf("unexpected taskgroup metrics %+v", info)
+	}
+}
+
+func TestExternalMetric(t *testing.T) {
+	target := &commtypes.AutoscalerMetricTarget{
+		Type: commtypes.ExternalMetricSourceType,
+		Name: "queue_depth",
+	}
+	scalarTarget := &commtypes.AutoscalerMetricTarget{
+		Type:  commtypes.ExternalMetricSourceType,
+		Name:  "lb_qps",
+		Query: "scalar(sum(rate(lb_requests_total[1m])))",
+	}
+	server := fakePrometheus(map[string]string{
+		"sum(queue_depth)": `{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{},"value":[1600000000,"42"]}]}}`,
+		scalarTarget.Query: `{"status":"success","data":{"resultType":"scalar","result":[1600000000,"1200"]}}`,
+		"sum(empty)":       `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+	})
+	defer server.Close()
+	client := newQueryClient(server.URL, time.Second)
+
+	tests := []struct {
+		target   *commtypes.AutoscalerMetricTarget
+		expected float32
+		hasErr   bool
+	}{
+		{target: target, expected: 42},
+		{target: scalarTarget, expected: 1200},
+		{target: &commtypes.AutoscalerMetricTarget{Name: "empty"}, hasErr: true},
+		{target: &commtypes.AutoscalerMetricTarget{Name: "unknown"}, hasErr: true},
+	}
+	for _, test := range tests {
+		query := externalQuery(test.target)
+		vector, err := client.Query(context.Background(), query, time.Now())
+		if err != nil {
+			if !test.hasErr {
+				t.Errorf("query %s failed, %s", query, err.Error())
+			}
+			continue
+		}
+		metric, err := externalMetric(vector, time.Now())
+		if err != nil {
+			if !test.hasErr {
+				t.Errorf("query %s result invalid, %s", query, err.Error())
+			}
+			continue
+		}
+		if test.hasErr {
+			t.Errorf("query %s expected error, got %f", query, metric.Value)
+			continue
+		}
+		if metric.Value != test.expected {
+			t.Errorf("query %s expected %f, got %f", query, test.expected, metric.Value)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package prometheus
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-hpacontroller/hpacontroller/metrics"
+
+	"github.com/prometheus/common/model"
+)
+
+// taskgroupQuery build the query of Taskgroup metric, the result is one sample for each taskgroup
+func taskgroupQuery(target *commtypes.AutoscalerMetricTarget, label string, taskgroups []string) string {
+	if target.Query != "" {
+		return target.Query
+	}
+
+	ids := make([]string, 0, len(taskgroups))
+	for _, id := range taskgroups {
+		ids = append(ids, regexp.QuoteMeta(id))
+	}
+	matchers := []string{fmt.Sprintf("%s=~%s", label, strconv.Quote(strings.Join(ids, "|")))}
+	matchers = append(matchers, selectorMatchers(target.Selector)...)
+	return fmt.Sprintf("sum by (%s) (%s{%s})", label, target.Name, strings.Join(matchers, ","))
+}
+
+// externalQuery build the query of External metric, all series of the metric are summed up
+func externalQuery(target *commtypes.AutoscalerMetricTarget) string {
+	if target.Query != "" {
+		return target.Query
+	}
+
+	matchers := selectorMatchers(target.Selector)
+	if len(matchers) == 0 {
+		return fmt.Sprintf("sum(%s)", target.Name)
+	}
+	return fmt.Sprintf("sum(%s{%s})", target.Name, strings.Join(matchers, ","))
+}
+
+// selectorMatchers convert selector to promql equality label matchers, in order of label name
+func selectorMatchers(selector map[string]string) []string {
+	matchers := make([]string, 0, len(selector))
+	for k, v := range selector {
+		matchers = append(matchers, fmt.Sprintf("%s=%s", k, strconv.Quote(v)))
+	}
+	sort.Strings(matchers)
+	return matchers
+}
+
+// taskgroupMetrics pick the samples of taskgroups from query result by taskgroup label
+func taskgroupMetrics(vector model.Vector, label string, taskgroups []string, ts time.Time,
+	window int) metrics.TaskgroupMetricsInfo {
+
+	info := make(metrics.TaskgroupMetricsInfo, len(taskgroups))
+	ids := make(map[string]struct{}, len(taskgroups))
+	for _, id := range taskgroups {
+		ids[id] = struct{}{}
+	}
+	for _, sample := range vector {
+		id := string(sample.Metric[model.LabelName(label)])
+		if _, ok := ids[id]; !ok {
+			continue
+		}
+		if math.IsNaN(float64(sample.Value)) || math.IsInf(float64(sample.Value), 0) {
+			continue
+		}
+		metric := info[id]
+		metric.Timestamp = ts
+		metric.Window = window
+		metric.Value += float32(sample.Value)
+		info[id] = metric
+	}
+	return info
+}
+
+// externalMetric sum up the samples of query result
+func externalMetric(vector model.Vector, ts time.Time) (*metrics.ExternalMetric, error) {
+	metric := &metrics.ExternalMetric{Timestamp: ts}
+	valid := 0
+	for _, sample := range vector {
+		if math.IsNaN(float64(sample.Value)) || math.IsInf(float64(sample.Value), 0) {
+			continue
+		}
+		metric.Value += float32(sample.Value)
+		valid++
+	}
+	if valid == 0 {
+		return nil, fmt.Errorf("query result has no valid sample")
+	}
+	return metric, nil
+}
@@ -97,3 +97,13 @@ func (resources *resourceMetrics) GetResourceMetric(resourceName, uuid string) (
 
 	return nil, fmt.Errorf("resource name %s is invalid", resourceName)
 }
+
+// GetTaskgroupMetric custom taskgroup metrics are not collected by resource metrics
+func (resources *resourceMetrics) GetTaskgroupMetric(metricName, uuid string) (metrics.TaskgroupMetricsInfo, error) {
+	return nil, fmt.Errorf("taskgroup metric %s is not supported by resource metrics", metricName)
+}
+
+// GetExternalMetric external metrics are not collected by resource metrics
+func (resources *resourceMetrics) GetExternalMetric(metricName, uuid string) (*metrics.ExternalMetric, error) {
+	return nil, fmt.Errorf("external metric %s is not supported by resource metrics", metricName)
+}
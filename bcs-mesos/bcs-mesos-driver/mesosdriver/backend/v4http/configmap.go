@@ -19,7 +19,7 @@ import (
 	bhttp "github.com/Tencent/bk-bcs/bcs-common/common/http"
 )
 
-func (s *Scheduler) CreateConfigMap(body []byte) (string, error) {
+func (s *Scheduler) CreateConfigMap(body []byte, dryRun string) (string, error) {
 
 	blog.Info("create configmap data(%s)", string(body))
 
@@ -30,6 +30,7 @@ func (s *Scheduler) CreateConfigMap(body []byte) (string, error) {
 	}
 
 	url := s.GetHost() + "/v1/configmap"
+	url = withDryRun(url, dryRun)
 	blog.Info("post a request to url(%s), request:%s", url, string(body))
 
 	reply, err := s.client.POST(url, nil, body)
@@ -42,7 +43,7 @@ func (s *Scheduler) CreateConfigMap(body []byte) (string, error) {
 	return string(reply), nil
 }
 
-func (s *Scheduler) UpdateConfigMap(body []byte) (string, error) {
+func (s *Scheduler) UpdateConfigMap(body []byte, dryRun string) (string, error) {
 
 	blog.Info("update configmap data(%s)", string(body))
 
@@ -53,6 +54,7 @@ func (s *Scheduler) UpdateConfigMap(body []byte) (string, error) {
 	}
 
 	url := s.GetHost() + "/v1/configmap"
+	url = withDryRun(url, dryRun)
 	blog.Info("put a request to url(%s), request:%s", url, string(body))
 
 	reply, err := s.client.PUT(url, nil, body)
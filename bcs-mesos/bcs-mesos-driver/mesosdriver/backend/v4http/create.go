@@ -29,7 +29,7 @@ import (
 )
 
 //CreateApplication create application implementation
-func (s *Scheduler) CreateApplication(body []byte) (string, error) {
+func (s *Scheduler) CreateApplication(body []byte, dryRun string) (string, error) {
 	blog.Info("create application. param(%s)", string(body))
 	var param bcstype.ReplicaController
 	//encoding param by json
@@ -61,6 +61,7 @@ func (s *Scheduler) CreateApplication(body []byte) (string, error) {
 	}
 
 	url := s.GetHost() + "/v1/apps"
+	url = withDryRun(url, dryRun)
 	blog.Info("post a request to url(%s), request:%s", url, string(data))
 
 	//reply, err := bhttp.Request(url, "POST", nil, strings.NewReader(string(data)))
@@ -119,6 +120,13 @@ func (s *Scheduler) newVersionWithParam(param *bcstype.ReplicaController) (*type
 	}
 	version.Instances = int32(param.ReplicaControllerSpec.Instance)
 	version.Constraints = param.Constraints
+	version.Priority = param.ReplicaControllerSpec.Priority
+	version.Preemption = param.ReplicaControllerSpec.Preemption
+	if version.Priority < 0 || (version.Preemption != nil && version.Preemption.MaxEvictions < 0) {
+		blog.Error("error priority %d or preemption of application(%s)", version.Priority, param.Name)
+		replyErr := bhttp.InternalError(common.BcsErrMesosDriverParameterErr, common.BcsErrMesosDriverParameterErrStr+"priority or preemption error")
+		return nil, replyErr
+	}
 
 	for k, v := range param.Labels {
 		version.Labels[k] = v
@@ -25,7 +25,7 @@ import (
 )
 
 // CreateDeployment create deployment, call scheduler create deployment api
-func (s *Scheduler) CreateDeployment(body []byte) (string, error) {
+func (s *Scheduler) CreateDeployment(body []byte, dryRun string) (string, error) {
 	blog.Info("create deployment. param(%s)", string(body))
 	var param bcstype.BcsDeployment
 
@@ -62,6 +62,7 @@ func (s *Scheduler) CreateDeployment(body []byte) (string, error) {
 	namespace := deploymentDef.ObjectMeta.NameSpace
 
 	url := fmt.Sprintf("%s/v1/deployment/%s/%s", s.GetHost(), namespace, name)
+	url = withDryRun(url, dryRun)
 	blog.Info("post a request to url(%s), request:%s", url, string(data))
 
 	reply, err := s.client.POST(url, nil, data)
@@ -75,7 +76,7 @@ func (s *Scheduler) CreateDeployment(body []byte) (string, error) {
 }
 
 // UpdateDeployment do update deployment, call scheduler update deployment api
-func (s *Scheduler) UpdateDeployment(body []byte, args string) (string, error) {
+func (s *Scheduler) UpdateDeployment(body []byte, args, dryRun string) (string, error) {
 	blog.Info("udpate deployment. param(%s)", string(body))
 	var param bcstype.BcsDeployment
 
@@ -113,6 +114,7 @@ func (s *Scheduler) UpdateDeployment(body []byte, args string) (string, error) {
 	namespace := deploymentDef.ObjectMeta.NameSpace
 
 	url := fmt.Sprintf("%s/v1/deployment/%s/%s?args=%s", s.GetHost(), namespace, name, args)
+	url = withDryRun(url, dryRun)
 	blog.Info("post a request to url(%s), request:%s", url, string(data))
 
 	reply, err := s.client.PUT(url, nil, data)
@@ -296,6 +298,14 @@ func (s *Scheduler) newDeploymentDefWithParam(param *bcstype.BcsDeployment) (*ty
 	version.RunAs = param.NameSpace
 	version.Instances = int32(param.Spec.Instance)
 	version.Constraints = param.Constraints
+	version.Priority = param.Spec.Priority
+	version.Preemption = param.Spec.Preemption
+	if version.Priority < 0 || (version.Preemption != nil && version.Preemption.MaxEvictions < 0) {
+		blog.Error("error priority %d or preemption of deployment(%s)", version.Priority, param.Name)
+		replyErr := bhttp.InternalError(common.BcsErrMesosDriverParameterErr,
+			common.BcsErrMesosDriverParameterErrStr+"priority or preemption error")
+		return nil, replyErr
+	}
 
 	for k, v := range param.Labels {
 		version.Labels[k] = v
@@ -0,0 +1,107 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package v4http
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common"
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	bhttp "github.com/Tencent/bk-bcs/bcs-common/common/http"
+	bcstype "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/common/util"
+
+	restful "github.com/emicklei/go-restful"
+)
+
+func (s *Scheduler) createDisruptionBudgetHandler(req *restful.Request, resp *restful.Response) {
+	s.saveDisruptionBudget(req, resp, "POST")
+}
+
+func (s *Scheduler) updateDisruptionBudgetHandler(req *restful.Request, resp *restful.Response) {
+	s.saveDisruptionBudget(req, resp, "PUT")
+}
+
+func (s *Scheduler) saveDisruptionBudget(req *restful.Request, resp *restful.Response, method string) {
+	body, err := s.getRequestInfo(req)
+	if err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	//check whether disruption budget type
+	err = util.CheckKind(bcstype.BcsDataType_DisruptionBudget, body)
+	if err != nil {
+		blog.Error("fail to save disruption budget(%s). err(%s)", string(body), err.Error())
+		err = bhttp.InternalError(common.BcsErrCommRequestDataErr, err.Error())
+		resp.Write([]byte(err.Error()))
+		return
+	}
+
+	var param bcstype.BcsDisruptionBudget
+	if err = json.Unmarshal(body, &param); err != nil {
+		blog.Error("parse disruption budget failed. param(%s), err(%s)", string(body), err.Error())
+		err = bhttp.InternalError(common.BcsErrCommJsonDecode, common.BcsErrCommJsonDecodeStr)
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	param.NameSpace = req.PathParameter("ns")
+	if err = param.MetaIsValid(); err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	if err = param.Validate(); err != nil {
+		blog.Error("disruption budget(%s.%s) is invalid, err(%s)", param.NameSpace, param.Name, err.Error())
+		err = bhttp.InternalError(common.BcsErrCommRequestDataErr, err.Error())
+		resp.Write([]byte(err.Error()))
+		return
+	}
+
+	data, _ := json.Marshal(param)
+	reply, err := s.postToScheduler(method, fmt.Sprintf("%s/v1/disruptionbudgets", s.GetHost()), data)
+	if err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	resp.Write([]byte(reply))
+}
+
+func (s *Scheduler) listDisruptionBudgetsHandler(req *restful.Request, resp *restful.Response) {
+	url := fmt.Sprintf("%s/v1/disruptionbudgets?namespace=%s", s.GetHost(), req.PathParameter("ns"))
+	s.proxyRequest(resp, "GET", url)
+}
+
+func (s *Scheduler) fetchDisruptionBudgetHandler(req *restful.Request, resp *restful.Response) {
+	url := fmt.Sprintf("%s/v1/disruptionbudgets/%s/%s",
+		s.GetHost(), req.PathParameter("ns"), req.PathParameter("name"))
+	s.proxyRequest(resp, "GET", url)
+}
+
+func (s *Scheduler) deleteDisruptionBudgetHandler(req *restful.Request, resp *restful.Response) {
+	url := fmt.Sprintf("%s/v1/disruptionbudgets/%s/%s",
+		s.GetHost(), req.PathParameter("ns"), req.PathParameter("name"))
+	s.proxyRequest(resp, "DELETE", url)
+}
+
+func (s *Scheduler) drainAgentHandler(req *restful.Request, resp *restful.Response) {
+	IP := req.PathParameter("IP")
+	blog.Infof("drain agent %s", IP)
+	url := fmt.Sprintf("%s/v1/agentsetting/%s/drain", s.GetHost(), IP)
+	reply, err := s.postToScheduler("POST", url, nil)
+	if err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	resp.Write([]byte(reply))
+}
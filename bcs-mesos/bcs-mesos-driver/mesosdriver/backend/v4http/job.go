@@ -0,0 +1,285 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package v4http
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common"
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	bhttp "github.com/Tencent/bk-bcs/bcs-common/common/http"
+	bcstype "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/common/util"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+
+	restful "github.com/emicklei/go-restful"
+)
+
+const (
+	defaultJobCompletions                = 1
+	defaultJobParallelism                = 1
+	defaultJobBackoffLimit               = 6
+	defaultCronJobSuccessfulHistoryLimit = 3
+	defaultCronJobFailedHistoryLimit     = 1
+)
+
+func (s *Scheduler) createJobHandler(req *restful.Request, resp *restful.Response) {
+	body, err := s.getRequestInfo(req)
+	if err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	//check whether job type
+	err = util.CheckKind(bcstype.BcsDataType_Job, body)
+	if err != nil {
+		blog.Error("fail to create job(%s). err(%s)", string(body), err.Error())
+		err = bhttp.InternalError(common.BcsErrCommRequestDataErr, err.Error())
+		resp.Write([]byte(err.Error()))
+		return
+	}
+
+	var param bcstype.BcsJob
+	if err = json.Unmarshal(body, &param); err != nil {
+		blog.Error("parse job failed. param(%s), err(%s)", string(body), err.Error())
+		err = bhttp.InternalError(common.BcsErrCommJsonDecode, common.BcsErrCommJsonDecodeStr)
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	if err = param.MetaIsValid(); err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+
+	job, err := s.newJobDefWithParam(param.ObjectMeta, &param.Spec, param.KillPolicy)
+	if err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	data, _ := json.Marshal(job)
+	reply, err := s.postToScheduler("POST", fmt.Sprintf("%s/v1/jobs", s.GetHost()), data)
+	if err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	resp.Write([]byte(reply))
+}
+
+func (s *Scheduler) createCronJobHandler(req *restful.Request, resp *restful.Response) {
+	s.saveCronJob(req, resp, "POST")
+}
+
+func (s *Scheduler) updateCronJobHandler(req *restful.Request, resp *restful.Response) {
+	s.saveCronJob(req, resp, "PUT")
+}
+
+func (s *Scheduler) saveCronJob(req *restful.Request, resp *restful.Response, method string) {
+	body, err := s.getRequestInfo(req)
+	if err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	//check whether cronjob type
+	err = util.CheckKind(bcstype.BcsDataType_CronJob, body)
+	if err != nil {
+		blog.Error("fail to save cronjob(%s). err(%s)", string(body), err.Error())
+		err = bhttp.InternalError(common.BcsErrCommRequestDataErr, err.Error())
+		resp.Write([]byte(err.Error()))
+		return
+	}
+
+	var param bcstype.BcsCronJob
+	if err = json.Unmarshal(body, &param); err != nil {
+		blog.Error("parse cronjob failed. param(%s), err(%s)", string(body), err.Error())
+		err = bhttp.InternalError(common.BcsErrCommJsonDecode, common.BcsErrCommJsonDecodeStr)
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	if err = param.MetaIsValid(); err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+
+	cronJob, err := s.newCronJobDefWithParam(&param)
+	if err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	data, _ := json.Marshal(cronJob)
+	reply, err := s.postToScheduler(method, fmt.Sprintf("%s/v1/cronjobs", s.GetHost()), data)
+	if err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	resp.Write([]byte(reply))
+}
+
+// postToScheduler send definition to bcs-mesos-scheduler
+func (s *Scheduler) postToScheduler(method, url string, data []byte) (string, error) {
+	if s.GetHost() == "" {
+		blog.Error("no scheduler is connected by driver")
+		err := bhttp.InternalError(common.BcsErrCommHttpDo, common.BcsErrCommHttpDoStr+"scheduler not exist")
+		return err.Error(), err
+	}
+
+	blog.Info("%s a request to url(%s), request:%s", method, url, string(data))
+	var reply []byte
+	var err error
+	if method == "PUT" {
+		reply, err = s.client.PUT(url, nil, data)
+	} else {
+		reply, err = s.client.POST(url, nil, data)
+	}
+	if err != nil {
+		blog.Error("%s request to url(%s) failed! err(%s)", method, url, err.Error())
+		err = bhttp.InternalError(common.BcsErrCommHttpDo, common.BcsErrCommHttpDoStr+err.Error())
+		return err.Error(), err
+	}
+	return string(reply), nil
+}
+
+// newJobDefWithParam build scheduler job from job spec, defaults are filled here
+func (s *Scheduler) newJobDefWithParam(meta bcstype.ObjectMeta, spec *bcstype.JobSpec,
+	killPolicy bcstype.KillPolicy) (*types.BcsJob, error) {
+	job := &types.BcsJob{
+		ObjectMeta: meta,
+		JobParameter: types.JobParameter{
+			Completions:           spec.Completions,
+			Parallelism:           spec.Parallelism,
+			BackoffLimit:          defaultJobBackoffLimit,
+			ActiveDeadlineSeconds: spec.ActiveDeadlineSeconds,
+		},
+	}
+	if job.Completions == 0 {
+		job.Completions = defaultJobCompletions
+	}
+	if job.Parallelism == 0 {
+		job.Parallelism = defaultJobParallelism
+	}
+	if spec.BackoffLimit != nil {
+		job.BackoffLimit = *spec.BackoffLimit
+	}
+	if spec.Template == nil {
+		return nil, bhttp.InternalError(common.BcsErrMesosDriverParameterErr,
+			common.BcsErrMesosDriverParameterErrStr+"job template is empty")
+	}
+
+	version := &types.Version{
+		Container: []*types.Container{},
+		Labels:    make(map[string]string),
+		Uris:      []string{},
+		Ip:        []string{},
+	}
+	version.ObjectMeta = meta
+	version.ID = meta.Name
+	version.RunAs = meta.NameSpace
+	version.KillPolicy = &killPolicy
+	//taskgroups of job run to completion and are never restarted
+	version.RestartPolicy = &bcstype.RestartPolicy{Policy: bcstype.RestartPolicy_NEVER}
+	for k, v := range meta.Labels {
+		version.Labels[k] = v
+	}
+	version.Kind = bcstype.BcsDataType_Job
+	version, err := s.setVersionWithPodSpec(version, spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	job.Version = version
+	return job, nil
+}
+
+// newCronJobDefWithParam build scheduler cronjob from cronjob spec
+func (s *Scheduler) newCronJobDefWithParam(param *bcstype.BcsCronJob) (*types.BcsCronJob, error) {
+	cronJob := &types.BcsCronJob{
+		ObjectMeta:                 param.ObjectMeta,
+		Schedule:                   param.Spec.Schedule,
+		ConcurrencyPolicy:          param.Spec.ConcurrencyPolicy,
+		Suspend:                    param.Spec.Suspend,
+		SuccessfulJobsHistoryLimit: defaultCronJobSuccessfulHistoryLimit,
+		FailedJobsHistoryLimit:     defaultCronJobFailedHistoryLimit,
+	}
+	if _, err := util.ParseCronSchedule(cronJob.Schedule); err != nil {
+		return nil, bhttp.InternalError(common.BcsErrMesosDriverParameterErr,
+			common.BcsErrMesosDriverParameterErrStr+err.Error())
+	}
+	if cronJob.ConcurrencyPolicy == "" {
+		cronJob.ConcurrencyPolicy = bcstype.ConcurrencyPolicy_ALLOW
+	}
+	if param.Spec.SuccessfulJobsHistoryLimit != nil {
+		cronJob.SuccessfulJobsHistoryLimit = *param.Spec.SuccessfulJobsHistoryLimit
+	}
+	if param.Spec.FailedJobsHistoryLimit != nil {
+		cronJob.FailedJobsHistoryLimit = *param.Spec.FailedJobsHistoryLimit
+	}
+
+	//jobs created by cronjob are named after the cronjob, scheduler fills name of each one
+	meta := param.Spec.JobTemplate.ObjectMeta
+	meta.Name = param.Name
+	meta.NameSpace = param.NameSpace
+	template, err := s.newJobDefWithParam(meta, &param.Spec.JobTemplate.Spec, param.Spec.JobTemplate.KillPolicy)
+	if err != nil {
+		return nil, err
+	}
+	cronJob.JobTemplate = template
+	return cronJob, nil
+}
+
+func (s *Scheduler) listJobsHandler(req *restful.Request, resp *restful.Response) {
+	ns := req.PathParameter("ns")
+	url := fmt.Sprintf("%s/v1/jobs?namespace=%s", s.GetHost(), ns)
+	s.proxyRequest(resp, "GET", url)
+}
+
+func (s *Scheduler) fetchJobHandler(req *restful.Request, resp *restful.Response) {
+	url := fmt.Sprintf("%s/v1/jobs/%s/%s", s.GetHost(), req.PathParameter("ns"), req.PathParameter("name"))
+	s.proxyRequest(resp, "GET", url)
+}
+
+func (s *Scheduler) deleteJobHandler(req *restful.Request, resp *restful.Response) {
+	url := fmt.Sprintf("%s/v1/jobs/%s/%s", s.GetHost(), req.PathParameter("ns"), req.PathParameter("name"))
+	s.proxyRequest(resp, "DELETE", url)
+}
+
+func (s *Scheduler) listCronJobsHandler(req *restful.Request, resp *restful.Response) {
+	ns := req.PathParameter("ns")
+	url := fmt.Sprintf("%s/v1/cronjobs?namespace=%s", s.GetHost(), ns)
+	s.proxyRequest(resp, "GET", url)
+}
+
+func (s *Scheduler) fetchCronJobHandler(req *restful.Request, resp *restful.Response) {
+	url := fmt.Sprintf("%s/v1/cronjobs/%s/%s", s.GetHost(), req.PathParameter("ns"), req.PathParameter("name"))
+	s.proxyRequest(resp, "GET", url)
+}
+
+func (s *Scheduler) deleteCronJobHandler(req *restful.Request, resp *restful.Response) {
+	url := fmt.Sprintf("%s/v1/cronjobs/%s/%s", s.GetHost(), req.PathParameter("ns"), req.PathParameter("name"))
+	s.proxyRequest(resp, "DELETE", url)
+}
+
+func (s *Scheduler) proxyRequest(resp *restful.Response, method, url string) {
+	var reply []byte
+	var err error
+	if method == "DELETE" {
+		reply, err = s.client.DELETE(url, nil, nil)
+	} else {
+		reply, err = s.client.GET(url, nil, nil)
+	}
+	if err != nil {
+		blog.Errorf("%s request to url (%s) failed, err (%s)", method, url, err.Error())
+		err = bhttp.InternalError(common.BcsErrCommHttpDo, common.BcsErrCommHttpDoStr+err.Error())
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	resp.Write([]byte(reply))
+}
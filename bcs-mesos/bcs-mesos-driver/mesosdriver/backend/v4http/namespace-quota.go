@@ -0,0 +1,76 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v4http
+
+import (
+	"fmt"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common"
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	bhttp "github.com/Tencent/bk-bcs/bcs-common/common/http"
+
+	restful "github.com/emicklei/go-restful"
+)
+
+func (s *Scheduler) listNamespaceQuotasHandler(req *restful.Request, resp *restful.Response) {
+	url := fmt.Sprintf("%s/v1/namespacequotas", s.GetHost())
+	reply, err := s.client.GET(url, nil, nil)
+	if err != nil {
+		blog.Errorf("list namespace quotas to url (%s) failed, err (%s)", url, err.Error())
+		err = bhttp.InternalError(common.BcsErrCommHttpDo, common.BcsErrCommHttpDoStr+err.Error())
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	resp.Write([]byte(reply))
+}
+
+func (s *Scheduler) fetchNamespaceQuotaHandler(req *restful.Request, resp *restful.Response) {
+	namespace := req.PathParameter("ns")
+	url := fmt.Sprintf("%s/v1/namespacequota/%s", s.GetHost(), namespace)
+	reply, err := s.client.GET(url, nil, nil)
+	if err != nil {
+		blog.Errorf("fetch namespace %s quota to url (%s) failed, err (%s)", namespace, url, err.Error())
+		err = bhttp.InternalError(common.BcsErrCommHttpDo, common.BcsErrCommHttpDoStr+err.Error())
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	resp.Write([]byte(reply))
+}
+
+func (s *Scheduler) saveNamespaceQuotaHandler(req *restful.Request, resp *restful.Response) {
+	namespace := req.PathParameter("ns")
+	body, _ := s.getRequestInfo(req)
+	url := fmt.Sprintf("%s/v1/namespacequota/%s", s.GetHost(), namespace)
+	blog.Infof("put url(%s) body(%s)", url, string(body))
+	reply, err := s.client.PUT(url, nil, body)
+	if err != nil {
+		blog.Errorf("save namespace %s quota to url (%s) failed, err (%s)", namespace, url, err.Error())
+		err = bhttp.InternalError(common.BcsErrCommHttpDo, common.BcsErrCommHttpDoStr+err.Error())
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	resp.Write([]byte(reply))
+}
+
+func (s *Scheduler) deleteNamespaceQuotaHandler(req *restful.Request, resp *restful.Response) {
+	namespace := req.PathParameter("ns")
+	url := fmt.Sprintf("%s/v1/namespacequota/%s", s.GetHost(), namespace)
+	reply, err := s.client.DELETE(url, nil, nil)
+	if err != nil {
+		blog.Errorf("delete namespace %s quota to url (%s) failed, err (%s)", namespace, url, err.Error())
+		err = bhttp.InternalError(common.BcsErrCommHttpDo, common.BcsErrCommHttpDoStr+err.Error())
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	resp.Write([]byte(reply))
+}
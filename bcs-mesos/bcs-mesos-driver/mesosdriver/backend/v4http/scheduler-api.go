@@ -16,6 +16,7 @@ package v4http
 import (
 	"io/ioutil"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/Tencent/bk-bcs/bcs-common/common"
@@ -168,11 +169,44 @@ func (s *Scheduler) initActions() {
 		httpserver.NewAction("DELETE", "/namespaces/{ns}/daemonset/{name}", nil, s.deleteDaemonsetHandler),
 		/*================= daemonset ====================*/
 
+		/*================= job ====================*/
+		httpserver.NewAction("POST", "/namespaces/{ns}/job", nil, s.createJobHandler),
+		httpserver.NewAction("GET", "/namespaces/{ns}/jobs", nil, s.listJobsHandler),
+		httpserver.NewAction("GET", "/namespaces/{ns}/job/{name}", nil, s.fetchJobHandler),
+		httpserver.NewAction("DELETE", "/namespaces/{ns}/job/{name}", nil, s.deleteJobHandler),
+		httpserver.NewAction("POST", "/namespaces/{ns}/cronjob", nil, s.createCronJobHandler),
+		httpserver.NewAction("PUT", "/namespaces/{ns}/cronjob", nil, s.updateCronJobHandler),
+		httpserver.NewAction("GET", "/namespaces/{ns}/cronjobs", nil, s.listCronJobsHandler),
+		httpserver.NewAction("GET", "/namespaces/{ns}/cronjob/{name}", nil, s.fetchCronJobHandler),
+		httpserver.NewAction("DELETE", "/namespaces/{ns}/cronjob/{name}", nil, s.deleteCronJobHandler),
+		/*================= job ====================*/
+
+		/*================= simulation ====================*/
+		httpserver.NewAction("POST", "/namespaces/{ns}/simulation/application", nil, s.simulateApplicationHandler),
+		httpserver.NewAction("POST", "/namespaces/{ns}/simulation/deployment", nil, s.simulateDeploymentHandler),
+		/*================= simulation ====================*/
+
+		/*================= disruption budget ====================*/
+		httpserver.NewAction("POST", "/namespaces/{ns}/disruptionbudget", nil, s.createDisruptionBudgetHandler),
+		httpserver.NewAction("PUT", "/namespaces/{ns}/disruptionbudget", nil, s.updateDisruptionBudgetHandler),
+		httpserver.NewAction("GET", "/namespaces/{ns}/disruptionbudgets", nil, s.listDisruptionBudgetsHandler),
+		httpserver.NewAction("GET", "/namespaces/{ns}/disruptionbudget/{name}", nil, s.fetchDisruptionBudgetHandler),
+		httpserver.NewAction("DELETE", "/namespaces/{ns}/disruptionbudget/{name}", nil, s.deleteDisruptionBudgetHandler),
+		httpserver.NewAction("POST", "/agentsetting/{IP}/drain", nil, s.drainAgentHandler),
+		/*================= disruption budget ====================*/
+
 		/*================= transaction ====================*/
 		httpserver.NewAction("GET", "/transactions/{ns}", nil, s.listTransactionHandler),
 		httpserver.NewAction("DELETE", "/transactions/{ns}/{name}", nil, s.deleteTransactionHandler),
 		/*================= transaction ====================*/
 
+		/*================= namespace quota ====================*/
+		httpserver.NewAction("GET", "/namespacequotas", nil, s.listNamespaceQuotasHandler),
+		httpserver.NewAction("GET", "/namespacequota/{ns}", nil, s.fetchNamespaceQuotaHandler),
+		httpserver.NewAction("PUT", "/namespacequota/{ns}", nil, s.saveNamespaceQuotaHandler),
+		httpserver.NewAction("DELETE", "/namespacequota/{ns}", nil, s.deleteNamespaceQuotaHandler),
+		/*================= namespace quota ====================*/
+
 		/*================= agentsetting ====================*/
 		//	httpserver.NewAction("POST","/agentsetting/{IP}/disable",nil,s.disableAgentHandler),
 		//	httpserver.NewAction("POST","/agentsetting/{IP}/enable",nil,s.enableAgentHandler),
@@ -702,7 +736,7 @@ func (s *Scheduler) CreateConfigMapHandler(req *restful.Request, resp *restful.R
 		return
 	}
 
-	reply, err := s.CreateConfigMap(body)
+	reply, err := s.CreateConfigMap(body, req.QueryParameter("dryRun"))
 	if err != nil {
 		blog.Error("fail to create configmap(%s). reply(%s), err(%s)", string(body), reply, err.Error())
 		resp.Write([]byte(err.Error()))
@@ -727,7 +761,7 @@ func (s *Scheduler) UpdateConfigMapHandler(req *restful.Request, resp *restful.R
 		return
 	}
 
-	reply, err := s.UpdateConfigMap(body)
+	reply, err := s.UpdateConfigMap(body, req.QueryParameter("dryRun"))
 	if err != nil {
 		blog.Error("fail to update configmap(%s). reply(%s), err(%s)", string(body), reply, err.Error())
 		resp.Write([]byte(err.Error()))
@@ -763,7 +797,7 @@ func (s *Scheduler) CreateSecretHandler(req *restful.Request, resp *restful.Resp
 		return
 	}
 
-	reply, err := s.CreateSecret(body)
+	reply, err := s.CreateSecret(body, req.QueryParameter("dryRun"))
 	if err != nil {
 		blog.Error("fail to create secret(%s). reply(%s), err(%s)", string(body), reply, err.Error())
 		resp.Write([]byte(err.Error()))
@@ -788,7 +822,7 @@ func (s *Scheduler) UpdateSecretHandler(req *restful.Request, resp *restful.Resp
 		return
 	}
 
-	reply, err := s.UpdateSecret(body)
+	reply, err := s.UpdateSecret(body, req.QueryParameter("dryRun"))
 	if err != nil {
 		blog.Error("fail to update secret(%s). reply(%s), err(%s)", string(body), reply, err.Error())
 		resp.Write([]byte(err.Error()))
@@ -824,7 +858,7 @@ func (s *Scheduler) CreateServiceHandler(req *restful.Request, resp *restful.Res
 		return
 	}
 
-	reply, err := s.CreateService(body)
+	reply, err := s.CreateService(body, req.QueryParameter("dryRun"))
 	if err != nil {
 		blog.Error("fail to create service(%s). reply(%s), err(%s)", string(body), reply, err.Error())
 		resp.Write([]byte(err.Error()))
@@ -849,7 +883,7 @@ func (s *Scheduler) UpdateServiceHandler(req *restful.Request, resp *restful.Res
 		return
 	}
 
-	reply, err := s.UpdateService(body)
+	reply, err := s.UpdateService(body, req.QueryParameter("dryRun"))
 	if err != nil {
 		blog.Error("fail to update service(%s). reply(%s), err(%s)", string(body), reply, err.Error())
 		resp.Write([]byte(err.Error()))
@@ -885,7 +919,7 @@ func (s *Scheduler) CreateApplicationHandler(req *restful.Request, resp *restful
 		return
 	}
 
-	reply, err := s.CreateApplication(body)
+	reply, err := s.CreateApplication(body, req.QueryParameter("dryRun"))
 	if err != nil {
 		blog.Error("fail to create application. reply(%s), err(%s)", reply, err.Error())
 		resp.Write([]byte(err.Error()))
@@ -910,7 +944,7 @@ func (s *Scheduler) CreateProcessHandler(req *restful.Request, resp *restful.Res
 		return
 	}
 
-	reply, err := s.CreateApplication(body)
+	reply, err := s.CreateApplication(body, req.QueryParameter("dryRun"))
 	if err != nil {
 		blog.Error("fail to create process. reply(%s), err(%s)", reply, err.Error())
 		resp.Write([]byte(err.Error()))
@@ -938,7 +972,7 @@ func (s *Scheduler) UpdateApplicationHandler(req *restful.Request, resp *restful
 	instances := req.QueryParameter("instances")
 	args := req.QueryParameter("args")
 
-	reply, err := s.UpdateApplication(body, instances, args)
+	reply, err := s.UpdateApplication(body, instances, args, req.QueryParameter("dryRun"))
 	if err != nil {
 		blog.Error("fail to update application for instances(%d). reply(%s), err(%s)", instances, reply, err.Error())
 		resp.Write([]byte(err.Error()))
@@ -966,7 +1000,7 @@ func (s *Scheduler) UpdateProcessHandler(req *restful.Request, resp *restful.Res
 	instances := req.QueryParameter("instances")
 	args := req.QueryParameter("args")
 
-	reply, err := s.UpdateApplication(body, instances, args)
+	reply, err := s.UpdateApplication(body, instances, args, req.QueryParameter("dryRun"))
 	if err != nil {
 		blog.Error("fail to update process for instances(%d). reply(%s), err(%s)", instances, reply, err.Error())
 		resp.Write([]byte(err.Error()))
@@ -1253,6 +1287,17 @@ func (s *Scheduler) FetchApplicationVersionHandler(req *restful.Request, resp *r
 	resp.Write([]byte(reply))
 }
 
+// withDryRun appends dryRun query to scheduler url, scheduler only checks the request without saving when it is true
+func withDryRun(url, dryRun string) string {
+	if dryRun == "" {
+		return url
+	}
+	if strings.Contains(url, "?") {
+		return url + "&dryRun=" + dryRun
+	}
+	return url + "?dryRun=" + dryRun
+}
+
 func (s *Scheduler) getRequestInfo(req *restful.Request) ([]byte, error) {
 	appid := req.PathParameter("appid")
 	blog.V(3).Infof("recv a request from app(%s), url(%s)", appid, req.Request.RequestURI)
@@ -1280,7 +1325,7 @@ func (s *Scheduler) createDeploymentHandler(req *restful.Request, resp *restful.
 		return
 	}
 
-	reply, err := s.CreateDeployment(body)
+	reply, err := s.CreateDeployment(body, req.QueryParameter("dryRun"))
 	if err != nil {
 		blog.Error("fail to create deployment. reply(%s), err(%s)", reply, err.Error())
 		resp.Write([]byte(err.Error()))
@@ -1306,7 +1351,7 @@ func (s *Scheduler) udpateDeploymentHandler(req *restful.Request, resp *restful.
 	}
 
 	args := req.QueryParameter("args")
-	reply, err := s.UpdateDeployment(body, args)
+	reply, err := s.UpdateDeployment(body, args, req.QueryParameter("dryRun"))
 	if err != nil {
 		blog.Error("fail to create deployment. reply(%s), err(%s)", reply, err.Error())
 		resp.Write([]byte(err.Error()))
@@ -22,7 +22,7 @@ import (
 	//"encoding/json"
 )
 
-func (s *Scheduler) CreateSecret(body []byte) (string, error) {
+func (s *Scheduler) CreateSecret(body []byte, dryRun string) (string, error) {
 
 	blog.Info("create secret data(%s)", string(body))
 
@@ -33,6 +33,7 @@ func (s *Scheduler) CreateSecret(body []byte) (string, error) {
 	}
 
 	url := s.GetHost() + "/v1/secret"
+	url = withDryRun(url, dryRun)
 	blog.Info("post a request to url(%s), request:%s", url, string(body))
 
 	reply, err := s.client.POST(url, nil, body)
@@ -45,7 +46,7 @@ func (s *Scheduler) CreateSecret(body []byte) (string, error) {
 	return string(reply), nil
 }
 
-func (s *Scheduler) UpdateSecret(body []byte) (string, error) {
+func (s *Scheduler) UpdateSecret(body []byte, dryRun string) (string, error) {
 
 	blog.Info("update secret data(%s)", string(body))
 
@@ -56,6 +57,7 @@ func (s *Scheduler) UpdateSecret(body []byte) (string, error) {
 	}
 
 	url := s.GetHost() + "/v1/secret"
+	url = withDryRun(url, dryRun)
 	blog.Info("put a request to url(%s), request:%s", url, string(body))
 
 	reply, err := s.client.PUT(url, nil, body)
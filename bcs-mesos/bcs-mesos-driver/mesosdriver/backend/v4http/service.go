@@ -23,7 +23,7 @@ import (
 )
 
 //CreateService create service request forwarding
-func (s *Scheduler) CreateService(body []byte) (string, error) {
+func (s *Scheduler) CreateService(body []byte, dryRun string) (string, error) {
 
 	blog.Info("create service data(%s)", string(body))
 
@@ -34,6 +34,7 @@ func (s *Scheduler) CreateService(body []byte) (string, error) {
 	}
 
 	url := s.GetHost() + "/v1/service"
+	url = withDryRun(url, dryRun)
 	blog.Info("post a request to url(%s), request:%s", url, string(body))
 
 	reply, err := s.client.POST(url, nil, body)
@@ -47,7 +48,7 @@ func (s *Scheduler) CreateService(body []byte) (string, error) {
 }
 
 //UpdateService update service request forwarding
-func (s *Scheduler) UpdateService(body []byte) (string, error) {
+func (s *Scheduler) UpdateService(body []byte, dryRun string) (string, error) {
 
 	blog.Info("update service data(%s)", string(body))
 
@@ -58,6 +59,7 @@ func (s *Scheduler) UpdateService(body []byte) (string, error) {
 	}
 
 	url := s.GetHost() + "/v1/service"
+	url = withDryRun(url, dryRun)
 	blog.Info("put a request to url(%s), request:%s", url, string(body))
 
 	reply, err := s.client.PUT(url, nil, body)
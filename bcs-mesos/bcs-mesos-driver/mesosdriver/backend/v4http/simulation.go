@@ -0,0 +1,113 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package v4http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common"
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	bhttp "github.com/Tencent/bk-bcs/bcs-common/common/http"
+	bcstype "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/common/util"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+
+	restful "github.com/emicklei/go-restful"
+)
+
+// simulateApplicationHandler simulate scheduling taskgroups of application without launching,
+// query parameter replicas is the number of taskgroups, instance of application by default
+func (s *Scheduler) simulateApplicationHandler(req *restful.Request, resp *restful.Response) {
+	body, err := s.getRequestInfo(req)
+	if err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	err = util.CheckKind(bcstype.BcsDataType_APP, body)
+	if err != nil {
+		blog.Error("fail to simulate application(%s). err(%s)", string(body), err.Error())
+		err = bhttp.InternalError(common.BcsErrCommRequestDataErr, err.Error())
+		resp.Write([]byte(err.Error()))
+		return
+	}
+
+	var param bcstype.ReplicaController
+	if err = json.Unmarshal(body, &param); err != nil {
+		blog.Error("parse application failed. param(%s), err(%s)", string(body), err.Error())
+		err = bhttp.InternalError(common.BcsErrCommJsonDecode, common.BcsErrCommJsonDecodeStr)
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	version, err := s.newVersionWithParam(&param)
+	if err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	resp.Write([]byte(s.simulate(version, req.QueryParameter("replicas"))))
+}
+
+// simulateDeploymentHandler simulate scheduling taskgroups of deployment without launching,
+// query parameter replicas is the number of taskgroups, instance of deployment by default
+func (s *Scheduler) simulateDeploymentHandler(req *restful.Request, resp *restful.Response) {
+	body, err := s.getRequestInfo(req)
+	if err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	err = util.CheckKind(bcstype.BcsDataType_DEPLOYMENT, body)
+	if err != nil {
+		blog.Error("fail to simulate deployment(%s). err(%s)", string(body), err.Error())
+		err = bhttp.InternalError(common.BcsErrCommRequestDataErr, err.Error())
+		resp.Write([]byte(err.Error()))
+		return
+	}
+
+	var param bcstype.BcsDeployment
+	if err = json.Unmarshal(body, &param); err != nil {
+		blog.Error("parse deployment failed. param(%s), err(%s)", string(body), err.Error())
+		err = bhttp.InternalError(common.BcsErrCommJsonDecode, common.BcsErrCommJsonDecodeStr)
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	deploymentDef, err := s.newDeploymentDefWithParam(&param)
+	if err != nil {
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	if deploymentDef.Version == nil {
+		err = bhttp.InternalError(common.BcsErrMesosDriverParameterErr,
+			common.BcsErrMesosDriverParameterErrStr+"deployment template is empty")
+		resp.Write([]byte(err.Error()))
+		return
+	}
+	resp.Write([]byte(s.simulate(deploymentDef.Version, req.QueryParameter("replicas"))))
+}
+
+// simulate post version to bcs-mesos-scheduler for scheduling simulation, returns the reply
+func (s *Scheduler) simulate(version *types.Version, replicas string) string {
+	data, err := json.Marshal(version)
+	if err != nil {
+		blog.Error("marshal parameter version by json failed. err:%s", err.Error())
+		err = bhttp.InternalError(common.BcsErrCommJsonEncode, common.BcsErrCommJsonEncodeStr+"encode version by json")
+		return err.Error()
+	}
+	reqURL := fmt.Sprintf("%s/v1/simulation", s.GetHost())
+	if replicas != "" {
+		reqURL = reqURL + "?replicas=" + url.QueryEscape(replicas)
+	}
+	reply, _ := s.postToScheduler("POST", reqURL, data)
+	return reply
+}
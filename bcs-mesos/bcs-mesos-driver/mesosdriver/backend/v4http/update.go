@@ -26,7 +26,7 @@ import (
 )
 
 //UpdateApplication update application forwarding
-func (s *Scheduler) UpdateApplication(body []byte, instances, args string) (string, error) {
+func (s *Scheduler) UpdateApplication(body []byte, instances, args, dryRun string) (string, error) {
 	blog.Info("update application. param(%s), instances(%s), args(%s)", string(body), instances, args)
 	var param bcstype.ReplicaController
 	//encoding param by json
@@ -59,6 +59,7 @@ func (s *Scheduler) UpdateApplication(body []byte, instances, args string) (stri
 	}
 
 	url := s.GetHost() + "/v1/apps/" + version.RunAs + "/" + version.ID + "/" + "update?instances=" + instances + "&args=" + args
+	url = withDryRun(url, dryRun)
 	blog.Info("post a request to url(%s), request:%s", url, string(data))
 
 	//reply, err := bhttp.Request(url, "POST", nil, strings.NewReader(string(data)))
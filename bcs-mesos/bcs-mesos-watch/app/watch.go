@@ -34,6 +34,7 @@ import (
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/cluster/etcd"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/cluster/mesos"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/service"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/sink"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/storage"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/types"
 
@@ -112,6 +113,13 @@ func Run(cfg *types.CmdConfig) error {
 		return ccErr
 	}
 	ccStorage.SetDCAddress(cfg.StorageAddresses)
+	//dispatch sync data to sinks besides bcs-storage
+	sinkStorage, sinkErr := sink.NewSinkStorage(cfg, ccStorage)
+	if sinkErr != nil {
+		blog.Error("Create SinkStorage Err: %s", sinkErr.Error())
+		return sinkErr
+	}
+	ccStorage = sinkStorage
 	//servermetric.SetDCStatus(false)
 	clusterState.Set(stateErr)
 	ccCxt, _ := context.WithCancel(rootCxt)
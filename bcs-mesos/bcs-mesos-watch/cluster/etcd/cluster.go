@@ -94,6 +94,8 @@ type EtcdCluster struct {
 	secret            *SecretWatch
 	service           *ServiceWatch
 	deployment        *DeploymentWatch
+	job               *JobWatch
+	cronJob           *CronJobWatch
 	endpoint          *EndpointWatch
 	netServiceWatcher *NetServiceWatcher
 	stopCh            chan struct{}
@@ -138,6 +140,9 @@ func (ms *EtcdCluster) registerReportHandler() error {
 		ms.reportCallback[deploymentChannel] = ms.reportDeployment
 	}
 
+	ms.reportCallback["Job"] = ms.reportJob
+	ms.reportCallback["CronJob"] = ms.reportCronJob
+
 	ms.reportCallback["Endpoint"] = ms.reportEndpoint
 
 	// report ip pool static resource data callback.
@@ -181,6 +186,14 @@ func (ms *EtcdCluster) createDatTypeWatch() error {
 	ms.deployment = NewDeploymentWatch(deploymentCxt, ms.factory.Bkbcs().V2().Deployments(), ms)
 	go ms.deployment.Work()
 
+	jobCxt, _ := context.WithCancel(ms.connCxt)
+	ms.job = NewJobWatch(jobCxt, ms.factory.Bkbcs().V2().BcsJobs(), ms)
+	go ms.job.Work()
+
+	cronJobCxt, _ := context.WithCancel(ms.connCxt)
+	ms.cronJob = NewCronJobWatch(cronJobCxt, ms.factory.Bkbcs().V2().BcsCronJobs(), ms)
+	go ms.cronJob.Work()
+
 	endpointCxt, _ := context.WithCancel(ms.connCxt)
 	ms.endpoint = NewEndpointWatch(endpointCxt, ms.factory.Bkbcs().V2().BcsEndpoints(), ms)
 	go ms.endpoint.Work()
@@ -219,6 +232,8 @@ func (ms *EtcdCluster) initialize() error {
 	ms.factory.Bkbcs().V2().BcsServices().Informer()
 	ms.factory.Bkbcs().V2().TaskGroups().Informer()
 	ms.factory.Bkbcs().V2().BcsEndpoints().Informer()
+	ms.factory.Bkbcs().V2().BcsJobs().Informer()
+	ms.factory.Bkbcs().V2().BcsCronJobs().Informer()
 
 	blog.Infof("EtcdCluster SharedInformerFactory start...")
 	ms.factory.Start(ms.stopCh)
@@ -292,6 +307,30 @@ func (ms *EtcdCluster) reportDeployment(data *types.BcsSyncData) error {
 	return nil
 }
 
+func (ms *EtcdCluster) reportJob(data *types.BcsSyncData) error {
+	dataType := data.Item.(*schedtypes.BcsJob)
+	blog.V(3).Infof("mesos cluster report job(%s.%s) for action(%s)",
+		dataType.ObjectMeta.NameSpace, dataType.ObjectMeta.Name, data.Action)
+	if err := ms.storage.SyncTimeout(data, SyncDefaultTimeOut); err != nil {
+		blog.Error("job(%s.%s) sync(%s) dispatch failed: %+v",
+			dataType.ObjectMeta.NameSpace, dataType.ObjectMeta.Name, data.Action, err)
+		return err
+	}
+	return nil
+}
+
+func (ms *EtcdCluster) reportCronJob(data *types.BcsSyncData) error {
+	dataType := data.Item.(*schedtypes.BcsCronJob)
+	blog.V(3).Infof("mesos cluster report cronjob(%s.%s) for action(%s)",
+		dataType.ObjectMeta.NameSpace, dataType.ObjectMeta.Name, data.Action)
+	if err := ms.storage.SyncTimeout(data, SyncDefaultTimeOut); err != nil {
+		blog.Error("cronjob(%s.%s) sync(%s) dispatch failed: %+v",
+			dataType.ObjectMeta.NameSpace, dataType.ObjectMeta.Name, data.Action, err)
+		return err
+	}
+	return nil
+}
+
 func (ms *EtcdCluster) reportSecret(data *types.BcsSyncData) error {
 	dataType := data.Item.(*commtypes.BcsSecret)
 	blog.V(3).Infof("mesos cluster report secret(%s.%s) for action(%s)",
@@ -0,0 +1,245 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package etcd
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/cache"
+	schedulertypes "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/cluster"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/types"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/util"
+	bkbcsv2 "github.com/Tencent/bk-bcs/bcs-mesos/kubebkbcsv2/client/informers/externalversions/bkbcs/v2"
+
+	"golang.org/x/net/context"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+//CronJobInfo wrapper for BCS CronJob
+type CronJobInfo struct {
+	data       *schedulertypes.BcsCronJob
+	syncTime   int64
+	reportTime int64
+}
+
+//NewCronJobWatch create cronjob watch
+func NewCronJobWatch(cxt context.Context, informer bkbcsv2.BcsCronJobInformer, reporter cluster.Reporter) *CronJobWatch {
+
+	keyFunc := func(data interface{}) (string, error) {
+		dataType, ok := data.(*CronJobInfo)
+		if !ok {
+			return "", fmt.Errorf("SchedulerMeta type Assert failed")
+		}
+		return dataType.data.ObjectMeta.NameSpace + "." + dataType.data.ObjectMeta.Name, nil
+	}
+
+	return &CronJobWatch{
+		report:    reporter,
+		cancelCxt: cxt,
+		informer:  informer,
+		dataCache: cache.NewCache(keyFunc),
+		//nsCache:   cache.NewCache(nsKeyFunc),
+	}
+}
+
+//CronJobWatch watch all cronjob data and store to local cache
+type CronJobWatch struct {
+	eventLock sync.Mutex       //lock for event
+	report    cluster.Reporter //reporter
+	cancelCxt context.Context  //context for cancel
+	dataCache cache.Store      //cache for all cronjob data
+	//nsCache   cache.Store     //all namespace path / namespace goroutine control info
+	watchPath string
+	informer  bkbcsv2.BcsCronJobInformer
+}
+
+//Work to add path and node watch
+func (watch *CronJobWatch) Work() {
+	blog.Infof("CronJobWatch start work")
+
+	watch.ProcessAllCronJobs()
+	tick := time.NewTicker(10 * time.Second)
+	defer tick.Stop()
+	for {
+		select {
+		case <-watch.cancelCxt.Done():
+			blog.Infof("CronJobWatch asked to exit")
+			return
+		case <-tick.C:
+			blog.V(3).Infof("CronJobWatch is running")
+			watch.ProcessAllCronJobs()
+		}
+	}
+}
+
+//ProcessAllCronJobs handle all namespace cronjob data
+func (watch *CronJobWatch) ProcessAllCronJobs() error {
+	currTime := time.Now().Unix()
+	blog.V(3).Infof("sync all cronjobs, currTime(%d)", currTime)
+
+	v2Objs, err := watch.informer.Lister().List(labels.Everything())
+	if err != nil {
+		blog.Errorf("list cronjobs error %s", err.Error())
+		return err
+	}
+
+	var numNode, numDel int
+	for _, obj := range v2Objs {
+		numNode++
+		cronjob := &obj.Spec.BcsCronJob
+		key := cronjob.ObjectMeta.NameSpace + "." + cronjob.ObjectMeta.Name
+		cacheData, exist, err := watch.dataCache.GetByKey(key)
+		if err != nil {
+			blog.Error("get cronjob %s from cache return err:%s", key, err.Error())
+			continue
+		}
+		if exist == true {
+			cacheDataInfo, ok := cacheData.(*CronJobInfo)
+			if !ok {
+				blog.Error("convert cachedata to CronJobInfo fail, key(%s)", key)
+				continue
+			}
+			blog.V(3).Infof("cronjob %s is in cache, update sync time(%d)", key, currTime)
+			//watch.UpdateEvent(cacheDataInfo.data, data)
+			if reflect.DeepEqual(cacheDataInfo.data, cronjob) {
+				if cacheDataInfo.reportTime > currTime {
+					cacheDataInfo.reportTime = currTime
+				}
+				if currTime-cacheDataInfo.reportTime > 360 {
+					blog.Info("cronjob %s data not changed, but long time not report, do report", key)
+					watch.UpdateEvent(cacheDataInfo.data, cronjob)
+					cacheDataInfo.reportTime = currTime
+				}
+			} else {
+				blog.Info("cronjob %s data changed, do report", key)
+				watch.UpdateEvent(cacheDataInfo.data, cronjob)
+				cacheDataInfo.reportTime = currTime
+			}
+
+			cacheDataInfo.syncTime = currTime
+			cacheDataInfo.data = cronjob
+		} else {
+			blog.Info("cronjob %s is not in cache, add, time(%d)", key, currTime)
+			watch.AddEvent(cronjob)
+			dataInfo := new(CronJobInfo)
+			dataInfo.data = cronjob
+			dataInfo.syncTime = currTime
+			dataInfo.reportTime = currTime
+			watch.dataCache.Add(dataInfo)
+		}
+	}
+
+	// check cache, create delete events
+	keyList := watch.dataCache.ListKeys()
+	for _, key := range keyList {
+		blog.V(3).Infof("to check cache cronjob %s", key)
+		cacheData, exist, err := watch.dataCache.GetByKey(key)
+		if err != nil {
+			blog.Error("cronjob %s in cache keylist, but get return err:%s", err.Error())
+			continue
+		}
+		if exist == false {
+			blog.Error("cronjob %s in cache keylist, but get return not exist", key)
+			continue
+		}
+		cacheDataInfo, ok := cacheData.(*CronJobInfo)
+		if !ok {
+			blog.Error("convert cachedata to CronJobInfo fail, key(%s)", key)
+			continue
+		}
+
+		if cacheDataInfo.syncTime != currTime {
+			numDel++
+			blog.Info("cronjob %s is in cache, but syncTime(%d) != currTime(%d), to delete ",
+				key, cacheDataInfo.syncTime, currTime)
+			watch.DeleteEvent(cacheDataInfo.data)
+			watch.dataCache.Delete(cacheDataInfo)
+		}
+	}
+
+	blog.Info("sync %d cronjobs from etcd, delete %d cache cronjobs", numNode, numDel)
+
+	return nil
+}
+
+//AddEvent call when data added
+func (watch *CronJobWatch) AddEvent(obj interface{}) {
+	cronJobData, ok := obj.(*schedulertypes.BcsCronJob)
+	if !ok {
+		blog.Error("can not convert object to CronJob in AddEvent, object %v", obj)
+		return
+	}
+	blog.Info("EVENT:: Add Event for CronJob %s.%s", cronJobData.ObjectMeta.NameSpace, cronJobData.ObjectMeta.Name)
+
+	data := &types.BcsSyncData{
+		DataType: "CronJob",
+		Action:   "Add",
+		Item:     obj,
+	}
+	if err := watch.report.ReportData(data); err != nil {
+		util.ReportSyncTotal(watch.report.GetClusterID(), cluster.DataTypeCronJob, types.ActionAdd, cluster.SyncFailure)
+	} else {
+		util.ReportSyncTotal(watch.report.GetClusterID(), cluster.DataTypeCronJob, types.ActionAdd, cluster.SyncSuccess)
+	}
+}
+
+//DeleteEvent when delete
+func (watch *CronJobWatch) DeleteEvent(obj interface{}) {
+	cronJobData, ok := obj.(*schedulertypes.BcsCronJob)
+	if !ok {
+		blog.Error("can not convert object to CronJob in DeleteEvent, object %v", obj)
+		return
+	}
+	blog.Info("EVENT:: Delete Event for CronJob %s.%s", cronJobData.ObjectMeta.NameSpace, cronJobData.ObjectMeta.Name)
+	//report to cluster
+	data := &types.BcsSyncData{
+		DataType: "CronJob",
+		Action:   "Delete",
+		Item:     obj,
+	}
+	if err := watch.report.ReportData(data); err != nil {
+		util.ReportSyncTotal(watch.report.GetClusterID(), cluster.DataTypeCronJob, types.ActionDelete, cluster.SyncFailure)
+	} else {
+		util.ReportSyncTotal(watch.report.GetClusterID(), cluster.DataTypeCronJob, types.ActionDelete, cluster.SyncSuccess)
+	}
+}
+
+//UpdateEvent when update
+func (watch *CronJobWatch) UpdateEvent(old, cur interface{}) {
+	cronJobData, ok := cur.(*schedulertypes.BcsCronJob)
+	if !ok {
+		blog.Error("can not convert object to CronJob in UpdateEvent, object %v", cur)
+		return
+	}
+
+	blog.V(3).Infof("EVENT:: Update Event for CronJob %s.%s", cronJobData.ObjectMeta.NameSpace, cronJobData.ObjectMeta.Name)
+
+	//report to cluster
+	data := &types.BcsSyncData{
+		DataType: "CronJob",
+		Action:   "Update",
+		Item:     cur,
+	}
+	if err := watch.report.ReportData(data); err != nil {
+		util.ReportSyncTotal(watch.report.GetClusterID(), cluster.DataTypeCronJob, types.ActionUpdate, cluster.SyncFailure)
+	} else {
+		util.ReportSyncTotal(watch.report.GetClusterID(), cluster.DataTypeCronJob, types.ActionUpdate, cluster.SyncSuccess)
+	}
+}
+
@@ -0,0 +1,272 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package mesos
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/cache"
+	schedulertypes "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/cluster"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/types"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/util"
+)
+
+//JobInfo wrapper for BCS Job
+type JobInfo struct {
+	data       *schedulertypes.BcsJob
+	syncTime   int64
+	reportTime int64
+}
+
+//NewJobWatch create job watch
+func NewJobWatch(cxt context.Context, client ZkClient, reporter cluster.Reporter, watchPath string) *JobWatch {
+
+	keyFunc := func(data interface{}) (string, error) {
+		dataType, ok := data.(*JobInfo)
+		if !ok {
+			return "", fmt.Errorf("SchedulerMeta type Assert failed")
+		}
+		return dataType.data.ObjectMeta.NameSpace + "." + dataType.data.ObjectMeta.Name, nil
+	}
+
+	return &JobWatch{
+		report:    reporter,
+		cancelCxt: cxt,
+		client:    client,
+		watchPath: watchPath,
+		dataCache: cache.NewCache(keyFunc),
+		//nsCache:   cache.NewCache(nsKeyFunc),
+	}
+}
+
+//JobWatch watch all job data and store to local cache
+type JobWatch struct {
+	eventLock sync.Mutex       //lock for event
+	report    cluster.Reporter //reporter
+	cancelCxt context.Context  //context for cancel
+	client    ZkClient         //client for zookeeper
+	dataCache cache.Store      //cache for all job data
+	//nsCache   cache.Store     //all namespace path / namespace goroutine control info
+	watchPath string
+}
+
+//Work to add path and node watch
+func (watch *JobWatch) Work() {
+	watch.ProcessAllJobs()
+	tick := time.NewTicker(10 * time.Second)
+	defer tick.Stop()
+	for {
+		select {
+		case <-watch.cancelCxt.Done():
+			blog.V(3).Infof("JobWatch asked to exit")
+			return
+		case <-tick.C:
+			blog.V(3).Infof("JobWatch is running")
+			watch.ProcessAllJobs()
+		}
+	}
+}
+
+//ProcessAllJobs handle all namespace job data
+func (watch *JobWatch) ProcessAllJobs() error {
+
+	currTime := time.Now().Unix()
+	basePath := watch.watchPath + "/job"
+	blog.V(3).Infof("sync all jobs under(%s), currTime(%d)", basePath, currTime)
+
+	nmList, _, err := watch.client.GetChildrenEx(basePath)
+	if err != nil {
+		blog.Error("get path(%s) children err: %s", basePath, err.Error())
+		return err
+	}
+	if len(nmList) == 0 {
+		blog.V(3).Infof("get empty namespace list under path(%s)", basePath)
+		return nil
+	}
+
+	// sync all secrets from zk and update cache, create add and update events
+	numZk := 0
+	numDel := 0
+	for _, nmNode := range nmList {
+		blog.V(3).Infof("get namespace node(%s) under path(%s)", nmNode, basePath)
+		nmPath := basePath + "/" + nmNode
+		nodeList, _, err := watch.client.GetChildrenEx(nmPath)
+		if err != nil {
+			blog.Error("get children nodes under %s err: %s", nmPath, err.Error())
+			continue
+		}
+		for _, oneNode := range nodeList {
+			numZk++
+			blog.V(3).Infof("get node(%s) under path(%s)", oneNode, nmPath)
+			nodePath := nmPath + "/" + oneNode
+			byteData, _, err := watch.client.GetEx(nodePath)
+			if err != nil {
+				blog.Error("Get %s data err: %s", nodePath, err.Error())
+				continue
+			}
+			data := new(schedulertypes.BcsJob)
+			if jsonErr := json.Unmarshal(byteData, data); jsonErr != nil {
+				blog.Error("Parse %s json data(%s) Err: %s", nodePath, string(byteData), jsonErr.Error())
+				continue
+			}
+
+			key := data.ObjectMeta.NameSpace + "." + data.ObjectMeta.Name
+			cacheData, exist, err := watch.dataCache.GetByKey(key)
+			if err != nil {
+				blog.Error("get job %s from cache return err:%s", key, err.Error())
+				continue
+			}
+			if exist == true {
+				cacheDataInfo, ok := cacheData.(*JobInfo)
+				if !ok {
+					blog.Error("convert cachedata to JobInfo fail, key(%s)", key)
+					continue
+				}
+				blog.V(3).Infof("job %s is in cache, update sync time(%d)", key, currTime)
+				//watch.UpdateEvent(cacheDataInfo.data, data)
+				if reflect.DeepEqual(cacheDataInfo.data, data) {
+					if cacheDataInfo.reportTime > currTime {
+						cacheDataInfo.reportTime = currTime
+					}
+					if currTime-cacheDataInfo.reportTime > 180 {
+						blog.Info("job %s data not changed, but long time not report, do report", key)
+						watch.UpdateEvent(cacheDataInfo.data, data)
+						cacheDataInfo.reportTime = currTime
+					}
+				} else {
+					blog.Info("job %s data changed, do report", key)
+					watch.UpdateEvent(cacheDataInfo.data, data)
+					cacheDataInfo.reportTime = currTime
+				}
+
+				cacheDataInfo.syncTime = currTime
+				cacheDataInfo.data = data
+			} else {
+				blog.Info("job %s is not in cache, add, time(%d)", key, currTime)
+				watch.AddEvent(data)
+				dataInfo := new(JobInfo)
+				dataInfo.data = data
+				dataInfo.syncTime = currTime
+				dataInfo.reportTime = currTime
+				watch.dataCache.Add(dataInfo)
+			}
+		}
+	}
+
+	// check cache, create delete events
+	keyList := watch.dataCache.ListKeys()
+	for _, key := range keyList {
+		blog.V(3).Infof("to check cache job %s", key)
+		cacheData, exist, err := watch.dataCache.GetByKey(key)
+		if err != nil {
+			blog.Error("job %s in cache keylist, but get return err:%s", err.Error())
+			continue
+		}
+		if exist == false {
+			blog.Error("job %s in cache keylist, but get return not exist", key)
+			continue
+		}
+		cacheDataInfo, ok := cacheData.(*JobInfo)
+		if !ok {
+			blog.Error("convert cachedata to JobInfo fail, key(%s)", key)
+			continue
+		}
+
+		if cacheDataInfo.syncTime != currTime {
+			numDel++
+			blog.Info("job %s is in cache, but syncTime(%d) != currTime(%d), to delete ",
+				key, cacheDataInfo.syncTime, currTime)
+			watch.DeleteEvent(cacheDataInfo.data)
+			watch.dataCache.Delete(cacheDataInfo)
+		}
+	}
+
+	blog.Info("sync %d jobs from zk, delete %d cache jobs", numZk, numDel)
+
+	return nil
+}
+
+//AddEvent call when data added
+func (watch *JobWatch) AddEvent(obj interface{}) {
+	jobData, ok := obj.(*schedulertypes.BcsJob)
+	if !ok {
+		blog.Error("can not convert object to Job in AddEvent, object %v", obj)
+		return
+	}
+	blog.Info("EVENT:: Add Event for Job %s.%s", jobData.ObjectMeta.NameSpace, jobData.ObjectMeta.Name)
+
+	data := &types.BcsSyncData{
+		DataType: "Job",
+		Action:   "Add",
+		Item:     obj,
+	}
+	if err := watch.report.ReportData(data); err != nil {
+		util.ReportSyncTotal(watch.report.GetClusterID(), cluster.DataTypeJob, types.ActionAdd, cluster.SyncFailure)
+	} else {
+		util.ReportSyncTotal(watch.report.GetClusterID(), cluster.DataTypeJob, types.ActionAdd, cluster.SyncSuccess)
+	}
+}
+
+//DeleteEvent when delete
+func (watch *JobWatch) DeleteEvent(obj interface{}) {
+	jobData, ok := obj.(*schedulertypes.BcsJob)
+	if !ok {
+		blog.Error("can not convert object to Job in DeleteEvent, object %v", obj)
+		return
+	}
+	blog.Info("EVENT:: Delete Event for Job %s.%s", jobData.ObjectMeta.NameSpace, jobData.ObjectMeta.Name)
+	//report to cluster
+	data := &types.BcsSyncData{
+		DataType: "Job",
+		Action:   "Delete",
+		Item:     obj,
+	}
+	if err := watch.report.ReportData(data); err != nil {
+		util.ReportSyncTotal(watch.report.GetClusterID(), cluster.DataTypeJob, types.ActionDelete, cluster.SyncFailure)
+	} else {
+		util.ReportSyncTotal(watch.report.GetClusterID(), cluster.DataTypeJob, types.ActionDelete, cluster.SyncSuccess)
+	}
+}
+
+//UpdateEvent when update
+func (watch *JobWatch) UpdateEvent(old, cur interface{}) {
+	jobData, ok := cur.(*schedulertypes.BcsJob)
+	if !ok {
+		blog.Error("can not convert object to Job in UpdateEvent, object %v", cur)
+		return
+	}
+
+	blog.V(3).Infof("EVENT:: Update Event for Job %s.%s", jobData.ObjectMeta.NameSpace, jobData.ObjectMeta.Name)
+
+	//report to cluster
+	data := &types.BcsSyncData{
+		DataType: "Job",
+		Action:   "Update",
+		Item:     cur,
+	}
+	if err := watch.report.ReportData(data); err != nil {
+		util.ReportSyncTotal(watch.report.GetClusterID(), cluster.DataTypeJob, types.ActionUpdate, cluster.SyncFailure)
+	} else {
+		util.ReportSyncTotal(watch.report.GetClusterID(), cluster.DataTypeJob, types.ActionUpdate, cluster.SyncSuccess)
+	}
+}
+
@@ -124,6 +124,8 @@ type MesosCluster struct {
 	secret            *SecretWatch
 	service           *ServiceWatch
 	deployment        *DeploymentWatch
+	job               *JobWatch
+	cronJob           *CronJobWatch
 	endpoint          *EndpointWatch
 	netServiceWatcher *clusteretcd.NetServiceWatcher
 	stopCh            chan struct{}
@@ -203,6 +205,9 @@ func (ms *MesosCluster) registerReportHandler() error {
 		ms.reportCallback[deploymentChannel] = ms.reportDeployment
 	}
 
+	ms.reportCallback["Job"] = ms.reportJob
+	ms.reportCallback["CronJob"] = ms.reportCronJob
+
 	ms.reportCallback["Endpoint"] = ms.reportEndpoint
 
 	// report ip pool static resource data callback.
@@ -252,6 +257,14 @@ func (ms *MesosCluster) createDatTypeWatch() error {
 	ms.deployment = NewDeploymentWatch(deploymentCxt, ms.client, ms, ms.watchPath)
 	go ms.deployment.Work()
 
+	jobCxt, _ := context.WithCancel(ms.connCxt)
+	ms.job = NewJobWatch(jobCxt, ms.client, ms, ms.watchPath)
+	go ms.job.Work()
+
+	cronJobCxt, _ := context.WithCancel(ms.connCxt)
+	ms.cronJob = NewCronJobWatch(cronJobCxt, ms.client, ms, ms.watchPath)
+	go ms.cronJob.Work()
+
 	endpointCxt, _ := context.WithCancel(ms.connCxt)
 	ms.endpoint = NewEndpointWatch(endpointCxt, ms.client, ms, ms.watchPath)
 	go ms.endpoint.Work()
@@ -369,6 +382,30 @@ func (ms *MesosCluster) reportDeployment(data *types.BcsSyncData) error {
 	return nil
 }
 
+func (ms *MesosCluster) reportJob(data *types.BcsSyncData) error {
+	dataType := data.Item.(*schedtypes.BcsJob)
+	blog.V(3).Infof("mesos cluster report job(%s.%s) for action(%s)",
+		dataType.ObjectMeta.NameSpace, dataType.ObjectMeta.Name, data.Action)
+	if err := ms.storage.SyncTimeout(data, SyncDefaultTimeOut); err != nil {
+		blog.Error("job(%s.%s) sync(%s) dispatch failed: %+v",
+			dataType.ObjectMeta.NameSpace, dataType.ObjectMeta.Name, data.Action, err)
+		return err
+	}
+	return nil
+}
+
+func (ms *MesosCluster) reportCronJob(data *types.BcsSyncData) error {
+	dataType := data.Item.(*schedtypes.BcsCronJob)
+	blog.V(3).Infof("mesos cluster report cronjob(%s.%s) for action(%s)",
+		dataType.ObjectMeta.NameSpace, dataType.ObjectMeta.Name, data.Action)
+	if err := ms.storage.SyncTimeout(data, SyncDefaultTimeOut); err != nil {
+		blog.Error("cronjob(%s.%s) sync(%s) dispatch failed: %+v",
+			dataType.ObjectMeta.NameSpace, dataType.ObjectMeta.Name, data.Action, err)
+		return err
+	}
+	return nil
+}
+
 func (ms *MesosCluster) reportSecret(data *types.BcsSyncData) error {
 	dataType := data.Item.(*commtypes.BcsSecret)
 	blog.V(3).Infof("mesos cluster report secret(%s.%s) for action(%s)",
@@ -26,6 +26,8 @@ const (
 	DataTypeCfg       = "Configmap"
 	//DataTypeSecret    = "Secret"
 	DataTypeDeploy             = "Deployment"
+	DataTypeJob                = "Job"
+	DataTypeCronJob            = "CronJob"
 	DataTypeSvr                = "Service"
 	DataTypeExpSVR             = "ExportService"
 	DataTypeIPPoolStatic       = "IPPoolStatic"
@@ -69,6 +69,7 @@ func setCfg(op *MesosWatchOptions) {
 	storageAddr := op.StorageAddress
 	storageAddr = strings.Replace(storageAddr, ";", ",", -1)
 	cfg.StorageAddresses = strings.Split(storageAddr, ",")
+	cfg.SinkConfig = op.SinkConfig
 }
 
 func main() {
@@ -127,4 +128,7 @@ type MesosWatchOptions struct {
 
 	// StorageAddress storage address
 	StorageAddress string `json:"storage_address" value:"" usage:"storage address"`
+
+	// SinkConfig json file for sinks of sync data, such as msgqueue and file
+	SinkConfig string `json:"sink_config" value:"" usage:"sink config file for sync data, only bcs-storage if empty"`
 }
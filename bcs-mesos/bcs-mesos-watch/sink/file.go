@@ -0,0 +1,157 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/types"
+)
+
+//FileRecord one line in data file
+type FileRecord struct {
+	ClusterID string      `json:"clusterId"`
+	DataType  string      `json:"dataType"`
+	Action    string      `json:"action"`
+	Timestamp int64       `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+//dataFile opened data file of one data type
+type dataFile struct {
+	file *os.File
+	size int64
+}
+
+//FileWriter write BcsSyncData as newline-delimited json, every data
+//type is written to file Dir/<data type>.json
+type FileWriter struct {
+	clusterID  string
+	dir        string
+	maxSize    int64
+	maxBackups int
+	files      map[string]*dataFile
+}
+
+//NewFileWriter create writer for newline-delimited json files
+func NewFileWriter(clusterID string, config *FileConfig) (*FileWriter, error) {
+	if len(config.Dir) == 0 {
+		return nil, fmt.Errorf("file sink dir is empty")
+	}
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileWriter{
+		clusterID:  clusterID,
+		dir:        config.Dir,
+		maxSize:    int64(config.MaxSize) * 1024 * 1024,
+		maxBackups: config.MaxBackups,
+		files:      make(map[string]*dataFile),
+	}, nil
+}
+
+//Write append data as one json line to file of data type
+func (w *FileWriter) Write(data *types.BcsSyncData) error {
+	record := &FileRecord{
+		ClusterID: w.clusterID,
+		DataType:  data.DataType,
+		Action:    data.Action,
+		Timestamp: time.Now().Unix(),
+		Data:      data.Item,
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	df, err := w.getFile(data.DataType)
+	if err != nil {
+		return err
+	}
+	if w.maxSize > 0 && df.size > 0 && df.size+int64(len(line)) > w.maxSize {
+		if df, err = w.rotate(data.DataType); err != nil {
+			return err
+		}
+	}
+	n, err := df.file.Write(line)
+	df.size += int64(n)
+	return err
+}
+
+//Close close all opened files
+func (w *FileWriter) Close() {
+	for dataType, df := range w.files {
+		df.file.Close()
+		delete(w.files, dataType)
+	}
+}
+
+func (w *FileWriter) filePath(dataType string) string {
+	return filepath.Join(w.dir, dataType+".json")
+}
+
+func (w *FileWriter) getFile(dataType string) (*dataFile, error) {
+	if df, ok := w.files[dataType]; ok {
+		return df, nil
+	}
+	file, err := os.OpenFile(w.filePath(dataType), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	df := &dataFile{file: file, size: info.Size()}
+	w.files[dataType] = df
+	return df, nil
+}
+
+//rotate rename current file to <data type>.json.<timestamp> and
+//clean backups out of maxBackups
+func (w *FileWriter) rotate(dataType string) (*dataFile, error) {
+	df := w.files[dataType]
+	df.file.Close()
+	delete(w.files, dataType)
+
+	path := w.filePath(dataType)
+	backup := path + "." + time.Now().Format("20060102150405.000000")
+	if err := os.Rename(path, backup); err != nil {
+		return nil, err
+	}
+	blog.Infof("file sink rotate %s to %s", path, backup)
+
+	if w.maxBackups > 0 {
+		backups, err := filepath.Glob(path + ".*")
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(backups)
+		for len(backups) > w.maxBackups {
+			if err := os.Remove(backups[0]); err != nil {
+				blog.Errorf("file sink remove backup %s failed, %s", backups[0], err.Error())
+			}
+			backups = backups[1:]
+		}
+	}
+	return w.getFile(dataType)
+}
@@ -0,0 +1,147 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/micro/go-micro/v2/broker"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	lbtypes "github.com/Tencent/bk-bcs/bcs-common/pkg/loadbalance/v2"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/msgqueue"
+	schedtypes "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/types"
+)
+
+//MsgQueueWriter publish BcsSyncData to msgqueue topics, message body is
+//json of data item, cluster, namespace, name and event are in headers
+type MsgQueueWriter struct {
+	clusterID string
+	queue     msgqueue.MessageQueue
+}
+
+//NewMsgQueueWriter create writer for msgqueue, every data type is
+//published to topic TopicPrefix + data type
+func NewMsgQueueWriter(clusterID string, config *MsgQueueConfig) (*MsgQueueWriter, error) {
+	if len(config.Address) == 0 {
+		return nil, fmt.Errorf("msgqueue address is empty")
+	}
+
+	resourceToQueue := make(map[string]string)
+	for _, dataType := range DataTypes {
+		resourceToQueue[dataType] = config.TopicPrefix + dataType
+	}
+	opts := []msgqueue.QueueOption{
+		msgqueue.CommonOpts(&msgqueue.CommonOptions{
+			QueueFlag:       true,
+			QueueKind:       msgqueue.QueueKind(config.QueueKind),
+			ResourceToQueue: resourceToQueue,
+			Address:         config.Address,
+		}),
+	}
+	switch msgqueue.QueueKind(config.QueueKind) {
+	case msgqueue.RABBITMQ:
+		exchange := *msgqueue.DefaultExchangeOptions
+		if len(config.Exchange) != 0 {
+			exchange.Name = config.Exchange
+		}
+		opts = append(opts, msgqueue.Exchange(&exchange))
+	case msgqueue.NATSTREAMING:
+		opts = append(opts, msgqueue.NatsOpts(&msgqueue.NatsOptions{
+			ClusterID:    config.NatsClusterID,
+			ClientID:     "bcs-mesos-watch-" + clusterID,
+			ConnectRetry: true,
+		}))
+	case msgqueue.KAFKA:
+		opts = append(opts, msgqueue.KafkaOpts(&msgqueue.KafkaOptions{
+			Version:  config.KafkaVersion,
+			ClientID: "bcs-mesos-watch-" + clusterID,
+		}))
+	default:
+		return nil, fmt.Errorf("msgqueue kind %s is not supported", config.QueueKind)
+	}
+
+	queue, err := msgqueue.NewMsgQueue(opts...)
+	if err != nil {
+		return nil, err
+	}
+	blog.Infof("msgqueue writer for %s(%s) created", config.QueueKind, config.Address)
+	return &MsgQueueWriter{
+		clusterID: clusterID,
+		queue:     queue,
+	}, nil
+}
+
+//Write publish data to topic of data type
+func (w *MsgQueueWriter) Write(data *types.BcsSyncData) error {
+	body, err := json.Marshal(data.Item)
+	if err != nil {
+		return err
+	}
+	namespace, name := itemMeta(data.Item)
+	message := &broker.Message{
+		Header: map[string]string{
+			string(msgqueue.ClusterID):    w.clusterID,
+			string(msgqueue.Namespace):    namespace,
+			string(msgqueue.ResourceType): data.DataType,
+			string(msgqueue.ResourceName): name,
+			string(msgqueue.EventType):    string(eventKind(data.Action)),
+		},
+		Body: body,
+	}
+	return w.queue.Publish(message)
+}
+
+//Close stop msgqueue
+func (w *MsgQueueWriter) Close() {
+	w.queue.Stop()
+}
+
+//metaObject data item with embedded ObjectMeta
+type metaObject interface {
+	GetNamespace() string
+	GetName() string
+}
+
+//itemMeta get namespace and name of data item
+func itemMeta(item interface{}) (string, string) {
+	switch obj := item.(type) {
+	case *schedtypes.Application:
+		return obj.RunAs, obj.ID
+	case *schedtypes.TaskGroup:
+		return obj.RunAs, obj.ID
+	case *schedtypes.Deployment:
+		return obj.ObjectMeta.NameSpace, obj.ObjectMeta.Name
+	case *lbtypes.ExportService:
+		return obj.Namespace, obj.ServiceName
+	case metaObject:
+		return obj.GetNamespace(), obj.GetName()
+	}
+	return "", ""
+}
+
+//eventKind convert BcsSyncData action to msgqueue event
+func eventKind(action string) msgqueue.EventKind {
+	switch action {
+	case types.ActionAdd:
+		return msgqueue.EventTypeAdd
+	case types.ActionUpdate:
+		return msgqueue.EventTypeUpdate
+	case types.ActionDelete:
+		return msgqueue.EventTypeDelete
+	}
+	return msgqueue.EventTypeUnknown
+}
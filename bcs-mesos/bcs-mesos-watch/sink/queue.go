@@ -0,0 +1,119 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package sink
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/types"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/util"
+)
+
+//Writer write BcsSyncData to downstream system, data type
+//of BcsSyncData is already without channel index
+type Writer interface {
+	Write(data *types.BcsSyncData) error
+	Close()
+}
+
+//queueSink write data asynchronously in private goroutine,
+//so slow downstream system never blocks cluster watchers
+type queueSink struct {
+	name      string
+	clusterID string
+	handler   string //label for metrics
+	queue     chan *types.BcsSyncData
+	writer    Writer
+}
+
+func newQueueSink(clusterID, name string, size int, writer Writer) *queueSink {
+	return &queueSink{
+		name:      name,
+		clusterID: clusterID,
+		handler:   "sink_" + name,
+		queue:     make(chan *types.BcsSyncData, size),
+		writer:    writer,
+	}
+}
+
+//Name return sink name
+func (s *queueSink) Name() string {
+	return s.name
+}
+
+//Sync push data to sink queue
+func (s *queueSink) Sync(data *types.BcsSyncData) error {
+	s.queue <- s.convert(data)
+	util.ReportHandlerQueueLengthInc(s.clusterID, s.handler)
+	return nil
+}
+
+//SyncTimeout push data to sink queue with timeout, data is
+//discarded when timeout
+func (s *queueSink) SyncTimeout(data *types.BcsSyncData, timeout time.Duration) error {
+	select {
+	case s.queue <- s.convert(data):
+		util.ReportHandlerQueueLengthInc(s.clusterID, s.handler)
+	case <-time.After(timeout):
+		blog.Warnf("sink %s handle data into queue timeout, current task queue(%d/%d)",
+			s.name, len(s.queue), cap(s.queue))
+		util.ReportHandlerDiscardEvents(s.clusterID, s.handler)
+	}
+	return nil
+}
+
+//Run start sink worker goroutine
+func (s *queueSink) Run(cxt context.Context) error {
+	go s.worker(cxt)
+	return nil
+}
+
+func (s *queueSink) worker(cxt context.Context) {
+	defer s.writer.Close()
+
+	tick := time.NewTicker(120 * time.Second)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			blog.Info("tick: sink %s is alive, current task queue(%d/%d)", s.name, len(s.queue), cap(s.queue))
+			util.ReportHandlerQueueLength(s.clusterID, s.handler, float64(len(s.queue)))
+		case <-cxt.Done():
+			blog.Info("sink %s asked to exit, current task queue(%d/%d)", s.name, len(s.queue), cap(s.queue))
+			return
+		case data := <-s.queue:
+			util.ReportHandlerQueueLengthDec(s.clusterID, s.handler)
+			started := time.Now()
+			if err := s.writer.Write(data); err != nil {
+				blog.Errorf("sink %s write %s(%s) failed, %s", s.name, data.DataType, data.Action, err.Error())
+				util.ReportStorageMetrics(s.clusterID, data.DataType, data.Action, s.handler, util.StatusFailure, started)
+				continue
+			}
+			util.ReportStorageMetrics(s.clusterID, data.DataType, data.Action, s.handler, util.StatusSuccess, started)
+		}
+	}
+}
+
+//convert strip channel index of data type, item is shared
+//with other sinks and must not be modified
+func (s *queueSink) convert(data *types.BcsSyncData) *types.BcsSyncData {
+	return &types.BcsSyncData{
+		DataType: BaseDataType(data.DataType),
+		Action:   data.Action,
+		Item:     data.Item,
+	}
+}
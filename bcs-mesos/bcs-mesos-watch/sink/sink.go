@@ -0,0 +1,320 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/storage"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/types"
+)
+
+const (
+	//KindStorage sink kind for bcs-storage
+	KindStorage = "storage"
+	//KindMsgQueue sink kind for bcs-common/pkg/msgqueue
+	KindMsgQueue = "msgqueue"
+	//KindFile sink kind for newline-delimited json files
+	KindFile = "file"
+
+	//StorageSinkName name of built-in bcs-storage sink
+	StorageSinkName = "storage"
+	//DefaultRoute route key for data types without explicit route
+	DefaultRoute = "*"
+
+	//defaultSinkQueueSize default queue size of asynchronous sink
+	defaultSinkQueueSize = 10240
+)
+
+//DataTypes all BcsSyncData types reported by cluster watchers
+var DataTypes = []string{
+	"Application",
+	"TaskGroup",
+	"ExportService",
+	"ConfigMap",
+	"Service",
+	"Secret",
+	"Deployment",
+	"Job",
+	"CronJob",
+	"Endpoint",
+	"IPPoolStatic",
+	"IPPoolStaticDetail",
+}
+
+//channelPrefixes channel prefix to data type
+var channelPrefixes = map[string]string{
+	types.ApplicationChannelPrefix:   "Application",
+	types.TaskgroupChannelPrefix:     "TaskGroup",
+	types.ExportserviceChannelPrefix: "ExportService",
+	types.DeploymentChannelPrefix:    "Deployment",
+}
+
+//Config sink configuration, BcsSyncData is dispatched to sinks
+//by data type according to Routes
+type Config struct {
+	Sinks []SinkConfig `json:"sinks"`
+	//Routes data type to sink names, such as {"TaskGroup": ["storage", "kafka"], "*": ["storage"]}
+	Routes map[string][]string `json:"routes"`
+}
+
+//SinkConfig configuration for one sink
+type SinkConfig struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	//QueueSize size of sink queue, default 10240
+	QueueSize int             `json:"queueSize,omitempty"`
+	MsgQueue  *MsgQueueConfig `json:"msgqueue,omitempty"`
+	File      *FileConfig     `json:"file,omitempty"`
+}
+
+//MsgQueueConfig configuration for msgqueue sink
+type MsgQueueConfig struct {
+	//QueueKind rabbitmq, nats-streaming or kafka
+	QueueKind string `json:"queueKind"`
+	Address   string `json:"address"`
+	//TopicPrefix topic of data type is TopicPrefix + data type
+	TopicPrefix string `json:"topicPrefix,omitempty"`
+	//Exchange exchange name for rabbitmq
+	Exchange string `json:"exchange,omitempty"`
+	//NatsClusterID cluster id for nats-streaming
+	NatsClusterID string `json:"natsClusterId,omitempty"`
+	//KafkaVersion kafka version for kafka
+	KafkaVersion string `json:"kafkaVersion,omitempty"`
+}
+
+//FileConfig configuration for newline-delimited json file sink
+type FileConfig struct {
+	//Dir directory of data files, one file for every data type
+	Dir string `json:"dir"`
+	//MaxSize max size(MB) of one file before rotation, 0 means no rotation
+	MaxSize int `json:"maxSize,omitempty"`
+	//MaxBackups max rotated files kept for every data type
+	MaxBackups int `json:"maxBackups,omitempty"`
+}
+
+//Sink is destination of BcsSyncData stream
+type Sink interface {
+	Name() string
+	Sync(data *types.BcsSyncData) error
+	SyncTimeout(data *types.BcsSyncData, timeout time.Duration) error
+	Run(cxt context.Context) error
+}
+
+//LoadConfig load sink config from json file, empty file means
+//only bcs-storage sink for all data types
+func LoadConfig(file string) (*Config, error) {
+	config := &Config{}
+	if len(file) != 0 {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read sink config %s failed, %s", file, err.Error())
+		}
+		if err := json.Unmarshal(content, config); err != nil {
+			return nil, fmt.Errorf("decode sink config %s failed, %s", file, err.Error())
+		}
+	}
+	if len(config.Routes) == 0 {
+		config.Routes = map[string][]string{DefaultRoute: {StorageSinkName}}
+	}
+	return config, nil
+}
+
+//NewSinkStorage create Storage dispatching BcsSyncData to configured sinks,
+//other Storage operations are still handled by bcs-storage
+func NewSinkStorage(cfg *types.CmdConfig, st storage.Storage) (storage.Storage, error) {
+	config, err := LoadConfig(cfg.SinkConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newRouterStorage(cfg.ClusterID, config, st)
+}
+
+func newRouterStorage(clusterID string, config *Config, st storage.Storage) (*RouterStorage, error) {
+	router := &RouterStorage{
+		Storage:   st,
+		clusterID: clusterID,
+		sinks:     make(map[string]Sink),
+		routes:    make(map[string][]Sink),
+	}
+	router.sinks[StorageSinkName] = &storageSink{storage: st}
+
+	for _, sc := range config.Sinks {
+		if _, ok := router.sinks[sc.Name]; ok {
+			if sc.Name == StorageSinkName && sc.Kind == KindStorage {
+				continue
+			}
+			return nil, fmt.Errorf("sink %s duplicated", sc.Name)
+		}
+		s, err := newSink(clusterID, sc)
+		if err != nil {
+			return nil, err
+		}
+		router.sinks[sc.Name] = s
+	}
+
+	for dataType, names := range config.Routes {
+		var sinks []Sink
+		for _, name := range names {
+			s, ok := router.sinks[name]
+			if !ok {
+				return nil, fmt.Errorf("route %s refers to unknown sink %s", dataType, name)
+			}
+			sinks = append(sinks, s)
+		}
+		router.routes[dataType] = sinks
+	}
+	if _, ok := router.routes[DefaultRoute]; !ok {
+		router.routes[DefaultRoute] = []Sink{router.sinks[StorageSinkName]}
+	}
+	blog.Infof("sink storage for cluster %s created, routes: %v", clusterID, config.Routes)
+	return router, nil
+}
+
+func newSink(clusterID string, sc SinkConfig) (Sink, error) {
+	if len(sc.Name) == 0 {
+		return nil, fmt.Errorf("sink name is empty")
+	}
+	if sc.QueueSize <= 0 {
+		sc.QueueSize = defaultSinkQueueSize
+	}
+
+	var (
+		writer Writer
+		err    error
+	)
+	switch sc.Kind {
+	case KindMsgQueue:
+		if sc.MsgQueue == nil {
+			return nil, fmt.Errorf("sink %s lost msgqueue config", sc.Name)
+		}
+		writer, err = NewMsgQueueWriter(clusterID, sc.MsgQueue)
+	case KindFile:
+		if sc.File == nil {
+			return nil, fmt.Errorf("sink %s lost file config", sc.Name)
+		}
+		writer, err = NewFileWriter(clusterID, sc.File)
+	default:
+		return nil, fmt.Errorf("sink %s kind %s is not supported", sc.Name, sc.Kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create sink %s failed, %s", sc.Name, err.Error())
+	}
+	return newQueueSink(clusterID, sc.Name, sc.QueueSize, writer), nil
+}
+
+//RouterStorage dispatch BcsSyncData to sinks by data type
+type RouterStorage struct {
+	storage.Storage
+	clusterID string
+	sinks     map[string]Sink   //all sinks, key is sink name
+	routes    map[string][]Sink //sinks for data type
+}
+
+//Sync sync data to all sinks of data type
+func (router *RouterStorage) Sync(data *types.BcsSyncData) error {
+	return router.dispatch(data, func(s Sink) error {
+		return s.Sync(data)
+	})
+}
+
+//SyncTimeout sync data to all sinks of data type with timeout
+func (router *RouterStorage) SyncTimeout(data *types.BcsSyncData, timeout time.Duration) error {
+	return router.dispatch(data, func(s Sink) error {
+		return s.SyncTimeout(data, timeout)
+	})
+}
+
+func (router *RouterStorage) dispatch(data *types.BcsSyncData, fn func(s Sink) error) error {
+	if data == nil {
+		blog.Error("RouterStorage get nil BcsSyncData")
+		return nil
+	}
+	var lastErr error
+	for _, s := range router.getSinks(data.DataType) {
+		if err := fn(s); err != nil {
+			blog.Errorf("sink %s sync %s(%s) failed, %s", s.Name(), data.DataType, data.Action, err.Error())
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (router *RouterStorage) getSinks(dataType string) []Sink {
+	if sinks, ok := router.routes[BaseDataType(dataType)]; ok {
+		return sinks
+	}
+	return router.routes[DefaultRoute]
+}
+
+//Run start bcs-storage and all sinks
+func (router *RouterStorage) Run(cxt context.Context) error {
+	if err := router.Storage.Run(cxt); err != nil {
+		return err
+	}
+	for name, s := range router.sinks {
+		if name == StorageSinkName {
+			continue
+		}
+		blog.Info("RouterStorage starting sink %s", name)
+		if err := s.Run(cxt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//BaseDataType data type without channel index, such as
+//Application_1 to Application
+func BaseDataType(dataType string) string {
+	for prefix, baseType := range channelPrefixes {
+		if strings.HasPrefix(dataType, prefix) {
+			return baseType
+		}
+	}
+	return dataType
+}
+
+//storageSink sink for bcs-storage, it keeps data type with channel
+//index for CCStorage dispatching
+type storageSink struct {
+	storage storage.Storage
+}
+
+//Name return sink name
+func (s *storageSink) Name() string {
+	return StorageSinkName
+}
+
+//Sync sync data to bcs-storage
+func (s *storageSink) Sync(data *types.BcsSyncData) error {
+	return s.storage.Sync(data)
+}
+
+//SyncTimeout sync data to bcs-storage with timeout
+func (s *storageSink) SyncTimeout(data *types.BcsSyncData, timeout time.Duration) error {
+	return s.storage.SyncTimeout(data, timeout)
+}
+
+//Run bcs-storage is started by RouterStorage
+func (s *storageSink) Run(cxt context.Context) error {
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package sink
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/storage"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-mesos-watch/types"
+)
+
+//fakeStorage record data synced to bcs-storage
+type fakeStorage struct {
+	storage.Storage
+	synced []*types.BcsSyncData
+}
+
+func (st *fakeStorage) Sync(data *types.BcsSyncData) error {
+	st.synced = append(st.synced, data)
+	return nil
+}
+
+func (st *fakeStorage) SyncTimeout(data *types.BcsSyncData, timeout time.Duration) error {
+	return st.Sync(data)
+}
+
+func (st *fakeStorage) Run(cxt context.Context) error {
+	return nil
+}
+
+func readRecords(t *testing.T, path string) []*FileRecord {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open %s failed, %s", path, err.Error())
+	}
+	defer file.Close()
+	var records []*FileRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := &FileRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			t.Fatalf("decode line %s failed, %s", scanner.Text(), err.Error())
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestRouterStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mesos-watch-sink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := &Config{
+		Sinks: []SinkConfig{
+			{Name: "ndjson", Kind: KindFile, File: &FileConfig{Dir: dir}},
+		},
+		Routes: map[string][]string{
+			"TaskGroup": {StorageSinkName, "ndjson"},
+			"Service":   {"ndjson"},
+		},
+	}
+	st := &fakeStorage{}
+	router, err := newRouterStorage("BCS-MESOS-10000", config, st)
+	if err != nil {
+		t.Fatalf("create router failed, %s", err.Error())
+	}
+	cxt, cancel := context.WithCancel(context.Background())
+	router.Run(cxt)
+
+	svc := &commtypes.BcsService{}
+	svc.NameSpace = "ns"
+	svc.Name = "svc"
+	router.SyncTimeout(&types.BcsSyncData{DataType: types.TaskgroupChannelPrefix + "3", Action: types.ActionAdd, Item: svc}, time.Second)
+	router.SyncTimeout(&types.BcsSyncData{DataType: "Service", Action: types.ActionUpdate, Item: svc}, time.Second)
+	router.SyncTimeout(&types.BcsSyncData{DataType: "Secret", Action: types.ActionDelete, Item: svc}, time.Second)
+
+	//storage keeps channel data type and gets default route
+	if len(st.synced) != 2 || st.synced[0].DataType != types.TaskgroupChannelPrefix+"3" || st.synced[1].DataType != "Secret" {
+		t.Fatalf("unexpected storage data: %+v", st.synced)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+
+	records := readRecords(t, filepath.Join(dir, "TaskGroup.json"))
+	if len(records) != 1 || records[0].DataType != "TaskGroup" || records[0].Action != types.ActionAdd {
+		t.Fatalf("unexpected TaskGroup records: %+v", records)
+	}
+	records = readRecords(t, filepath.Join(dir, "Service.json"))
+	if len(records) != 1 || records[0].ClusterID != "BCS-MESOS-10000" || records[0].Action != types.ActionUpdate {
+		t.Fatalf("unexpected Service records: %+v", records)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Secret.json")); !os.IsNotExist(err) {
+		t.Fatalf("Secret should not be written to file sink")
+	}
+
+	if _, err := newRouterStorage("BCS-MESOS-10000", &Config{Routes: map[string][]string{"*": {"kafka"}}}, st); err == nil {
+		t.Fatalf("route to unknown sink should fail")
+	}
+}
+
+func TestFileWriterRotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mesos-watch-sink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writer, err := NewFileWriter("BCS-MESOS-10000", &FileConfig{Dir: dir, MaxSize: 1, MaxBackups: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+
+	item := map[string]string{"data": strings.Repeat("x", 400*1024)}
+	for i := 0; i < 10; i++ {
+		if err := writer.Write(&types.BcsSyncData{DataType: "Application", Action: types.ActionUpdate, Item: item}); err != nil {
+			t.Fatalf("write failed, %s", err.Error())
+		}
+	}
+	backups, _ := filepath.Glob(filepath.Join(dir, "Application.json.*"))
+	if len(backups) != 2 {
+		t.Fatalf("expect 2 backups, but got %v", backups)
+	}
+	info, err := os.Stat(filepath.Join(dir, "Application.json"))
+	if err != nil || info.Size() > 1024*1024 {
+		t.Fatalf("unexpected current file: %v, %v", info, err)
+	}
+}
@@ -234,6 +234,28 @@ func (cc *CCStorage) init() error {
 		},
 	}
 
+	cc.handlers[dataTypeJob] = &ChannelProxy{
+		clusterID: cc.ClusterID,
+		dataQueue: make(chan *types.BcsSyncData, defaultHandlerQueueSize2),
+		actionHandler: &JobHandler{
+			oper:         cc,
+			dataType:     "job",
+			ClusterID:    cc.ClusterID,
+			DoCheckDirty: true,
+		},
+	}
+
+	cc.handlers[dataTypeCronJob] = &ChannelProxy{
+		clusterID: cc.ClusterID,
+		dataQueue: make(chan *types.BcsSyncData, defaultHandlerQueueSize2),
+		actionHandler: &CronJobHandler{
+			oper:         cc,
+			dataType:     "cronjob",
+			ClusterID:    cc.ClusterID,
+			DoCheckDirty: true,
+		},
+	}
+
 	cc.handlers[dataTypeEp] = &ChannelProxy{
 		clusterID: cc.ClusterID,
 		dataQueue: make(chan *types.BcsSyncData, defaultHandlerQueueSize2),
@@ -19,6 +19,8 @@ var (
 	dataTypeCfg                = "Configmap"
 	dataTypeSecret             = "Secret"
 	dataTypeDeploy             = "Deployment"
+	dataTypeJob                = "Job"
+	dataTypeCronJob            = "CronJob"
 	dataTypeSvr                = "Service"
 	dataTypeExpSVR             = "ExportService"
 	dataTypeEp                 = "Endpoint"
@@ -70,6 +70,9 @@ type CmdConfig struct {
 
 	// StorageAddresses address for bcs-storage
 	StorageAddresses []string
+
+	// SinkConfig json file for sinks of sync data besides bcs-storage
+	SinkConfig string
 }
 
 const (
@@ -38,6 +38,13 @@ type Option struct {
 	DataDir      string `json:"data_dir" value:"" usage:"the process daemon data dir"`
 	UnixSocket   string `json:"unix_socket" value:"" usage:"the unix socket path"`
 	WorkspaceDir string `json:"workspace_dir" value:"" usage:"the process packages dir"`
+	CgroupRoot   string `json:"cgroup_root" value:"/sys/fs/cgroup" usage:"the cgroup mount root, v1 or v2 is detected"`
+	CgroupParent string `json:"cgroup_parent" value:"" usage:"the parent cgroup of processes, such as bcs-process, empty to disable resource isolation"`
+
+	PackageCacheDir        string `json:"package_cache_dir" value:"" usage:"the package cache dir, default workspace_dir/package_cache"`
+	PackageCacheSize       int64  `json:"package_cache_size" value:"10240" usage:"the max size of package cache in MB, 0 means no limit"`
+	PackagePublicKey       string `json:"package_public_key" value:"" usage:"the PEM public key file to verify package signatures"`
+	RequireVerifiedPackage bool   `json:"require_verified_package" value:"false" usage:"reject packages without sha256 or signature"`
 }
 
 // Init process init
@@ -61,6 +68,13 @@ func Init() {
 	config := &config.Config{
 		DataDir:      op.DataDir,
 		WorkspaceDir: op.WorkspaceDir,
+		CgroupRoot:   op.CgroupRoot,
+		CgroupParent: op.CgroupParent,
+
+		PackageCacheDir:        op.PackageCacheDir,
+		PackageCacheSize:       op.PackageCacheSize,
+		PackagePublicKey:       op.PackagePublicKey,
+		RequireVerifiedPackage: op.RequireVerifiedPackage,
 	}
 	manager := manager.NewManager(config)
 	err = manager.Init()
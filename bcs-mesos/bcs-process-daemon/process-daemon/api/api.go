@@ -82,6 +82,7 @@ func (r *Router) initRoutes() {
 	r.actions = append(r.actions, httpserver.NewAction("GET", "/process/{id}/status", nil, r.inspectProcessStatus))
 	r.actions = append(r.actions, httpserver.NewAction("PUT", "/process/{id}/stop/{timeout}", nil, r.stopProcess))
 	r.actions = append(r.actions, httpserver.NewAction("DELETE", "/process/{id}", nil, r.deleteProcess))
+	r.actions = append(r.actions, httpserver.NewAction("POST", "/process/{id}/heartbeat", nil, r.heartbeat))
 }
 
 func (r *Router) createProcess(req *restful.Request, resp *restful.Response) {
@@ -193,6 +194,36 @@ func (r *Router) deleteProcess(req *restful.Request, resp *restful.Response) {
 	return
 }
 
+func (r *Router) heartbeat(req *restful.Request, resp *restful.Response) {
+	processId := req.PathParameter("id")
+	blog.V(3).Infof("Router heartbeat process %s", processId)
+
+	var heartbeat *types.HeartBeat
+	err := json.NewDecoder(req.Request.Body).Decode(&heartbeat)
+	if err != nil {
+		blog.Errorf("Router heartbeat process %s decode body error %s", processId, err.Error())
+		data := createResponeData(err, common.BcsErrDaemonHeartBeatFailed,
+			err.Error(), nil)
+		resp.Write(data)
+		return
+	}
+	heartbeat.ProcessId = processId
+
+	pong, err := r.backend.HeartBeat(heartbeat)
+	if err != nil {
+		data := createResponeData(err, common.BcsErrDaemonHeartBeatFailed,
+			err.Error(), nil)
+		resp.Write(data)
+		return
+	}
+
+	data := createResponeData(nil, 0, "", pong)
+	resp.Write(data)
+	blog.V(3).Infof("Router heartbeat process %s success", processId)
+
+	return
+}
+
 //replace variables
 func (r *Router) parseVariable(by []byte) []byte {
 	str := string(by)
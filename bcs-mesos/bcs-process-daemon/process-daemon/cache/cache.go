@@ -0,0 +1,248 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+)
+
+//PackageCache content-addressed package cache on host, shared by processes.
+//packages are stored as files named by sha256 digest, and evicted by LRU
+//when total size exceeds the limit, packages used by processes are never evicted
+type PackageCache struct {
+	sync.Mutex
+
+	dir     string
+	maxSize int64
+	size    int64
+
+	//key: sha256 digest
+	entries map[string]*entry
+	//front is the most recently used
+	lru *list.List
+
+	//called after package is evicted, such as removing its extracted dir
+	onEvict func(digest string)
+}
+
+type entry struct {
+	digest string
+	size   int64
+	//process ids use the package
+	refs map[string]struct{}
+	elem *list.Element
+}
+
+//NewPackageCache create package cache in dir, packages already in dir are loaded,
+//maxSize is bytes, 0 means no limit
+func NewPackageCache(dir string, maxSize int64, onEvict func(digest string)) (*PackageCache, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("mkdir %s error %s", dir, err.Error())
+	}
+
+	c := &PackageCache{
+		dir:     dir,
+		maxSize: maxSize,
+		entries: make(map[string]*entry),
+		lru:     list.New(),
+		onEvict: onEvict,
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read dir %s error %s", dir, err.Error())
+	}
+	//load packages by modify time, the latest used is in front
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().After(files[j].ModTime())
+	})
+	for _, file := range files {
+		if file.IsDir() || !isDigest(file.Name()) {
+			continue
+		}
+		e := &entry{
+			digest: file.Name(),
+			size:   file.Size(),
+			refs:   make(map[string]struct{}),
+		}
+		e.elem = c.lru.PushBack(e)
+		c.entries[e.digest] = e
+		c.size += e.size
+	}
+	blog.Infof("package cache %s load %d packages size %d", dir, len(c.entries), c.size)
+
+	return c, nil
+}
+
+//Path return file path of package
+func (c *PackageCache) Path(digest string) string {
+	return filepath.Join(c.dir, digest)
+}
+
+//Get return package file path if package is cached, and mark it recently used and used by process
+func (c *PackageCache) Get(digest, processID string) (string, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	e, ok := c.entries[digest]
+	if !ok {
+		return "", false
+	}
+	e.refs[processID] = struct{}{}
+	c.lru.MoveToFront(e.elem)
+	now := time.Now()
+	os.Chtimes(c.Path(digest), now, now)
+	return c.Path(digest), true
+}
+
+//Add read package from r into cache and mark it used by process, return its sha256 digest and file path.
+//the package is stored only if its digest equals expectDigest, when expectDigest is not empty
+func (c *PackageCache) Add(r io.Reader, expectDigest, processID string) (string, string, error) {
+	tmp, err := ioutil.TempFile(c.dir, "tmp-")
+	if err != nil {
+		return "", "", fmt.Errorf("create temp file in %s error %s", c.dir, err.Error())
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), r)
+	tmp.Close()
+	if err != nil {
+		return "", "", fmt.Errorf("write package to %s error %s", tmp.Name(), err.Error())
+	}
+	digest := hex.EncodeToString(h.Sum(nil))
+	if expectDigest != "" && digest != expectDigest {
+		return digest, "", &DigestMismatchError{Expect: expectDigest, Actual: digest}
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if e, ok := c.entries[digest]; ok {
+		e.refs[processID] = struct{}{}
+		c.lru.MoveToFront(e.elem)
+		return digest, c.Path(digest), nil
+	}
+	err = os.Rename(tmp.Name(), c.Path(digest))
+	if err != nil {
+		return digest, "", fmt.Errorf("rename %s to %s error %s", tmp.Name(), c.Path(digest), err.Error())
+	}
+
+	e := &entry{
+		digest: digest,
+		size:   size,
+		refs:   map[string]struct{}{processID: {}},
+	}
+	e.elem = c.lru.PushFront(e)
+	c.entries[digest] = e
+	c.size += size
+	blog.Infof("package cache add package %s size %d, cache size %d", digest, size, c.size)
+
+	c.evict()
+	return digest, c.Path(digest), nil
+}
+
+//Use mark package used by process, such as processes restored after restart,
+//the package won't be evicted until released
+func (c *PackageCache) Use(digest, processID string) {
+	c.Lock()
+	defer c.Unlock()
+
+	e, ok := c.entries[digest]
+	if !ok {
+		return
+	}
+	e.refs[processID] = struct{}{}
+}
+
+//Release process doesn't use the package any more
+func (c *PackageCache) Release(digest, processID string) {
+	c.Lock()
+	defer c.Unlock()
+
+	e, ok := c.entries[digest]
+	if !ok {
+		return
+	}
+	delete(e.refs, processID)
+	c.evict()
+}
+
+//Size return total size of cached packages
+func (c *PackageCache) Size() int64 {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.size
+}
+
+//evict the least recently used packages which are not used by processes, until size is under limit
+func (c *PackageCache) evict() {
+	if c.maxSize <= 0 {
+		return
+	}
+
+	for elem := c.lru.Back(); elem != nil && c.size > c.maxSize; {
+		e := elem.Value.(*entry)
+		elem = elem.Prev()
+		if len(e.refs) > 0 {
+			continue
+		}
+
+		err := os.Remove(c.Path(e.digest))
+		if err != nil && !os.IsNotExist(err) {
+			blog.Errorf("package cache remove package %s error %s", e.digest, err.Error())
+			continue
+		}
+		c.lru.Remove(e.elem)
+		delete(c.entries, e.digest)
+		c.size -= e.size
+		blog.Infof("package cache evict package %s size %d, cache size %d", e.digest, e.size, c.size)
+
+		if c.onEvict != nil {
+			c.onEvict(e.digest)
+		}
+	}
+}
+
+//DigestMismatchError sha256 digest of package is not the expected one
+type DigestMismatchError struct {
+	Expect string
+	Actual string
+}
+
+func (e *DigestMismatchError) Error() string {
+	return fmt.Sprintf("package sha256 %s doesn't match expected %s", e.Actual, e.Expect)
+}
+
+func isDigest(name string) bool {
+	if len(name) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil
+}
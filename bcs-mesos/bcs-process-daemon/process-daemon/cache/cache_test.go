@@ -0,0 +1,88 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func digestOf(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestPackageCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "package-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	evicted := make([]string, 0)
+	c, err := NewPackageCache(dir, 10, func(digest string) {
+		evicted = append(evicted, digest)
+	})
+	if err != nil {
+		t.Fatalf("create cache error %s", err.Error())
+	}
+
+	//digest mismatch is not stored
+	_, _, err = c.Add(strings.NewReader("aaaa"), digestOf("bbbb"), "process-1")
+	if _, ok := err.(*DigestMismatchError); !ok {
+		t.Fatalf("expect DigestMismatchError, but got %v", err)
+	}
+	if c.Size() != 0 {
+		t.Fatalf("expect empty cache, but size %d", c.Size())
+	}
+
+	a, _, err := c.Add(strings.NewReader("aaaa"), digestOf("aaaa"), "process-1")
+	if err != nil {
+		t.Fatalf("add package error %s", err.Error())
+	}
+	b, _, _ := c.Add(strings.NewReader("bbbb"), "", "process-2")
+	c.Release(b, "process-2")
+	if _, ok := c.Get(b, "process-3"); !ok {
+		t.Fatalf("package %s not found", b)
+	}
+	c.Release(b, "process-3")
+
+	//a is the least recently used, but it's used by process
+	d, _, _ := c.Add(strings.NewReader("dddd"), "", "process-4")
+	c.Release(d, "process-4")
+	if len(evicted) != 1 || evicted[0] != b {
+		t.Fatalf("expect package %s evicted, but got %v", b, evicted)
+	}
+	if _, ok := c.Get(a, "process-1"); !ok {
+		t.Fatalf("package %s in use is evicted", a)
+	}
+
+	c.Release(a, "process-1")
+	if len(evicted) != 1 || c.Size() != 8 {
+		t.Fatalf("unexpected evicted %v size %d", evicted, c.Size())
+	}
+
+	//reload from dir
+	c, err = NewPackageCache(dir, 10, nil)
+	if err != nil {
+		t.Fatalf("reload cache error %s", err.Error())
+	}
+	if _, ok := c.Get(d, "process-5"); !ok || c.Size() != 8 {
+		t.Fatalf("package %s not reloaded, size %d", d, c.Size())
+	}
+}
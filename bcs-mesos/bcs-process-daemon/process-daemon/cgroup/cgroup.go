@@ -0,0 +1,113 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cgroup
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+//NewManager create cgroup manager for processes under parent cgroup,
+//cgroup version is detected from root, such as /sys/fs/cgroup
+func NewManager(root, parent string) (Manager, error) {
+	if root == "" || parent == "" {
+		return nil, fmt.Errorf("cgroup root and parent can't be empty")
+	}
+	if _, err := os.Stat(root); err != nil {
+		return nil, fmt.Errorf("cgroup root %s is invalid, %s", root, err.Error())
+	}
+
+	//cgroup.controllers only exists in the root of cgroup v2 unified hierarchy
+	if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err == nil {
+		return newV2Manager(root, parent)
+	}
+	return newV1Manager(root, parent)
+}
+
+func writeFile(dir, file, data string) error {
+	err := ioutil.WriteFile(filepath.Join(dir, file), []byte(data), 0644)
+	if err != nil {
+		return fmt.Errorf("write %s to %s error %s", data, filepath.Join(dir, file), err.Error())
+	}
+	return nil
+}
+
+func readUint(dir, file string) (uint64, error) {
+	by, err := ioutil.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return 0, err
+	}
+	value := strings.TrimSpace(string(by))
+	//cgroup v2 limit file
+	if value == "max" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+//readKeyValue read flat keyed file, such as memory.events, cpu.stat
+func readKeyValue(dir, file string) (map[string]uint64, error) {
+	f, err := os.Open(filepath.Join(dir, file))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		values[fields[0]] = value
+	}
+	return values, scanner.Err()
+}
+
+//destroyDir kill processes in cgroup dir, and remove it
+func destroyDir(dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	var err error
+	for i := 0; i < 5; i++ {
+		by, _ := ioutil.ReadFile(filepath.Join(dir, "cgroup.procs"))
+		for _, line := range strings.Fields(string(by)) {
+			pid, _ := strconv.Atoi(line)
+			if pid > 0 {
+				syscall.Kill(pid, syscall.SIGKILL)
+			}
+		}
+
+		err = os.Remove(dir)
+		if err == nil || os.IsNotExist(err) {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("remove cgroup %s error %s", dir, err.Error())
+}
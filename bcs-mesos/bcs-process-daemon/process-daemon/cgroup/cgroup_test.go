@@ -0,0 +1,144 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cgroup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestV2Manager(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup-v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	ioutil.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpu io memory"), 0644)
+	ioutil.WriteFile(filepath.Join(root, "cgroup.subtree_control"), []byte("cpu io memory\n"), 0644)
+	//controllers delegated to parent cgroup
+	os.MkdirAll(filepath.Join(root, "bcs-process"), 0755)
+	ioutil.WriteFile(filepath.Join(root, "bcs-process", "cgroup.controllers"), []byte("cpu io memory\n"), 0644)
+
+	m, err := NewManager(root, "bcs-process")
+	if err != nil {
+		t.Fatalf("new manager error %s", err.Error())
+	}
+	if m.Version() != Version2 {
+		t.Fatalf("expect version %s, but got %s", Version2, m.Version())
+	}
+	by, _ := ioutil.ReadFile(filepath.Join(root, "cgroup.subtree_control"))
+	if string(by) != "cpu io memory\n" {
+		t.Errorf("unexpected root cgroup.subtree_control %s", string(by))
+	}
+	by, _ = ioutil.ReadFile(filepath.Join(root, "bcs-process", "cgroup.subtree_control"))
+	if string(by) != "+cpu +memory" {
+		t.Errorf("unexpected parent cgroup.subtree_control %s", string(by))
+	}
+
+	if err = m.Create("proc-1", &Resources{Cpus: 1.5, Mem: 128}); err != nil {
+		t.Fatalf("create cgroup error %s", err.Error())
+	}
+	dir := filepath.Join(root, "bcs-process", "proc-1")
+	by, _ = ioutil.ReadFile(filepath.Join(dir, "cpu.max"))
+	if string(by) != "150000 100000" {
+		t.Errorf("unexpected cpu.max %s", string(by))
+	}
+	by, _ = ioutil.ReadFile(filepath.Join(dir, "memory.max"))
+	if string(by) != "134217728" {
+		t.Errorf("unexpected memory.max %s", string(by))
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, "cpu.stat"), []byte("usage_usec 2000\nuser_usec 1500\n"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "memory.current"), []byte("4096\n"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "memory.events"), []byte("low 0\nhigh 0\nmax 3\noom 2\noom_kill 1\n"), 0644)
+	stats, err := m.Stats("proc-1")
+	if err != nil {
+		t.Fatalf("stats error %s", err.Error())
+	}
+	if stats.CPUUsageNanos != 2000000 || stats.MemoryUsage != 4096 || stats.MemoryLimit != 134217728 ||
+		stats.OOMKillCount != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestV2ManagerNotDelegated(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup-v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	ioutil.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpu memory"), 0644)
+	ioutil.WriteFile(filepath.Join(root, "cgroup.subtree_control"), []byte("cpu\n"), 0644)
+	os.MkdirAll(filepath.Join(root, "bcs-process"), 0755)
+	ioutil.WriteFile(filepath.Join(root, "bcs-process", "cgroup.controllers"), []byte("cpu\n"), 0644)
+
+	if _, err = NewManager(root, "bcs-process"); err == nil || !strings.Contains(err.Error(), "memory") {
+		t.Fatalf("expect error of undelegated memory controller, but got %v", err)
+	}
+	if _, err = NewManager(root, "/"); err == nil {
+		t.Fatalf("expect error of root cgroup as parent")
+	}
+	//root cgroup is never written
+	by, _ := ioutil.ReadFile(filepath.Join(root, "cgroup.subtree_control"))
+	if string(by) != "cpu\n" {
+		t.Errorf("unexpected root cgroup.subtree_control %s", string(by))
+	}
+	if _, err = os.Stat(filepath.Join(root, "bcs-process", "cgroup.subtree_control")); !os.IsNotExist(err) {
+		t.Errorf("expect parent cgroup.subtree_control not written, %v", err)
+	}
+}
+
+func TestV1Manager(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup-v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	for _, subsystem := range v1Subsystems {
+		os.MkdirAll(filepath.Join(root, subsystem), 0755)
+	}
+
+	m, err := NewManager(root, "bcs-process")
+	if err != nil {
+		t.Fatalf("new manager error %s", err.Error())
+	}
+	if m.Version() != Version1 {
+		t.Fatalf("expect version %s, but got %s", Version1, m.Version())
+	}
+
+	if err = m.Create("proc-1", &Resources{Cpus: 0.5}); err != nil {
+		t.Fatalf("create cgroup error %s", err.Error())
+	}
+	by, _ := ioutil.ReadFile(filepath.Join(root, "cpu", "bcs-process", "proc-1", "cpu.cfs_quota_us"))
+	if string(by) != "50000" {
+		t.Errorf("unexpected cpu.cfs_quota_us %s", string(by))
+	}
+
+	memDir := filepath.Join(root, "memory", "bcs-process", "proc-1")
+	ioutil.WriteFile(filepath.Join(root, "cpuacct", "bcs-process", "proc-1", "cpuacct.usage"), []byte("12345\n"), 0644)
+	ioutil.WriteFile(filepath.Join(memDir, "memory.usage_in_bytes"), []byte("8192\n"), 0644)
+	ioutil.WriteFile(filepath.Join(memDir, "memory.limit_in_bytes"), []byte("9223372036854771712\n"), 0644)
+	ioutil.WriteFile(filepath.Join(memDir, "memory.oom_control"),
+		[]byte(strings.Join([]string{"oom_kill_disable 0", "under_oom 0", "oom_kill 2"}, "\n")), 0644)
+	stats, err := m.Stats("proc-1")
+	if err != nil {
+		t.Fatalf("stats error %s", err.Error())
+	}
+	if stats.CPUUsageNanos != 12345 || stats.MemoryUsage != 8192 || stats.MemoryLimit != 0 || stats.OOMKillCount != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
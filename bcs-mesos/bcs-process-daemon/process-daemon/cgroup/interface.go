@@ -0,0 +1,64 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cgroup
+
+const (
+	//Version1 cgroup v1, controllers are mounted separately
+	Version1 = "v1"
+	//Version2 cgroup v2, unified hierarchy
+	Version2 = "v2"
+
+	//DefaultCPUPeriod cfs period in microseconds
+	DefaultCPUPeriod = 100000
+)
+
+//Resources limits of process cgroup
+type Resources struct {
+	//cpu cores, 0 means no limit
+	Cpus float64
+	//memory MB, 0 means no limit
+	Mem float64
+}
+
+//Stats usage of process cgroup
+type Stats struct {
+	//cpu time of all processes in cgroup, nanoseconds
+	CPUUsageNanos uint64
+	//memory usage bytes
+	MemoryUsage uint64
+	//memory limit bytes, 0 means no limit
+	MemoryLimit uint64
+	//times of processes in cgroup killed by oom killer
+	OOMKillCount uint64
+}
+
+//Manager manage cgroups of processes, every process has its own cgroup
+//under parent cgroup, named by process id
+type Manager interface {
+	//cgroup version, v1 or v2
+	Version() string
+
+	//create cgroup of process, and set limits
+	Create(id string, resources *Resources) error
+
+	//move pid into cgroup of process,
+	//the children forked by pid are in the cgroup too
+	Apply(id string, pid int) error
+
+	//get usage stats of process cgroup
+	Stats(id string) (*Stats, error)
+
+	//kill processes left in cgroup, and remove it
+	Destroy(id string) error
+}
@@ -0,0 +1,135 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cgroup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+//cgroup v1 controllers used by process
+var v1Subsystems = []string{"cpu", "cpuacct", "memory"}
+
+type v1Manager struct {
+	root   string
+	parent string
+}
+
+func newV1Manager(root, parent string) (Manager, error) {
+	m := &v1Manager{
+		root:   root,
+		parent: parent,
+	}
+	for _, subsystem := range v1Subsystems {
+		if _, err := os.Stat(filepath.Join(root, subsystem)); err != nil {
+			return nil, fmt.Errorf("cgroup v1 subsystem %s is not mounted, %s", subsystem, err.Error())
+		}
+	}
+	return m, nil
+}
+
+func (m *v1Manager) dir(subsystem, id string) string {
+	return filepath.Join(m.root, subsystem, m.parent, id)
+}
+
+func (m *v1Manager) Version() string {
+	return Version1
+}
+
+func (m *v1Manager) Create(id string, resources *Resources) error {
+	for _, subsystem := range v1Subsystems {
+		if err := os.MkdirAll(m.dir(subsystem, id), 0755); err != nil {
+			return fmt.Errorf("create cgroup %s error %s", m.dir(subsystem, id), err.Error())
+		}
+	}
+	if resources == nil {
+		return nil
+	}
+
+	if resources.Cpus > 0 {
+		dir := m.dir("cpu", id)
+		shares := int64(resources.Cpus * 1024)
+		if shares < 2 {
+			shares = 2
+		}
+		if err := writeFile(dir, "cpu.shares", strconv.FormatInt(shares, 10)); err != nil {
+			return err
+		}
+		if err := writeFile(dir, "cpu.cfs_period_us", strconv.Itoa(DefaultCPUPeriod)); err != nil {
+			return err
+		}
+		quota := int64(resources.Cpus * DefaultCPUPeriod)
+		if err := writeFile(dir, "cpu.cfs_quota_us", strconv.FormatInt(quota, 10)); err != nil {
+			return err
+		}
+	}
+
+	if resources.Mem > 0 {
+		limit := int64(resources.Mem * 1024 * 1024)
+		if err := writeFile(m.dir("memory", id), "memory.limit_in_bytes", strconv.FormatInt(limit, 10)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *v1Manager) Apply(id string, pid int) error {
+	for _, subsystem := range v1Subsystems {
+		if err := writeFile(m.dir(subsystem, id), "cgroup.procs", strconv.Itoa(pid)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *v1Manager) Stats(id string) (*Stats, error) {
+	stats := &Stats{}
+	var err error
+
+	stats.CPUUsageNanos, err = readUint(m.dir("cpuacct", id), "cpuacct.usage")
+	if err != nil {
+		return nil, err
+	}
+	stats.MemoryUsage, err = readUint(m.dir("memory", id), "memory.usage_in_bytes")
+	if err != nil {
+		return nil, err
+	}
+	stats.MemoryLimit, err = readUint(m.dir("memory", id), "memory.limit_in_bytes")
+	if err != nil {
+		return nil, err
+	}
+	//memory.limit_in_bytes is a huge number when no limit
+	if stats.MemoryLimit >= 1<<62 {
+		stats.MemoryLimit = 0
+	}
+
+	//oom_kill is supported since linux 4.13
+	control, err := readKeyValue(m.dir("memory", id), "memory.oom_control")
+	if err != nil {
+		return nil, err
+	}
+	stats.OOMKillCount = control["oom_kill"]
+	return stats, nil
+}
+
+func (m *v1Manager) Destroy(id string) error {
+	for _, subsystem := range v1Subsystems {
+		if err := destroyDir(m.dir(subsystem, id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
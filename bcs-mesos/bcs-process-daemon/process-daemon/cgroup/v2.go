@@ -0,0 +1,162 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cgroup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//cgroup v2 controllers used by process
+var v2Controllers = []string{"cpu", "memory"}
+
+type v2Manager struct {
+	root   string
+	parent string
+}
+
+func newV2Manager(root, parent string) (Manager, error) {
+	m := &v2Manager{
+		root:   root,
+		parent: parent,
+	}
+
+	//controllers must be delegated to parent cgroup by its ancestors, such as systemd unit
+	//with Delegate=yes, they are only enabled for children of parent cgroup
+	parentDir := filepath.Join(root, parent)
+	if parentDir == filepath.Clean(root) {
+		return nil, fmt.Errorf("cgroup parent %s can't be root cgroup %s", parent, root)
+	}
+	if err := os.MkdirAll(parentDir, 0755); err != nil {
+		return nil, fmt.Errorf("create cgroup %s error %s", parentDir, err.Error())
+	}
+	if err := enableV2Controllers(parentDir); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+//enableV2Controllers enable controllers missing in subtree_control of dir,
+//the controllers must be available in cgroup.controllers of dir
+func enableV2Controllers(dir string) error {
+	by, err := ioutil.ReadFile(filepath.Join(dir, "cgroup.controllers"))
+	if err != nil {
+		return fmt.Errorf("read cgroup.controllers of %s error %s", dir, err.Error())
+	}
+	available := strings.Fields(string(by))
+	if undelegated := missingControllers(available); len(undelegated) != 0 {
+		return fmt.Errorf("controllers %s are not delegated to cgroup %s, enable them in "+
+			"cgroup.subtree_control of its parent", strings.Join(undelegated, ","), dir)
+	}
+
+	by, _ = ioutil.ReadFile(filepath.Join(dir, "cgroup.subtree_control"))
+	missing := missingControllers(strings.Fields(string(by)))
+	if len(missing) == 0 {
+		return nil
+	}
+	for i := range missing {
+		missing[i] = "+" + missing[i]
+	}
+	return writeFile(dir, "cgroup.subtree_control", strings.Join(missing, " "))
+}
+
+//missingControllers controllers used by process but not in names
+func missingControllers(names []string) []string {
+	var missing []string
+	for _, controller := range v2Controllers {
+		found := false
+		for _, name := range names {
+			if name == controller {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, controller)
+		}
+	}
+	return missing
+}
+
+func (m *v2Manager) dir(id string) string {
+	return filepath.Join(m.root, m.parent, id)
+}
+
+func (m *v2Manager) Version() string {
+	return Version2
+}
+
+func (m *v2Manager) Create(id string, resources *Resources) error {
+	dir := m.dir(id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create cgroup %s error %s", dir, err.Error())
+	}
+	if resources == nil {
+		return nil
+	}
+
+	if resources.Cpus > 0 {
+		quota := int64(resources.Cpus * DefaultCPUPeriod)
+		if err := writeFile(dir, "cpu.max", fmt.Sprintf("%d %d", quota, DefaultCPUPeriod)); err != nil {
+			return err
+		}
+	}
+
+	if resources.Mem > 0 {
+		limit := int64(resources.Mem * 1024 * 1024)
+		if err := writeFile(dir, "memory.max", strconv.FormatInt(limit, 10)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *v2Manager) Apply(id string, pid int) error {
+	return writeFile(m.dir(id), "cgroup.procs", strconv.Itoa(pid))
+}
+
+func (m *v2Manager) Stats(id string) (*Stats, error) {
+	dir := m.dir(id)
+	stats := &Stats{}
+
+	cpuStat, err := readKeyValue(dir, "cpu.stat")
+	if err != nil {
+		return nil, err
+	}
+	stats.CPUUsageNanos = cpuStat["usage_usec"] * 1000
+
+	stats.MemoryUsage, err = readUint(dir, "memory.current")
+	if err != nil {
+		return nil, err
+	}
+	stats.MemoryLimit, err = readUint(dir, "memory.max")
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := readKeyValue(dir, "memory.events")
+	if err != nil {
+		return nil, err
+	}
+	stats.OOMKillCount = events["oom_kill"]
+	return stats, nil
+}
+
+func (m *v2Manager) Destroy(id string) error {
+	return destroyDir(m.dir(id))
+}
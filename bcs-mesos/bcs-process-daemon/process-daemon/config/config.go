@@ -16,4 +16,18 @@ package config
 type Config struct {
 	DataDir      string
 	WorkspaceDir string
+
+	//cgroup of processes, process isolation is disabled if CgroupParent is empty.
+	//cpu and memory controllers must be delegated to CgroupParent when cgroup v2 is used
+	CgroupRoot   string
+	CgroupParent string
+
+	//content-addressed package cache shared by processes, default WorkspaceDir/package_cache
+	PackageCacheDir string
+	//max size of package cache in MB, 0 means no limit
+	PackageCacheSize int64
+	//PEM public key file to verify package signatures
+	PackagePublicKey string
+	//reject packages without sha256 or signature
+	RequireVerifiedPackage bool
 }
@@ -0,0 +1,163 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package manager
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-daemon/process-daemon/cgroup"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-executor/process-executor/types"
+)
+
+//init cgroup manager, process isolation is disabled when cgroup parent is empty or cgroup is unavailable
+func (m *manager) initCgroups() {
+	if m.conf.CgroupParent == "" {
+		blog.Infof("cgroup parent is empty, process resource isolation is disabled")
+		return
+	}
+
+	cgroups, err := cgroup.NewManager(m.conf.CgroupRoot, m.conf.CgroupParent)
+	if err != nil {
+		blog.Errorf("init cgroup root %s parent %s error %s, process resource isolation is disabled",
+			m.conf.CgroupRoot, m.conf.CgroupParent, err.Error())
+		return
+	}
+	m.cgroups = cgroups
+	blog.Infof("init cgroup %s root %s parent %s success", cgroups.Version(), m.conf.CgroupRoot, m.conf.CgroupParent)
+}
+
+//create process cgroup with limits of process resource
+func (m *manager) createProcessCgroup(processInfo *types.ProcessInfo) error {
+	if m.cgroups == nil {
+		return nil
+	}
+
+	resources := &cgroup.Resources{}
+	if processInfo.Resource != nil {
+		resources.Cpus = processInfo.Resource.Cpus
+		resources.Mem = processInfo.Resource.Mem
+	}
+	err := m.cgroups.Create(processInfo.Id, resources)
+	if err != nil {
+		blog.Errorf("process %s create cgroup cpus %f mem %fMB error %s", processInfo.Id,
+			resources.Cpus, resources.Mem, err.Error())
+		return fmt.Errorf("create cgroup error %s", err.Error())
+	}
+
+	blog.Infof("process %s create cgroup cpus %f mem %fMB success", processInfo.Id, resources.Cpus, resources.Mem)
+	return nil
+}
+
+const (
+	//shell wrapping start command when resource isolation is enabled
+	cgroupWrapperShell = "/bin/sh"
+	//wait on fd 3 until daemon moves the shell into process cgroup, then exec start command,
+	//the shell exits without executing start command if daemon closes fd 3 without ready signal
+	cgroupWrapperScript = `read -r ready <&3 || exit 1; exec 3<&-; exec "$0" "$@"`
+)
+
+//startInProcessCgroup starts cmd in process cgroup if resource isolation is enabled.
+//cmd is wrapped by shell which is moved into process cgroup before it execs start command,
+//so start command and processes forked by it never run outside the cgroup
+func (m *manager) startInProcessCgroup(processInfo *types.ProcessInfo, cmd *exec.Cmd) error {
+	if m.cgroups == nil {
+		return cmd.Start()
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("create cgroup ready pipe error %s", err.Error())
+	}
+	args := cmd.Args
+	if len(args) == 0 {
+		args = []string{cmd.Path}
+	}
+	cmd.Args = append([]string{cgroupWrapperShell, "-c", cgroupWrapperScript, cmd.Path}, args[1:]...)
+	cmd.Path = cgroupWrapperShell
+	cmd.ExtraFiles = []*os.File{reader}
+
+	err = cmd.Start()
+	reader.Close()
+	if err != nil {
+		writer.Close()
+		return err
+	}
+
+	pid := cmd.Process.Pid
+	err = m.cgroups.Apply(processInfo.Id, pid)
+	if err == nil {
+		_, err = writer.Write([]byte("\n"))
+	}
+	writer.Close()
+	if err != nil {
+		blog.Errorf("process %s apply pid %d to cgroup error %s", processInfo.Id, pid, err.Error())
+		//the wrapper exits without executing start command
+		cmd.Wait()
+		return fmt.Errorf("apply pid %d to cgroup error %s", pid, err.Error())
+	}
+	blog.V(3).Infof("process %s apply pid %d to cgroup success", processInfo.Id, pid)
+	return nil
+}
+
+//update process usage and oom events from process cgroup
+func (m *manager) updateProcessUsage(processInfo *types.ProcessInfo) {
+	if m.cgroups == nil {
+		return
+	}
+
+	stats, err := m.cgroups.Stats(processInfo.Id)
+	if err != nil {
+		blog.Errorf("process %s get cgroup stats error %s", processInfo.Id, err.Error())
+		return
+	}
+
+	status := processInfo.StatusInfo
+	now := time.Now()
+	usage := &types.ProcessUsage{
+		CPUUsageNanos: stats.CPUUsageNanos,
+		MemoryUsage:   stats.MemoryUsage,
+		MemoryLimit:   stats.MemoryLimit,
+		Timestamp:     now.UnixNano(),
+	}
+	last := status.Usage
+	if last != nil && usage.Timestamp > last.Timestamp && usage.CPUUsageNanos >= last.CPUUsageNanos {
+		usage.CPUUsage = float64(usage.CPUUsageNanos-last.CPUUsageNanos) / float64(usage.Timestamp-last.Timestamp)
+	}
+	status.Usage = usage
+
+	if stats.OOMKillCount > status.OOMKillCount {
+		blog.Warnf("process %s oom killed %d times, memory usage %d limit %d", processInfo.Id,
+			stats.OOMKillCount-status.OOMKillCount, stats.MemoryUsage, stats.MemoryLimit)
+		status.LastOOMKillTime = now.Unix()
+	}
+	status.OOMKillCount = stats.OOMKillCount
+}
+
+//remove process cgroup, processes left in it are killed
+func (m *manager) destroyProcessCgroup(processInfo *types.ProcessInfo) {
+	if m.cgroups == nil {
+		return
+	}
+
+	err := m.cgroups.Destroy(processInfo.Id)
+	if err != nil {
+		blog.Errorf("process %s destroy cgroup error %s", processInfo.Id, err.Error())
+		return
+	}
+	blog.Infof("process %s destroy cgroup success", processInfo.Id)
+}
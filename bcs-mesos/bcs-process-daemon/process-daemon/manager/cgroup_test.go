@@ -0,0 +1,89 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package manager
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-daemon/process-daemon/cgroup"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-executor/process-executor/types"
+)
+
+//fakeCgroups records pids applied to process cgroup
+type fakeCgroups struct {
+	cgroup.Manager
+	applied  []int
+	applyErr error
+}
+
+func (f *fakeCgroups) Apply(id string, pid int) error {
+	f.applied = append(f.applied, pid)
+	return f.applyErr
+}
+
+func TestStartInProcessCgroup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "process-cgroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	script := filepath.Join(dir, "start.sh")
+	pidFile := filepath.Join(dir, "pid")
+	ioutil.WriteFile(script, []byte(fmt.Sprintf("#!/bin/sh\necho $$ $1 > %s\n", pidFile)), 0755)
+
+	tests := []struct {
+		name     string
+		applyErr error
+	}{
+		{name: "apply success"},
+		{name: "apply failed", applyErr: fmt.Errorf("permission denied")},
+	}
+	for _, test := range tests {
+		os.Remove(pidFile)
+		cgroups := &fakeCgroups{applyErr: test.applyErr}
+		m := &manager{cgroups: cgroups}
+		cmd := &exec.Cmd{Path: script, Args: []string{script, "arg1"}}
+
+		err := m.startInProcessCgroup(&types.ProcessInfo{Id: "proc-1"}, cmd)
+		if test.applyErr != nil {
+			if err == nil {
+				t.Errorf("%s: expect error", test.name)
+			}
+			if _, statErr := os.Stat(pidFile); statErr == nil {
+				t.Errorf("%s: start command should not run outside cgroup", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error %s", test.name, err.Error())
+		}
+		cmd.Wait()
+		if len(cgroups.applied) != 1 || cgroups.applied[0] != cmd.Process.Pid {
+			t.Fatalf("%s: expect pid %d applied, got %v", test.name, cmd.Process.Pid, cgroups.applied)
+		}
+		//start command is executed by the pid applied to cgroup
+		by, _ := ioutil.ReadFile(pidFile)
+		expect := strconv.Itoa(cmd.Process.Pid) + " arg1"
+		if strings.TrimSpace(string(by)) != expect {
+			t.Errorf("%s: expect start command output %s, got %s", test.name, expect, string(by))
+		}
+	}
+}
@@ -14,21 +14,25 @@
 package manager
 
 import (
+	"bytes"
 	"compress/gzip"
 	"crypto/md5"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
 	"os/user"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
 	"github.com/Tencent/bk-bcs/bcs-common/common/http/httpclient"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-daemon/process-daemon/cache"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-executor/process-executor/types"
 
 	"github.com/Microsoft/go-winio/archive/tar"
@@ -61,42 +65,112 @@ func (m *manager) requestManager(method, uri string, data []byte, header map[str
 	return resp.Reply, err
 }
 
-// RAR file：           /data/bcs/workspace/packages_dir/lgame_v1.tar.gz
-// unzipped directory： /data/bcs/workspace/extract_dir/lgame_v1/***
-// symbolic link：      /data/bcs/workspace/work_dir/lgame_v1  -> /data/bcs/extract_dir/lgame_v1
+// package file：     /data/bcs/workspace/package_cache/${sha256}
+// unzipped directory：/data/bcs/workspace/extract_dir/${sha256}/***
+// symbolic link：     ${outputDir} -> /data/bcs/workspace/extract_dir/${sha256}
 
-func (m *manager) downloadAndTarProcessPackages(processInfo *types.ProcessInfo) error {
+//initPackageCache init package cache and public key for package signatures
+func (m *manager) initPackageCache() error {
+	dir := m.conf.PackageCacheDir
+	if dir == "" {
+		dir = filepath.Join(m.conf.WorkspaceDir, "package_cache")
+	}
+
+	var err error
+	m.packageCache, err = cache.NewPackageCache(dir, m.conf.PackageCacheSize*1024*1024, func(digest string) {
+		m.removeExtractedPackage(digest)
+	})
+	if err != nil {
+		blog.Errorf("init package cache %s error %s", dir, err.Error())
+		return err
+	}
+
+	if m.conf.PackagePublicKey != "" {
+		m.packagePublicKey, err = loadPackagePublicKey(m.conf.PackagePublicKey)
+		if err != nil {
+			blog.Errorf("load package public key %s error %s", m.conf.PackagePublicKey, err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+//downloadAndTarProcessPackages fetch process package into package cache and extract it,
+//return the reason if failed
+func (m *manager) downloadAndTarProcessPackages(processInfo *types.ProcessInfo) (types.ProcessStatusReason, error) {
 	if processInfo.Uris == nil || len(processInfo.Uris) == 0 {
-		return nil
+		return "", nil
 	}
 	uriPack := processInfo.Uris[0]
-	m.lockObjectKey(uriPack.PackagesFile)
-	defer m.unLockObjectKey(uriPack.PackagesFile)
+	m.lockObjectKey(uriPack.Value)
+	defer m.unLockObjectKey(uriPack.Value)
 
-	exist, err := m.downloadProcessPackages(processInfo)
+	reason, err := m.fetchProcessPackage(processInfo)
 	if err != nil {
-		return err
+		return reason, err
+	}
+
+	//processes with the same package share the extracted directory
+	m.lockObjectKey(uriPack.Digest)
+	defer m.unLockObjectKey(uriPack.Digest)
+	uriPack.ExtractDir = m.extractDir(uriPack.Digest)
+	_, err = os.Stat(uriPack.ExtractDir + ".done")
+	if err == nil {
+		blog.Infof("process %s package %s is extracted in %s", processInfo.Id, uriPack.Digest, uriPack.ExtractDir)
+		return "", nil
+	}
+
+	err = m.extractProcessPackage(processInfo)
+	if err != nil {
+		return types.ProcessReasonPackageExtractFailed, err
+	}
+	err = ioutil.WriteFile(uriPack.ExtractDir+".done", []byte(uriPack.PackagesFile), 0644)
+	if err != nil {
+		blog.Errorf("process %s write file %s error %s", processInfo.Id, uriPack.ExtractDir+".done", err.Error())
+		return types.ProcessReasonPackageExtractFailed, err
 	}
 
-	if exist {
-		blog.Infof("process %s PackagesFile %s is valid, and not need extract", processInfo.Id, uriPack.PackagesFile)
-		return nil
+	return "", nil
+}
+
+func (m *manager) extractDir(digest string) string {
+	return filepath.Join(m.conf.WorkspaceDir, "extract_dir", digest)
+}
+
+//remove extracted directory of package evicted from cache
+func (m *manager) removeExtractedPackage(digest string) {
+	dir := m.extractDir(digest)
+	os.Remove(dir + ".done")
+	err := os.RemoveAll(dir)
+	if err != nil {
+		blog.Errorf("remove extracted package %s error %s", dir, err.Error())
+		return
 	}
+	blog.Infof("remove extracted package %s success", dir)
+}
+
+func (m *manager) extractProcessPackage(processInfo *types.ProcessInfo) error {
+	uriPack := processInfo.Uris[0]
 
 	//whether uriPack.ExtractDir exist
-	_, err = os.Stat(uriPack.ExtractDir)
+	_, err := os.Stat(uriPack.ExtractDir)
 	if err != nil {
 		blog.Errorf("process %s ExtractDir %s not exist, and need mkdir", processInfo.Id, uriPack.ExtractDir)
-		err = os.MkdirAll(filepath.Dir(uriPack.ExtractDir), 0755)
+		err = os.MkdirAll(uriPack.ExtractDir, 0755)
 		if err != nil {
-			blog.Errorf("process %s mkdir %s error %s", processInfo.Id, filepath.Dir(uriPack.OutputDir), err.Error())
+			blog.Errorf("process %s mkdir %s error %s", processInfo.Id, uriPack.ExtractDir, err.Error())
 			return err
 		}
 	} else {
 		blog.Infof("process %s ExtractDir %s exist, and need remove", processInfo.Id, uriPack.ExtractDir)
 		err = os.RemoveAll(uriPack.ExtractDir)
 		if err != nil {
-			blog.Errorf("process %s remove file %s error %s", processInfo.Id, uriPack.OutputDir, err.Error())
+			blog.Errorf("process %s remove file %s error %s", processInfo.Id, uriPack.ExtractDir, err.Error())
+			return err
+		}
+		err = os.MkdirAll(uriPack.ExtractDir, 0755)
+		if err != nil {
+			blog.Errorf("process %s mkdir %s error %s", processInfo.Id, uriPack.ExtractDir, err.Error())
 			return err
 		}
 	}
@@ -177,85 +251,132 @@ func (m *manager) downloadAndTarProcessPackages(processInfo *types.ProcessInfo)
 	return nil
 }
 
-func (m *manager) downloadProcessPackages(processInfo *types.ProcessInfo) (bool, error) {
-	//"http://xxxx.artifactory.xxxx.com/generic-local/xxxx/pack-master.tar.gz" convert to
-	//"http://xxxx.artifactory.xxxx.com/api/storage/generic-local/xxxx/pack-master.tar.gz"
+//fetchProcessPackage get verified process package from package cache, or download it into cache
+func (m *manager) fetchProcessPackage(processInfo *types.ProcessInfo) (types.ProcessStatusReason, error) {
 	uriPack := processInfo.Uris[0]
-	u, err := url.Parse(uriPack.Value)
-	if err != nil {
-		blog.Errorf("process %s url.parse %s error %s", processInfo.Id, uriPack.Value, err.Error())
-		return false, err
+	expectDigest := strings.ToLower(uriPack.Sha256)
+	if expectDigest == "" && uriPack.Signature == "" && m.conf.RequireVerifiedPackage {
+		blog.Errorf("process %s package %s has neither sha256 nor signature", processInfo.Id, uriPack.Value)
+		return types.ProcessReasonPackageUnverified,
+			fmt.Errorf("package %s has neither sha256 nor signature", uriPack.Value)
+	}
+	if uriPack.Signature != "" && m.packagePublicKey == nil {
+		blog.Errorf("process %s package %s is signed, but package public key is not configured",
+			processInfo.Id, uriPack.Value)
+		return types.ProcessReasonPackageSignatureInvalid,
+			fmt.Errorf("package %s is signed, but package public key is not configured", uriPack.Value)
+	}
+
+	//package pinned by sha256 is used from cache without registry
+	if expectDigest != "" {
+		if file, ok := m.packageCache.Get(expectDigest, processInfo.Id); ok {
+			blog.Infof("process %s package %s sha256 %s is cached", processInfo.Id, uriPack.Value, expectDigest)
+			return m.useCachedPackage(processInfo, expectDigest, file)
+		}
 	}
 
-	uri := fmt.Sprintf("%s://%s/api/storage%s", u.Scheme, u.Host, u.Path)
 	auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", uriPack.User, uriPack.Pwd)))
 	header := map[string]string{"Authorization": fmt.Sprintf("Basic %s", auth)}
-	by, err := m.requestManager("GET", uri, nil, header)
-	if err != nil {
-		blog.Errorf("check process %s packages uri %s error %s", processInfo.Id, uri, err.Error())
-		return false, err
+
+	//checksums of package in registry, the cached package with the same sha256 is used
+	var checksums *types.CheckSum
+	if expectDigest == "" {
+		packageInfo, err := m.getRegistryPackageInfo(processInfo, header)
+		if err != nil {
+			return types.ProcessReasonPackageDownloadFailed, err
+		}
+		checksums = packageInfo.Checksums
+		if checksums != nil && checksums.Sha256 != "" {
+			digest := strings.ToLower(checksums.Sha256)
+			if file, ok := m.packageCache.Get(digest, processInfo.Id); ok {
+				blog.Infof("process %s package %s registry sha256 %s is cached", processInfo.Id, uriPack.Value, digest)
+				return m.useCachedPackage(processInfo, digest, file)
+			}
+		}
 	}
-	blog.Infof("process %s check jfroginfo %s", processInfo.Id, string(by))
 
-	var packegeInfo *types.JfrogRegistry
-	err = json.Unmarshal(by, &packegeInfo)
+	by, err := m.requestManager("GET", uriPack.Value, nil, header)
 	if err != nil {
-		blog.Errorf("process %s unmarshal data %s to types.JfrogRegistry error %s", processInfo.Id, string(by), err.Error())
-		return false, err
+		blog.Errorf("download process %s packages uri %s error %s", processInfo.Id, uriPack.Value, err.Error())
+		return types.ProcessReasonPackageDownloadFailed, err
 	}
 
-	/*if uriPack.Value!=packegeInfo.DownloadUri {
-		blog.Errorf("process %s DownloadUri %s is invalid",processInfo.Id,uriPack.Value)
-		return fmt.Errorf("DownloadUri %s is invalid",uriPack.Value)
-	}*/
-
-	var packMd5 string
-	h := md5.New()
-	f, err := os.Open(uriPack.PackagesFile)
-	if err != nil {
-		blog.Errorf("process %s open PackagesFile %s error %s", processInfo.Id, uriPack.PackagesFile, err.Error())
-		goto DownloadRESP
+	//package broken in transit
+	if checksums != nil && checksums.Md5 != "" {
+		packMd5 := fmt.Sprintf("%x", md5.Sum(by))
+		if packMd5 != checksums.Md5 {
+			blog.Errorf("process %s package %s md5 %s doesn't match registry %s", processInfo.Id, uriPack.Value,
+				packMd5, checksums.Md5)
+			return types.ProcessReasonPackageDigestMismatch,
+				fmt.Errorf("package md5 %s doesn't match registry %s", packMd5, checksums.Md5)
+		}
 	}
 
-	_, err = io.Copy(h, f)
-	f.Close()
+	digest, file, err := m.packageCache.Add(bytes.NewReader(by), expectDigest, processInfo.Id)
 	if err != nil {
-		blog.Errorf("process %s copy PackagesFile %s error %s", processInfo.Id, uriPack.Value, err.Error())
-		goto DownloadRESP
+		blog.Errorf("process %s add package %s to cache error %s", processInfo.Id, uriPack.Value, err.Error())
+		if _, ok := err.(*cache.DigestMismatchError); ok {
+			return types.ProcessReasonPackageDigestMismatch, err
+		}
+		return types.ProcessReasonPackageDownloadFailed, err
 	}
+	blog.Infof("process %s download package %s sha256 %s success", processInfo.Id, uriPack.Value, digest)
 
-	packMd5 = fmt.Sprintf("%x", h.Sum(nil))
-	if packMd5 == packegeInfo.Checksums.Md5 {
-		blog.Infof("process %s PackagesFile %s md5 %s is valid, and not need download", processInfo.Id, uriPack.PackagesFile, packegeInfo.Checksums.Md5)
-		return true, nil
+	return m.useCachedPackage(processInfo, digest, file)
+}
+
+//useCachedPackage verify signature of cached package, and use it for process
+func (m *manager) useCachedPackage(processInfo *types.ProcessInfo, digest, file string) (
+	types.ProcessStatusReason, error) {
+	uriPack := processInfo.Uris[0]
+	if uriPack.Signature != "" {
+		err := verifyPackageSignature(m.packagePublicKey, digest, uriPack.Signature)
+		if err != nil {
+			m.packageCache.Release(digest, processInfo.Id)
+			blog.Errorf("process %s package %s sha256 %s signature is invalid, %s", processInfo.Id,
+				uriPack.Value, digest, err.Error())
+			return types.ProcessReasonPackageSignatureInvalid,
+				fmt.Errorf("package %s signature is invalid, %s", uriPack.Value, err.Error())
+		}
+		blog.Infof("process %s package %s sha256 %s signature is valid", processInfo.Id, uriPack.Value, digest)
 	}
 
-DownloadRESP:
-	blog.Errorf("process %s PackagesFile %s md5 %s is invalid, and need download", processInfo.Id, uriPack.PackagesFile, packMd5)
-	err = os.MkdirAll(filepath.Dir(uriPack.PackagesFile), 0755)
-	if err != nil {
-		blog.Errorf("process %s mkdir %s error %s", processInfo.Id, filepath.Dir(uriPack.PackagesFile), err.Error())
-		return false, err
+	//process moves to another package, the old one can be evicted
+	if uriPack.Digest != "" && uriPack.Digest != digest {
+		blog.Infof("process %s package changes from sha256 %s to %s", processInfo.Id, uriPack.Digest, digest)
+		m.packageCache.Release(uriPack.Digest, processInfo.Id)
 	}
+	uriPack.Digest = digest
+	uriPack.PackagesFile = file
+	return "", nil
+}
 
-	file, err := os.OpenFile(uriPack.PackagesFile, os.O_RDWR|os.O_CREATE, 0644)
+//getRegistryPackageInfo get package info from jfrog storage api
+func (m *manager) getRegistryPackageInfo(processInfo *types.ProcessInfo, header map[string]string) (
+	*types.JfrogRegistry, error) {
+	//"http://xxxx.artifactory.xxxx.com/generic-local/xxxx/pack-master.tar.gz" convert to
+	//"http://xxxx.artifactory.xxxx.com/api/storage/generic-local/xxxx/pack-master.tar.gz"
+	uriPack := processInfo.Uris[0]
+	u, err := url.Parse(uriPack.Value)
 	if err != nil {
-		blog.Errorf("process %s openfile %s error %s", processInfo.Id, uriPack.PackagesFile, err.Error())
-		return false, err
+		blog.Errorf("process %s url.parse %s error %s", processInfo.Id, uriPack.Value, err.Error())
+		return nil, err
 	}
-	defer file.Close()
 
-	by, err = m.requestManager("GET", uriPack.Value, nil, header)
+	uri := fmt.Sprintf("%s://%s/api/storage%s", u.Scheme, u.Host, u.Path)
+	by, err := m.requestManager("GET", uri, nil, header)
 	if err != nil {
-		blog.Errorf("download process %s packages uri %s error %s", processInfo.Id, uriPack.Value, err.Error())
-		return false, err
+		blog.Errorf("check process %s packages uri %s error %s", processInfo.Id, uri, err.Error())
+		return nil, err
 	}
+	blog.Infof("process %s check jfroginfo %s", processInfo.Id, string(by))
 
-	_, err = file.Write(by)
+	var packegeInfo *types.JfrogRegistry
+	err = json.Unmarshal(by, &packegeInfo)
 	if err != nil {
-		blog.Errorf("process %s write file %s error %s", processInfo.Id, uriPack.PackagesFile, err.Error())
-		return false, err
+		blog.Errorf("process %s unmarshal data %s to types.JfrogRegistry error %s", processInfo.Id, string(by), err.Error())
+		return nil, err
 	}
-	blog.Infof("process %s download packages %s success", processInfo.Id, uriPack.PackagesFile)
-	return false, nil
+
+	return packegeInfo, nil
 }
@@ -0,0 +1,195 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package manager
+
+import (
+	"crypto"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-daemon/process-daemon/cache"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-daemon/process-daemon/config"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-executor/process-executor/types"
+)
+
+//fakeRegistry serves package and its jfrog storage info
+type fakeRegistry struct {
+	sync.Mutex
+	data []byte
+}
+
+func (r *fakeRegistry) setPackage(data []byte) {
+	r.Lock()
+	defer r.Unlock()
+	r.data = data
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+
+	switch req.URL.Path {
+	case "/generic-local/pack.tar.gz":
+		w.Write(r.data)
+	case "/api/storage/generic-local/pack.tar.gz":
+		hashed := sha256.Sum256(r.data)
+		by, _ := json.Marshal(&types.JfrogRegistry{
+			Checksums: &types.CheckSum{
+				Md5:    fmt.Sprintf("%x", md5.Sum(r.data)),
+				Sha256: hex.EncodeToString(hashed[:]),
+			},
+		})
+		w.Write(by)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newPackageManager(t *testing.T, dir string, maxSize int64, key crypto.PublicKey, requireVerified bool) *manager {
+	m := &manager{
+		processLocks: make(map[string]*sync.Mutex),
+		conf: &config.Config{
+			WorkspaceDir:           dir,
+			RequireVerifiedPackage: requireVerified,
+		},
+		packagePublicKey: key,
+	}
+	m.initCli()
+	var err error
+	m.packageCache, err = cache.NewPackageCache(filepath.Join(dir, "package_cache"), maxSize, nil)
+	if err != nil {
+		t.Fatalf("create package cache error %s", err.Error())
+	}
+	return m
+}
+
+func newPackageProcess(id, uri, sha, signature string) *types.ProcessInfo {
+	return &types.ProcessInfo{
+		Id: id,
+		Uris: []*types.Uri{{
+			Value:     uri,
+			Sha256:    sha,
+			Signature: signature,
+		}},
+	}
+}
+
+func TestFetchProcessPackage(t *testing.T) {
+	rsaKey, ecdsaKey := newTestKeys(t)
+	data := []byte("process package")
+	digest, rsaSig := signPackage(t, rsaKey, data)
+	_, ecdsaSig := signPackage(t, ecdsaKey, data)
+	otherDigest, otherSig := signPackage(t, rsaKey, []byte("other package"))
+
+	registry := &fakeRegistry{data: data}
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	uri := server.URL + "/generic-local/pack.tar.gz"
+
+	tests := []struct {
+		name            string
+		key             crypto.PublicKey
+		requireVerified bool
+		sha256          string
+		signature       string
+		reason          types.ProcessStatusReason
+	}{
+		{name: "rsa signed", key: &rsaKey.PublicKey, signature: rsaSig},
+		{name: "rsa signed and pinned", key: &rsaKey.PublicKey, sha256: digest, signature: rsaSig},
+		{name: "ecdsa signed", key: &ecdsaKey.PublicKey, signature: ecdsaSig, requireVerified: true},
+		{name: "pinned", sha256: digest, requireVerified: true},
+		{name: "unverified", requireVerified: false},
+		{name: "signature of other package", key: &rsaKey.PublicKey, signature: otherSig,
+			reason: types.ProcessReasonPackageSignatureInvalid},
+		{name: "signature by other key", key: &ecdsaKey.PublicKey, signature: rsaSig,
+			reason: types.ProcessReasonPackageSignatureInvalid},
+		{name: "public key not configured", signature: rsaSig,
+			reason: types.ProcessReasonPackageSignatureInvalid},
+		{name: "digest mismatch", sha256: otherDigest, reason: types.ProcessReasonPackageDigestMismatch},
+		{name: "unverified required", requireVerified: true, reason: types.ProcessReasonPackageUnverified},
+	}
+	for i, test := range tests {
+		dir, err := ioutil.TempDir("", "package-fetch")
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := newPackageManager(t, dir, 0, test.key, test.requireVerified)
+		processInfo := newPackageProcess(fmt.Sprintf("process-%d", i), uri, test.sha256, test.signature)
+
+		reason, err := m.fetchProcessPackage(processInfo)
+		uriPack := processInfo.Uris[0]
+		if reason != test.reason {
+			t.Errorf("%s: expect reason %q, got %q, error %v", test.name, test.reason, reason, err)
+		}
+		if test.reason != "" {
+			if err == nil || uriPack.Digest != "" {
+				t.Errorf("%s: expect error and no package, got digest %q", test.name, uriPack.Digest)
+			}
+		} else {
+			by, _ := ioutil.ReadFile(uriPack.PackagesFile)
+			if err != nil || uriPack.Digest != digest || string(by) != string(data) {
+				t.Errorf("%s: expect package %s fetched, got digest %q error %v", test.name, digest, uriPack.Digest, err)
+			}
+		}
+		os.RemoveAll(dir)
+	}
+}
+
+func TestFetchProcessPackageChanged(t *testing.T) {
+	dir, err := ioutil.TempDir("", "package-changed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	registry := &fakeRegistry{data: []byte("package v1")}
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	//every package not used by processes is evicted
+	m := newPackageManager(t, dir, 1, nil, false)
+	processInfo := newPackageProcess("process", server.URL+"/generic-local/pack.tar.gz", "", "")
+	if _, err = m.fetchProcessPackage(processInfo); err != nil {
+		t.Fatalf("fetch package v1 error %s", err.Error())
+	}
+	oldDigest := processInfo.Uris[0].Digest
+	if _, err = os.Stat(m.packageCache.Path(oldDigest)); err != nil {
+		t.Fatalf("package v1 used by process is evicted")
+	}
+
+	registry.setPackage([]byte("package v2"))
+	if _, err = m.fetchProcessPackage(processInfo); err != nil {
+		t.Fatalf("fetch package v2 error %s", err.Error())
+	}
+	newDigest := processInfo.Uris[0].Digest
+	if newDigest == oldDigest {
+		t.Fatalf("expect package digest changed")
+	}
+	if _, err = os.Stat(m.packageCache.Path(newDigest)); err != nil {
+		t.Errorf("package v2 used by process is evicted")
+	}
+	if _, err = os.Stat(m.packageCache.Path(oldDigest)); !os.IsNotExist(err) {
+		t.Errorf("package v1 is still referenced by process after package changed")
+	}
+}
@@ -28,8 +28,8 @@ type Manager interface {
 	//get config
 	GetConfig() *config.Config
 
-	//heartbeat
-	HeartBeat(heartbeat *types.HeartBeat)
+	//heartbeat from executor, pong with process status and resource usage
+	HeartBeat(heartbeat *types.HeartBeat) (*types.HeartBeat, error)
 
 	//Create process
 	CreateProcess(processInfo *types.ProcessInfo) error
@@ -15,9 +15,12 @@ package manager
 
 import (
 	"bytes"
+	"crypto"
 	"fmt"
 	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
 	"github.com/Tencent/bk-bcs/bcs-common/common/http/httpclient"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-daemon/process-daemon/cache"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-daemon/process-daemon/cgroup"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-daemon/process-daemon/config"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-daemon/process-daemon/store"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-process-executor/process-executor/types"
@@ -29,7 +32,6 @@ import (
 	"path"
 	"path/filepath"
 	"strconv"
-	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -51,6 +53,14 @@ type manager struct {
 	cli *httpclient.HttpClient
 
 	conf *config.Config
+
+	//cgroups of processes, nil if resource isolation is disabled
+	cgroups cgroup.Manager
+
+	//process packages shared by processes
+	packageCache *cache.PackageCache
+	//public key to verify package signatures
+	packagePublicKey crypto.PublicKey
 }
 
 func NewManager(conf *config.Config) Manager {
@@ -61,10 +71,16 @@ func NewManager(conf *config.Config) Manager {
 		conf:         conf,
 	}
 	m.initCli()
+	m.initCgroups()
 	return m
 }
 
 func (m *manager) Init() error {
+	err := m.initPackageCache()
+	if err != nil {
+		return err
+	}
+
 	processInfos, err := m.store.GetAllProcessInfos()
 	if err != nil {
 		blog.Errorf("get all processinfos error %s", err.Error())
@@ -74,6 +90,12 @@ func (m *manager) Init() error {
 	m.Lock()
 	for _, processInfo := range processInfos {
 		m.processInfos[processInfo.Id] = processInfo
+		//packages used by processes are kept in cache
+		for _, uri := range processInfo.Uris {
+			if uri.Digest != "" {
+				m.packageCache.Use(uri.Digest, processInfo.Id)
+			}
+		}
 	}
 	m.Unlock()
 
@@ -124,25 +146,32 @@ func (m *manager) unLockObjectKey(key string) {
 	myLock.Unlock()
 }
 
-func (m *manager) HeartBeat(heartbeat *types.HeartBeat) {
-	m.Lock()
-	defer m.Unlock()
-
+func (m *manager) HeartBeat(heartbeat *types.HeartBeat) (*types.HeartBeat, error) {
+	m.RLock()
 	process, ok := m.processInfos[heartbeat.ProcessId]
+	m.RUnlock()
 	if !ok {
 		blog.Errorf("heartbeat process %s not found", heartbeat.ProcessId)
-		return
+		return nil, fmt.Errorf("process %s not found", heartbeat.ProcessId)
 	}
 
 	m.lockObjectKey(process.Id)
+	defer m.unLockObjectKey(process.Id)
+
 	process.ExecutorHeartBeatTime = time.Now().Unix()
 	err := m.store.StoreProcessInfo(process)
 	if err != nil {
 		blog.Errorf("store processInfo %s error %s", process.Id, err.Error())
 	}
-	m.unLockObjectKey(process.Id)
 
-	return
+	status := *process.StatusInfo
+	pong := &types.HeartBeat{
+		ProcessId:  process.Id,
+		ExecutorId: heartbeat.ExecutorId,
+		Type:       types.HeartBeatPong,
+		StatusInfo: &status,
+	}
+	return pong, nil
 }
 
 func (m *manager) CreateProcess(processInfo *types.ProcessInfo) error {
@@ -163,14 +192,6 @@ func (m *manager) CreateProcess(processInfo *types.ProcessInfo) error {
 	processInfo.StatusInfo = status
 	processInfo.ExecutorHeartBeatTime = time.Now().Unix()
 
-	for _, uri := range processInfo.Uris {
-		arr := strings.Split(uri.Value, "/")
-		fileName := arr[len(arr)-1]
-
-		uri.PackagesFile = filepath.Join(m.conf.WorkspaceDir, "packages_dir", fileName)
-		uri.ExtractDir = filepath.Join(m.conf.WorkspaceDir, "extract_dir", strings.TrimRight(fileName, ".tar.gz"))
-	}
-
 	m.processInfos[processInfo.Id] = processInfo
 	err := m.store.StoreProcessInfo(processInfo)
 	if err != nil {
@@ -344,6 +365,12 @@ func (m *manager) DeleteProcess(processId string) error {
 		}
 	}
 
+	m.destroyProcessCgroup(processInfo)
+	for _, uri := range processInfo.Uris {
+		if uri.Digest != "" {
+			m.packageCache.Release(uri.Digest, processInfo.Id)
+		}
+	}
 	delete(m.processInfos, processInfo.Id)
 	blog.Infof("delete process %s success", processInfo.Id)
 
@@ -392,10 +419,13 @@ func (m *manager) startProcess(processInfo *types.ProcessInfo) {
 	m.lockObjectKey(processInfo.Id)
 	defer m.unLockObjectKey(processInfo.Id)
 
-	err := m.downloadAndTarProcessPackages(processInfo)
+	//reason of the last failed start doesn't apply to this start
+	processInfo.StatusInfo.Reason = ""
+	reason, err := m.downloadAndTarProcessPackages(processInfo)
 	if err != nil {
 		processInfo.StatusInfo.Status = types.ProcessStatusStopped
-		processInfo.StatusInfo.Message = err.Error()
+		processInfo.StatusInfo.Reason = reason
+		processInfo.StatusInfo.Message = fmt.Sprintf("%s: %s", reason, err.Error())
 		err = m.store.StoreProcessInfo(processInfo)
 		if err != nil {
 			blog.Errorf("store processInfo %s error %s", processInfo.Id, err.Error())
@@ -414,6 +444,17 @@ func (m *manager) startProcess(processInfo *types.ProcessInfo) {
 		return
 	}
 
+	err = m.createProcessCgroup(processInfo)
+	if err != nil {
+		processInfo.StatusInfo.Status = types.ProcessStatusStopped
+		processInfo.StatusInfo.Message = err.Error()
+		err = m.store.StoreProcessInfo(processInfo)
+		if err != nil {
+			blog.Errorf("store processInfo %s error %s", processInfo.Id, err.Error())
+		}
+		return
+	}
+
 	cmd := exec.Cmd{
 		Path: processInfo.StartCmd,
 		Args: processInfo.Argv,
@@ -437,7 +478,11 @@ func (m *manager) startProcess(processInfo *types.ProcessInfo) {
 
 	buf := bytes.NewBuffer(make([]byte, 1024))
 	cmd.Stderr = buf
-	err = cmd.Run()
+	//start command runs in process cgroup, then the process it forks is limited by the cgroup
+	err = m.startInProcessCgroup(processInfo, &cmd)
+	if err == nil {
+		err = cmd.Wait()
+	}
 	//_,err := os.StartProcess(processInfo.StartCmd,processInfo.Argv,attr)
 	if err != nil {
 		blog.Errorf("start process %s startcmd %s stderr %s error %s", processInfo.Id, processInfo.StartCmd, buf.String(), err.Error())
@@ -458,6 +503,7 @@ func (m *manager) startProcess(processInfo *types.ProcessInfo) {
 
 	processInfo.StatusInfo.Status = types.ProcessStatusStarting
 	processInfo.StatusInfo.LastStartTime = time.Now().Unix()
+	processInfo.StatusInfo.OOMKilled = false
 	err = m.store.StoreProcessInfo(processInfo)
 	if err != nil {
 		blog.Errorf("store processInfo %s error %s", processInfo.Id, err.Error())
@@ -515,9 +561,16 @@ func (m *manager) checkProcess(processInfo *types.ProcessInfo) {
 
 	oldStatus := processInfo.StatusInfo.Status
 	_, pid, err := m.processIsOk(processInfo)
+	m.updateProcessUsage(processInfo)
 	if err != nil {
 		processInfo.StatusInfo.Status = types.ProcessStatusStopped
 		processInfo.StatusInfo.Message = err.Error()
+		//oom kill after last start, the process is stopped by oom killer
+		if processInfo.StatusInfo.LastOOMKillTime >= processInfo.StatusInfo.LastStartTime &&
+			processInfo.StatusInfo.OOMKillCount > 0 {
+			processInfo.StatusInfo.OOMKilled = true
+			processInfo.StatusInfo.Message = fmt.Sprintf("process %s is oom killed, %s", processInfo.Id, err.Error())
+		}
 	} else {
 		processInfo.StatusInfo.Status = types.ProcessStatusRunning
 		processInfo.StatusInfo.Message = fmt.Sprintf("process %s is running", processInfo.Id)
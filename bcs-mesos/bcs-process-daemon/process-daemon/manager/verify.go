@@ -0,0 +1,85 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package manager
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+)
+
+//loadPackagePublicKey load PEM encoded PKIX public key, rsa and ecdsa keys are supported
+func loadPackagePublicKey(file string) (crypto.PublicKey, error) {
+	by, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(by)
+	if block == nil {
+		return nil, fmt.Errorf("file %s is not PEM encoded", file)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key %s error %s", file, err.Error())
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("public key type %T is not supported", key)
+	}
+}
+
+//verifyPackageSignature verify base64 signature of package sha256 digest,
+//signature is created by: openssl dgst -sha256 -sign private.pem package.tar.gz | base64
+func verifyPackageSignature(key crypto.PublicKey, digest, signature string) error {
+	hashed, err := hex.DecodeString(digest)
+	if err != nil {
+		return fmt.Errorf("digest %s is invalid", digest)
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("signature is not base64 encoded")
+	}
+
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, hashed, sig)
+
+	case *ecdsa.PublicKey:
+		var ecdsaSig struct {
+			R, S *big.Int
+		}
+		if _, err = asn1.Unmarshal(sig, &ecdsaSig); err != nil {
+			return fmt.Errorf("ecdsa signature is invalid, %s", err.Error())
+		}
+		if !ecdsa.Verify(pub, hashed, ecdsaSig.R, ecdsaSig.S) {
+			return fmt.Errorf("ecdsa verification error")
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("public key type %T is not supported", key)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package manager
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+//writePublicKey write PEM encoded PKIX public key into dir
+func writePublicKey(t *testing.T, dir, name string, pub crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("marshal public key error %s", err.Error())
+	}
+	file := filepath.Join(dir, name)
+	err = ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644)
+	if err != nil {
+		t.Fatalf("write public key error %s", err.Error())
+	}
+	return file
+}
+
+//signPackage return hex sha256 digest of data and its base64 signature,
+//as openssl dgst -sha256 -sign does
+func signPackage(t *testing.T, key crypto.Signer, data []byte) (string, string) {
+	hashed := sha256.Sum256(data)
+	sig, err := key.Sign(rand.Reader, hashed[:], crypto.SHA256)
+	if err != nil {
+		t.Fatalf("sign package error %s", err.Error())
+	}
+	return hex.EncodeToString(hashed[:]), base64.StdEncoding.EncodeToString(sig)
+}
+
+func newTestKeys(t *testing.T) (*rsa.PrivateKey, *ecdsa.PrivateKey) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key error %s", err.Error())
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ecdsa key error %s", err.Error())
+	}
+	return rsaKey, ecdsaKey
+}
+
+func TestLoadPackagePublicKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "package-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, ecdsaKey := newTestKeys(t)
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notPEM := filepath.Join(dir, "not-pem")
+	if err = ioutil.WriteFile(notPEM, []byte("public key"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		wantErr bool
+	}{
+		{name: "rsa", file: writePublicKey(t, dir, "rsa.pem", &rsaKey.PublicKey)},
+		{name: "ecdsa", file: writePublicKey(t, dir, "ecdsa.pem", &ecdsaKey.PublicKey)},
+		{name: "unsupported ed25519", file: writePublicKey(t, dir, "ed25519.pem", edPub), wantErr: true},
+		{name: "not PEM encoded", file: notPEM, wantErr: true},
+		{name: "not existed", file: filepath.Join(dir, "not-existed"), wantErr: true},
+	}
+	for _, test := range tests {
+		key, err := loadPackagePublicKey(test.file)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: expect error, got key %T", test.name, key)
+			}
+			continue
+		}
+		if err != nil || key == nil {
+			t.Errorf("%s: load public key error %v", test.name, err)
+		}
+	}
+}
+
+func TestVerifyPackageSignature(t *testing.T) {
+	rsaKey, ecdsaKey := newTestKeys(t)
+	data := []byte("process package")
+	rsaDigest, rsaSig := signPackage(t, rsaKey, data)
+	ecdsaDigest, ecdsaSig := signPackage(t, ecdsaKey, data)
+	otherDigest, _ := signPackage(t, rsaKey, []byte("other package"))
+
+	tests := []struct {
+		name      string
+		key       crypto.PublicKey
+		digest    string
+		signature string
+		wantErr   bool
+	}{
+		{name: "rsa", key: &rsaKey.PublicKey, digest: rsaDigest, signature: rsaSig},
+		{name: "ecdsa", key: &ecdsaKey.PublicKey, digest: ecdsaDigest, signature: ecdsaSig},
+		{name: "rsa other package", key: &rsaKey.PublicKey, digest: otherDigest, signature: rsaSig, wantErr: true},
+		{name: "ecdsa other package", key: &ecdsaKey.PublicKey, digest: otherDigest, signature: ecdsaSig,
+			wantErr: true},
+		{name: "rsa signature by ecdsa", key: &rsaKey.PublicKey, digest: ecdsaDigest, signature: ecdsaSig,
+			wantErr: true},
+		{name: "ecdsa signature by rsa", key: &ecdsaKey.PublicKey, digest: rsaDigest, signature: rsaSig,
+			wantErr: true},
+		{name: "invalid digest", key: &rsaKey.PublicKey, digest: "not-hex", signature: rsaSig, wantErr: true},
+		{name: "not base64", key: &rsaKey.PublicKey, digest: rsaDigest, signature: "!!!", wantErr: true},
+	}
+	for _, test := range tests {
+		err := verifyPackageSignature(test.key, test.digest, test.signature)
+		if test.wantErr && err == nil {
+			t.Errorf("%s: expect verification error", test.name)
+		}
+		if !test.wantErr && err != nil {
+			t.Errorf("%s: verification error %s", test.name, err.Error())
+		}
+	}
+}
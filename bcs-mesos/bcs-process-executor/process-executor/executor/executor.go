@@ -173,6 +173,10 @@ func (e *bcsExecutor) loopInspectTasks() {
 			if inspectNum%ReportTaskStatusPeriod == 0 {
 				isUpdate = true
 				blog.Infof("update process %s status %s", taskid, status.Status)
+				e.heartbeatProcess(task)
+			}
+			if status.OOMKilled && task.GetBcsInfo() != nil {
+				task.GetBcsInfo().OOMKilled = true
 			}
 
 			if !isUpdate {
@@ -191,6 +195,38 @@ func (e *bcsExecutor) loopInspectTasks() {
 	}
 }
 
+//heartbeat to process daemon, and keep process status and usage for task status report
+func (e *bcsExecutor) heartbeatProcess(task *types.ProcessTaskInfo) {
+	pong, err := e.procDaemon.HeartBeat(&types.HeartBeat{
+		ProcessId:  task.TaskId,
+		ExecutorId: task.ProcInfo.ExecutorId,
+		Type:       types.HeartBeatPing,
+	})
+	if err != nil {
+		blog.Errorf("heartbeat process %s error %s", task.TaskId, err.Error())
+		return
+	}
+	if pong.StatusInfo == nil {
+		return
+	}
+
+	status := pong.StatusInfo
+	task.SetBcsInfo(&types.BcsProcessInfo{
+		ID:        task.TaskId,
+		Name:      task.ProcInfo.ProcessName,
+		Pid:       status.Pid,
+		Status:    string(status.Status),
+		Message:   status.Message,
+		Resource:  task.ProcInfo.Resource,
+		OOMKilled: status.OOMKilled,
+		Usage:     status.Usage,
+	})
+	if status.Usage != nil {
+		blog.V(3).Infof("process %s usage cpu %.2f memory %d limit %d", task.TaskId, status.Usage.CPUUsage,
+			status.Usage.MemoryUsage, status.Usage.MemoryLimit)
+	}
+}
+
 //getTaskStatusFromProcessStatus
 func (e *bcsExecutor) getTaskStatusFromProcessStatus(status types.ProcessStatusType) (types.TaskStatus, error) {
 	switch status {
@@ -411,6 +447,10 @@ func (e *bcsExecutor) updateTaskStatus(taskId string, status types.TaskStatus, m
 	now := float64(time.Now().Unix())
 	update.Timestamp = proto.Float64(now)
 	update.Uuid = ID
+	//process status and resource usage from heartbeat
+	if task, ok := e.tasks[taskId]; ok && task.GetBcsInfo() != nil {
+		update.Data, _ = json.Marshal(task.GetBcsInfo())
+	}
 
 	Func, ok := e.callbackFuncs[types.CallbackFuncUpdateTask]
 	if !ok {
@@ -488,6 +528,8 @@ func createProcessTaskinfo(task *mesos.TaskInfo) (*types.ProcessTaskInfo, error)
 			User:      uri.User,
 			Pwd:       uri.Pwd,
 			OutputDir: uri.OutputDir,
+			Sha256:    uri.Sha256,
+			Signature: uri.Signature,
 		}
 		processTask.ProcInfo.Uris = append(processTask.ProcInfo.Uris, u)
 	}
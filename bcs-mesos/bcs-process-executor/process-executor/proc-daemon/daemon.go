@@ -82,6 +82,24 @@ func (d *daemon) DeleteProcess(procId string) error {
 	return nil
 }
 
+func (d *daemon) HeartBeat(heartbeat *types.HeartBeat) (*types.HeartBeat, error) {
+	by, _ := json.Marshal(heartbeat)
+	by, err := d.cli.requestProcessDaemon("POST", fmt.Sprintf("/process/%s/heartbeat", heartbeat.ProcessId), by)
+	if err != nil {
+		blog.Errorf("daemon heartbeat process %s error %s", heartbeat.ProcessId, err.Error())
+		return nil, err
+	}
+
+	var pong *types.HeartBeat
+	err = json.Unmarshal(by, &pong)
+	if err != nil {
+		blog.Errorf("Unmarshal data %s to types.HeartBeat error %s", string(by), err.Error())
+		return nil, err
+	}
+
+	return pong, nil
+}
+
 func (d *daemon) ReloadProcess(procId string) error {
 	return nil
 }
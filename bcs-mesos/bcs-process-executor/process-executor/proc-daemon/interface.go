@@ -30,6 +30,9 @@ type ProcDaemon interface {
 	//Delete process
 	DeleteProcess(procId string) error
 
+	//heartbeat to process daemon, pong with process status and resource usage
+	HeartBeat(heartbeat *types.HeartBeat) (*types.HeartBeat, error)
+
 	//set process envs
 	//types.BcsKV: key = env.key, value = env.value
 	//SetProcessEnvs([]types.BcsKV)error
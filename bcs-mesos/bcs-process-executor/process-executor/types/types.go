@@ -32,6 +32,16 @@ type ProcessTaskInfo struct {
 	bcsInfo *BcsProcessInfo
 }
 
+//GetBcsInfo get process info reported to bcs scheduler
+func (t *ProcessTaskInfo) GetBcsInfo() *BcsProcessInfo {
+	return t.bcsInfo
+}
+
+//SetBcsInfo set process info reported to bcs scheduler
+func (t *ProcessTaskInfo) SetBcsInfo(info *BcsProcessInfo) {
+	t.bcsInfo = info
+}
+
 //process task status type
 type TaskStatus string
 
@@ -92,6 +102,9 @@ type Uri struct {
 	OutputDir    string
 	ExtractDir   string
 	PackagesFile string
+	Sha256       string //hex sha256 digest the package is pinned to
+	Signature    string //base64 signature of package sha256 digest
+	Digest       string //sha256 digest of the package in cache
 }
 
 type ProcessStatusInfo struct {
@@ -102,6 +115,22 @@ type ProcessStatusInfo struct {
 	Pid           int
 	RegisterTime  int64
 	LastStartTime int64
+	//why process is stopped, such as package verification failure
+	Reason ProcessStatusReason
+
+	OOMKilled       bool   //process stopped by oom killer after last start
+	OOMKillCount    uint64 //times of processes in cgroup killed by oom killer
+	LastOOMKillTime int64  //last time oom kill found
+	Usage           *ProcessUsage
+}
+
+//ProcessUsage resource usage of process cgroup
+type ProcessUsage struct {
+	CPUUsage      float64 //cpu cores used since last sample
+	CPUUsageNanos uint64  //total cpu time of processes in cgroup
+	MemoryUsage   uint64  //bytes
+	MemoryLimit   uint64  //bytes, 0 means no limit
+	Timestamp     int64
 }
 
 type ProcessStatusType string
@@ -114,6 +143,17 @@ const (
 	ProcessStatusStopped  ProcessStatusType = "stopped"
 )
 
+//ProcessStatusReason reason of process status
+type ProcessStatusReason string
+
+const (
+	ProcessReasonPackageDownloadFailed   ProcessStatusReason = "PackageDownloadFailed"
+	ProcessReasonPackageUnverified       ProcessStatusReason = "PackageUnverified"
+	ProcessReasonPackageDigestMismatch   ProcessStatusReason = "PackageDigestMismatch"
+	ProcessReasonPackageSignatureInvalid ProcessStatusReason = "PackageSignatureInvalid"
+	ProcessReasonPackageExtractFailed    ProcessStatusReason = "PackageExtractFailed"
+)
+
 //CallbackFuncType
 type CallbackFuncType string
 
@@ -152,6 +192,8 @@ type BcsProcessInfo struct {
 	Message     string                 `json:"Message,omitempty"`     //status message for container
 	Resource    *schedTypes.Resource   `json:"Resource,omitempty"`
 	BcsMessage  *schedTypes.BcsMessage `json:",omitempty"`
+	OOMKilled   bool                   `json:"OOMKilled,omitempty"` //process exited, whether oom
+	Usage       *ProcessUsage          `json:"Usage,omitempty"`     //resource usage of process cgroup
 }
 
 //BcsPort port service for process port reflection
@@ -168,6 +210,8 @@ type HeartBeat struct {
 	ProcessId  string
 	ExecutorId string
 	Type       HeartBeatType
+	//process status and resource usage, only in pong
+	StatusInfo *ProcessStatusInfo `json:",omitempty"`
 }
 
 type HeartBeatType string
@@ -183,5 +227,6 @@ type JfrogRegistry struct {
 }
 
 type CheckSum struct {
-	Md5 string
+	Md5    string
+	Sha256 string
 }
@@ -1,5 +1,5 @@
 #!/bin/bash
-objects=("versions" "admissionwebhookconfigurations" "agents" "agentschedinfos" "applications" "bcsclusteragentsettings" "bcscommandinfos" "bcsconfigmaps" "bcsendpoints" "bcssecrets" "bcsservices" "deployments" "frameworks" "taskgroups" "tasks")
+objects=("versions" "admissionwebhookconfigurations" "agents" "agentschedinfoes" "applications" "bcsclusteragentsettings" "bcscommandinfoes" "bcsconfigmaps" "bcsendpoints" "bcssecrets" "bcsservices" "deployments" "frameworks" "taskgroups" "tasks" "bcstransactions" "crrs" "crds" "bcsnamespacequotas" "bcsjobs" "bcscronjobs" "bcsdisruptionbudgets")
 
 for o in ${objects[@]};
 do
@@ -0,0 +1,377 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"fmt"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+)
+
+// storeObject one kind of object stored by bcs-scheduler
+type storeObject struct {
+	kind string
+	// list all objects of kind in store, key is unique in kind
+	list func(s store.Store) (map[string]interface{}, error)
+	// save one object returned by list into store
+	save func(s store.Store, obj interface{}) error
+}
+
+// migrateObjects all kinds of objects to migrate, in the order of migration.
+// daemonset and command are not included, zk store don't support daemonset
+// and command is only temporary data.
+func migrateObjects() []*storeObject {
+	return []*storeObject{
+		{kind: "framework", list: listFramework, save: saveFramework},
+		{kind: "version", list: listVersions, save: func(s store.Store, obj interface{}) error {
+			return s.UpdateVersion(obj.(*types.Version))
+		}},
+		{kind: "application", list: listApplications, save: func(s store.Store, obj interface{}) error {
+			return s.SaveApplication(obj.(*types.Application))
+		}},
+		{kind: "taskgroup", list: listTaskGroups, save: func(s store.Store, obj interface{}) error {
+			return s.SaveTaskGroup(obj.(*types.TaskGroup))
+		}},
+		{kind: "agent", list: listAgents, save: func(s store.Store, obj interface{}) error {
+			return s.SaveAgent(obj.(*types.Agent))
+		}},
+		{kind: "agentsetting", list: listAgentSettings, save: func(s store.Store, obj interface{}) error {
+			return s.SaveAgentSetting(obj.(*commtypes.BcsClusterAgentSetting))
+		}},
+		{kind: "agentschedinfo", list: listAgentSchedInfos, save: func(s store.Store, obj interface{}) error {
+			return s.SaveAgentSchedInfo(obj.(*types.AgentSchedInfo))
+		}},
+		{kind: "configmap", list: listConfigmaps, save: func(s store.Store, obj interface{}) error {
+			return s.SaveConfigMap(obj.(*commtypes.BcsConfigMap))
+		}},
+		{kind: "secret", list: listSecrets, save: func(s store.Store, obj interface{}) error {
+			return s.SaveSecret(obj.(*commtypes.BcsSecret))
+		}},
+		{kind: "service", list: listServices, save: func(s store.Store, obj interface{}) error {
+			return s.SaveService(obj.(*commtypes.BcsService))
+		}},
+		{kind: "endpoint", list: listEndpoints, save: func(s store.Store, obj interface{}) error {
+			return s.SaveEndpoint(obj.(*commtypes.BcsEndpoint))
+		}},
+		{kind: "deployment", list: listDeployments, save: func(s store.Store, obj interface{}) error {
+			return s.SaveDeployment(obj.(*types.Deployment))
+		}},
+		{kind: "transaction", list: listTransactions, save: func(s store.Store, obj interface{}) error {
+			return s.SaveTransaction(obj.(*types.Transaction))
+		}},
+		{kind: "customresourceregister", list: listCrrs, save: func(s store.Store, obj interface{}) error {
+			return s.SaveCustomResourceRegister(obj.(*commtypes.Crr))
+		}},
+		{kind: "customresource", list: listCrds, save: func(s store.Store, obj interface{}) error {
+			return s.SaveCustomResourceDefinition(obj.(*commtypes.Crd))
+		}},
+		{kind: "admissionwebhook", list: listAdmissions, save: func(s store.Store, obj interface{}) error {
+			return s.SaveAdmissionWebhook(obj.(*commtypes.AdmissionWebhookConfiguration))
+		}},
+		{kind: "namespacequota", list: listNamespaceQuotas, save: func(s store.Store, obj interface{}) error {
+			return s.SaveNamespaceQuota(obj.(*commtypes.BcsNamespaceQuota))
+		}},
+		{kind: "job", list: listJobs, save: func(s store.Store, obj interface{}) error {
+			return s.SaveJob(obj.(*types.BcsJob))
+		}},
+		{kind: "cronjob", list: listCronJobs, save: func(s store.Store, obj interface{}) error {
+			return s.SaveCronJob(obj.(*types.BcsCronJob))
+		}},
+		{kind: "disruptionbudget", list: listDisruptionBudgets, save: func(s store.Store, obj interface{}) error {
+			return s.SaveDisruptionBudget(obj.(*commtypes.BcsDisruptionBudget))
+		}},
+	}
+}
+
+func nsKey(ns, name string) string {
+	return ns + "/" + name
+}
+
+func listFramework(s store.Store) (map[string]interface{}, error) {
+	objs := make(map[string]interface{})
+	// both stores return error when framework id not exist
+	framework, err := s.FetchFrameworkID()
+	if err != nil || framework == "" {
+		return objs, nil
+	}
+	objs["framework"] = framework
+	return objs, nil
+}
+
+func saveFramework(s store.Store, obj interface{}) error {
+	return s.SaveFrameworkID(obj.(string))
+}
+
+func listApplications(s store.Store) (map[string]interface{}, error) {
+	apps, err := s.ListAllApplications()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllApplications failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(apps))
+	for _, app := range apps {
+		objs[nsKey(app.RunAs, app.ID)] = app
+	}
+	return objs, nil
+}
+
+func listVersions(s store.Store) (map[string]interface{}, error) {
+	apps, err := s.ListAllApplications()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllApplications failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{})
+	for _, app := range apps {
+		runAs, appID := app.RunAs, app.ID
+		versions, err := s.ListVersions(runAs, appID)
+		if err != nil {
+			return nil, fmt.Errorf("ListVersions(%s:%s) failed: %s", runAs, appID, err.Error())
+		}
+		for _, no := range versions {
+			version, err := s.FetchVersion(runAs, appID, no)
+			if err != nil {
+				return nil, fmt.Errorf("FetchVersion(%s:%s:%s) failed: %s", runAs, appID, no, err.Error())
+			}
+			objs[nsKey(runAs, appID)+"/"+no] = version
+		}
+	}
+	return objs, nil
+}
+
+func listTaskGroups(s store.Store) (map[string]interface{}, error) {
+	apps, err := s.ListAllApplications()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllApplications failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{})
+	for _, app := range apps {
+		taskgs, err := s.ListTaskGroups(app.RunAs, app.ID)
+		if err != nil {
+			return nil, fmt.Errorf("ListTaskGroups Application(%s:%s) error %s", app.RunAs, app.ID, err.Error())
+		}
+		for _, taskg := range taskgs {
+			objs[taskg.ID] = taskg
+		}
+	}
+	return objs, nil
+}
+
+func listAgents(s store.Store) (map[string]interface{}, error) {
+	agents, err := s.ListAllAgents()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllAgents failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(agents))
+	for _, agent := range agents {
+		objs[agent.Key] = agent
+	}
+	return objs, nil
+}
+
+func listAgentSettings(s store.Store) (map[string]interface{}, error) {
+	settings, err := s.ListAgentsettings()
+	if err != nil {
+		return nil, fmt.Errorf("ListAgentsettings failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(settings))
+	for _, setting := range settings {
+		objs[setting.InnerIP] = setting
+	}
+	return objs, nil
+}
+
+func listAgentSchedInfos(s store.Store) (map[string]interface{}, error) {
+	infos, err := s.ListAgentSchedInfo()
+	if err != nil {
+		return nil, fmt.Errorf("ListAgentSchedInfo failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(infos))
+	for _, info := range infos {
+		objs[info.HostName] = info
+	}
+	return objs, nil
+}
+
+func listConfigmaps(s store.Store) (map[string]interface{}, error) {
+	cfgs, err := s.ListAllConfigmaps()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllConfigmaps failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(cfgs))
+	for _, cfg := range cfgs {
+		objs[nsKey(cfg.NameSpace, cfg.Name)] = cfg
+	}
+	return objs, nil
+}
+
+func listSecrets(s store.Store) (map[string]interface{}, error) {
+	scts, err := s.ListAllSecrets()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllSecrets failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(scts))
+	for _, sct := range scts {
+		// '_' is not allowed in kube-apiserver object name
+		if sct.Name == "paas_image_secret" {
+			sct.Name = "paas-image-secret"
+		}
+		objs[nsKey(sct.NameSpace, sct.Name)] = sct
+	}
+	return objs, nil
+}
+
+func listServices(s store.Store) (map[string]interface{}, error) {
+	svcs, err := s.ListAllServices()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllServices failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(svcs))
+	for _, svc := range svcs {
+		objs[nsKey(svc.NameSpace, svc.Name)] = svc
+	}
+	return objs, nil
+}
+
+// listEndpoints endpoints can't be listed directly, they share name with services
+func listEndpoints(s store.Store) (map[string]interface{}, error) {
+	svcs, err := s.ListAllServices()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllServices failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(svcs))
+	for _, svc := range svcs {
+		end, err := s.FetchEndpoint(svc.NameSpace, svc.Name)
+		if err != nil || end == nil {
+			continue
+		}
+		objs[nsKey(end.NameSpace, end.Name)] = end
+	}
+	return objs, nil
+}
+
+func listDeployments(s store.Store) (map[string]interface{}, error) {
+	deployments, err := s.ListAllDeployments()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllDeployments failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(deployments))
+	for _, deployment := range deployments {
+		objs[nsKey(deployment.ObjectMeta.NameSpace, deployment.ObjectMeta.Name)] = deployment
+	}
+	return objs, nil
+}
+
+func listTransactions(s store.Store) (map[string]interface{}, error) {
+	trans, err := s.ListAllTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllTransaction failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(trans))
+	for _, tran := range trans {
+		objs[nsKey(tran.Namespace, tran.TransactionID)] = tran
+	}
+	return objs, nil
+}
+
+func listCrrs(s store.Store) (map[string]interface{}, error) {
+	crrs, err := s.ListCustomResourceRegister()
+	if err != nil {
+		return nil, fmt.Errorf("ListCustomResourceRegister failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(crrs))
+	for _, crr := range crrs {
+		objs[crr.Spec.Names.Kind] = crr
+	}
+	return objs, nil
+}
+
+func listCrds(s store.Store) (map[string]interface{}, error) {
+	crrs, err := s.ListCustomResourceRegister()
+	if err != nil {
+		return nil, fmt.Errorf("ListCustomResourceRegister failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{})
+	for _, crr := range crrs {
+		kind := crr.Spec.Names.Kind
+		crds, err := s.ListAllCrds(kind)
+		if err != nil {
+			return nil, fmt.Errorf("ListAllCrds(%s) failed: %s", kind, err.Error())
+		}
+		for _, crd := range crds {
+			objs[string(crd.Kind)+"/"+nsKey(crd.NameSpace, crd.Name)] = crd
+		}
+	}
+	return objs, nil
+}
+
+func listAdmissions(s store.Store) (map[string]interface{}, error) {
+	admissions, err := s.FetchAllAdmissionWebhooks()
+	if err != nil {
+		return nil, fmt.Errorf("FetchAllAdmissionWebhooks failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(admissions))
+	for _, admission := range admissions {
+		objs[nsKey(admission.NameSpace, admission.Name)] = admission
+	}
+	return objs, nil
+}
+
+func listNamespaceQuotas(s store.Store) (map[string]interface{}, error) {
+	quotas, err := s.ListNamespaceQuotas()
+	if err != nil {
+		return nil, fmt.Errorf("ListNamespaceQuotas failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(quotas))
+	for _, quota := range quotas {
+		objs[quota.NameSpace] = quota
+	}
+	return objs, nil
+}
+
+func listJobs(s store.Store) (map[string]interface{}, error) {
+	jobs, err := s.ListAllJobs()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllJobs failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(jobs))
+	for _, job := range jobs {
+		objs[nsKey(job.NameSpace, job.Name)] = job
+	}
+	return objs, nil
+}
+
+func listCronJobs(s store.Store) (map[string]interface{}, error) {
+	cronJobs, err := s.ListAllCronJobs()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllCronJobs failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(cronJobs))
+	for _, cronJob := range cronJobs {
+		objs[nsKey(cronJob.NameSpace, cronJob.Name)] = cronJob
+	}
+	return objs, nil
+}
+
+func listDisruptionBudgets(s store.Store) (map[string]interface{}, error) {
+	budgets, err := s.ListAllDisruptionBudgets()
+	if err != nil {
+		return nil, fmt.Errorf("ListAllDisruptionBudgets failed: %s", err.Error())
+	}
+	objs := make(map[string]interface{}, len(budgets))
+	for _, budget := range budgets {
+		objs[nsKey(budget.NameSpace, budget.Name)] = budget
+	}
+	return objs, nil
+}
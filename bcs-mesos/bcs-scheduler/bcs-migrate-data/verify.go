@@ -0,0 +1,182 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+)
+
+// syncReport result of migrating or comparing one kind of object
+type syncReport struct {
+	kind        string
+	sourceCount int
+	targetCount int
+	// number of objects written to target
+	synced int
+	// keys exist in source but not in target
+	missing []string
+	// keys exist in both but checksum is different
+	mismatch []string
+	// keys exist in target but not in source
+	extra []string
+}
+
+// success check report, extra objects in etcd only fail the read-only comparison,
+// they are left by previous migration and will be seen by bcs-scheduler after cutover
+func (r *syncReport) success(strict bool) bool {
+	if len(r.missing) != 0 || len(r.mismatch) != 0 {
+		return false
+	}
+	return !strict || len(r.extra) == 0
+}
+
+// checksum sha256 of object json data, fields not serialized such as
+// ResourceVersion are ignored
+func checksum(obj interface{}) (string, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// diffObjects compare objects by key and checksum
+func diffObjects(kind string, source, target map[string]interface{}) (*syncReport, error) {
+	report := &syncReport{
+		kind:        kind,
+		sourceCount: len(source),
+		targetCount: len(target),
+	}
+	for key, obj := range source {
+		targetObj, ok := target[key]
+		if !ok {
+			report.missing = append(report.missing, key)
+			continue
+		}
+		sum, err := checksum(obj)
+		if err != nil {
+			return nil, fmt.Errorf("checksum %s %s failed: %s", kind, key, err.Error())
+		}
+		targetSum, err := checksum(targetObj)
+		if err != nil {
+			return nil, fmt.Errorf("checksum %s %s failed: %s", kind, key, err.Error())
+		}
+		if sum != targetSum {
+			report.mismatch = append(report.mismatch, key)
+		}
+	}
+	for key := range target {
+		if _, ok := source[key]; !ok {
+			report.extra = append(report.extra, key)
+		}
+	}
+	sort.Strings(report.missing)
+	sort.Strings(report.mismatch)
+	sort.Strings(report.extra)
+	return report, nil
+}
+
+// compareObject read-only comparison of one kind of object in zk and etcd
+func compareObject(object *storeObject, zkStore, etcdStore store.Store) (*syncReport, error) {
+	blog.Infof("start compare %s data...", object.kind)
+	source, err := object.list(zkStore)
+	if err != nil {
+		return nil, err
+	}
+	target, err := object.list(etcdStore)
+	if err != nil {
+		return nil, err
+	}
+	report, err := diffObjects(object.kind, source, target)
+	if err != nil {
+		return nil, err
+	}
+	logReport(report)
+	return report, nil
+}
+
+// migrateObject save all objects of kind from zk to etcd and verify them.
+// when bcs-scheduler is still running on zk, objects may change during migration,
+// the missing and mismatched ones are read from zk and saved again, at most retry rounds
+func migrateObject(object *storeObject, zkStore, etcdStore store.Store, retry int) (*syncReport, error) {
+	blog.Infof("start sync %s data...", object.kind)
+	source, err := object.list(zkStore)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(source))
+	for key := range source {
+		keys = append(keys, key)
+	}
+
+	synced := 0
+	for round := 0; ; round++ {
+		for _, key := range keys {
+			if err := object.save(etcdStore, source[key]); err != nil {
+				return nil, fmt.Errorf("save %s %s failed: %s", object.kind, key, err.Error())
+			}
+			synced++
+			blog.V(3).Infof("save %s %s success", object.kind, key)
+		}
+
+		target, err := object.list(etcdStore)
+		if err != nil {
+			return nil, err
+		}
+		report, err := diffObjects(object.kind, source, target)
+		if err != nil {
+			return nil, err
+		}
+		report.synced = synced
+		if report.success(false) || round >= retry {
+			logReport(report)
+			return report, nil
+		}
+
+		blog.Warnf("%s has %d missing and %d mismatched objects after round %d, sync them again",
+			object.kind, len(report.missing), len(report.mismatch), round)
+		if source, err = object.list(zkStore); err != nil {
+			return nil, err
+		}
+		keys = make([]string, 0, len(report.missing)+len(report.mismatch))
+		for _, key := range append(report.missing, report.mismatch...) {
+			// deleted from zk during migration
+			if _, ok := source[key]; ok {
+				keys = append(keys, key)
+			}
+		}
+	}
+}
+
+func logReport(r *syncReport) {
+	blog.Infof("%s: zk %d, etcd %d, synced %d, missing %d, mismatch %d, extra %d",
+		r.kind, r.sourceCount, r.targetCount, r.synced, len(r.missing), len(r.mismatch), len(r.extra))
+	for _, key := range r.missing {
+		blog.Errorf("%s %s exists in zk but not in etcd", r.kind, key)
+	}
+	for _, key := range r.mismatch {
+		blog.Errorf("%s %s checksum in zk and etcd is different", r.kind, key)
+	}
+	for _, key := range r.extra {
+		blog.Warnf("%s %s exists in etcd but not in zk", r.kind, key)
+	}
+}
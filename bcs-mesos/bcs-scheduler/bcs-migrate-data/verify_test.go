@@ -0,0 +1,69 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+)
+
+func TestDiffObjects(t *testing.T) {
+	newCfg := func(name, value string) *commtypes.BcsConfigMap {
+		cfg := &commtypes.BcsConfigMap{}
+		cfg.NameSpace = "ns"
+		cfg.Name = name
+		cfg.Labels = map[string]string{"key": value}
+		return cfg
+	}
+	source := map[string]interface{}{
+		"ns/same":    newCfg("same", "a"),
+		"ns/changed": newCfg("changed", "a"),
+		"ns/missing": newCfg("missing", "a"),
+	}
+	sameCopy := newCfg("same", "a")
+	// ResourceVersion is not part of object data
+	sameCopy.ResourceVersion = "100"
+	target := map[string]interface{}{
+		"ns/same":    sameCopy,
+		"ns/changed": newCfg("changed", "b"),
+		"ns/extra":   newCfg("extra", "a"),
+	}
+
+	report, err := diffObjects("configmap", source, target)
+	if err != nil {
+		t.Fatalf("diffObjects failed: %s", err.Error())
+	}
+	if report.sourceCount != 3 || report.targetCount != 3 {
+		t.Errorf("expect count 3/3, got %d/%d", report.sourceCount, report.targetCount)
+	}
+	if !reflect.DeepEqual(report.missing, []string{"ns/missing"}) {
+		t.Errorf("unexpected missing %v", report.missing)
+	}
+	if !reflect.DeepEqual(report.mismatch, []string{"ns/changed"}) {
+		t.Errorf("unexpected mismatch %v", report.mismatch)
+	}
+	if !reflect.DeepEqual(report.extra, []string{"ns/extra"}) {
+		t.Errorf("unexpected extra %v", report.extra)
+	}
+
+	report.missing, report.mismatch = nil, nil
+	if !report.success(false) {
+		t.Errorf("extra objects should not fail migration")
+	}
+	if report.success(true) {
+		t.Errorf("extra objects should fail comparison")
+	}
+}
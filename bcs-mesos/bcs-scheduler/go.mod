@@ -22,6 +22,8 @@ require (
 	github.com/samuel/go-zookeeper v0.0.0-20201211165307-7117e9ea2414
 	github.com/stretchr/testify v1.7.0
 	golang.org/x/net v0.0.0-20210428140749-89ef3d95e781
+	google.golang.org/grpc v1.27.0
+	google.golang.org/protobuf v1.26.0
 	k8s.io/api v0.18.16
 	k8s.io/apiextensions-apiserver v0.18.16
 	k8s.io/apimachinery v0.18.16
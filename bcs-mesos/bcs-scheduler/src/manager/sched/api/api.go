@@ -350,7 +350,11 @@ func (r *Router) createDeployment(req *restful.Request, resp *restful.Response)
 			deploymentDef.ObjectMeta.NameSpace, deploymentDef.ObjectMeta.Name)
 	}
 
-	if errcode, err := r.backend.CreateDeployment(&deploymentDef); err != nil {
+	createDeployment := r.backend.CreateDeployment
+	if isDryRun(req) {
+		createDeployment = r.backend.DryRunCreateDeployment
+	}
+	if errcode, err := createDeployment(&deploymentDef); err != nil {
 		blog.Error("fail to create deployment(%s.%s), err:%s",
 			deploymentDef.ObjectMeta.NameSpace, deploymentDef.ObjectMeta.Name, err.Error())
 		data := createResponseDataV2(errcode, err.Error(), nil)
@@ -393,7 +397,9 @@ func (r *Router) updateDeployment(req *restful.Request, resp *restful.Response)
 
 	var errCode int
 	var err error
-	if args == "resource" {
+	if isDryRun(req) {
+		errCode, err = r.backend.DryRunUpdateDeployment(&deploymentDef, args == "resource")
+	} else if args == "resource" {
 		errCode, err = r.backend.UpdateDeploymentResource(&deploymentDef)
 	} else {
 		errCode, err = r.backend.UpdateDeployment(&deploymentDef)
@@ -595,6 +601,12 @@ func (r *Router) createConfigMap(req *restful.Request, resp *restful.Response) {
 		return
 	}
 
+	if isDryRun(req) {
+		blog.Info("request save configmap(%s.%s) dry run success", configmap.ObjectMeta.NameSpace, configmap.ObjectMeta.Name)
+		resp.Write([]byte(createResponseData(nil, "success", nil)))
+		return
+	}
+
 	if err := r.backend.SaveConfigMap(&configmap); err != nil {
 		blog.Error("fail to save configmap, err:%s", err.Error())
 		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
@@ -635,6 +647,12 @@ func (r *Router) updateConfigMap(req *restful.Request, resp *restful.Response) {
 		return
 	}
 
+	if isDryRun(req) {
+		blog.Info("request save configmap(%s.%s) dry run success", configmap.ObjectMeta.NameSpace, configmap.ObjectMeta.Name)
+		resp.Write([]byte(createResponseData(nil, "success", nil)))
+		return
+	}
+
 	if err := r.backend.SaveConfigMap(&configmap); err != nil {
 		blog.Error("fail to save configmap, err:%s", err.Error())
 		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
@@ -700,6 +718,12 @@ func (r *Router) createSecret(req *restful.Request, resp *restful.Response) {
 		return
 	}
 
+	if isDryRun(req) {
+		blog.Info("request save secret(%s.%s) dry run success", secret.ObjectMeta.NameSpace, secret.ObjectMeta.Name)
+		resp.Write([]byte(createResponseData(nil, "success", nil)))
+		return
+	}
+
 	if err := r.backend.SaveSecret(&secret); err != nil {
 		blog.Error("fail to save secret, err:%s", err.Error())
 		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
@@ -739,6 +763,12 @@ func (r *Router) updateSecret(req *restful.Request, resp *restful.Response) {
 		return
 	}
 
+	if isDryRun(req) {
+		blog.Info("request save secret(%s.%s) dry run success", secret.ObjectMeta.NameSpace, secret.ObjectMeta.Name)
+		resp.Write([]byte(createResponseData(nil, "success", nil)))
+		return
+	}
+
 	if err := r.backend.SaveSecret(&secret); err != nil {
 		blog.Error("fail to save secret, err:%s", err.Error())
 		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
@@ -804,6 +834,12 @@ func (r *Router) createService(req *restful.Request, resp *restful.Response) {
 		return
 	}
 
+	if isDryRun(req) {
+		blog.Info("request save service(%s.%s) dry run success", service.ObjectMeta.NameSpace, service.ObjectMeta.Name)
+		resp.Write([]byte(createResponseData(nil, "success", nil)))
+		return
+	}
+
 	if err := r.backend.SaveService(&service); err != nil {
 		blog.Error("fail to save service, err:%s", err.Error())
 		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
@@ -849,6 +885,12 @@ func (r *Router) updateService(req *restful.Request, resp *restful.Response) {
 	service.ObjectMeta.Labels = currData.ObjectMeta.Labels
 	service.TypeMeta = currData.TypeMeta
 
+	if isDryRun(req) {
+		blog.Info("request save service(%s.%s) dry run success", service.ObjectMeta.NameSpace, service.ObjectMeta.Name)
+		resp.Write([]byte(createResponseData(nil, "success", nil)))
+		return
+	}
+
 	if err := r.backend.SaveService(&service); err != nil {
 		blog.Error("fail to save service, err:%s", err.Error())
 		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
@@ -944,6 +986,10 @@ func (r *Router) buildApplication(req *restful.Request, resp *restful.Response)
 		return
 	}
 
+	// application and its launch transaction are saved under the quota lock
+	r.backend.LockNamespaceQuota(version.RunAs)
+	defer r.backend.UnLockNamespaceQuota(version.RunAs)
+
 	app, err := r.backend.FetchApplication(version.RunAs, version.ID)
 	if err != nil && err != store.ErrNoFound {
 		blog.Error("request build: fail to fetch application, err:%s", err.Error())
@@ -960,6 +1006,19 @@ func (r *Router) buildApplication(req *restful.Request, resp *restful.Response)
 		return
 	}
 
+	if err := r.backend.CheckNamespaceQuota(&version, uint64(version.Instances)); err != nil {
+		blog.Error("request build application(%s.%s) fail: %s", version.RunAs, version.ID, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedQuotaExceeded, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+
+	if isDryRun(req) {
+		blog.Info("request build application(%s.%s) dry run success", version.RunAs, version.ID)
+		resp.Write([]byte(createResponseData(nil, "success", nil)))
+		return
+	}
+
 	application := types.Application{
 		Kind:             version.Kind,
 		ID:               version.ID,
@@ -1386,6 +1445,21 @@ func (r *Router) updateApplication(req *restful.Request, resp *restful.Response)
 		return
 	}
 
+	r.backend.LockNamespaceQuota(runAs)
+	defer r.backend.UnLockNamespaceQuota(runAs)
+	if err := r.backend.CheckNamespaceQuota(&version, uint64(version.Instances), appId); err != nil {
+		blog.Error("request update application(%s.%s) fail: %s", runAs, appId, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedQuotaExceeded, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+
+	if isDryRun(req) {
+		blog.Info("request update application(%s.%s) dry run success", runAs, appId)
+		resp.Write([]byte(createResponseData(nil, "success", nil)))
+		return
+	}
+
 	if err := r.backend.SaveVersion(runAs, appId, &version); err != nil {
 		blog.Error("request update application(%s.%s) fail to save version. err:%s", runAs, appId, err.Error())
 		data := createResponseData(err, err.Error(), nil)
@@ -1779,6 +1853,12 @@ func (r *Router) sendMessageApplicationTaskGroup(req *restful.Request, resp *res
 	return
 }
 
+// isDryRun returns true when request only runs checks without saving anything, it is set by query dryRun=true
+func isDryRun(req *restful.Request) bool {
+	dryRun, _ := strconv.ParseBool(req.QueryParameter("dryRun"))
+	return dryRun
+}
+
 // TODO(jinrui), http server will improve, this function is just use for this http server framwork
 func createResponseData(err error, msg string, data interface{}) string {
 	var rpyErr error
@@ -1827,6 +1907,9 @@ func (r *Router) reschedulerTaskgroup(req *restful.Request, resp *restful.Respon
 	if err := r.backend.RescheduleTaskgroup(taskgroupId, hostRetainTime); err != nil {
 		blog.Error("request rescheduler taskgroup(%s) err(%s)", taskgroupId, err.Error())
 		data := createResponseData(err, err.Error(), nil)
+		if errors.Is(err, scheduler.ErrDisruptionDenied) {
+			data = createResponseDataV2(comm.BcsErrMesosSchedDisruptionDenied, err.Error(), nil)
+		}
 		resp.Write([]byte(data))
 		return
 	}
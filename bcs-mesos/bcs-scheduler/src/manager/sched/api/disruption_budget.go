@@ -0,0 +1,141 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"encoding/json"
+
+	comm "github.com/Tencent/bk-bcs/bcs-common/common"
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/scheduler"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+
+	"github.com/emicklei/go-restful"
+)
+
+// create or update disruption budget
+func (r *Router) saveDisruptionBudget(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	var budget commtypes.BcsDisruptionBudget
+	if err := json.NewDecoder(req.Request.Body).Decode(&budget); err != nil {
+		blog.Errorf("fail to decode BcsDisruptionBudget json, err:%s", err.Error())
+		data := createResponseDataV2(comm.BcsErrCommJsonDecode, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	blog.Infof("request save disruption budget(%s.%s)", budget.NameSpace, budget.Name)
+
+	if err := r.backend.SaveDisruptionBudget(&budget); err != nil {
+		blog.Errorf("request save disruption budget(%s.%s) failed, err %s", budget.NameSpace, budget.Name, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", nil)
+	resp.Write([]byte(data))
+	return
+}
+
+// list disruption budgets with current status, all namespaces if query parameter namespace is empty
+func (r *Router) listDisruptionBudgets(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	ns := req.QueryParameter("namespace")
+	budgets, err := r.backend.ListDisruptionBudgets(ns)
+	if err != nil {
+		blog.Errorf("request list disruption budgets in namespace(%s) failed, err %s", ns, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", budgets)
+	resp.Write([]byte(data))
+	return
+}
+
+// fetch disruption budget with current status
+func (r *Router) fetchDisruptionBudget(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	ns := req.PathParameter("namespace")
+	name := req.PathParameter("name")
+	budget, err := r.backend.FetchDisruptionBudget(ns, name)
+	if err == store.ErrNoFound {
+		data := createResponseDataV2(comm.BcsErrMesosSchedNotFound, "disruption budget not found", nil)
+		resp.Write([]byte(data))
+		return
+	}
+	if err != nil {
+		blog.Errorf("request fetch disruption budget(%s.%s) failed, err %s", ns, name, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", budget)
+	resp.Write([]byte(data))
+	return
+}
+
+// delete disruption budget
+func (r *Router) deleteDisruptionBudget(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	ns := req.PathParameter("namespace")
+	name := req.PathParameter("name")
+	blog.Infof("request delete disruption budget(%s.%s)", ns, name)
+	if err := r.backend.DeleteDisruptionBudget(ns, name); err != nil {
+		blog.Errorf("request delete disruption budget(%s.%s) failed, err %s", ns, name, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", nil)
+	resp.Write([]byte(data))
+	return
+}
+
+// drain agent, the taskgroups blocked by disruption budgets are returned and should be drained again
+func (r *Router) drainAgent(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	IP := req.PathParameter("IP")
+	blog.Infof("request drain agent(%s)", IP)
+	result, err := r.backend.DrainAgent(IP)
+	if err != nil {
+		blog.Errorf("request drain agent(%s) failed, err %s", IP, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", result)
+	resp.Write([]byte(data))
+	return
+}
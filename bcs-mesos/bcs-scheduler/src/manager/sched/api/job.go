@@ -0,0 +1,237 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+
+	comm "github.com/Tencent/bk-bcs/bcs-common/common"
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/scheduler"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+
+	"github.com/emicklei/go-restful"
+)
+
+// create job
+func (r *Router) createJob(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	var job types.BcsJob
+	if err := json.NewDecoder(req.Request.Body).Decode(&job); err != nil {
+		blog.Errorf("fail to decode BcsJob json, err:%s", err.Error())
+		data := createResponseDataV2(comm.BcsErrCommJsonDecode, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	blog.Infof("request create job(%s)", job.GetUuid())
+	if code, err := r.backend.LaunchJob(&job); err != nil {
+		blog.Errorf("fail to launch job(%s), err:%s", job.GetUuid(), err.Error())
+		data := createResponseDataV2(code, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", nil)
+	resp.Write([]byte(data))
+	return
+}
+
+// list jobs, all namespaces if query parameter namespace is empty
+func (r *Router) listJobs(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	ns := req.QueryParameter("namespace")
+	jobs, err := r.backend.ListJobs(ns)
+	if err != nil {
+		blog.Errorf("request list jobs in namespace(%s) failed, err %s", ns, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", jobs)
+	resp.Write([]byte(data))
+	return
+}
+
+// fetch job with status
+func (r *Router) fetchJob(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	ns := req.PathParameter("namespace")
+	name := req.PathParameter("name")
+	job, err := r.backend.FetchJob(ns, name)
+	if err == store.ErrNoFound {
+		data := createResponseDataV2(comm.BcsErrMesosSchedNotFound, "job not found", nil)
+		resp.Write([]byte(data))
+		return
+	}
+	if err != nil {
+		blog.Errorf("request fetch job(%s.%s) failed, err %s", ns, name, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", job)
+	resp.Write([]byte(data))
+	return
+}
+
+// delete job and its taskgroups
+func (r *Router) deleteJob(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	ns := req.PathParameter("namespace")
+	name := req.PathParameter("name")
+	blog.Infof("request delete job(%s.%s)", ns, name)
+	if err := r.backend.DeleteJob(ns, name); err != nil {
+		blog.Errorf("fail to delete job(%s.%s), err:%s", ns, name, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", nil)
+	resp.Write([]byte(data))
+	return
+}
+
+// create cronjob
+func (r *Router) createCronJob(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	var cronJob types.BcsCronJob
+	if err := json.NewDecoder(req.Request.Body).Decode(&cronJob); err != nil {
+		blog.Errorf("fail to decode BcsCronJob json, err:%s", err.Error())
+		data := createResponseDataV2(comm.BcsErrCommJsonDecode, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	blog.Infof("request create cronjob(%s)", cronJob.GetUuid())
+	if code, err := r.backend.LaunchCronJob(&cronJob); err != nil {
+		blog.Errorf("fail to launch cronjob(%s), err:%s", cronJob.GetUuid(), err.Error())
+		data := createResponseDataV2(code, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", nil)
+	resp.Write([]byte(data))
+	return
+}
+
+// update cronjob, such as schedule and suspend
+func (r *Router) updateCronJob(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	var cronJob types.BcsCronJob
+	if err := json.NewDecoder(req.Request.Body).Decode(&cronJob); err != nil {
+		blog.Errorf("fail to decode BcsCronJob json, err:%s", err.Error())
+		data := createResponseDataV2(comm.BcsErrCommJsonDecode, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	blog.Infof("request update cronjob(%s)", cronJob.GetUuid())
+	if code, err := r.backend.UpdateCronJob(&cronJob); err != nil {
+		blog.Errorf("fail to update cronjob(%s), err:%s", cronJob.GetUuid(), err.Error())
+		data := createResponseDataV2(code, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", nil)
+	resp.Write([]byte(data))
+	return
+}
+
+// list cronjobs, all namespaces if query parameter namespace is empty
+func (r *Router) listCronJobs(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	ns := req.QueryParameter("namespace")
+	cronJobs, err := r.backend.ListCronJobs(ns)
+	if err != nil {
+		blog.Errorf("request list cronjobs in namespace(%s) failed, err %s", ns, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", cronJobs)
+	resp.Write([]byte(data))
+	return
+}
+
+// fetch cronjob with status
+func (r *Router) fetchCronJob(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	ns := req.PathParameter("namespace")
+	name := req.PathParameter("name")
+	cronJob, err := r.backend.FetchCronJob(ns, name)
+	if err == store.ErrNoFound {
+		data := createResponseDataV2(comm.BcsErrMesosSchedNotFound, "cronjob not found", nil)
+		resp.Write([]byte(data))
+		return
+	}
+	if err != nil {
+		blog.Errorf("request fetch cronjob(%s.%s) failed, err %s", ns, name, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", cronJob)
+	resp.Write([]byte(data))
+	return
+}
+
+// delete cronjob and the jobs created by it
+func (r *Router) deleteCronJob(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	ns := req.PathParameter("namespace")
+	name := req.PathParameter("name")
+	blog.Infof("request delete cronjob(%s.%s)", ns, name)
+	if err := r.backend.DeleteCronJob(ns, name); err != nil {
+		blog.Errorf("fail to delete cronjob(%s.%s), err:%s", ns, name, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", nil)
+	resp.Write([]byte(data))
+	return
+}
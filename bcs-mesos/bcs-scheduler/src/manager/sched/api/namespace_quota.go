@@ -0,0 +1,118 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+
+	comm "github.com/Tencent/bk-bcs/bcs-common/common"
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/scheduler"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+
+	"github.com/emicklei/go-restful"
+)
+
+// list quotas of all namespaces with current usage
+func (r *Router) listNamespaceQuotas(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	quotas, err := r.backend.ListNamespaceQuotas()
+	if err != nil {
+		blog.Errorf("request list namespace quotas failed, err %s", err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", quotas)
+	resp.Write([]byte(data))
+	return
+}
+
+// fetch quota of namespace with current usage
+func (r *Router) fetchNamespaceQuota(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	ns := req.PathParameter("namespace")
+	quota, err := r.backend.FetchNamespaceQuota(ns)
+	if err == store.ErrNoFound {
+		data := createResponseDataV2(comm.BcsErrMesosSchedNotFound, "namespace quota not found", nil)
+		resp.Write([]byte(data))
+		return
+	}
+	if err != nil {
+		blog.Errorf("request fetch namespace %s quota failed, err %s", ns, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", quota)
+	resp.Write([]byte(data))
+	return
+}
+
+// create or update quota of namespace
+func (r *Router) saveNamespaceQuota(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	ns := req.PathParameter("namespace")
+	var quota commtypes.BcsNamespaceQuota
+	if err := json.NewDecoder(req.Request.Body).Decode(&quota); err != nil {
+		blog.Errorf("request save namespace %s quota, decode body failed, err %s", ns, err.Error())
+		data := createResponseDataV2(comm.BcsErrCommJsonDecode, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	quota.NameSpace = ns
+	blog.Infof("request save namespace %s quota %+v", ns, quota.Hard)
+
+	if err := r.backend.SaveNamespaceQuota(&quota); err != nil {
+		blog.Errorf("request save namespace %s quota failed, err %s", ns, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", nil)
+	resp.Write([]byte(data))
+	return
+}
+
+// delete quota of namespace
+func (r *Router) deleteNamespaceQuota(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	ns := req.PathParameter("namespace")
+	blog.Infof("request delete namespace %s quota", ns)
+	if err := r.backend.DeleteNamespaceQuota(ns); err != nil {
+		blog.Errorf("request delete namespace %s quota failed, err %s", ns, err.Error())
+		data := createResponseDataV2(comm.BcsErrMesosSchedCommon, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", nil)
+	resp.Write([]byte(data))
+	return
+}
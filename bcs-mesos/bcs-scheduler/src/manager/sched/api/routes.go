@@ -235,4 +235,40 @@ func (r *Router) initRoutes() {
 	r.actions = append(r.actions, httpserver.NewAction("GET", "/transactions/{namespace}", nil, r.listTransaction))
 	r.actions = append(r.actions, httpserver.NewAction("DELETE", "/transactions/{namespace}/{name}", nil, r.deleteTransaction))
 	/*--------------transaction---------------------*/
+
+	/*--------------namespace quota-----------------*/
+	r.actions = append(r.actions, httpserver.NewAction("GET", "/namespacequotas", nil, r.listNamespaceQuotas))
+	r.actions = append(r.actions, httpserver.NewAction("GET", "/namespacequota/{namespace}", nil, r.fetchNamespaceQuota))
+	r.actions = append(r.actions, httpserver.NewAction("PUT", "/namespacequota/{namespace}", nil, r.saveNamespaceQuota))
+	r.actions = append(r.actions, httpserver.NewAction(
+		"DELETE", "/namespacequota/{namespace}", nil, r.deleteNamespaceQuota))
+	/*--------------namespace quota-----------------*/
+
+	/*--------------job-----------------------------*/
+	r.actions = append(r.actions, httpserver.NewAction("POST", "/jobs", nil, r.createJob))
+	r.actions = append(r.actions, httpserver.NewAction("GET", "/jobs", nil, r.listJobs))
+	r.actions = append(r.actions, httpserver.NewAction("GET", "/jobs/{namespace}/{name}", nil, r.fetchJob))
+	r.actions = append(r.actions, httpserver.NewAction("DELETE", "/jobs/{namespace}/{name}", nil, r.deleteJob))
+	r.actions = append(r.actions, httpserver.NewAction("POST", "/cronjobs", nil, r.createCronJob))
+	r.actions = append(r.actions, httpserver.NewAction("PUT", "/cronjobs", nil, r.updateCronJob))
+	r.actions = append(r.actions, httpserver.NewAction("GET", "/cronjobs", nil, r.listCronJobs))
+	r.actions = append(r.actions, httpserver.NewAction("GET", "/cronjobs/{namespace}/{name}", nil, r.fetchCronJob))
+	r.actions = append(r.actions, httpserver.NewAction(
+		"DELETE", "/cronjobs/{namespace}/{name}", nil, r.deleteCronJob))
+	/*--------------job-----------------------------*/
+
+	/*--------------simulation----------------------*/
+	r.actions = append(r.actions, httpserver.NewAction("POST", "/simulation", nil, r.simulateApplication))
+	/*--------------simulation----------------------*/
+
+	/*--------------disruption budget---------------*/
+	r.actions = append(r.actions, httpserver.NewAction("POST", "/disruptionbudgets", nil, r.saveDisruptionBudget))
+	r.actions = append(r.actions, httpserver.NewAction("PUT", "/disruptionbudgets", nil, r.saveDisruptionBudget))
+	r.actions = append(r.actions, httpserver.NewAction("GET", "/disruptionbudgets", nil, r.listDisruptionBudgets))
+	r.actions = append(r.actions, httpserver.NewAction(
+		"GET", "/disruptionbudgets/{namespace}/{name}", nil, r.fetchDisruptionBudget))
+	r.actions = append(r.actions, httpserver.NewAction(
+		"DELETE", "/disruptionbudgets/{namespace}/{name}", nil, r.deleteDisruptionBudget))
+	r.actions = append(r.actions, httpserver.NewAction("POST", "/agentsetting/{IP}/drain", nil, r.drainAgent))
+	/*--------------disruption budget---------------*/
 }
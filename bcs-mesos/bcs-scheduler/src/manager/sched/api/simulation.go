@@ -0,0 +1,78 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	comm "github.com/Tencent/bk-bcs/bcs-common/common"
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/scheduler"
+
+	"github.com/emicklei/go-restful"
+)
+
+// maxSimulationReplicas limits taskgroups simulated in one request, every taskgroup scans all offers
+const maxSimulationReplicas = 1000
+
+// simulate launching taskgroups of version, query parameter replicas is the number of taskgroups,
+// instances of version by default
+func (r *Router) simulateApplication(req *restful.Request, resp *restful.Response) {
+	if r.backend.GetRole() != scheduler.SchedulerRoleMaster {
+		blog.Warn("scheduler is not master, can not process cmd")
+		return
+	}
+
+	var version types.Version
+	if err := json.NewDecoder(req.Request.Body).Decode(&version); err != nil {
+		blog.Errorf("fail to decode version json for simulation, err:%s", err.Error())
+		data := createResponseDataV2(comm.BcsErrCommJsonDecode, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+
+	replicas := int(version.Instances)
+	if param := req.QueryParameter("replicas"); param != "" {
+		num, err := strconv.Atoi(param)
+		if err != nil {
+			blog.Errorf("request simulate application(%s.%s) replicas %s error", version.RunAs, version.ID, param)
+			data := createResponseDataV2(comm.BcsErrCommRequestDataErr, "replicas error: "+err.Error(), nil)
+			resp.Write([]byte(data))
+			return
+		}
+		replicas = num
+	}
+	if replicas > maxSimulationReplicas {
+		blog.Errorf("request simulate application(%s.%s) replicas %d exceeds %d",
+			version.RunAs, version.ID, replicas, maxSimulationReplicas)
+		data := createResponseDataV2(comm.BcsErrCommRequestDataErr,
+			fmt.Sprintf("replicas %d exceeds max %d", replicas, maxSimulationReplicas), nil)
+		resp.Write([]byte(data))
+		return
+	}
+
+	result, err := r.backend.SimulateApplication(&version, replicas)
+	if err != nil {
+		blog.Errorf("request simulate application(%s.%s) failed, err %s", version.RunAs, version.ID, err.Error())
+		data := createResponseDataV2(comm.BcsErrCommRequestDataErr, err.Error(), nil)
+		resp.Write([]byte(data))
+		return
+	}
+	data := createResponseData(nil, "success", result)
+	resp.Write([]byte(data))
+	return
+}
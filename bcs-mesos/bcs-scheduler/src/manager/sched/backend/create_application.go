@@ -18,11 +18,15 @@ import (
 	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
 	commontypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
 	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/strategy"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/task"
 	"time"
 )
 
 func (b *backend) CheckVersion(version *types.Version) error {
+	if err := strategy.CheckOfferScorers(version); err != nil {
+		return err
+	}
 	return task.CheckVersion(version, b.store)
 }
 
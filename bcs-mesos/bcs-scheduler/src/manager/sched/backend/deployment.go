@@ -216,6 +216,12 @@ func (b *backend) createDeploymentApplication(version *types.Version) (int, erro
 		err := errors.New("version constraints error")
 		return comm.BcsErrCommRequestDataErr, err
 	}
+	b.LockNamespaceQuota(version.RunAs)
+	defer b.UnLockNamespaceQuota(version.RunAs)
+	if err := b.CheckNamespaceQuota(version, uint64(version.Instances)); err != nil {
+		blog.Error("deployment application(%s.%s) quota error: %s", version.RunAs, version.ID, err.Error())
+		return comm.BcsErrMesosSchedQuotaExceeded, err
+	}
 	app, err := b.store.FetchApplication(version.RunAs, version.ID)
 	if err != nil && err != store.ErrNoFound {
 		blog.Error("create deployment application, fetch application(%s.%s) ret:%s", version.RunAs, version.ID, err.Error())
@@ -310,6 +316,10 @@ func (b *backend) UpdateDeployment(deployment *types.DeploymentDef) (int, error)
 		return comm.BcsErrMesosSchedNotFound, err
 	}
 
+	// lock quota admission of namespace before applications, the same order as scaling application
+	b.LockNamespaceQuota(ns)
+	defer b.UnLockNamespaceQuota(ns)
+
 	// lock current application
 	b.store.LockApplication(ns + "." + currDeployment.Application.ApplicationName)
 	defer b.store.UnLockApplication(ns + "." + currDeployment.Application.ApplicationName)
@@ -380,6 +390,12 @@ func (b *backend) UpdateDeployment(deployment *types.DeploymentDef) (int, error)
 		err := errors.New("constraints error")
 		return comm.BcsErrCommRequestDataErr, err
 	}
+	// extension application replaces current application when rolling update finished
+	if err := b.CheckNamespaceQuota(version, uint64(version.Instances),
+		currDeployment.Application.ApplicationName); err != nil {
+		blog.Error("update deployment, application(%s.%s) quota error: %s", version.RunAs, version.ID, err.Error())
+		return comm.BcsErrMesosSchedQuotaExceeded, err
+	}
 	// lock extension application
 	b.store.LockApplication(ns + "." + version.ID)
 	defer b.store.UnLockApplication(ns + "." + version.ID)
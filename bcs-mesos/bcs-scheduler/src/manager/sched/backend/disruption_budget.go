@@ -0,0 +1,150 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package backend
+
+import (
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/scheduler"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/task"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+)
+
+// SaveDisruptionBudget create or update disruption budget
+func (b *backend) SaveDisruptionBudget(budget *commtypes.BcsDisruptionBudget) error {
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+	// status is calculated when disruption budget is queried, never store it
+	budget.Status = nil
+	return b.store.SaveDisruptionBudget(budget)
+}
+
+// FetchDisruptionBudget fetch disruption budget with its current status
+func (b *backend) FetchDisruptionBudget(ns, name string) (*commtypes.BcsDisruptionBudget, error) {
+	budget, err := b.store.FetchDisruptionBudget(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	budget.Status, err = b.sched.DisruptionBudgetStatus(budget)
+	if err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+// ListDisruptionBudgets list disruption budgets in namespace with their current status,
+// disruption budgets of all namespaces are listed if ns is empty
+func (b *backend) ListDisruptionBudgets(ns string) ([]*commtypes.BcsDisruptionBudget, error) {
+	var budgets []*commtypes.BcsDisruptionBudget
+	var err error
+	if ns == "" {
+		budgets, err = b.store.ListAllDisruptionBudgets()
+	} else {
+		budgets, err = b.store.ListDisruptionBudgets(ns)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, budget := range budgets {
+		budget.Status, err = b.sched.DisruptionBudgetStatus(budget)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return budgets, nil
+}
+
+// DeleteDisruptionBudget delete disruption budget
+func (b *backend) DeleteDisruptionBudget(ns, name string) error {
+	return b.store.DeleteDisruptionBudget(ns, name)
+}
+
+// DrainAgent disable agent and evict taskgroups on it as far as disruption budgets allow,
+// taskgroups of daemonsets are kept. Drain should be requested again until no taskgroup is blocked.
+func (b *backend) DrainAgent(ip string) (*commtypes.DrainAgentResult, error) {
+	if err := b.DisableAgent(ip); err != nil {
+		return nil, err
+	}
+	agent, err := b.store.FetchAgentSetting(ip)
+	if err != nil {
+		blog.Errorf("drain agent(%s), fetch agent setting failed, err %s", ip, err.Error())
+		return nil, err
+	}
+
+	result := &commtypes.DrainAgentResult{
+		InnerIP: ip,
+		Evicted: make([]string, 0),
+		Blocked: make([]*commtypes.DrainBlockedTaskgroup, 0),
+	}
+	if agent == nil {
+		return result, nil
+	}
+
+	checker := b.sched.NewDisruptionChecker()
+	for _, taskgroupID := range agent.Pods {
+		if b.sched.CheckPodBelongDaemonset(taskgroupID) {
+			continue
+		}
+		taskgroup, err := b.store.FetchTaskGroup(taskgroupID)
+		if err == store.ErrNoFound {
+			continue
+		}
+		if err != nil {
+			blog.Errorf("drain agent(%s), fetch taskgroup(%s) failed, err %s", ip, taskgroupID, err.Error())
+			result.Blocked = append(result.Blocked, &commtypes.DrainBlockedTaskgroup{
+				TaskgroupID: taskgroupID,
+				Reason:      err.Error(),
+			})
+			continue
+		}
+		if task.IsTaskGroupEnd(taskgroup) {
+			continue
+		}
+		if taskgroup.Status == types.TASKGROUP_STATUS_KILLING {
+			result.Blocked = append(result.Blocked, &commtypes.DrainBlockedTaskgroup{
+				TaskgroupID: taskgroupID,
+				Reason:      "taskgroup is being killed",
+			})
+			continue
+		}
+
+		if err := b.rescheduleTaskgroup(taskgroupID, 0, checker); err != nil {
+			blog.Warnf("drain agent(%s), evict taskgroup(%s) failed, err %s", ip, taskgroupID, err.Error())
+			result.Blocked = append(result.Blocked, &commtypes.DrainBlockedTaskgroup{
+				TaskgroupID: taskgroupID,
+				Reason:      err.Error(),
+			})
+			continue
+		}
+		result.Evicted = append(result.Evicted, taskgroupID)
+	}
+	blog.Infof("drain agent(%s): %d taskgroups evicted, %d taskgroups blocked",
+		ip, len(result.Evicted), len(result.Blocked))
+	return result, nil
+}
+
+// checkDisruption check whether a running taskgroup of application can be voluntarily disrupted
+func (b *backend) checkDisruption(runAs, appID, taskgroupID string, checker *scheduler.DisruptionChecker) error {
+	app, err := b.store.FetchApplication(runAs, appID)
+	if err == store.ErrNoFound {
+		return nil
+	}
+	if err != nil {
+		blog.Errorf("fetch application(%s.%s) failed, err %s", runAs, appID, err.Error())
+		return err
+	}
+	return checker.Disrupt(app, taskgroupID)
+}
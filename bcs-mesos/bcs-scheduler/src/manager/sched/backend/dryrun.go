@@ -0,0 +1,184 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	comm "github.com/Tencent/bk-bcs/bcs-common/common"
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/utils"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+)
+
+// DryRunCreateDeployment runs all checks of CreateDeployment without saving anything
+func (b *backend) DryRunCreateDeployment(deploymentDef *types.DeploymentDef) (int, error) {
+	ns := deploymentDef.ObjectMeta.NameSpace
+	name := deploymentDef.ObjectMeta.Name
+	blog.Info("dry run create deployment(%s.%s)", ns, name)
+
+	currDeployment, err := b.store.FetchDeployment(ns, name)
+	if err != nil && err != store.ErrNoFound {
+		return comm.BcsErrMesosSchedCommon, err
+	}
+	if currDeployment != nil {
+		return comm.BcsErrMesosSchedResourceExist, fmt.Errorf("deployment(%s.%s) already exist", ns, name)
+	}
+
+	matchCount := 0
+	if deploymentDef.Selector != nil {
+		apps, err := b.ListApplications(ns)
+		if err != nil {
+			return comm.BcsErrCommListZkNodeFail, fmt.Errorf("list application under namespace(%s) err: %s", ns, err.Error())
+		}
+		for _, app := range apps {
+			version, _ := b.GetVersion(app.RunAs, app.ID)
+			if version == nil {
+				continue
+			}
+			for kd, vd := range deploymentDef.Selector {
+				if va, ok := version.Labels[kd]; ok && va == vd {
+					matchCount++
+				}
+			}
+		}
+	}
+
+	switch {
+	case matchCount > 1:
+		return comm.BcsErrCommRequestDataErr, errors.New("too many application exist matching deployment")
+	case matchCount == 1 && deploymentDef.Version == nil:
+		// just bind current exist application
+		return comm.BcsSuccess, nil
+	case deploymentDef.Version == nil || deploymentDef.Version.RunAs != ns:
+		return comm.BcsErrCommRequestDataErr, errors.New("version empty or namespace error")
+	}
+	// matched application is deleted and replaced by a new one, so its name is never duplicated
+	return b.dryRunDeploymentVersion(deploymentDef.Version, matchCount == 0)
+}
+
+// DryRunUpdateDeployment runs all checks of UpdateDeployment without saving anything,
+// onlyResource stands for checks of UpdateDeploymentResource
+func (b *backend) DryRunUpdateDeployment(deployment *types.DeploymentDef, onlyResource bool) (int, error) {
+	ns := deployment.ObjectMeta.NameSpace
+	name := deployment.ObjectMeta.Name
+	blog.Info("dry run update deployment(%s.%s), only resource %t", ns, name, onlyResource)
+
+	if !onlyResource {
+		if deployment.Strategy.RollingUpdate == nil {
+			return comm.BcsErrCommRequestDataErr, errors.New("update strategy not set")
+		}
+		if deployment.Strategy.RollingUpdate.RollingOrder != commtypes.CreateFirstOrder &&
+			deployment.Strategy.RollingUpdate.RollingOrder != commtypes.DeleteFirstOrder {
+			return comm.BcsErrCommRequestDataErr, errors.New("update strategy rolling order error")
+		}
+	}
+
+	currDeployment, err := b.store.FetchDeployment(ns, name)
+	if err != nil && err != store.ErrNoFound {
+		return comm.BcsErrCommGetZkNodeFail, err
+	}
+	if currDeployment == nil {
+		return comm.BcsErrMesosSchedNotFound, errors.New("deployment not exist")
+	}
+	if currDeployment.Status != types.DEPLOYMENT_STATUS_RUNNING {
+		return comm.BcsErrMesosSchedCommon, errors.New("deployment is not running, cannot update")
+	}
+	if currDeployment.Application == nil {
+		return comm.BcsErrMesosSchedNotFound,
+			errors.New("deployment has not application, cannot do update, you can delete and recreate it")
+	}
+	if len(deployment.Selector) != len(currDeployment.Selector) {
+		return comm.BcsErrCommRequestDataErr, errors.New("deployment's selector cannot be updated'")
+	}
+	for k, v := range currDeployment.Selector {
+		if deployment.Selector[k] != v {
+			return comm.BcsErrCommRequestDataErr, errors.New("deployment's selector cannot be updated'")
+		}
+	}
+	if onlyResource && !reflect.DeepEqual(deployment.Strategy, currDeployment.Strategy) {
+		return comm.BcsErrMesosSchedCommon, errors.New("cannot change deployment meta and strategy when update resource")
+	}
+
+	appName := currDeployment.Application.ApplicationName
+	app, err := b.store.FetchApplication(ns, appName)
+	if err != nil && err != store.ErrNoFound {
+		return comm.BcsErrCommGetZkNodeFail, err
+	}
+	if app == nil {
+		return comm.BcsErrMesosSchedNotFound,
+			errors.New("deployment has not application, cannot update, you can delete and recreate it")
+	}
+
+	if onlyResource {
+		if app.Status == types.APP_STATUS_OPERATING || app.Status == types.APP_STATUS_ROLLINGUPDATE {
+			return comm.BcsErrMesosSchedCommon, fmt.Errorf(
+				"application(%s.%s) of deployment(%s.%s) cannot do update under status(%s)",
+				ns, appName, ns, name, app.Status)
+		}
+		currVersion, _ := b.GetVersion(ns, appName)
+		if currVersion == nil {
+			return comm.BcsErrCommGetZkNodeFail, fmt.Errorf("get current version of application(%s.%s) failed", ns, appName)
+		}
+		if err = utils.IsOnlyResourceIncreased(currVersion, deployment.Version); err != nil {
+			return comm.BcsErrMesosSchedCommon, fmt.Errorf("check update resource failed, err %s", err.Error())
+		}
+		return comm.BcsSuccess, nil
+	}
+
+	if app.Status != types.APP_STATUS_RUNNING && app.Status != types.APP_STATUS_ABNORMAL {
+		return comm.BcsErrMesosSchedCommon,
+			errors.New("deployment bind application is not running, cannot update, please try later")
+	}
+	if deployment.Version == nil || deployment.Version.RunAs != ns {
+		return comm.BcsErrCommRequestDataErr, errors.New("version empty or namespace error")
+	}
+	// new application of rolling update is named with timestamp suffix, it is never duplicated
+	return b.dryRunDeploymentVersion(deployment.Version, false, appName)
+}
+
+// dryRunDeploymentVersion checks version of application created by deployment,
+// excludeApps are applications replaced by the new one
+func (b *backend) dryRunDeploymentVersion(version *types.Version, checkExist bool, excludeApps ...string) (int, error) {
+	if version.Instances <= 0 {
+		return comm.BcsErrCommRequestDataErr, errors.New("application instances error")
+	}
+	if err := b.CheckVersion(version); err != nil {
+		return comm.BcsErrCommRequestDataErr, err
+	}
+	if err := version.CheckAndDefaultResource(); err != nil {
+		return comm.BcsErrCommRequestDataErr, err
+	}
+	if !version.CheckConstraints() {
+		return comm.BcsErrCommRequestDataErr, errors.New("version constraints error")
+	}
+	if err := b.CheckNamespaceQuota(version, uint64(version.Instances), excludeApps...); err != nil {
+		return comm.BcsErrMesosSchedQuotaExceeded, err
+	}
+	if !checkExist {
+		return comm.BcsSuccess, nil
+	}
+	app, err := b.store.FetchApplication(version.RunAs, version.ID)
+	if err != nil && err != store.ErrNoFound {
+		return comm.BcsErrCommGetZkNodeFail, err
+	}
+	if app != nil {
+		return comm.BcsErrCommRequestDataErr, errors.New("application duplicated when create application for deployment")
+	}
+	return comm.BcsSuccess, nil
+}
@@ -169,6 +169,13 @@ type Backend interface {
 	// UpdateDeploymentResource update deployment resource only
 	UpdateDeploymentResource(*types.DeploymentDef) (int, error)
 
+	// DryRunCreateDeployment check deployment creation without saving anything
+	DryRunCreateDeployment(*types.DeploymentDef) (int, error)
+
+	// DryRunUpdateDeployment check deployment update without saving anything, second para is true
+	// when only resource is updated
+	DryRunUpdateDeployment(*types.DeploymentDef, bool) (int, error)
+
 	// CancelUpdateDeployment cancel update deployment, and rollback the application
 	// first para is namespace, second one is deployment's name
 	CancelUpdateDeployment(string, string) error
@@ -318,4 +325,60 @@ type Backend interface {
 	// DeleteTransaction delete transaction
 	DeleteTransaction(transNs, transName string) error
 	/*==========Transaction==============*/
+
+	/*==========NamespaceQuota===========*/
+	// SaveNamespaceQuota save resource quota of namespace
+	SaveNamespaceQuota(quota *commtypes.BcsNamespaceQuota) error
+	// FetchNamespaceQuota fetch resource quota of namespace with current usage
+	FetchNamespaceQuota(ns string) (*commtypes.BcsNamespaceQuota, error)
+	// ListNamespaceQuotas list resource quotas of all namespaces with current usage
+	ListNamespaceQuotas() ([]*commtypes.BcsNamespaceQuota, error)
+	// DeleteNamespaceQuota delete resource quota of namespace
+	DeleteNamespaceQuota(ns string) error
+	// LockNamespaceQuota serializes quota admission of namespace until UnLockNamespaceQuota
+	LockNamespaceQuota(ns string)
+	// UnLockNamespaceQuota release the quota admission lock of namespace
+	UnLockNamespaceQuota(ns string)
+	// CheckNamespaceQuota check whether namespace quota is enough for instances of version,
+	// usage of excluded applications is not counted
+	CheckNamespaceQuota(version *types.Version, instances uint64, excludeApps ...string) error
+	/*==========NamespaceQuota===========*/
+
+	/*===============Job=================*/
+	// LaunchJob save job, the taskgroups of job are launched by scheduler
+	LaunchJob(job *types.BcsJob) (int, error)
+	// FetchJob fetch job by namespace and name
+	FetchJob(ns, name string) (*types.BcsJob, error)
+	// ListJobs list jobs in namespace, all namespaces if ns is empty
+	ListJobs(ns string) ([]*types.BcsJob, error)
+	// DeleteJob kill the taskgroups of job and delete it
+	DeleteJob(ns, name string) error
+	// LaunchCronJob save cronjob, jobs are created by scheduler on schedule
+	LaunchCronJob(cronJob *types.BcsCronJob) (int, error)
+	// UpdateCronJob update schedule, policies and job template of cronjob
+	UpdateCronJob(cronJob *types.BcsCronJob) (int, error)
+	// FetchCronJob fetch cronjob by namespace and name
+	FetchCronJob(ns, name string) (*types.BcsCronJob, error)
+	// ListCronJobs list cronjobs in namespace, all namespaces if ns is empty
+	ListCronJobs(ns string) ([]*types.BcsCronJob, error)
+	// DeleteCronJob delete cronjob and all jobs created by it
+	DeleteCronJob(ns, name string) error
+	/*===============Job=================*/
+
+	// SimulateApplication run replicas taskgroups of version through constraint and resource fitting
+	// against current offers without launching anything
+	SimulateApplication(version *types.Version, replicas int) (*types.SimulationResult, error)
+
+	/*=========DisruptionBudget==========*/
+	// SaveDisruptionBudget create or update disruption budget
+	SaveDisruptionBudget(budget *commtypes.BcsDisruptionBudget) error
+	// FetchDisruptionBudget fetch disruption budget with current status
+	FetchDisruptionBudget(ns, name string) (*commtypes.BcsDisruptionBudget, error)
+	// ListDisruptionBudgets list disruption budgets in namespace with current status, all namespaces if ns is empty
+	ListDisruptionBudgets(ns string) ([]*commtypes.BcsDisruptionBudget, error)
+	// DeleteDisruptionBudget delete disruption budget
+	DeleteDisruptionBudget(ns, name string) error
+	// DrainAgent disable agent and evict taskgroups on it as disruption budgets allow
+	DrainAgent(ip string) (*commtypes.DrainAgentResult, error)
+	/*=========DisruptionBudget==========*/
 }
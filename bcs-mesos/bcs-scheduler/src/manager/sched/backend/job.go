@@ -0,0 +1,285 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	comm "github.com/Tencent/bk-bcs/bcs-common/common"
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	commutil "github.com/Tencent/bk-bcs/bcs-common/common/util"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/util"
+)
+
+// checkJobDefinition check job parameters and version of job taskgroups
+func (b *backend) checkJobDefinition(job *types.BcsJob) (int, error) {
+	if job.Completions <= 0 || job.Parallelism <= 0 {
+		return comm.BcsErrCommRequestDataErr, errors.New("completions and parallelism of job must be positive")
+	}
+	if job.BackoffLimit < 0 || job.ActiveDeadlineSeconds < 0 {
+		return comm.BcsErrCommRequestDataErr, errors.New("backoffLimit and activeDeadlineSeconds of job can not be negative")
+	}
+	version := job.Version
+	if version == nil {
+		return comm.BcsErrCommRequestDataErr, errors.New("version of job is empty")
+	}
+	// taskgroups of job run to completion, they are never restarted
+	version.Kind = commtypes.BcsDataType_Job
+	version.RestartPolicy = &commtypes.RestartPolicy{Policy: commtypes.RestartPolicy_NEVER}
+	if err := b.CheckVersion(version); err != nil {
+		return comm.BcsErrCommRequestDataErr, err
+	}
+	if err := version.CheckAndDefaultResource(); err != nil {
+		return comm.BcsErrCommRequestDataErr, err
+	}
+	if !version.CheckConstraints() {
+		return comm.BcsErrCommRequestDataErr, errors.New("version constraints error")
+	}
+	return comm.BcsSuccess, nil
+}
+
+// LaunchJob save job, the taskgroups of job are launched by scheduler
+func (b *backend) LaunchJob(job *types.BcsJob) (int, error) {
+	blog.Infof("launch job(%s)", job.GetUuid())
+	if job.Version != nil {
+		job.Version.RunAs = job.NameSpace
+		job.Version.ID = job.Name
+	}
+	if code, err := b.checkJobDefinition(job); err != nil {
+		blog.Errorf("launch job(%s) failed, definition error: %s", job.GetUuid(), err.Error())
+		return code, err
+	}
+	instances := job.Parallelism
+	if job.Completions < instances {
+		instances = job.Completions
+	}
+	b.LockNamespaceQuota(job.NameSpace)
+	defer b.UnLockNamespaceQuota(job.NameSpace)
+	if err := b.CheckNamespaceQuota(job.Version, uint64(instances)); err != nil {
+		blog.Errorf("launch job(%s) failed, quota error: %s", job.GetUuid(), err.Error())
+		return comm.BcsErrMesosSchedQuotaExceeded, err
+	}
+
+	util.Lock.Lock(types.BcsJob{}, job.GetUuid())
+	defer util.Lock.UnLock(types.BcsJob{}, job.GetUuid())
+
+	exist, err := b.store.FetchJob(job.NameSpace, job.Name)
+	if err != nil && err != store.ErrNoFound {
+		blog.Errorf("launch job(%s), but FetchJob failed: %s", job.GetUuid(), err.Error())
+		return comm.BcsErrCommGetZkNodeFail, err
+	}
+	if exist != nil {
+		return comm.BcsErrCommRequestDataErr, fmt.Errorf("job(%s) already exists", job.GetUuid())
+	}
+	app, err := b.store.FetchApplication(job.NameSpace, job.Name)
+	if err != nil && err != store.ErrNoFound {
+		blog.Errorf("launch job(%s), but FetchApplication failed: %s", job.GetUuid(), err.Error())
+		return comm.BcsErrCommGetZkNodeFail, err
+	}
+	if app != nil {
+		return comm.BcsErrCommRequestDataErr, fmt.Errorf("application(%s) with the same name as job exists", job.GetUuid())
+	}
+
+	now := time.Now().Unix()
+	job.Status = types.Job_Status_Staging
+	job.Message = "job is staging"
+	job.Active, job.Succeeded, job.Failed = 0, 0, 0
+	job.Created = now
+	job.StartTime, job.CompletionTime = 0, 0
+	job.LastUpdateTime = now
+	if err := b.store.SaveJob(job); err != nil {
+		blog.Errorf("launch job(%s), but SaveJob failed: %s", job.GetUuid(), err.Error())
+		return comm.BcsErrCommCreateZkNodeFail, err
+	}
+	blog.Infof("launch job(%s) success", job.GetUuid())
+	return comm.BcsSuccess, nil
+}
+
+// FetchJob fetch job by namespace and name
+func (b *backend) FetchJob(ns, name string) (*types.BcsJob, error) {
+	return b.store.FetchJob(ns, name)
+}
+
+// ListJobs list jobs in namespace, all namespaces if ns is empty
+func (b *backend) ListJobs(ns string) ([]*types.BcsJob, error) {
+	if ns == "" {
+		return b.store.ListAllJobs()
+	}
+	return b.store.ListJobs(ns)
+}
+
+// DeleteJob mark job deleting, scheduler kills the taskgroups and deletes the job
+func (b *backend) DeleteJob(ns, name string) error {
+	util.Lock.Lock(types.BcsJob{}, ns+"."+name)
+	defer util.Lock.UnLock(types.BcsJob{}, ns+"."+name)
+
+	job, err := b.store.FetchJob(ns, name)
+	if err == store.ErrNoFound {
+		return nil
+	}
+	if err != nil {
+		blog.Errorf("delete job(%s.%s), but FetchJob failed: %s", ns, name, err.Error())
+		return err
+	}
+	if job.Status == types.Job_Status_Deleting {
+		return nil
+	}
+	blog.Infof("job(%s) status from(%s)->to(%s)", job.GetUuid(), job.Status, types.Job_Status_Deleting)
+	job.Status = types.Job_Status_Deleting
+	job.LastUpdateTime = time.Now().Unix()
+	return b.store.SaveJob(job)
+}
+
+// checkCronJobDefinition check schedule and job template of cronjob
+func (b *backend) checkCronJobDefinition(cronJob *types.BcsCronJob) (int, error) {
+	if _, err := commutil.ParseCronSchedule(cronJob.Schedule); err != nil {
+		return comm.BcsErrCommRequestDataErr, err
+	}
+	switch cronJob.ConcurrencyPolicy {
+	case "":
+		cronJob.ConcurrencyPolicy = commtypes.ConcurrencyPolicy_ALLOW
+	case commtypes.ConcurrencyPolicy_ALLOW, commtypes.ConcurrencyPolicy_FORBID, commtypes.ConcurrencyPolicy_REPLACE:
+	default:
+		return comm.BcsErrCommRequestDataErr, fmt.Errorf("unknown concurrencyPolicy %s", cronJob.ConcurrencyPolicy)
+	}
+	if cronJob.SuccessfulJobsHistoryLimit < 0 || cronJob.FailedJobsHistoryLimit < 0 {
+		return comm.BcsErrCommRequestDataErr, errors.New("history limits of cronjob can not be negative")
+	}
+	if cronJob.JobTemplate == nil {
+		return comm.BcsErrCommRequestDataErr, errors.New("jobTemplate of cronjob is empty")
+	}
+	// jobs are named by cronjob name and scheduled time, the template is checked with the cronjob name
+	cronJob.JobTemplate.NameSpace = cronJob.NameSpace
+	cronJob.JobTemplate.Name = cronJob.Name
+	cronJob.JobTemplate.CronJob = cronJob.Name
+	if cronJob.JobTemplate.Version != nil {
+		cronJob.JobTemplate.Version.RunAs = cronJob.NameSpace
+		cronJob.JobTemplate.Version.ID = cronJob.Name
+	}
+	return b.checkJobDefinition(cronJob.JobTemplate)
+}
+
+// LaunchCronJob save cronjob, jobs are created by scheduler on schedule
+func (b *backend) LaunchCronJob(cronJob *types.BcsCronJob) (int, error) {
+	blog.Infof("launch cronjob(%s) schedule(%s)", cronJob.GetUuid(), cronJob.Schedule)
+	if code, err := b.checkCronJobDefinition(cronJob); err != nil {
+		blog.Errorf("launch cronjob(%s) failed, definition error: %s", cronJob.GetUuid(), err.Error())
+		return code, err
+	}
+
+	util.Lock.Lock(types.BcsCronJob{}, cronJob.GetUuid())
+	defer util.Lock.UnLock(types.BcsCronJob{}, cronJob.GetUuid())
+
+	exist, err := b.store.FetchCronJob(cronJob.NameSpace, cronJob.Name)
+	if err != nil && err != store.ErrNoFound {
+		blog.Errorf("launch cronjob(%s), but FetchCronJob failed: %s", cronJob.GetUuid(), err.Error())
+		return comm.BcsErrCommGetZkNodeFail, err
+	}
+	if exist != nil {
+		return comm.BcsErrCommRequestDataErr, fmt.Errorf("cronjob(%s) already exists", cronJob.GetUuid())
+	}
+
+	now := time.Now().Unix()
+	cronJob.LastScheduleTime = 0
+	cronJob.Active = nil
+	cronJob.Created = now
+	cronJob.LastUpdateTime = now
+	if err := b.store.SaveCronJob(cronJob); err != nil {
+		blog.Errorf("launch cronjob(%s), but SaveCronJob failed: %s", cronJob.GetUuid(), err.Error())
+		return comm.BcsErrCommCreateZkNodeFail, err
+	}
+	blog.Infof("launch cronjob(%s) success", cronJob.GetUuid())
+	return comm.BcsSuccess, nil
+}
+
+// UpdateCronJob update schedule, policies and job template of cronjob, jobs already created are not affected
+func (b *backend) UpdateCronJob(cronJob *types.BcsCronJob) (int, error) {
+	blog.Infof("update cronjob(%s) schedule(%s) suspend(%t)", cronJob.GetUuid(), cronJob.Schedule, cronJob.Suspend)
+	if code, err := b.checkCronJobDefinition(cronJob); err != nil {
+		blog.Errorf("update cronjob(%s) failed, definition error: %s", cronJob.GetUuid(), err.Error())
+		return code, err
+	}
+
+	util.Lock.Lock(types.BcsCronJob{}, cronJob.GetUuid())
+	defer util.Lock.UnLock(types.BcsCronJob{}, cronJob.GetUuid())
+
+	exist, err := b.store.FetchCronJob(cronJob.NameSpace, cronJob.Name)
+	if err == store.ErrNoFound {
+		return comm.BcsErrMesosSchedNotFound, fmt.Errorf("cronjob(%s) not found", cronJob.GetUuid())
+	}
+	if err != nil {
+		blog.Errorf("update cronjob(%s), but FetchCronJob failed: %s", cronJob.GetUuid(), err.Error())
+		return comm.BcsErrCommGetZkNodeFail, err
+	}
+
+	cronJob.LastScheduleTime = exist.LastScheduleTime
+	cronJob.Active = exist.Active
+	cronJob.Created = exist.Created
+	cronJob.LastUpdateTime = time.Now().Unix()
+	if err := b.store.SaveCronJob(cronJob); err != nil {
+		blog.Errorf("update cronjob(%s), but SaveCronJob failed: %s", cronJob.GetUuid(), err.Error())
+		return comm.BcsErrCommCreateZkNodeFail, err
+	}
+	return comm.BcsSuccess, nil
+}
+
+// FetchCronJob fetch cronjob by namespace and name
+func (b *backend) FetchCronJob(ns, name string) (*types.BcsCronJob, error) {
+	return b.store.FetchCronJob(ns, name)
+}
+
+// ListCronJobs list cronjobs in namespace, all namespaces if ns is empty
+func (b *backend) ListCronJobs(ns string) ([]*types.BcsCronJob, error) {
+	if ns == "" {
+		return b.store.ListAllCronJobs()
+	}
+	return b.store.ListCronJobs(ns)
+}
+
+// DeleteCronJob delete cronjob and all jobs created by it
+func (b *backend) DeleteCronJob(ns, name string) error {
+	util.Lock.Lock(types.BcsCronJob{}, ns+"."+name)
+	defer util.Lock.UnLock(types.BcsCronJob{}, ns+"."+name)
+
+	if _, err := b.store.FetchCronJob(ns, name); err != nil {
+		if err == store.ErrNoFound {
+			return nil
+		}
+		blog.Errorf("delete cronjob(%s.%s), but FetchCronJob failed: %s", ns, name, err.Error())
+		return err
+	}
+
+	jobs, err := b.store.ListJobs(ns)
+	if err != nil {
+		blog.Errorf("delete cronjob(%s.%s), but ListJobs failed: %s", ns, name, err.Error())
+		return err
+	}
+	for _, job := range jobs {
+		if job.CronJob != name {
+			continue
+		}
+		if err := b.DeleteJob(job.NameSpace, job.Name); err != nil {
+			blog.Errorf("delete cronjob(%s.%s), but delete job(%s) failed: %s", ns, name, job.GetUuid(), err.Error())
+			return err
+		}
+	}
+
+	blog.Infof("delete cronjob(%s.%s)", ns, name)
+	return b.store.DeleteCronJob(ns, name)
+}
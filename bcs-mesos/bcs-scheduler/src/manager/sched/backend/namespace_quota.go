@@ -0,0 +1,246 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/util"
+)
+
+// SaveNamespaceQuota save resource quota of namespace
+func (b *backend) SaveNamespaceQuota(quota *commtypes.BcsNamespaceQuota) error {
+	if err := quota.Validate(); err != nil {
+		return err
+	}
+	// usage is calculated when quota is queried, never store it
+	quota.Used = nil
+	return b.store.SaveNamespaceQuota(quota)
+}
+
+// FetchNamespaceQuota fetch resource quota of namespace with its current usage
+func (b *backend) FetchNamespaceQuota(ns string) (*commtypes.BcsNamespaceQuota, error) {
+	quota, err := b.store.FetchNamespaceQuota(ns)
+	if err != nil {
+		return nil, err
+	}
+	quota.Used, err = b.namespaceQuotaUsage(ns, nil)
+	if err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+// ListNamespaceQuotas list resource quotas of all namespaces with their current usage
+func (b *backend) ListNamespaceQuotas() ([]*commtypes.BcsNamespaceQuota, error) {
+	quotas, err := b.store.ListNamespaceQuotas()
+	if err != nil {
+		return nil, err
+	}
+	for _, quota := range quotas {
+		quota.Used, err = b.namespaceQuotaUsage(quota.NameSpace, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return quotas, nil
+}
+
+// DeleteNamespaceQuota delete resource quota of namespace
+func (b *backend) DeleteNamespaceQuota(ns string) error {
+	return b.store.DeleteNamespaceQuota(ns)
+}
+
+// LockNamespaceQuota serializes quota admission of namespace, the lock should be held
+// from CheckNamespaceQuota until the admitted application, job or transaction is saved
+func (b *backend) LockNamespaceQuota(ns string) {
+	util.Lock.Lock(commtypes.BcsNamespaceQuota{}, ns)
+}
+
+// UnLockNamespaceQuota release the quota admission lock of namespace
+func (b *backend) UnLockNamespaceQuota(ns string) {
+	util.Lock.UnLock(commtypes.BcsNamespaceQuota{}, ns)
+}
+
+// CheckNamespaceQuota check whether the quota of version's namespace is enough for instances of version.
+// Usage of applications in excludeApps is not counted, they are going to be replaced by version.
+// Callers admitting new taskgroups should hold LockNamespaceQuota of the namespace.
+func (b *backend) CheckNamespaceQuota(version *types.Version, instances uint64, excludeApps ...string) error {
+	quota, err := b.store.FetchNamespaceQuota(version.RunAs)
+	if err == store.ErrNoFound {
+		return nil
+	}
+	if err != nil {
+		blog.Errorf("fetch namespace(%s) quota failed, err %s", version.RunAs, err.Error())
+		return err
+	}
+
+	used, err := b.namespaceQuotaUsage(version.RunAs, excludeApps)
+	if err != nil {
+		return err
+	}
+	addQuotaUsage(used, version.AllResource(), int(instances))
+
+	exceeded := quota.Hard.Exceeded(used)
+	if len(exceeded) != 0 {
+		blog.Warnf("application(%s.%s) with %d instances exceeds namespace quota: %s",
+			version.RunAs, version.ID, instances, strings.Join(exceeded, ","))
+		return fmt.Errorf("namespace %s quota exceeded: %s", version.RunAs, strings.Join(exceeded, ","))
+	}
+	return nil
+}
+
+// namespaceQuotaUsage sum resources of application taskgroups in namespace except excludeApps,
+// taskgroups admitted by pending transactions or staging jobs but not created yet are counted too
+func (b *backend) namespaceQuotaUsage(ns string, excludeApps []string) (*commtypes.NamespaceQuotaResource, error) {
+	isExcluded := func(appID string) bool {
+		for _, name := range excludeApps {
+			if appID == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	apps, err := b.store.ListApplications(ns)
+	if err != nil {
+		blog.Errorf("list applications in namespace(%s) failed, err %s", ns, err.Error())
+		return nil, err
+	}
+
+	used := &commtypes.NamespaceQuotaResource{}
+	// number of taskgroups created for application, ended ones included
+	created := make(map[string]int)
+	// taskgroups holding resources
+	holding := make(map[string]bool)
+	for _, app := range apps {
+		created[app.ID] = 0
+		if isExcluded(app.ID) {
+			continue
+		}
+
+		taskgroups, err := b.store.ListTaskGroups(ns, app.ID)
+		if err != nil {
+			blog.Errorf("list taskgroups of application(%s.%s) failed, err %s", ns, app.ID, err.Error())
+			return nil, err
+		}
+		created[app.ID] = len(taskgroups)
+		for _, taskgroup := range taskgroups {
+			if isTaskGroupQuotaReleased(taskgroup) {
+				continue
+			}
+			holding[taskgroup.ID] = true
+			resource := taskgroup.CurrResource
+			if resource == nil {
+				resource = taskgroup.LaunchResource
+			}
+			addQuotaUsage(used, resource, 1)
+		}
+	}
+
+	transactions, err := b.store.ListTransaction(ns)
+	if err != nil {
+		blog.Errorf("list transactions in namespace(%s) failed, err %s", ns, err.Error())
+		return nil, err
+	}
+	for _, trans := range transactions {
+		if trans.CurOp == nil || isExcluded(trans.ObjectName) || trans.Status == types.OPERATION_STATUS_FINISH ||
+			trans.Status == types.OPERATION_STATUS_FAIL || trans.Status == types.OPERATION_STATUS_TIMEOUT {
+			continue
+		}
+		resource, pending := pendingTransactionTaskgroups(trans, created[trans.ObjectName], holding)
+		addQuotaUsage(used, resource, pending)
+	}
+
+	// taskgroups of staging job are launched when scheduler creates the application of job
+	jobs, err := b.store.ListJobs(ns)
+	if err != nil {
+		blog.Errorf("list jobs in namespace(%s) failed, err %s", ns, err.Error())
+		return nil, err
+	}
+	for _, job := range jobs {
+		if _, ok := created[job.Name]; ok || isExcluded(job.Name) || job.Status != types.Job_Status_Staging ||
+			job.Version == nil {
+			continue
+		}
+		instances := job.Parallelism
+		if job.Completions < instances {
+			instances = job.Completions
+		}
+		addQuotaUsage(used, job.Version.AllResource(), int(instances))
+	}
+	return used, nil
+}
+
+// pendingTransactionTaskgroups returns resource of one taskgroup and the number of taskgroups
+// which transaction is going to create, created is the number of taskgroups the application has now
+func pendingTransactionTaskgroups(trans *types.Transaction, created int, holding map[string]bool) (*types.Resource, int) {
+	switch trans.CurOp.OpType {
+	case types.TransactionOpTypeLaunch:
+		data := trans.CurOp.OpLaunchData
+		if data == nil || data.Version == nil {
+			return nil, 0
+		}
+		resource := data.NeedResource
+		if resource == nil {
+			resource = data.Version.AllResource()
+		}
+		return resource, int(data.Version.Instances) - created
+	case types.TransactionOpTypeScale:
+		data := trans.CurOp.OpScaleData
+		if data == nil || data.Version == nil || data.IsDown {
+			return nil, 0
+		}
+		resource := data.NeedResource
+		if resource == nil {
+			resource = data.Version.AllResource()
+		}
+		return resource, int(data.Instances) - created
+	case types.TransactionOpTypeReschedule:
+		data := trans.CurOp.OpRescheduleData
+		// the taskgroup still holding resources is counted already
+		if data == nil || data.NeedResource == nil || holding[data.TaskGroupID] {
+			return nil, 0
+		}
+		return data.NeedResource, 1
+	}
+	return nil, 0
+}
+
+// isTaskGroupQuotaReleased returns true if taskgroup ended and holds no resources any more
+func isTaskGroupQuotaReleased(taskgroup *types.TaskGroup) bool {
+	switch taskgroup.Status {
+	case types.TASKGROUP_STATUS_FINISH, types.TASKGROUP_STATUS_FAIL, types.TASKGROUP_STATUS_KILLED,
+		types.TASKGROUP_STATUS_ERROR:
+		return true
+	}
+	return false
+}
+
+// addQuotaUsage add resources of taskgroups into used
+func addQuotaUsage(used *commtypes.NamespaceQuotaResource, resource *types.Resource, taskgroups int) {
+	if taskgroups <= 0 {
+		return
+	}
+	if resource != nil {
+		used.Cpu += resource.Cpus * float64(taskgroups)
+		used.Mem += resource.Mem * float64(taskgroups)
+		used.Disk += resource.Disk * float64(taskgroups)
+	}
+	used.Taskgroups += taskgroups
+}
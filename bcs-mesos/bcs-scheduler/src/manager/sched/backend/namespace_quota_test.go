@@ -0,0 +1,207 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package backend
+
+import (
+	"strings"
+	"testing"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	types "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+)
+
+// quotaStore keeps the objects counted by namespace quota in memory,
+// methods of store.Store not implemented here panic
+type quotaStore struct {
+	store.Store
+	quota        *commtypes.BcsNamespaceQuota
+	apps         []*types.Application
+	taskgroups   map[string][]*types.TaskGroup
+	transactions []*types.Transaction
+	jobs         []*types.BcsJob
+}
+
+func (f *quotaStore) FetchNamespaceQuota(ns string) (*commtypes.BcsNamespaceQuota, error) {
+	if f.quota == nil {
+		return nil, store.ErrNoFound
+	}
+	return f.quota, nil
+}
+
+func (f *quotaStore) ListApplications(runAs string) ([]*types.Application, error) {
+	return f.apps, nil
+}
+
+func (f *quotaStore) ListTaskGroups(runAs, appID string) ([]*types.TaskGroup, error) {
+	return f.taskgroups[appID], nil
+}
+
+func (f *quotaStore) ListTransaction(ns string) ([]*types.Transaction, error) {
+	return f.transactions, nil
+}
+
+func (f *quotaStore) ListJobs(ns string) ([]*types.BcsJob, error) {
+	return f.jobs, nil
+}
+
+func (f *quotaStore) addApplication(appID string, statuses ...string) {
+	f.apps = append(f.apps, &types.Application{ID: appID, RunAs: "ns"})
+	for i, status := range statuses {
+		f.taskgroups[appID] = append(f.taskgroups[appID], &types.TaskGroup{
+			ID:             appID + "." + string(rune('a'+i)),
+			Status:         status,
+			LaunchResource: &types.Resource{Cpus: 1, Mem: 100},
+		})
+	}
+}
+
+func newQuotaVersion(appID string, instances int32) *types.Version {
+	return &types.Version{
+		ID:        appID,
+		RunAs:     "ns",
+		Instances: instances,
+		Container: []*types.Container{
+			{DataClass: &types.DataClass{Resources: &types.Resource{Cpus: 1, Mem: 100}}},
+		},
+	}
+}
+
+func TestNamespaceQuotaUsage(t *testing.T) {
+	f := &quotaStore{taskgroups: make(map[string][]*types.TaskGroup)}
+	// ended taskgroups hold no resources
+	f.addApplication("web", types.TASKGROUP_STATUS_RUNNING, types.TASKGROUP_STATUS_FINISH,
+		types.TASKGROUP_STATUS_FAIL, types.TASKGROUP_STATUS_KILLED, types.TASKGROUP_STATUS_LOST)
+	// launching application with 1 of 3 taskgroups created
+	f.addApplication("launching", types.TASKGROUP_STATUS_STAGING)
+	f.addApplication("scaling", types.TASKGROUP_STATUS_RUNNING)
+	f.transactions = []*types.Transaction{
+		{
+			ObjectName: "launching",
+			Status:     types.OPERATION_STATUS_INIT,
+			CurOp: &types.TransactionOperartion{
+				OpType:       types.TransactionOpTypeLaunch,
+				OpLaunchData: &types.TransAPILaunchOpdata{Version: newQuotaVersion("launching", 3)},
+			},
+		},
+		{
+			ObjectName: "scaling",
+			Status:     types.OPERATION_STATUS_INIT,
+			CurOp: &types.TransactionOperartion{
+				OpType: types.TransactionOpTypeScale,
+				OpScaleData: &types.TransAPIScaleOpdata{
+					Version:   newQuotaVersion("scaling", 2),
+					Instances: 2,
+				},
+			},
+		},
+		// failed taskgroup is going to be replaced
+		{
+			ObjectName: "web",
+			Status:     types.OPERATION_STATUS_INIT,
+			CurOp: &types.TransactionOperartion{
+				OpType: types.TransactionOpTypeReschedule,
+				OpRescheduleData: &types.TransRescheduleOpData{
+					TaskGroupID:  "web.c",
+					NeedResource: &types.Resource{Cpus: 1, Mem: 100},
+				},
+			},
+		},
+		// lost taskgroup is counted already
+		{
+			ObjectName: "web",
+			Status:     types.OPERATION_STATUS_INIT,
+			CurOp: &types.TransactionOperartion{
+				OpType: types.TransactionOpTypeReschedule,
+				OpRescheduleData: &types.TransRescheduleOpData{
+					TaskGroupID:  "web.e",
+					NeedResource: &types.Resource{Cpus: 1, Mem: 100},
+				},
+			},
+		},
+		{
+			ObjectName: "web",
+			Status:     types.OPERATION_STATUS_FINISH,
+			CurOp: &types.TransactionOperartion{
+				OpType:       types.TransactionOpTypeLaunch,
+				OpLaunchData: &types.TransAPILaunchOpdata{Version: newQuotaVersion("web", 10)},
+			},
+		},
+	}
+	f.jobs = []*types.BcsJob{
+		{
+			ObjectMeta:   commtypes.ObjectMeta{NameSpace: "ns", Name: "job"},
+			Status:       types.Job_Status_Staging,
+			JobParameter: types.JobParameter{Parallelism: 2, Completions: 5},
+			Version:      newQuotaVersion("job", 1),
+		},
+		// job with application is counted by its taskgroups
+		{
+			ObjectMeta:   commtypes.ObjectMeta{NameSpace: "ns", Name: "web"},
+			Status:       types.Job_Status_Staging,
+			JobParameter: types.JobParameter{Parallelism: 2, Completions: 2},
+			Version:      newQuotaVersion("web", 1),
+		},
+	}
+	b := &backend{store: f}
+
+	tests := []struct {
+		name     string
+		excluded []string
+		want     int
+	}{
+		// web 2 + reschedule 1, launching 3, scaling 2, job 2
+		{name: "all", want: 10},
+		{name: "exclude launching", excluded: []string{"launching"}, want: 7},
+		{name: "exclude job", excluded: []string{"job"}, want: 8},
+	}
+	for _, test := range tests {
+		used, err := b.namespaceQuotaUsage("ns", test.excluded)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %s", test.name, err.Error())
+		}
+		if used.Taskgroups != test.want || used.Cpu != float64(test.want) {
+			t.Errorf("%s: expect %d taskgroups, got %+v", test.name, test.want, used)
+		}
+	}
+}
+
+func TestCheckNamespaceQuota(t *testing.T) {
+	f := &quotaStore{taskgroups: make(map[string][]*types.TaskGroup)}
+	b := &backend{store: f}
+	if err := b.CheckNamespaceQuota(newQuotaVersion("web", 100), 100); err != nil {
+		t.Fatalf("namespace without quota is not limited, got %s", err.Error())
+	}
+
+	f.quota = &commtypes.BcsNamespaceQuota{Hard: commtypes.NamespaceQuotaResource{Cpu: 4}}
+	f.addApplication("web", types.TASKGROUP_STATUS_RUNNING, types.TASKGROUP_STATUS_FINISH)
+	f.transactions = []*types.Transaction{{
+		ObjectName: "new",
+		Status:     types.OPERATION_STATUS_INIT,
+		CurOp: &types.TransactionOperartion{
+			OpType:       types.TransactionOpTypeLaunch,
+			OpLaunchData: &types.TransAPILaunchOpdata{Version: newQuotaVersion("new", 2)},
+		},
+	}}
+	if err := b.CheckNamespaceQuota(newQuotaVersion("other", 1), 1); err != nil {
+		t.Errorf("4 cpus are enough for 4 taskgroups, got %s", err.Error())
+	}
+	err := b.CheckNamespaceQuota(newQuotaVersion("other", 2), 2)
+	if err == nil || !strings.Contains(err.Error(), "cpu") {
+		t.Errorf("pending launch transaction should be counted, got %v", err)
+	}
+	if err := b.CheckNamespaceQuota(newQuotaVersion("web", 2), 2, "web"); err != nil {
+		t.Errorf("usage of replaced application should not be counted, got %s", err.Error())
+	}
+}
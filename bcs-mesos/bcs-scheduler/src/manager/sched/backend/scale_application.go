@@ -27,6 +27,9 @@ import (
 func (b *backend) ScaleApplication(runAs, appID string, instances uint64, kind commontypes.BcsDataType, isFromAPI bool) error {
 	blog.V(3).Infof("scale application(%s.%s) to instances:%d", runAs, appID, instances)
 
+	// quota lock is held until scale transaction is saved
+	b.LockNamespaceQuota(runAs)
+	defer b.UnLockNamespaceQuota(runAs)
 	b.store.LockApplication(runAs + "." + appID)
 	defer b.store.UnLockApplication(runAs + "." + appID)
 
@@ -75,6 +78,13 @@ func (b *backend) ScaleApplication(runAs, appID string, instances uint64, kind c
 		return fmt.Errorf("application(%s.%s) cannot scale for label netsvc.requestip not enough", runAs, appID)
 	}
 
+	if instances > app.Instances {
+		if err := b.CheckNamespaceQuota(version, instances, appID); err != nil {
+			blog.Error("scale application(%s.%s) fail: %s", runAs, appID, err.Error())
+			return err
+		}
+	}
+
 	blog.Info("get newest version(%s) for application(%s.%s) to do scale", newestVersion, runAs, appID)
 	version.Instances = int32(instances)
 	err = b.store.SaveVersion(version)
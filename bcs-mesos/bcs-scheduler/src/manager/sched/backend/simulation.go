@@ -0,0 +1,45 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+)
+
+// SimulateApplication simulates launching replicas taskgroups of version on current offers,
+// nothing is saved or launched
+func (b *backend) SimulateApplication(version *types.Version, replicas int) (*types.SimulationResult, error) {
+	blog.Info("simulate application(%s.%s) with %d replicas", version.RunAs, version.ID, replicas)
+
+	if replicas <= 0 {
+		return nil, fmt.Errorf("replicas %d error", replicas)
+	}
+	if version.RunAs == "" || version.ID == "" {
+		return nil, errors.New("version namespace or name empty")
+	}
+	if err := version.CheckAndDefaultResource(); err != nil {
+		return nil, err
+	}
+	if !version.CheckConstraints() {
+		return nil, errors.New("version constraints error")
+	}
+	if err := b.CheckVersion(version); err != nil {
+		return nil, err
+	}
+	return b.sched.SimulateLaunch(version, replicas), nil
+}
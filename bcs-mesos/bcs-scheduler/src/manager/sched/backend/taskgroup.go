@@ -22,6 +22,7 @@ import (
 	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
 	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
 	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/scheduler"
 )
 
 const (
@@ -30,6 +31,11 @@ const (
 
 //RescheduleTaskgroup is used to reschedule taskgroup.
 func (b *backend) RescheduleTaskgroup(taskgroupId string, hostRetainTime int64) error {
+	return b.rescheduleTaskgroup(taskgroupId, hostRetainTime, b.sched.NewDisruptionChecker())
+}
+
+//rescheduleTaskgroup reschedule taskgroup, kill of running taskgroup is checked by disruption checker
+func (b *backend) rescheduleTaskgroup(taskgroupId string, hostRetainTime int64, checker *scheduler.DisruptionChecker) error {
 	blog.Infof("reschedule taskgroup(%s)", taskgroupId)
 	runAs, appID := types.GetRunAsAndAppIDbyTaskGroupID(taskgroupId)
 	//check taskgroup whether belongs to daemonset
@@ -45,6 +51,13 @@ func (b *backend) RescheduleTaskgroup(taskgroupId string, hostRetainTime int64)
 		blog.Errorf("reschedule taskgroup(%s) fail, fetch taskgroup err: %s", taskgroupId, err.Error())
 		return err
 	}
+	// reschedule a running taskgroup is voluntary disruption, it must be allowed by disruption budgets
+	if taskgroup.Status == types.TASKGROUP_STATUS_RUNNING && !b.sched.CheckPodBelongDaemonset(taskgroupId) {
+		if err := b.checkDisruption(runAs, appID, taskgroupId, checker); err != nil {
+			blog.Errorf("reschedule taskgroup(%s) fail: %s", taskgroupId, err.Error())
+			return err
+		}
+	}
 	// here kill taskGroup
 	resp, err := b.sched.KillTaskGroup(taskgroup)
 	if err != nil {
@@ -89,6 +89,11 @@ type OfferPool interface {
 
 	//get offer pool's length
 	GetOffersLength() int
+
+	//build offer of agent's resources not used or offered, attributes are set as mesos's offers.
+	//it is used to evaluate hosts without offers, like hosts fully used, and can't be used to launch taskgroup.
+	//if the agent is lost or disabled, it return nil
+	BuildAgentOffer(*types.Agent) *Offer
 }
 
 type Offer struct {
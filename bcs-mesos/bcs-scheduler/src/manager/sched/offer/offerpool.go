@@ -325,6 +325,82 @@ func (p *offerPool) GetAllOffers() []*Offer {
 	return offers
 }
 
+//the implements of interface OfferPool's function BuildAgentOffer
+func (p *offerPool) BuildAgentOffer(agent *types.Agent) *Offer {
+	if agent == nil || agent.AgentInfo == nil || agent.AgentInfo.GetAgentInfo() == nil {
+		return nil
+	}
+	info := agent.AgentInfo.GetAgentInfo()
+	hostname := info.GetHostname()
+	if !p.validateLostslave(hostname) {
+		blog.V(3).Infof("agent %s is lost, no offer built", hostname)
+		return nil
+	}
+
+	//free scalar resources = total - allocated - offered
+	free := make(map[string]float64)
+	names := make([]string, 0)
+	for _, res := range agent.AgentInfo.GetTotalResources() {
+		if res.GetType() != mesos.Value_SCALAR {
+			continue
+		}
+		if _, ok := free[res.GetName()]; !ok {
+			names = append(names, res.GetName())
+		}
+		free[res.GetName()] += res.GetScalar().GetValue()
+	}
+	used := append(agent.AgentInfo.GetAllocatedResources(), agent.AgentInfo.GetOfferedResources()...)
+	for _, res := range used {
+		if _, ok := free[res.GetName()]; ok && res.GetType() == mesos.Value_SCALAR {
+			free[res.GetName()] -= res.GetScalar().GetValue()
+		}
+	}
+	resources := make([]*mesos.Resource, 0, len(names))
+	for _, name := range names {
+		resName := name
+		value := free[name]
+		if value < 0 {
+			value = 0
+		}
+		resources = append(resources, &mesos.Resource{
+			Name:   &resName,
+			Type:   mesos.Value_SCALAR.Enum(),
+			Scalar: &mesos.Value_Scalar{Value: &value},
+		})
+	}
+
+	offerID := "agent-" + hostname
+	o := &mesos.Offer{
+		Id:        &mesos.OfferID{Value: &offerID},
+		AgentId:   info.GetId(),
+		Hostname:  &hostname,
+		Resources: resources,
+		//attributes of offer are appended by agent setting, so don't share the slice of agent
+		Attributes: append([]*mesos.Attribute{}, info.GetAttributes()...),
+	}
+	if !p.validateDisableSlave(o) {
+		return nil
+	}
+	p.setOffersAttributes([]*mesos.Offer{o})
+
+	out := &Offer{
+		Offer:    o,
+		offerId:  offerID,
+		hostname: hostname,
+	}
+	agentSchedInfo, err := p.scheduler.FetchAgentSchedInfo(hostname)
+	if err != nil && !errors.Is(err, store.ErrNoFound) {
+		blog.Errorf("Fetch AgentSchedInfo %s failed, err %s", hostname, err.Error())
+		return nil
+	}
+	if agentSchedInfo != nil {
+		out.DeltaCPU = agentSchedInfo.DeltaCPU
+		out.DeltaMem = agentSchedInfo.DeltaMem
+		out.DeltaDisk = agentSchedInfo.DeltaDisk
+	}
+	return out
+}
+
 //the implements of interface OfferPool's function GetOfferGreaterThan
 /*func (p *offerPool) GetOfferGreaterThan(id int64) *Offer {
 	p.RLock()
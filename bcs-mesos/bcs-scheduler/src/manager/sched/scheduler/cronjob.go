@@ -0,0 +1,182 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	commutil "github.com/Tencent/bk-bcs/bcs-common/common/util"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/util"
+)
+
+// checkCronJob refresh the active jobs and history of cronjob, then create job if it is the time to run
+func (s *Scheduler) checkCronJob(ns, name string) {
+	util.Lock.Lock(types.BcsCronJob{}, ns+"."+name)
+	defer util.Lock.UnLock(types.BcsCronJob{}, ns+"."+name)
+
+	cronJob, err := s.store.FetchCronJob(ns, name)
+	if err != nil {
+		blog.Errorf("check cronjob(%s.%s), but FetchCronJob failed: %s", ns, name, err.Error())
+		return
+	}
+	jobs, err := s.store.ListJobs(ns)
+	if err != nil {
+		blog.Errorf("check cronjob(%s), but ListJobs failed: %s", cronJob.GetUuid(), err.Error())
+		return
+	}
+
+	var active, succeeded, failed []*types.BcsJob
+	for _, job := range jobs {
+		if job.CronJob != cronJob.Name || job.Status == types.Job_Status_Deleting {
+			continue
+		}
+		switch job.Status {
+		case types.Job_Status_Complete:
+			succeeded = append(succeeded, job)
+		case types.Job_Status_Failed:
+			failed = append(failed, job)
+		default:
+			active = append(active, job)
+		}
+	}
+	s.pruneCronJobHistory(cronJob, succeeded, cronJob.SuccessfulJobsHistoryLimit)
+	s.pruneCronJobHistory(cronJob, failed, cronJob.FailedJobsHistoryLimit)
+
+	lastActive, lastScheduleTime := strings.Join(cronJob.Active, ","), cronJob.LastScheduleTime
+	cronJob.Active = make([]string, 0, len(active))
+	for _, job := range active {
+		cronJob.Active = append(cronJob.Active, job.Name)
+	}
+	sort.Strings(cronJob.Active)
+	defer func() {
+		if strings.Join(cronJob.Active, ",") == lastActive && cronJob.LastScheduleTime == lastScheduleTime {
+			return
+		}
+		cronJob.LastUpdateTime = time.Now().Unix()
+		if err := s.store.SaveCronJob(cronJob); err != nil {
+			blog.Errorf("check cronjob(%s), but SaveCronJob failed: %s", cronJob.GetUuid(), err.Error())
+		}
+	}()
+
+	if cronJob.Suspend {
+		return
+	}
+	scheduled, ok := s.cronJobScheduledTime(cronJob, time.Now())
+	if !ok {
+		return
+	}
+
+	switch cronJob.ConcurrencyPolicy {
+	case commtypes.ConcurrencyPolicy_FORBID:
+		if len(active) > 0 {
+			blog.Infof("cronjob(%s) has %d active jobs, skip the run scheduled at %s",
+				cronJob.GetUuid(), len(active), scheduled.String())
+			cronJob.LastScheduleTime = scheduled.Unix()
+			return
+		}
+	case commtypes.ConcurrencyPolicy_REPLACE:
+		for _, job := range active {
+			blog.Infof("cronjob(%s) replace active job(%s)", cronJob.GetUuid(), job.Name)
+			s.markJobDeleting(job.NameSpace, job.Name)
+		}
+		cronJob.Active = cronJob.Active[:0]
+	}
+
+	job := s.newCronJobJob(cronJob, scheduled)
+	if err := s.store.SaveJob(job); err != nil {
+		blog.Errorf("cronjob(%s) create job(%s) failed: %s", cronJob.GetUuid(), job.Name, err.Error())
+		//the run is not taken as scheduled, it is retried in next check
+		return
+	}
+	cronJob.LastScheduleTime = scheduled.Unix()
+	cronJob.Active = append(cronJob.Active, job.Name)
+	blog.Infof("cronjob(%s) create job(%s) scheduled at %s", cronJob.GetUuid(), job.Name, scheduled.String())
+}
+
+// cronJobScheduledTime returns the latest schedule time not later than now which has not run yet,
+// only the latest one is run when several schedules are missed
+func (s *Scheduler) cronJobScheduledTime(cronJob *types.BcsCronJob, now time.Time) (time.Time, bool) {
+	schedule, err := commutil.ParseCronSchedule(cronJob.Schedule)
+	if err != nil {
+		blog.Errorf("cronjob(%s) schedule(%s) error: %s", cronJob.GetUuid(), cronJob.Schedule, err.Error())
+		return time.Time{}, false
+	}
+
+	last := cronJob.LastScheduleTime
+	if last == 0 {
+		last = cronJob.Created
+	}
+	var scheduled time.Time
+	for next := schedule.Next(time.Unix(last, 0)); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+		scheduled = next
+	}
+	return scheduled, !scheduled.IsZero()
+}
+
+// newCronJobJob build job from job template of cronjob, name of the job is suffixed by scheduled minutes
+func (s *Scheduler) newCronJobJob(cronJob *types.BcsCronJob, scheduled time.Time) *types.BcsJob {
+	job := cronJob.JobTemplate.DeepCopy()
+	name := fmt.Sprintf("%s-%d", cronJob.Name, scheduled.Unix()/60)
+	job.NameSpace = cronJob.NameSpace
+	job.Name = name
+	job.CronJob = cronJob.Name
+	job.Version.RunAs = cronJob.NameSpace
+	job.Version.ID = name
+	job.Version.ObjectMeta.Name = name
+	job.Version.PodObjectMeta.Name = name
+
+	now := time.Now().Unix()
+	job.Status = types.Job_Status_Staging
+	job.Message = "job is staging"
+	job.Created = now
+	job.LastUpdateTime = now
+	return job
+}
+
+// pruneCronJobHistory delete the oldest finished jobs exceeding the history limit
+func (s *Scheduler) pruneCronJobHistory(cronJob *types.BcsCronJob, finished []*types.BcsJob, limit int32) {
+	if len(finished) <= int(limit) {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].Created < finished[j].Created
+	})
+	for _, job := range finished[:len(finished)-int(limit)] {
+		blog.Infof("cronjob(%s) history limit %d exceeded, delete job(%s)", cronJob.GetUuid(), limit, job.Name)
+		s.markJobDeleting(job.NameSpace, job.Name)
+	}
+}
+
+// markJobDeleting mark job deleting, then the job loop deletes it
+func (s *Scheduler) markJobDeleting(ns, name string) {
+	util.Lock.Lock(types.BcsJob{}, ns+"."+name)
+	defer util.Lock.UnLock(types.BcsJob{}, ns+"."+name)
+
+	job, err := s.store.FetchJob(ns, name)
+	if err != nil {
+		blog.Errorf("mark job(%s.%s) deleting, but FetchJob failed: %s", ns, name, err.Error())
+		return
+	}
+	job.Status = types.Job_Status_Deleting
+	job.LastUpdateTime = time.Now().Unix()
+	if err := s.store.SaveJob(job); err != nil {
+		blog.Errorf("mark job(%s.%s) deleting, but SaveJob failed: %s", ns, name, err.Error())
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	types "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+)
+
+// cronJobStore fake store keeps one cronjob and its jobs, saving job fails if saveJobErr is set
+type cronJobStore struct {
+	store.Store
+	sync.Mutex
+
+	cronJob    *types.BcsCronJob
+	jobs       []*types.BcsJob
+	saveJobErr error
+	saved      int
+}
+
+func (c *cronJobStore) FetchCronJob(ns, name string) (*types.BcsCronJob, error) {
+	c.Lock()
+	defer c.Unlock()
+	copied := *c.cronJob
+	copied.Active = append([]string{}, c.cronJob.Active...)
+	return &copied, nil
+}
+
+func (c *cronJobStore) SaveCronJob(cronJob *types.BcsCronJob) error {
+	c.Lock()
+	defer c.Unlock()
+	c.cronJob = cronJob
+	c.saved++
+	return nil
+}
+
+func (c *cronJobStore) ListJobs(ns string) ([]*types.BcsJob, error) {
+	c.Lock()
+	defer c.Unlock()
+	return c.jobs, nil
+}
+
+func (c *cronJobStore) SaveJob(job *types.BcsJob) error {
+	c.Lock()
+	defer c.Unlock()
+	if c.saveJobErr != nil {
+		return c.saveJobErr
+	}
+	c.jobs = append(c.jobs, job)
+	return nil
+}
+
+func newTestCronJob(policy commtypes.ConcurrencyPolicy) *types.BcsCronJob {
+	cronJob := &types.BcsCronJob{
+		Schedule:          "* * * * *",
+		ConcurrencyPolicy: policy,
+		JobTemplate:       &types.BcsJob{Version: &types.Version{}},
+		Created:           time.Now().Add(-3 * time.Minute).Unix(),
+	}
+	cronJob.NameSpace = "ns"
+	cronJob.Name = "backup"
+	return cronJob
+}
+
+func TestCheckCronJobSaveJobFailed(t *testing.T) {
+	fake := &cronJobStore{
+		cronJob:    newTestCronJob(commtypes.ConcurrencyPolicy_ALLOW),
+		saveJobErr: fmt.Errorf("store unavailable"),
+	}
+	s := &Scheduler{store: fake}
+
+	s.checkCronJob("ns", "backup")
+	if fake.saved != 0 || fake.cronJob.LastScheduleTime != 0 || len(fake.jobs) != 0 {
+		t.Fatalf("run should not be taken as scheduled when job is not saved, last schedule time %d",
+			fake.cronJob.LastScheduleTime)
+	}
+
+	//the run is retried in next check
+	fake.saveJobErr = nil
+	s.checkCronJob("ns", "backup")
+	if len(fake.jobs) != 1 || fake.saved != 1 {
+		t.Fatalf("expect job created and cronjob saved, got %d jobs %d saves", len(fake.jobs), fake.saved)
+	}
+	if fake.cronJob.LastScheduleTime == 0 || len(fake.cronJob.Active) != 1 ||
+		fake.cronJob.Active[0] != fake.jobs[0].Name {
+		t.Errorf("cronjob status not updated, last schedule time %d active %v",
+			fake.cronJob.LastScheduleTime, fake.cronJob.Active)
+	}
+}
+
+func TestCheckCronJobForbidSkip(t *testing.T) {
+	fake := &cronJobStore{cronJob: newTestCronJob(commtypes.ConcurrencyPolicy_FORBID)}
+	running := &types.BcsJob{CronJob: "backup", Status: types.Job_Status_Running}
+	running.NameSpace = "ns"
+	running.Name = "backup-1"
+	fake.jobs = []*types.BcsJob{running}
+	s := &Scheduler{store: fake}
+
+	s.checkCronJob("ns", "backup")
+	if len(fake.jobs) != 1 {
+		t.Fatalf("job should not be created when job is active, got %d jobs", len(fake.jobs))
+	}
+	if fake.saved != 1 || fake.cronJob.LastScheduleTime == 0 {
+		t.Errorf("skipped run should be taken as scheduled, last schedule time %d", fake.cronJob.LastScheduleTime)
+	}
+}
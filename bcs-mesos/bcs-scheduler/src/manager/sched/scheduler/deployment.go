@@ -185,6 +185,11 @@ func (s *Scheduler) deploymentBeginRolling(deployment *types.Deployment) bool {
 		ns, name, app.ID, app.Instances, deployment.Application.CurrentTargetInstances,
 		appExt.ID, appExt.Instances, deployment.ApplicationExt.CurrentTargetInstances)
 
+	if deployment.Strategy.RollingUpdate.RollingOrder != commtypes.CreateFirstOrder &&
+		!s.limitRollingDeletion(deployment, app) {
+		return false
+	}
+
 	deployment.IsInRolling = true
 	deployment.LastRollingTime = time.Now().Unix()
 	deployment.Message = ""
@@ -252,6 +257,9 @@ func (s *Scheduler) checkCreateFirstRollingStart(deployment *types.Deployment) b
 		}
 		// do delete
 		if app.Instances > uint64(deployment.Application.CurrentTargetInstances) {
+			if !s.limitRollingDeletion(deployment, app) {
+				return false
+			}
 			deployment.CurrRollingOp = types.DEPLOYMENT_OPERATION_DELETE
 			deployment.LastRollingTime = time.Now().Unix()
 			s.innerScaleApplication(app.RunAs, app.ID, uint64(deployment.Application.CurrentTargetInstances))
@@ -314,6 +322,41 @@ func (s *Scheduler) checkCreateFirstRollingDelete(deployment *types.Deployment)
 	return false
 }
 
+// limitRollingDeletion limit the taskgroups of application deleted in one rolling step by disruption budgets,
+// the deletions are counted in budgets until application is scaled down.
+// false is returned when no taskgroup can be deleted now, the rolling step should wait and check again
+func (s *Scheduler) limitRollingDeletion(deployment *types.Deployment, app *types.Application) bool {
+	ns := deployment.ObjectMeta.NameSpace
+	name := deployment.ObjectMeta.Name
+	deleting := int(app.Instances) - deployment.Application.CurrentTargetInstances
+	if deleting <= 0 {
+		return true
+	}
+
+	allowed, budget, err := s.NewDisruptionChecker().DisruptUpTo(app, deleting)
+	if err != nil {
+		blog.Warn("deployment(%s.%s) rolling update: check disruption budget of application(%s) err:%s",
+			ns, name, app.ID, err.Error())
+		return false
+	}
+	if allowed >= deleting {
+		return true
+	}
+	if allowed == 0 {
+		blog.Info("deployment(%s.%s) rolling update: delete of application(%s) is waiting for disruption budget(%s)",
+			ns, name, app.ID, budget)
+		deployment.Message = fmt.Sprintf("waiting for disruption budget %s", budget)
+		s.store.SaveDeployment(deployment)
+		return false
+	}
+
+	blog.Info("deployment(%s.%s) rolling update: delete of application(%s) is limited from %d to %d by disruption budget(%s)",
+		ns, name, app.ID, deleting, allowed, budget)
+	deployment.Application.CurrentTargetInstances = int(app.Instances) - allowed
+	deployment.Application.CurrentRollingInstances = allowed
+	return true
+}
+
 func (s *Scheduler) checkCreateFirstRolling(deployment *types.Deployment) bool {
 
 	if deployment.CurrRollingOp == types.DEPLOYMENT_OPERATION_START {
@@ -0,0 +1,263 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+)
+
+// DisruptionInFlightTimeout max time a disruption is counted in before taskgroup status or application instances
+// reflect it, for example the kill of taskgroup is lost
+const DisruptionInFlightTimeout = 10 * time.Minute
+
+// ErrDisruptionDenied voluntary disruption of taskgroup is not allowed by disruption budget
+var ErrDisruptionDenied = errors.New("disruption denied by disruption budget")
+
+// inFlightDisruption disruption made but not reflected by store yet, running taskgroups selected by
+// disruption budget are not healthy any more when it is counted in
+type inFlightDisruption struct {
+	// taskgroup disrupted, it is reflected when taskgroup is not running
+	taskgroupID string
+	// application scaled down by rolling update, it is reflected when instances of application
+	// are not more than appInstances
+	appKey       string
+	appInstances uint64
+	expire       time.Time
+}
+
+// budgetTaskgroups the number of instances, the running taskgroups and the instances by namespace.name
+// of applications selected by disruption budget
+func (s *Scheduler) budgetTaskgroups(budget *commtypes.BcsDisruptionBudget) (int, map[string]bool, map[string]uint64, error) {
+	apps, err := s.store.ListApplications(budget.NameSpace)
+	if err != nil {
+		blog.Errorf("list applications in namespace(%s) failed, err %s", budget.NameSpace, err.Error())
+		return 0, nil, nil, err
+	}
+
+	expected := 0
+	running := make(map[string]bool)
+	instances := make(map[string]uint64)
+	for _, app := range apps {
+		if !budget.Selects(app.ObjectMeta.Labels) {
+			continue
+		}
+		expected += int(app.Instances)
+		instances[app.RunAs+"."+app.ID] = app.Instances
+		taskgroups, err := s.store.ListTaskGroups(app.RunAs, app.ID)
+		if err != nil {
+			blog.Errorf("list taskgroups of application(%s.%s) failed, err %s", app.RunAs, app.ID, err.Error())
+			return 0, nil, nil, err
+		}
+		for _, taskgroup := range taskgroups {
+			if taskgroup.Status == types.TASKGROUP_STATUS_RUNNING {
+				running[taskgroup.ID] = true
+			}
+		}
+	}
+	return expected, running, instances, nil
+}
+
+// DisruptionBudgetStatus calculate current status of disruption budget by the applications it selects,
+// disruptions in flight are not counted as healthy
+func (s *Scheduler) DisruptionBudgetStatus(budget *commtypes.BcsDisruptionBudget) (*commtypes.DisruptionBudgetStatus, error) {
+	s.disruptionLock.Lock()
+	defer s.disruptionLock.Unlock()
+
+	return s.disruptionBudgetStatus(budget)
+}
+
+// disruptionBudgetStatus calculate status of disruption budget, disruptionLock must be held
+func (s *Scheduler) disruptionBudgetStatus(budget *commtypes.BcsDisruptionBudget) (*commtypes.DisruptionBudgetStatus, error) {
+	expected, running, instances, err := s.budgetTaskgroups(budget)
+	if err != nil {
+		return nil, err
+	}
+
+	key := budget.NameSpace + "." + budget.Name
+	now := time.Now()
+	var inFlight []*inFlightDisruption
+	for _, d := range s.disruptionsInFlight[key] {
+		if now.After(d.expire) {
+			continue
+		}
+		if d.taskgroupID != "" && !running[d.taskgroupID] {
+			continue
+		}
+		if d.appKey != "" && instances[d.appKey] <= d.appInstances {
+			continue
+		}
+		inFlight = append(inFlight, d)
+	}
+	if len(inFlight) == 0 {
+		delete(s.disruptionsInFlight, key)
+	} else {
+		s.disruptionsInFlight[key] = inFlight
+	}
+
+	healthy := len(running) - len(inFlight)
+	if healthy < 0 {
+		healthy = 0
+	}
+	return budget.CalculateStatus(expected, healthy), nil
+}
+
+// DisruptionChecker checks voluntary disruptions against disruption budgets. Disruptions allowed are counted
+// by scheduler until taskgroup status or application instances reflect them, so concurrent operations, like
+// taskgroup reschedule, agent drain, preemption and rolling update, can't disrupt more than budgets allow.
+type DisruptionChecker struct {
+	sched *Scheduler
+}
+
+// NewDisruptionChecker create checker for voluntary disruption operations
+func (s *Scheduler) NewDisruptionChecker() *DisruptionChecker {
+	return &DisruptionChecker{
+		sched: s,
+	}
+}
+
+// budgets get the status of disruption budgets selecting application, disruptionLock must be held
+func (c *DisruptionChecker) budgets(app *types.Application) (map[string]*commtypes.DisruptionBudgetStatus, error) {
+	budgets, err := c.sched.store.ListDisruptionBudgets(app.RunAs)
+	if err != nil {
+		blog.Errorf("list disruption budgets in namespace(%s) failed, err %s", app.RunAs, err.Error())
+		return nil, err
+	}
+
+	selected := make(map[string]*commtypes.DisruptionBudgetStatus)
+	for _, budget := range budgets {
+		if !budget.Selects(app.ObjectMeta.Labels) {
+			continue
+		}
+		status, err := c.sched.disruptionBudgetStatus(budget)
+		if err != nil {
+			return nil, err
+		}
+		selected[budget.NameSpace+"."+budget.Name] = status
+	}
+	return selected, nil
+}
+
+// allowed return the least disruptions allowed in budgets and the most restrictive budget, -1 for no budget
+func allowedByBudgets(budgets map[string]*commtypes.DisruptionBudgetStatus) (int, string) {
+	allowed := -1
+	restrictive := ""
+	for key, status := range budgets {
+		if allowed == -1 || status.DisruptionsAllowed < allowed {
+			allowed = status.DisruptionsAllowed
+			restrictive = key
+		}
+	}
+	return allowed, restrictive
+}
+
+// addInFlight count disruption in all budgets, disruptionLock must be held
+func (c *DisruptionChecker) addInFlight(budgets map[string]*commtypes.DisruptionBudgetStatus, d *inFlightDisruption) {
+	if c.sched.disruptionsInFlight == nil {
+		c.sched.disruptionsInFlight = make(map[string][]*inFlightDisruption)
+	}
+	for key := range budgets {
+		c.sched.disruptionsInFlight[key] = append(c.sched.disruptionsInFlight[key], d)
+	}
+}
+
+// Allowed return the number of running taskgroups of application can be disrupted now and the most
+// restrictive disruption budget. -1 is returned if no disruption budget selects the application.
+func (c *DisruptionChecker) Allowed(app *types.Application) (int, string, error) {
+	c.sched.disruptionLock.Lock()
+	defer c.sched.disruptionLock.Unlock()
+
+	budgets, err := c.budgets(app)
+	if err != nil {
+		return 0, "", err
+	}
+	allowed, restrictive := allowedByBudgets(budgets)
+	return allowed, restrictive, nil
+}
+
+// Disrupt check whether running taskgroup of application can be disrupted, the disruption is counted
+// in all disruption budgets selecting application if it is allowed, until the taskgroup is not running
+func (c *DisruptionChecker) Disrupt(app *types.Application, taskgroupID string) error {
+	c.sched.disruptionLock.Lock()
+	defer c.sched.disruptionLock.Unlock()
+
+	budgets, err := c.budgets(app)
+	if err != nil {
+		return err
+	}
+
+	for key, status := range budgets {
+		if status.DisruptionsAllowed <= 0 {
+			blog.Warnf("disrupt taskgroup(%s) of application(%s.%s) is denied by disruption budget(%s), "+
+				"healthy %d desired %d", taskgroupID, app.RunAs, app.ID, key, status.HealthyTaskgroups, status.DesiredHealthy)
+			return fmt.Errorf("%w %s: healthy %d, desired %d",
+				ErrDisruptionDenied, key, status.HealthyTaskgroups, status.DesiredHealthy)
+		}
+	}
+	c.addInFlight(budgets, &inFlightDisruption{
+		taskgroupID: taskgroupID,
+		expire:      time.Now().Add(DisruptionInFlightTimeout),
+	})
+	return nil
+}
+
+// DisruptUpTo count at most n disruptions of application in all disruption budgets selecting it,
+// it is used when taskgroups disrupted are decided later, like deleting taskgroups by scaling down.
+// It returns the number of disruptions counted and the most restrictive disruption budget,
+// n is returned if no disruption budget selects the application.
+func (c *DisruptionChecker) DisruptUpTo(app *types.Application, n int) (int, string, error) {
+	c.sched.disruptionLock.Lock()
+	defer c.sched.disruptionLock.Unlock()
+
+	budgets, err := c.budgets(app)
+	if err != nil {
+		return 0, "", err
+	}
+	allowed, restrictive := allowedByBudgets(budgets)
+	if allowed < 0 {
+		return n, "", nil
+	}
+	if allowed > n {
+		allowed = n
+	}
+	for i := 0; i < allowed; i++ {
+		c.addInFlight(budgets, &inFlightDisruption{
+			appKey:       app.RunAs + "." + app.ID,
+			appInstances: app.Instances - uint64(allowed),
+			expire:       time.Now().Add(DisruptionInFlightTimeout),
+		})
+	}
+	return allowed, restrictive, nil
+}
+
+// Release stop counting disruption of taskgroup, it is used when taskgroup is not disrupted at last
+func (c *DisruptionChecker) Release(taskgroupID string) {
+	c.sched.disruptionLock.Lock()
+	defer c.sched.disruptionLock.Unlock()
+
+	for key, disruptions := range c.sched.disruptionsInFlight {
+		var kept []*inFlightDisruption
+		for _, d := range disruptions {
+			if d.taskgroupID != taskgroupID {
+				kept = append(kept, d)
+			}
+		}
+		c.sched.disruptionsInFlight[key] = kept
+	}
+}
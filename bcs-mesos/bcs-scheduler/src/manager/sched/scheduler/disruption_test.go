@@ -0,0 +1,254 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	types "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+)
+
+// fakeStore keeps applications, taskgroups and disruption budgets in memory,
+// methods of store.Store not implemented here panic
+type fakeStore struct {
+	store.Store
+	sync.Mutex
+	apps []*types.Application
+	// taskgroups by namespace.application
+	taskgroups  map[string][]*types.TaskGroup
+	budgets     []*commtypes.BcsDisruptionBudget
+	deployments []*types.Deployment
+	agents      []*types.Agent
+	versions    map[string]*types.Version
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		taskgroups: make(map[string][]*types.TaskGroup),
+		versions:   make(map[string]*types.Version),
+	}
+}
+
+// addApplication add application with running taskgroups
+func (f *fakeStore) addApplication(runAs, appID string, labels map[string]string, running int) *types.Application {
+	f.Lock()
+	defer f.Unlock()
+
+	app := &types.Application{
+		ID:         appID,
+		RunAs:      runAs,
+		Instances:  uint64(running),
+		ObjectMeta: commtypes.ObjectMeta{NameSpace: runAs, Name: appID, Labels: labels},
+	}
+	f.apps = append(f.apps, app)
+	for i := 0; i < running; i++ {
+		f.taskgroups[runAs+"."+appID] = append(f.taskgroups[runAs+"."+appID], &types.TaskGroup{
+			ID:     fmt.Sprintf("%d.%s.%s.cluster.%d", i, appID, runAs, i),
+			RunAs:  runAs,
+			AppID:  appID,
+			Status: types.TASKGROUP_STATUS_RUNNING,
+		})
+	}
+	return app
+}
+
+func (f *fakeStore) setTaskGroupStatus(taskgroupID, status string) {
+	f.Lock()
+	defer f.Unlock()
+
+	for _, taskgroups := range f.taskgroups {
+		for _, taskgroup := range taskgroups {
+			if taskgroup.ID == taskgroupID {
+				taskgroup.Status = status
+			}
+		}
+	}
+}
+
+func (f *fakeStore) ListApplications(ns string) ([]*types.Application, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	var apps []*types.Application
+	for _, app := range f.apps {
+		if app.RunAs == ns {
+			apps = append(apps, app)
+		}
+	}
+	return apps, nil
+}
+
+func (f *fakeStore) FetchApplication(runAs, appID string) (*types.Application, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	for _, app := range f.apps {
+		if app.RunAs == runAs && app.ID == appID {
+			return app, nil
+		}
+	}
+	return nil, store.ErrNoFound
+}
+
+func (f *fakeStore) ListTaskGroups(runAs, appID string) ([]*types.TaskGroup, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	var taskgroups []*types.TaskGroup
+	for _, taskgroup := range f.taskgroups[runAs+"."+appID] {
+		copied := *taskgroup
+		taskgroups = append(taskgroups, &copied)
+	}
+	return taskgroups, nil
+}
+
+func (f *fakeStore) ListDisruptionBudgets(ns string) ([]*commtypes.BcsDisruptionBudget, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	var budgets []*commtypes.BcsDisruptionBudget
+	for _, budget := range f.budgets {
+		if budget.NameSpace == ns {
+			budgets = append(budgets, budget)
+		}
+	}
+	return budgets, nil
+}
+
+func (f *fakeStore) SaveDeployment(deployment *types.Deployment) error {
+	f.Lock()
+	defer f.Unlock()
+
+	f.deployments = append(f.deployments, deployment)
+	return nil
+}
+
+func newTestBudget(ns, name string, selector map[string]string, maxUnavailable int) *commtypes.BcsDisruptionBudget {
+	return &commtypes.BcsDisruptionBudget{
+		ObjectMeta: commtypes.ObjectMeta{NameSpace: ns, Name: name},
+		Spec: commtypes.DisruptionBudgetSpec{
+			Selector:       selector,
+			MaxUnavailable: &maxUnavailable,
+		},
+	}
+}
+
+func TestDisruptionCheckerDisrupt(t *testing.T) {
+	fake := newFakeStore()
+	web := fake.addApplication("ns", "web", map[string]string{"app": "web"}, 4)
+	other := fake.addApplication("ns", "other", map[string]string{"app": "other"}, 2)
+	fake.budgets = append(fake.budgets, newTestBudget("ns", "web-pdb", map[string]string{"app": "web"}, 2))
+	s := &Scheduler{store: fake}
+	taskgroups, _ := fake.ListTaskGroups("ns", "web")
+
+	// concurrent operations share the disruptions in flight
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var allowed []string
+	for _, taskgroup := range taskgroups {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			err := s.NewDisruptionChecker().Disrupt(web, id)
+			if err != nil && !errors.Is(err, ErrDisruptionDenied) {
+				t.Errorf("unexpected error %v", err)
+			}
+			if err == nil {
+				lock.Lock()
+				allowed = append(allowed, id)
+				lock.Unlock()
+			}
+		}(taskgroup.ID)
+	}
+	wg.Wait()
+	if len(allowed) != 2 {
+		t.Fatalf("expect 2 disruptions allowed, got %d", len(allowed))
+	}
+	status, _ := s.DisruptionBudgetStatus(fake.budgets[0])
+	if status.HealthyTaskgroups != 2 || status.DisruptionsAllowed != 0 {
+		t.Errorf("unexpected status with disruptions in flight %+v", status)
+	}
+
+	// application not selected by any budget
+	if err := s.NewDisruptionChecker().Disrupt(other, "0.other.ns.cluster.0"); err != nil {
+		t.Errorf("disruption of application without budget should be allowed, err %v", err)
+	}
+
+	// disruption reflected by taskgroup status is not counted twice
+	fake.setTaskGroupStatus(allowed[0], types.TASKGROUP_STATUS_KILLING)
+	if err := s.NewDisruptionChecker().Disrupt(web, taskgroups[0].ID); err == nil {
+		t.Errorf("disruption should be denied when one taskgroup is killing and one is in flight")
+	}
+
+	// released disruption is not counted any more
+	s.NewDisruptionChecker().Release(allowed[1])
+	if status, _ = s.DisruptionBudgetStatus(fake.budgets[0]); status.DisruptionsAllowed != 1 {
+		t.Errorf("expect 1 disruption allowed after release, got %+v", status)
+	}
+}
+
+func TestLimitRollingDeletion(t *testing.T) {
+	fake := newFakeStore()
+	app := fake.addApplication("ns", "web", map[string]string{"app": "web"}, 5)
+	fake.addApplication("ns", "free", nil, 3)
+	fake.budgets = append(fake.budgets, newTestBudget("ns", "web-pdb", map[string]string{"app": "web"}, 2))
+	s := &Scheduler{store: fake}
+	newDeployment := func(target int) *types.Deployment {
+		return &types.Deployment{
+			ObjectMeta:  commtypes.ObjectMeta{NameSpace: "ns", Name: "web"},
+			Application: &types.DeploymentReferApplication{ApplicationName: app.ID, CurrentTargetInstances: target},
+		}
+	}
+
+	// limited from 3 to 2 deletions
+	deployment := newDeployment(2)
+	if !s.limitRollingDeletion(deployment, app) {
+		t.Fatalf("rolling deletion should be allowed")
+	}
+	if deployment.Application.CurrentTargetInstances != 3 || deployment.Application.CurrentRollingInstances != 2 {
+		t.Errorf("expect target 3 rolling 2, got %+v", deployment.Application)
+	}
+
+	// the deletions are counted until application is scaled down, so the next step waits
+	deployment = newDeployment(4)
+	if s.limitRollingDeletion(deployment, app) {
+		t.Errorf("rolling deletion should wait for disruption budget")
+	}
+	if deployment.Message == "" || len(fake.deployments) != 1 {
+		t.Errorf("waiting deployment should be saved with message, got %q", deployment.Message)
+	}
+
+	// application is scaled down, budget allows deletions again
+	fake.Lock()
+	app.Instances = 3
+	fake.taskgroups["ns.web"] = fake.taskgroups["ns.web"][:3]
+	fake.Unlock()
+	deployment = newDeployment(2)
+	if !s.limitRollingDeletion(deployment, app) || deployment.Application.CurrentTargetInstances != 2 {
+		t.Errorf("rolling deletion should be allowed after scaled down, got %+v", deployment.Application)
+	}
+
+	// application without budget is not limited
+	free, _ := fake.FetchApplication("ns", "free")
+	deployment = newDeployment(0)
+	deployment.Application.ApplicationName = free.ID
+	if !s.limitRollingDeletion(deployment, free) || deployment.Application.CurrentTargetInstances != 0 {
+		t.Errorf("rolling deletion without budget should not be limited, got %+v", deployment.Application)
+	}
+}
@@ -0,0 +1,294 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/util"
+)
+
+// a job is backed by the application with the same namespace and name, whose version kind is job and
+// restart policy is never. Taskgroups of the application are launched and scaled up by transactions
+// until enough of them finish, finished and failed taskgroups are kept as the history of the job.
+
+func (s *Scheduler) startCheckJobs() {
+	//start check jobs and cronjobs
+	//only master do the function
+	s.stopJob = make(chan struct{})
+	for {
+		time.Sleep(time.Second * 5)
+		select {
+		case <-s.stopJob:
+			blog.Warnf("stop check jobs and cronjobs")
+			return
+		default:
+			//ticker check jobs and cronjobs
+		}
+
+		cronJobs, err := s.store.ListAllCronJobs()
+		if err != nil {
+			blog.Errorf("ListAllCronJobs failed: %s", err.Error())
+		}
+		for _, cronJob := range cronJobs {
+			s.checkCronJob(cronJob.NameSpace, cronJob.Name)
+		}
+
+		jobs, err := s.store.ListAllJobs()
+		if err != nil {
+			blog.Errorf("ListAllJobs failed: %s", err.Error())
+			continue
+		}
+		for _, job := range jobs {
+			s.checkJob(job.NameSpace, job.Name)
+		}
+	}
+}
+
+// stop check jobs and cronjobs
+func (s *Scheduler) stopCheckJobs() {
+	if s.stopJob != nil {
+		close(s.stopJob)
+	}
+}
+
+// checkJob drive job to the next status
+func (s *Scheduler) checkJob(ns, name string) {
+	util.Lock.Lock(types.BcsJob{}, ns+"."+name)
+	defer util.Lock.UnLock(types.BcsJob{}, ns+"."+name)
+
+	job, err := s.store.FetchJob(ns, name)
+	if err != nil {
+		blog.Errorf("check job(%s.%s), but FetchJob failed: %s", ns, name, err.Error())
+		return
+	}
+
+	switch job.Status {
+	case types.Job_Status_Deleting:
+		s.deleteJob(job)
+	case types.Job_Status_Staging:
+		s.launchJob(job)
+	case types.Job_Status_Running:
+		s.syncJob(job)
+	default:
+		//complete or failed job has nothing to do
+	}
+}
+
+// launchJob create the application of job and launch the first batch of taskgroups
+func (s *Scheduler) launchJob(job *types.BcsJob) {
+	app, err := s.store.FetchApplication(job.NameSpace, job.Name)
+	if err != nil && err != store.ErrNoFound {
+		blog.Errorf("launch job(%s), but FetchApplication failed: %s", job.GetUuid(), err.Error())
+		return
+	}
+	//application is created already, the job status was not saved
+	if app == nil {
+		if err := s.launchJobApplication(job); err != nil {
+			blog.Errorf("launch job(%s) application failed: %s", job.GetUuid(), err.Error())
+			return
+		}
+	}
+
+	now := time.Now().Unix()
+	job.Status = types.Job_Status_Running
+	job.Message = "job is running"
+	job.StartTime = now
+	job.LastUpdateTime = now
+	if err := s.store.SaveJob(job); err != nil {
+		blog.Errorf("launch job(%s), but SaveJob failed: %s", job.GetUuid(), err.Error())
+		return
+	}
+	blog.Infof("job(%s) status from(%s)->to(%s)", job.GetUuid(), types.Job_Status_Staging, job.Status)
+}
+
+func (s *Scheduler) launchJobApplication(job *types.BcsJob) error {
+	version := job.Version.DeepCopy()
+	version.Instances = job.Parallelism
+	if job.Completions < version.Instances {
+		version.Instances = job.Completions
+	}
+
+	s.store.LockApplication(job.NameSpace + "." + job.Name)
+	defer s.store.UnLockApplication(job.NameSpace + "." + job.Name)
+
+	now := time.Now()
+	app := &types.Application{
+		Kind:            commtypes.BcsDataType_Job,
+		ID:              job.Name,
+		Name:            job.Name,
+		DefineInstances: uint64(version.Instances),
+		RunAs:           job.NameSpace,
+		ClusterId:       s.ClusterId,
+		Status:          types.APP_STATUS_OPERATING,
+		SubStatus:       types.APP_SUBSTATUS_UNKNOWN,
+		Message:         "application in launching",
+		Created:         now.Unix(),
+		UpdateTime:      now.Unix(),
+		ObjectMeta:      version.ObjectMeta,
+	}
+	if err := s.store.SaveVersion(version); err != nil {
+		return err
+	}
+
+	launchTrans := &types.Transaction{
+		ObjectKind:    string(commtypes.BcsDataType_APP),
+		ObjectName:    job.Name,
+		Namespace:     job.NameSpace,
+		TransactionID: types.GenerateTransactionID(string(commtypes.BcsDataType_APP)),
+		CreateTime:    now,
+		CheckInterval: time.Second,
+		CurOp: &types.TransactionOperartion{
+			OpType: types.TransactionOpTypeLaunch,
+			OpLaunchData: &types.TransAPILaunchOpdata{
+				Version:      version,
+				LaunchedNum:  0,
+				NeedResource: version.AllResource(),
+				Reason:       "job launch",
+			},
+		},
+		Status: types.OPERATION_STATUS_INIT,
+	}
+	if err := s.store.SaveApplication(app); err != nil {
+		return err
+	}
+	if err := s.store.SaveTransaction(launchTrans); err != nil {
+		return err
+	}
+	s.PushEventQueue(launchTrans)
+	blog.Infof("job(%s) launch application with %d instances", job.GetUuid(), version.Instances)
+	return nil
+}
+
+// syncJob count taskgroups of job, then finish the job or scale up the application
+func (s *Scheduler) syncJob(job *types.BcsJob) {
+	app, err := s.store.FetchApplication(job.NameSpace, job.Name)
+	if err == store.ErrNoFound || (err == nil && app == nil) {
+		s.finishJob(job, nil, types.Job_Status_Failed, "application of job not found")
+		return
+	}
+	if err != nil {
+		blog.Errorf("sync job(%s), but FetchApplication failed: %s", job.GetUuid(), err.Error())
+		return
+	}
+	taskgroups, err := s.store.ListTaskGroups(job.NameSpace, job.Name)
+	if err != nil {
+		blog.Errorf("sync job(%s), but ListTaskGroups failed: %s", job.GetUuid(), err.Error())
+		return
+	}
+
+	var active []*types.TaskGroup
+	var succeeded, failed int32
+	for _, taskgroup := range taskgroups {
+		switch taskgroup.Status {
+		case types.TASKGROUP_STATUS_FINISH:
+			succeeded++
+		case types.TASKGROUP_STATUS_FAIL, types.TASKGROUP_STATUS_ERROR, types.TASKGROUP_STATUS_KILLED,
+			types.TASKGROUP_STATUS_LOST:
+			failed++
+		default:
+			active = append(active, taskgroup)
+		}
+	}
+	job.Active = int32(len(active))
+	job.Succeeded = succeeded
+	job.Failed = failed
+
+	now := time.Now().Unix()
+	switch {
+	case succeeded >= job.Completions:
+		s.finishJob(job, active, types.Job_Status_Complete, "job completed")
+		return
+	case failed > job.BackoffLimit:
+		s.finishJob(job, active, types.Job_Status_Failed,
+			fmt.Sprintf("job has %d failed taskgroups, reached backoff limit %d", failed, job.BackoffLimit))
+		return
+	case job.ActiveDeadlineSeconds > 0 && now-job.StartTime >= job.ActiveDeadlineSeconds:
+		s.finishJob(job, active, types.Job_Status_Failed,
+			fmt.Sprintf("job was active longer than deadline %d seconds", job.ActiveDeadlineSeconds))
+		return
+	}
+
+	//keep parallelism taskgroups running until enough taskgroups finish,
+	//scale up only when the former transaction is done
+	want := job.Parallelism
+	if remain := job.Completions - succeeded; remain < want {
+		want = remain
+	}
+	if job.Active < want && app.Status != types.APP_STATUS_OPERATING &&
+		app.Status != types.APP_STATUS_ROLLINGUPDATE && uint64(len(taskgroups)) == app.Instances {
+		instances := app.Instances + uint64(want-job.Active)
+		blog.Infof("job(%s) active(%d) succeeded(%d) failed(%d), scale application to %d instances",
+			job.GetUuid(), job.Active, succeeded, failed, instances)
+		if err := s.innerScaleApplication(job.NameSpace, job.Name, instances); err != nil {
+			blog.Errorf("job(%s) scale application failed: %s", job.GetUuid(), err.Error())
+		}
+	}
+
+	job.LastUpdateTime = now
+	if err := s.store.SaveJob(job); err != nil {
+		blog.Errorf("sync job(%s), but SaveJob failed: %s", job.GetUuid(), err.Error())
+	}
+}
+
+// finishJob kill the taskgroups still active and set job to complete or failed,
+// taskgroups ended are kept as the history of job
+func (s *Scheduler) finishJob(job *types.BcsJob, active []*types.TaskGroup, status, message string) {
+	for _, taskgroup := range active {
+		blog.Infof("job(%s) is %s, kill taskgroup(%s)", job.GetUuid(), status, taskgroup.ID)
+		if _, err := s.KillTaskGroup(taskgroup); err != nil {
+			blog.Errorf("job(%s) kill taskgroup(%s) failed: %s", job.GetUuid(), taskgroup.ID, err.Error())
+		}
+	}
+
+	now := time.Now().Unix()
+	blog.Infof("job(%s) status from(%s)->to(%s): %s", job.GetUuid(), job.Status, status, message)
+	job.Status = status
+	job.Message = message
+	job.CompletionTime = now
+	job.LastUpdateTime = now
+	if err := s.store.SaveJob(job); err != nil {
+		blog.Errorf("finish job(%s), but SaveJob failed: %s", job.GetUuid(), err.Error())
+	}
+}
+
+// deleteJob delete the application of job, the job is deleted after the application is gone
+func (s *Scheduler) deleteJob(job *types.BcsJob) {
+	app, err := s.store.FetchApplication(job.NameSpace, job.Name)
+	if err != nil && err != store.ErrNoFound {
+		blog.Errorf("delete job(%s), but FetchApplication failed: %s", job.GetUuid(), err.Error())
+		return
+	}
+	if app != nil {
+		//application is in deleting
+		if app.Status == types.APP_STATUS_OPERATING {
+			return
+		}
+		if err := s.InnerDeleteApplication(job.NameSpace, job.Name, true); err != nil {
+			blog.Errorf("delete job(%s) application failed: %s", job.GetUuid(), err.Error())
+		}
+		return
+	}
+
+	if err := s.store.DeleteJob(job.NameSpace, job.Name); err != nil {
+		blog.Errorf("delete job(%s) failed: %s", job.GetUuid(), err.Error())
+		return
+	}
+	blog.Infof("job(%s) deleted", job.GetUuid())
+}
@@ -0,0 +1,419 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// preemption of taskgroups with lower priority
+
+package scheduler
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtype "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	types "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/offer"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PREEMPTION_SCHEDULER_NUM rounds of transaction without fit offer before preemption
+	PREEMPTION_SCHEDULER_NUM = 3
+	// PREEMPTION_COOLDOWN min interval between two preemptions for one transaction,
+	// the transaction waits for resources released by evicted taskgroups in this interval
+	PREEMPTION_COOLDOWN = 60 * time.Second
+	// PREEMPTION_VICTIM_DELAY delay of rescheduling evicted taskgroups,
+	// so that resources released by them are used by the preemptor first
+	PREEMPTION_VICTIM_DELAY = 30 * time.Second
+
+	// PreemptionEventPreempting event type of taskgroup preempting others
+	PreemptionEventPreempting = "Preempting"
+	// PreemptionEventPreempted event type of taskgroup evicted by preemption
+	PreemptionEventPreempted = "Preempted"
+)
+
+// PreemptForTransaction try to evict taskgroups with lower priority on one host, so that one taskgroup
+// of version can be launched when no offer fits it. The evicted taskgroups are rescheduled later.
+// It returns true if taskgroups are evicted for the transaction just now or in the cooldown period,
+// the caller should keep waiting for released resources instead of timeout then.
+func (s *Scheduler) PreemptForTransaction(trans *types.Transaction, version *types.Version,
+	needResource *types.Resource, taskgroupID string) bool {
+	if version.Priority <= 0 || version.Preemption == nil || version.Preemption.MaxEvictions <= 0 {
+		return false
+	}
+	if s.isPreemptionCoolingDown(trans.TransactionID) {
+		blog.V(3).Infof("transaction %s preemption for version(%s.%s) is cooling down",
+			trans.TransactionID, version.RunAs, version.ID)
+		return true
+	}
+
+	hostname, victims := s.selectPreemptionHost(trans, version, needResource, taskgroupID)
+	if len(victims) == 0 {
+		blog.Infof("transaction %s preemption for version(%s.%s) priority %d, no host fit",
+			trans.TransactionID, version.RunAs, version.ID, version.Priority)
+		return false
+	}
+
+	// victims are voluntary disruptions, all of them must be allowed by disruption budgets before any is evicted
+	checker := s.NewDisruptionChecker()
+	var disrupted []string
+	for _, victim := range victims {
+		err := s.disruptVictim(checker, victim)
+		if err == nil {
+			disrupted = append(disrupted, victim.ID)
+			continue
+		}
+		blog.Infof("transaction %s preemption for version(%s.%s) on host %s, victim %s is not allowed: %s",
+			trans.TransactionID, version.RunAs, version.ID, hostname, victim.ID, err.Error())
+		for _, id := range disrupted {
+			checker.Release(id)
+		}
+		return false
+	}
+
+	s.setPreemptionTime(trans.TransactionID)
+	for _, victim := range victims {
+		message := fmt.Sprintf("preempted by application(%s.%s) priority %d on host %s",
+			version.RunAs, version.ID, version.Priority, hostname)
+		if err := s.evictTaskGroup(victim.ID, message); err != nil {
+			blog.Errorf("transaction %s preemption evict taskgroup(%s) err: %s",
+				trans.TransactionID, victim.ID, err.Error())
+			checker.Release(victim.ID)
+			continue
+		}
+		s.producePreemptionEvent(victim.ID, victim.RunAs, victim.AppID, PreemptionEventPreempted,
+			message, commtype.Event_Level_Warning)
+	}
+	message := fmt.Sprintf("preempt %d taskgroups with lower priority on host %s", len(victims), hostname)
+	blog.Infof("transaction %s application(%s.%s) %s", trans.TransactionID, version.RunAs, version.ID, message)
+	s.producePreemptionEvent(trans.TransactionID, version.RunAs, version.ID, PreemptionEventPreempting,
+		message, commtype.Event_Level_Normal)
+	return true
+}
+
+// selectPreemptionHost selects the host and victims on it for preemption. Hosts are taken from agents, since
+// hosts fully used have no offer in offer pool. Constraints are checked by attributes of offer or agent,
+// and free resources are added to the resources released by victims.
+// Taskgroups of daemonset can't be victims, and running victims of one application are limited by its disruption budgets.
+func (s *Scheduler) selectPreemptionHost(trans *types.Transaction, version *types.Version,
+	needResource *types.Resource, taskgroupID string) (string, []*types.TaskGroup) {
+	taskgroups, err := s.store.ListClusterTaskgroups()
+	if err != nil {
+		blog.Errorf("transaction %s preemption for version(%s.%s), list taskgroups err: %s",
+			trans.TransactionID, version.RunAs, version.ID, err.Error())
+		return "", nil
+	}
+	hostTaskgroups := make(map[string][]*types.TaskGroup)
+	for _, taskgroup := range taskgroups {
+		if taskgroup.Priority >= version.Priority || taskgroup.LaunchResource == nil {
+			continue
+		}
+		if taskgroup.Status != types.TASKGROUP_STATUS_STAGING && taskgroup.Status != types.TASKGROUP_STATUS_STARTING &&
+			taskgroup.Status != types.TASKGROUP_STATUS_RUNNING {
+			continue
+		}
+		if taskgroup.RunAs == version.RunAs && taskgroup.AppID == version.ID {
+			continue
+		}
+		hostTaskgroups[taskgroup.HostName] = append(hostTaskgroups[taskgroup.HostName], taskgroup)
+	}
+	if len(hostTaskgroups) == 0 {
+		return "", nil
+	}
+	agents, err := s.store.ListAllAgents()
+	if err != nil {
+		blog.Errorf("transaction %s preemption for version(%s.%s), list agents err: %s",
+			trans.TransactionID, version.RunAs, version.ID, err.Error())
+		return "", nil
+	}
+	// offers in pool are the latest free resources of hosts, other hosts are evaluated by agent info
+	hostOffers := make(map[string]*offer.Offer)
+	for _, o := range s.offerPool.GetAllOffers() {
+		hostOffers[o.Offer.GetHostname()] = o
+	}
+
+	var victims []*types.TaskGroup
+	var victimHost string
+	daemonsets := make(map[string]bool)
+	disruptionsAllowed := make(map[string]int)
+	for _, agent := range agents {
+		if agent.AgentInfo == nil {
+			continue
+		}
+		hostname := agent.AgentInfo.GetAgentInfo().GetHostname()
+		candidates := s.filterDaemonsetTaskgroups(hostTaskgroups[hostname], daemonsets)
+		if len(candidates) == 0 {
+			continue
+		}
+		o, ok := hostOffers[hostname]
+		if !ok {
+			o = s.offerPool.BuildAgentOffer(agent)
+			if o == nil {
+				continue
+			}
+		}
+		if !s.IsConstraintsFit(version, o.Offer, taskgroupID) ||
+			!s.IsOfferExtendedResourcesFitLaunch(version.GetExtendedResources(), o) {
+			continue
+		}
+		s.loadDisruptionsAllowed(candidates, disruptionsAllowed)
+		selected := selectPreemptionVictims(s.offerFreeResource(o), needResource, candidates,
+			int(version.Preemption.MaxEvictions), disruptionsAllowed)
+		if len(selected) == 0 {
+			continue
+		}
+		// prefer host with less victims, and then with victims of lower priority
+		if victims == nil || len(selected) < len(victims) ||
+			(len(selected) == len(victims) && selected[len(selected)-1].Priority < victims[len(victims)-1].Priority) {
+			victims = selected
+			victimHost = hostname
+		}
+	}
+	return victimHost, victims
+}
+
+// selectPreemptionVictims selects victims from candidates by the lowest priority first, and the latest started
+// first for the same priority, until free resources and resources released by victims are enough for needResource.
+// Running victims of one application are no more than its disruptionsAllowed, if it is set and not -1.
+// It returns nil if resources are not enough with maxEvictions victims, or enough without any victim.
+func selectPreemptionVictims(free, needResource *types.Resource, candidates []*types.TaskGroup,
+	maxEvictions int, disruptionsAllowed map[string]int) []*types.TaskGroup {
+	sorted := make([]*types.TaskGroup, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority < sorted[j].Priority
+		}
+		return sorted[i].StartTime > sorted[j].StartTime
+	})
+
+	cpus, mem, disk := free.Cpus, free.Mem, free.Disk
+	isFit := func() bool {
+		return needResource.Cpus <= cpus && needResource.Mem <= mem && needResource.Disk <= disk
+	}
+	var victims []*types.TaskGroup
+	disrupted := make(map[string]int)
+	for _, taskgroup := range sorted {
+		if isFit() || len(victims) >= maxEvictions {
+			break
+		}
+		if taskgroup.Status == types.TASKGROUP_STATUS_RUNNING {
+			key := taskgroup.RunAs + "." + taskgroup.AppID
+			if allowed, ok := disruptionsAllowed[key]; ok && allowed >= 0 && disrupted[key] >= allowed {
+				continue
+			}
+			disrupted[key]++
+		}
+		cpus += taskgroup.LaunchResource.Cpus
+		mem += taskgroup.LaunchResource.Mem
+		disk += taskgroup.LaunchResource.Disk
+		victims = append(victims, taskgroup)
+	}
+	if !isFit() {
+		return nil
+	}
+	return victims
+}
+
+// filterDaemonsetTaskgroups drops taskgroups of daemonset, which can not be rescheduled to other hosts.
+// daemonsets caches whether application is daemonset
+func (s *Scheduler) filterDaemonsetTaskgroups(taskgroups []*types.TaskGroup, daemonsets map[string]bool) []*types.TaskGroup {
+	var filtered []*types.TaskGroup
+	for _, taskgroup := range taskgroups {
+		key := taskgroup.RunAs + "." + taskgroup.AppID
+		isDaemonset, ok := daemonsets[key]
+		if !ok {
+			isDaemonset = s.CheckPodBelongDaemonset(taskgroup.ID)
+			daemonsets[key] = isDaemonset
+		}
+		if !isDaemonset {
+			filtered = append(filtered, taskgroup)
+		}
+	}
+	return filtered
+}
+
+// loadDisruptionsAllowed loads the number of running taskgroups can be disrupted by disruption budgets
+// for applications of taskgroups, -1 for no limit. allowed caches it by namespace.application
+func (s *Scheduler) loadDisruptionsAllowed(taskgroups []*types.TaskGroup, allowed map[string]int) {
+	for _, taskgroup := range taskgroups {
+		key := taskgroup.RunAs + "." + taskgroup.AppID
+		if _, ok := allowed[key]; ok {
+			continue
+		}
+		allowed[key] = -1
+		app, err := s.store.FetchApplication(taskgroup.RunAs, taskgroup.AppID)
+		if err == store.ErrNoFound || (err == nil && app == nil) {
+			continue
+		}
+		if err != nil {
+			blog.Errorf("fetch application(%s) for preemption err: %s", key, err.Error())
+			allowed[key] = 0
+			continue
+		}
+		if allowed[key], _, err = s.NewDisruptionChecker().Allowed(app); err != nil {
+			allowed[key] = 0
+		}
+	}
+}
+
+// disruptVictim counts disruption of running victim in disruption budgets, victims not running yet are not counted
+func (s *Scheduler) disruptVictim(checker *DisruptionChecker, victim *types.TaskGroup) error {
+	if victim.Status != types.TASKGROUP_STATUS_RUNNING {
+		return nil
+	}
+	app, err := s.store.FetchApplication(victim.RunAs, victim.AppID)
+	if err == store.ErrNoFound || (err == nil && app == nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return checker.Disrupt(app, victim.ID)
+}
+
+// offerFreeResource returns resources of offer, resources being updated are excluded
+func (s *Scheduler) offerFreeResource(o *offer.Offer) *types.Resource {
+	cpus, mem, disk := s.OfferedResources(o.Offer)
+	if o.DeltaCPU > 0 {
+		cpus = cpus - o.DeltaCPU
+	}
+	if o.DeltaMem > 0 {
+		mem = mem - o.DeltaMem
+	}
+	if o.DeltaDisk > 0 {
+		disk = disk - o.DeltaDisk
+	}
+	return &types.Resource{Cpus: cpus, Mem: mem, Disk: disk}
+}
+
+// evictTaskGroup kills the taskgroup and reschedules it after PREEMPTION_VICTIM_DELAY
+func (s *Scheduler) evictTaskGroup(taskgroupID, message string) error {
+	runAs, appID := types.GetRunAsAndAppIDbyTaskGroupID(taskgroupID)
+	s.store.LockApplication(runAs + "." + appID)
+	defer s.store.UnLockApplication(runAs + "." + appID)
+
+	taskgroup, err := s.store.FetchTaskGroup(taskgroupID)
+	if err != nil {
+		return err
+	}
+	if taskgroup == nil {
+		return fmt.Errorf("taskgroup not found")
+	}
+	if taskgroup.Status != types.TASKGROUP_STATUS_STAGING && taskgroup.Status != types.TASKGROUP_STATUS_STARTING &&
+		taskgroup.Status != types.TASKGROUP_STATUS_RUNNING {
+		return fmt.Errorf("taskgroup status is %s", taskgroup.Status)
+	}
+	version, _ := s.store.GetVersion(runAs, appID)
+	if version == nil {
+		return fmt.Errorf("no version for application(%s.%s)", runAs, appID)
+	}
+
+	blog.Infof("evict taskgroup(%s) on host %s: %s", taskgroup.ID, taskgroup.HostName, message)
+	resp, err := s.KillTaskGroup(taskgroup)
+	if err != nil {
+		return fmt.Errorf("kill taskgroup err: %s", err.Error())
+	}
+	if resp != nil && resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("kill taskgroup return code %d", resp.StatusCode)
+	}
+	taskgroup.Message = message
+	if err := s.store.SaveTaskGroup(taskgroup); err != nil {
+		blog.Errorf("save taskgroup(%s) error %s", taskgroup.ID, err.Error())
+	}
+
+	rescheduleTrans := &types.Transaction{
+		ObjectKind:    string(commtype.BcsDataType_APP),
+		ObjectName:    appID,
+		Namespace:     runAs,
+		TransactionID: types.GenerateTransactionID(string(commtype.BcsDataType_APP)),
+		CreateTime:    time.Now(),
+		DelayTime:     PREEMPTION_VICTIM_DELAY,
+		CheckInterval: 3 * time.Second,
+		Status:        types.OPERATION_STATUS_INIT,
+		CurOp: &types.TransactionOperartion{
+			OpType: types.TransactionOpTypeReschedule,
+			OpRescheduleData: &types.TransRescheduleOpData{
+				TaskGroupID:  taskgroup.ID,
+				Force:        true,
+				IsInner:      false,
+				NeedResource: version.AllResource(),
+				Version:      version,
+			},
+		},
+	}
+	if err := s.store.SaveTransaction(rescheduleTrans); err != nil {
+		return fmt.Errorf("save reschedule transaction err: %s", err.Error())
+	}
+	s.PushEventQueue(rescheduleTrans)
+	return nil
+}
+
+func (s *Scheduler) isPreemptionCoolingDown(transactionID string) bool {
+	s.preemptionLock.Lock()
+	defer s.preemptionLock.Unlock()
+
+	now := time.Now()
+	for id, last := range s.preemptionTimes {
+		// transactions finished long ago
+		if last.Add(TRANSACTION_APPLICATION_LAUNCH_LIFEPERIOD * time.Second).Before(now) {
+			delete(s.preemptionTimes, id)
+		}
+	}
+	last, ok := s.preemptionTimes[transactionID]
+	return ok && last.Add(PREEMPTION_COOLDOWN).After(now)
+}
+
+func (s *Scheduler) setPreemptionTime(transactionID string) {
+	s.preemptionLock.Lock()
+	defer s.preemptionLock.Unlock()
+	s.preemptionTimes[transactionID] = time.Now()
+}
+
+func (s *Scheduler) producePreemptionEvent(id, runAs, appID, eventType, message string, level commtype.EventLevel) {
+	event := &commtype.BcsStorageEventIf{
+		ID:        id,
+		Env:       commtype.Event_Env_Mesos,
+		Kind:      commtype.TaskGroupEventKind,
+		Level:     level,
+		Component: commtype.Event_Component_Scheduler,
+		Type:      eventType,
+		EventTime: time.Now().Unix(),
+		Describe:  message,
+		ClusterId: s.BcsClusterId,
+		ExtraInfo: commtype.EventExtraInfo{
+			Namespace: runAs,
+			Name:      appID,
+			Kind:      commtype.ApplicationExtraKind,
+		},
+		Data: &v1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      appID,
+				Namespace: runAs,
+			},
+			InvolvedObject: v1.ObjectReference{
+				Kind:      string(commtype.ApplicationExtraKind),
+				Namespace: runAs,
+				Name:      appID,
+			},
+			Reason:  eventType,
+			Message: message,
+		},
+	}
+	go s.eventManager.syncEvent(event)
+}
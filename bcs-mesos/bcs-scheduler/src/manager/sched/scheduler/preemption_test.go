@@ -0,0 +1,170 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package scheduler
+
+import (
+	"testing"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/mesosproto/mesos"
+	mesos_master "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/mesosproto/mesos/master"
+	types "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/offer"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+)
+
+func TestSelectPreemptionVictims(t *testing.T) {
+	newTaskGroup := func(id string, priority int32, startTime int64, cpus float64) *types.TaskGroup {
+		return &types.TaskGroup{
+			ID:             id,
+			Priority:       priority,
+			StartTime:      startTime,
+			LaunchResource: &types.Resource{Cpus: cpus, Mem: 1024},
+		}
+	}
+	candidates := []*types.TaskGroup{
+		newTaskGroup("batch-old", 1, 100, 1),
+		newTaskGroup("service", 5, 100, 4),
+		newTaskGroup("batch-new", 1, 200, 1),
+	}
+	free := &types.Resource{Cpus: 0.5, Mem: 512}
+
+	cases := []struct {
+		need         *types.Resource
+		maxEvictions int
+		expect       []string
+	}{
+		// lowest priority and latest started first
+		{need: &types.Resource{Cpus: 1, Mem: 1024}, maxEvictions: 2, expect: []string{"batch-new"}},
+		{need: &types.Resource{Cpus: 2, Mem: 1024}, maxEvictions: 2, expect: []string{"batch-new", "batch-old"}},
+		{need: &types.Resource{Cpus: 6, Mem: 1024}, maxEvictions: 3, expect: []string{"batch-new", "batch-old", "service"}},
+		// bounded by max evictions
+		{need: &types.Resource{Cpus: 6, Mem: 1024}, maxEvictions: 2},
+		// no need to preempt
+		{need: &types.Resource{Cpus: 0.5, Mem: 512}, maxEvictions: 2},
+	}
+	for i, cs := range cases {
+		victims := selectPreemptionVictims(free, cs.need, candidates, cs.maxEvictions, nil)
+		if len(victims) != len(cs.expect) {
+			t.Errorf("case %d: expect victims %v, got %d victims", i, cs.expect, len(victims))
+			continue
+		}
+		for j, victim := range victims {
+			if victim.ID != cs.expect[j] {
+				t.Errorf("case %d: expect victims %v, got %s at %d", i, cs.expect, victim.ID, j)
+			}
+		}
+	}
+}
+
+func (f *fakeStore) ListClusterTaskgroups() ([]*types.TaskGroup, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	var taskgroups []*types.TaskGroup
+	for _, tgs := range f.taskgroups {
+		taskgroups = append(taskgroups, tgs...)
+	}
+	return taskgroups, nil
+}
+
+func (f *fakeStore) ListAllAgents() ([]*types.Agent, error) {
+	return f.agents, nil
+}
+
+func (f *fakeStore) FetchAgentSetting(ip string) (*commtypes.BcsClusterAgentSetting, error) {
+	return &commtypes.BcsClusterAgentSetting{InnerIP: ip}, nil
+}
+
+func (f *fakeStore) FetchAgentSchedInfo(hostname string) (*types.AgentSchedInfo, error) {
+	return nil, store.ErrNoFound
+}
+
+func (f *fakeStore) GetVersion(runAs, appID string) (*types.Version, error) {
+	return f.versions[runAs+"."+appID], nil
+}
+
+func newTestAgent(hostname, ip string, cpus, mem, allocatedCpus, allocatedMem float64) *types.Agent {
+	scalar := func(name string, value float64) *mesos.Resource {
+		return &mesos.Resource{Name: &name, Type: mesos.Value_SCALAR.Enum(), Scalar: &mesos.Value_Scalar{Value: &value}}
+	}
+	ipAttr := "InnerIP"
+	return &types.Agent{
+		Key: ip,
+		AgentInfo: &mesos_master.Response_GetAgents_Agent{
+			AgentInfo: &mesos.AgentInfo{
+				Hostname: &hostname,
+				Attributes: []*mesos.Attribute{{
+					Name: &ipAttr,
+					Type: mesos.Value_TEXT.Enum(),
+					Text: &mesos.Value_Text{Value: &ip},
+				}},
+			},
+			TotalResources:     []*mesos.Resource{scalar("cpus", cpus), scalar("mem", mem)},
+			AllocatedResources: []*mesos.Resource{scalar("cpus", allocatedCpus), scalar("mem", allocatedMem)},
+		},
+	}
+}
+
+// TestSelectPreemptionHostWithoutOffers hosts fully used have no offers, they should still be preempted
+func TestSelectPreemptionHostWithoutOffers(t *testing.T) {
+	fake := newFakeStore()
+	fake.addApplication("ns", "batch", map[string]string{"app": "batch"}, 2)
+	for _, taskgroup := range fake.taskgroups["ns.batch"] {
+		taskgroup.HostName = "host1"
+		taskgroup.LaunchResource = &types.Resource{Cpus: 1, Mem: 512}
+	}
+	fake.versions["ns.batch"] = &types.Version{ID: "batch", RunAs: "ns"}
+	fake.agents = []*types.Agent{
+		newTestAgent("host1", "127.0.0.1", 4, 4096, 4, 4096),
+		newTestAgent("host2", "127.0.0.2", 4, 4096, 4, 4096),
+	}
+	s := &Scheduler{store: fake}
+	s.offerPool = offer.NewOfferPool(&offer.OfferPara{Sched: s, Store: fake})
+	if len(s.offerPool.GetAllOffers()) != 0 {
+		t.Fatalf("offer pool should be empty")
+	}
+
+	trans := &types.Transaction{TransactionID: "trans"}
+	version := &types.Version{
+		ID:         "web",
+		RunAs:      "ns",
+		Priority:   10,
+		Preemption: &commtypes.PreemptionPolicy{MaxEvictions: 2},
+	}
+	need := &types.Resource{Cpus: 2, Mem: 1024}
+	host, victims := s.selectPreemptionHost(trans, version, need, "")
+	if host != "host1" || len(victims) != 2 {
+		t.Fatalf("expect 2 victims on host1, got %d on %q", len(victims), host)
+	}
+
+	// running victims are limited by disruption budget
+	fake.budgets = append(fake.budgets, newTestBudget("ns", "batch-pdb", map[string]string{"app": "batch"}, 1))
+	if host, victims = s.selectPreemptionHost(trans, version, need, ""); len(victims) != 0 {
+		t.Errorf("expect no victims limited by disruption budget, got %d on %q", len(victims), host)
+	}
+	need = &types.Resource{Cpus: 1, Mem: 512}
+	if host, victims = s.selectPreemptionHost(trans, version, need, ""); host != "host1" || len(victims) != 1 {
+		t.Errorf("expect 1 victim on host1 allowed by disruption budget, got %d on %q", len(victims), host)
+	}
+
+	// disruption of all victims is counted before eviction
+	checker := s.NewDisruptionChecker()
+	if err := s.disruptVictim(checker, victims[0]); err != nil {
+		t.Fatalf("disrupt victim err: %v", err)
+	}
+	if _, victims = s.selectPreemptionHost(trans, version, need, ""); len(victims) != 0 {
+		t.Errorf("expect no victims when disruption budget is used up, got %d", len(victims))
+	}
+}
@@ -108,6 +108,14 @@ type Scheduler struct {
 
 	lostSlave map[string]int64
 
+	preemptionLock sync.Mutex
+	// transaction ID -> time of the last preemption for it
+	preemptionTimes map[string]time.Time
+
+	disruptionLock sync.Mutex
+	// disruption budget namespace.name -> voluntary disruptions not reflected by store yet
+	disruptionsInFlight map[string][]*inFlightDisruption
+
 	// Cluster ID from mesos master
 	ClusterId  string
 	config     util.Scheduler
@@ -132,6 +140,8 @@ type Scheduler struct {
 	alertManager alertmanager.AlertManageInterface
 	//stop daemonset signal
 	stopDaemonset chan struct{}
+	//stop job and cronjob signal
+	stopJob chan struct{}
 
 	// queue for scheduler transaction
 	transactionQueue workqueue.RateLimitingInterface
@@ -145,6 +155,10 @@ func NewScheduler(config util.Scheduler, store store.Store, alert alertmanager.A
 		alertManager: alert,
 		eventManager: newBcsEventManager(config),
 		lostSlave:    make(map[string]int64),
+
+		preemptionTimes: make(map[string]time.Time),
+
+		disruptionsInFlight: make(map[string][]*inFlightDisruption),
 	}
 
 	para := &offer.OfferPara{Sched: s, Store: store}
@@ -157,7 +171,8 @@ func NewScheduler(config util.Scheduler, store store.Store, alert alertmanager.A
 	}
 
 	//init executor info
-	task.InitExecutorInfo(s.config.ContainerExecutor, s.config.ProcessExecutor, s.config.CniDir, s.config.NetImage)
+	task.InitExecutorInfo(s.config.ContainerExecutor, s.config.ProcessExecutor, s.config.CniDir, s.config.NetImage,
+		s.config.ContainerRuntime, s.config.CriEndpoint)
 
 	s.eventManager.Run()
 
@@ -792,6 +807,8 @@ func (s *Scheduler) checkRoleChange(currRole string) error {
 		s.store.UnInitCacheMgr()
 		//stop check and build daemonset
 		s.stopBuildDaemonset()
+		//stop check jobs and cronjobs
+		s.stopCheckJobs()
 		// stop transaction loop
 		s.stopTransactionLoop()
 		return nil
@@ -875,6 +892,8 @@ func (s *Scheduler) checkRoleChange(currRole string) error {
 	}
 	//start check and build daemonset
 	go s.startBuildDaemonsets()
+	//start check jobs and cronjobs
+	go s.startCheckJobs()
 	// start transaction loop
 	s.startTransactionLoop()
 
@@ -576,7 +576,7 @@ func (mgr *ServiceMgr) buildEndpoint(service *commtypes.BcsService, tskgroup *ty
 			podEndpoint.NodeIP = nodeAddress
 			podEndpoint.ContainerIP = nodeAddress
 			//nodeAddress = oneTask.AgentIPAddress
-		case commtypes.BcsDataType_APP, "":
+		case commtypes.BcsDataType_APP, "", commtypes.BcsDataType_Job:
 			if len(oneTask.StatusData) == 0 {
 				blog.Warn("ServiceMgr: buildEndpoint, but task %s StatusData is empty", oneTask.ID)
 				continue
@@ -0,0 +1,272 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/mesosproto/mesos"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/offer"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/strategy"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// simulationStore overlays the taskgroups placed in simulation on store,
+// so that constraints like UNIQUE and MAXPER count them as launched
+type simulationStore struct {
+	store.Store
+	runAs      string
+	appID      string
+	taskgroups []*types.TaskGroup
+}
+
+// ListTaskGroups list taskgroups of application, including the simulated ones
+func (ss *simulationStore) ListTaskGroups(runAs, appID string) ([]*types.TaskGroup, error) {
+	taskgroups, err := ss.Store.ListTaskGroups(runAs, appID)
+	if runAs != ss.runAs || appID != ss.appID {
+		return taskgroups, err
+	}
+	if err != nil && err != store.ErrNoFound {
+		return nil, err
+	}
+	return append(taskgroups, ss.taskgroups...), nil
+}
+
+// FetchApplication fetch application, a not existing application of simulation is faked,
+// instances include the simulated taskgroups
+func (ss *simulationStore) FetchApplication(runAs, appID string) (*types.Application, error) {
+	app, err := ss.Store.FetchApplication(runAs, appID)
+	if runAs != ss.runAs || appID != ss.appID {
+		return app, err
+	}
+	if err != nil && err != store.ErrNoFound {
+		return nil, err
+	}
+	simApp := &types.Application{
+		ID:    appID,
+		Name:  appID,
+		RunAs: runAs,
+	}
+	if app != nil {
+		copied := *app
+		simApp = &copied
+	}
+	simApp.Instances += uint64(len(ss.taskgroups))
+	return simApp, nil
+}
+
+// simulationOffer copied offer with resources used by simulated taskgroups,
+// cpu, mem and disk are counted in deltas, extended resources are taken from the copied mesos offer
+type simulationOffer struct {
+	*offer.Offer
+	placed int
+}
+
+// SimulateLaunch runs replicas taskgroups of version through the constraint and resource fitting
+// against the current offer pool without launching anything. Offers are never used, so they are
+// still available for launching real taskgroups. Offers are picked as launching does, by score
+// if offer scoring is enabled by version, otherwise by first-fit order.
+func (s *Scheduler) SimulateLaunch(version *types.Version, replicas int) *types.SimulationResult {
+	needResource := version.AllResource()
+	result := &types.SimulationResult{
+		RunAs:        version.RunAs,
+		ID:           version.ID,
+		Replicas:     replicas,
+		NeedResource: needResource,
+		Hosts:        make([]*types.SimulationHost, 0),
+	}
+	simStore := &simulationStore{
+		Store: s.store,
+		runAs: version.RunAs,
+		appID: version.ID,
+	}
+
+	offers := make([]*simulationOffer, 0)
+	for _, o := range s.offerPool.GetAllOffers() {
+		// copy offer, the deltas are increased and extended resources are decreased by simulated taskgroups
+		copied := *o
+		copied.Offer = proto.Clone(o.Offer).(*mesos.Offer)
+		offers = append(offers, &simulationOffer{Offer: &copied})
+	}
+	blog.Infof("simulate launching %d taskgroups of version(%s.%s) with %d offers",
+		replicas, version.RunAs, version.ID, len(offers))
+
+	for result.Placed < replicas {
+		fitOffers := make([]*simulationOffer, 0, len(offers))
+		rejections := make([]*types.SimulationRejection, 0)
+		for _, o := range offers {
+			rejection := s.simulateFit(version, needResource, o, simStore)
+			if rejection != nil {
+				rejections = append(rejections, rejection)
+				continue
+			}
+			fitOffers = append(fitOffers, o)
+		}
+		if len(fitOffers) == 0 {
+			result.Rejections = rejections
+			break
+		}
+
+		best := fitOffers[0]
+		if strategy.IsOfferScoringEnabled(version) && len(fitOffers) > 1 {
+			best = s.simulateBestOffer(version, fitOffers, simStore)
+		}
+		s.simulatePlace(version, needResource, best, simStore)
+		result.Placed++
+	}
+
+	for _, o := range offers {
+		if o.placed > 0 {
+			result.Hosts = append(result.Hosts, &types.SimulationHost{Hostname: o.Offer.Offer.GetHostname(), Placed: o.placed})
+		}
+	}
+	sort.Slice(result.Hosts, func(i, j int) bool {
+		return result.Hosts[i].Hostname < result.Hosts[j].Hostname
+	})
+	blog.Infof("simulate launching taskgroups of version(%s.%s): %d of %d placed on %d hosts",
+		version.RunAs, version.ID, result.Placed, replicas, len(result.Hosts))
+	return result
+}
+
+// simulateFit check whether simulation offer can hold one more taskgroup of version,
+// returns the rejection if not fit
+func (s *Scheduler) simulateFit(version *types.Version, needResource *types.Resource, o *simulationOffer,
+	simStore store.Store) *types.SimulationRejection {
+	hostname := o.Offer.Offer.GetHostname()
+	if !s.IsOfferResourceFitLaunch(needResource, o.Offer) {
+		cpus, mem, disk := s.OfferedResources(o.Offer.Offer)
+		return &types.SimulationRejection{
+			Hostname: hostname,
+			Reason:   types.SimulationReasonResource,
+			Message: fmt.Sprintf("need cpu %f mem %f disk %f, free cpu %f mem %f disk %f",
+				needResource.Cpus, needResource.Mem, needResource.Disk,
+				cpus-o.DeltaCPU, mem-o.DeltaMem, disk-o.DeltaDisk),
+		}
+	}
+	if needs := version.GetExtendedResources(); !s.IsOfferExtendedResourcesFitLaunch(needs, o.Offer) {
+		messages := make([]string, 0, len(needs))
+		for _, need := range needs {
+			resource := s.getNeedResourceOfOffer(o.Offer.Offer, need.Name)
+			messages = append(messages, fmt.Sprintf("need extended resource %s %f, free %f",
+				need.Name, need.Value, resource.GetScalar().GetValue()))
+		}
+		sort.Strings(messages)
+		return &types.SimulationRejection{
+			Hostname: hostname,
+			Reason:   types.SimulationReasonExtendedResource,
+			Message:  strings.Join(messages, ", "),
+		}
+	}
+	isFit, err := strategy.ConstraintsFit(version, o.Offer.Offer, simStore, "")
+	if !isFit {
+		message := "constraints of version not fit host"
+		if err != nil {
+			message = fmt.Sprintf("%s: %s", message, err.Error())
+		}
+		return &types.SimulationRejection{
+			Hostname: hostname,
+			Reason:   types.SimulationReasonConstraints,
+			Message:  message,
+		}
+	}
+	return nil
+}
+
+// simulateBestOffer returns the fit offer with the highest score, first one if scoring failed
+func (s *Scheduler) simulateBestOffer(version *types.Version, fitOffers []*simulationOffer,
+	simStore store.Store) *simulationOffer {
+	offers := make([]*offer.Offer, 0, len(fitOffers))
+	for _, o := range fitOffers {
+		offers = append(offers, o.Offer)
+	}
+	scores, err := strategy.ScoreOffers(version, offers, simStore)
+	if err != nil {
+		blog.Warnf("simulate: score offers for version(%s.%s) err: %s, use offers by first-fit",
+			version.RunAs, version.ID, err.Error())
+		return fitOffers[0]
+	}
+	best := 0
+	for i := range scores {
+		if scores[i] > scores[best] {
+			best = i
+		}
+	}
+	return fitOffers[best]
+}
+
+// simulatePlace place one taskgroup of version on simulation offer
+func (s *Scheduler) simulatePlace(version *types.Version, needResource *types.Resource, o *simulationOffer,
+	simStore *simulationStore) {
+	o.DeltaCPU += needResource.Cpus
+	o.DeltaMem += needResource.Mem
+	o.DeltaDisk += needResource.Disk
+	for _, need := range version.GetExtendedResources() {
+		if resource := s.getNeedResourceOfOffer(o.Offer.Offer, need.Name); resource.GetScalar() != nil {
+			resource.Scalar.Value = proto.Float64(resource.GetScalar().GetValue() - need.Value)
+		}
+	}
+	o.placed++
+
+	taskgroup := &types.TaskGroup{
+		ID:         fmt.Sprintf("simulation-%d.%s.%s", len(simStore.taskgroups), version.ID, version.RunAs),
+		Name:       version.ID,
+		AppID:      version.ID,
+		RunAs:      version.RunAs,
+		Status:     types.TASKGROUP_STATUS_STAGING,
+		HostName:   o.Offer.Offer.GetHostname(),
+		Attributes: simulationAttributes(version, o.Offer.Offer),
+	}
+	simStore.taskgroups = append(simStore.taskgroups, taskgroup)
+}
+
+// simulationAttributes copy attributes of constraints from offer, as taskgroup is built with offer
+func simulationAttributes(version *types.Version, o *mesos.Offer) []*mesos.Attribute {
+	attributes := make([]*mesos.Attribute, 0)
+	if version.Constraints == nil {
+		return attributes
+	}
+	names := make(map[string]bool)
+	for _, item := range version.Constraints.IntersectionItem {
+		if item == nil {
+			continue
+		}
+		for _, data := range item.UnionData {
+			if data == nil || names[data.Name] {
+				continue
+			}
+			names[data.Name] = true
+			if data.Name == "hostname" {
+				attrName := "hostname"
+				attrType := mesos.Value_TEXT
+				host := o.GetHostname()
+				attributes = append(attributes, &mesos.Attribute{
+					Name: &attrName,
+					Type: &attrType,
+					Text: &mesos.Value_Text{Value: &host},
+				})
+				continue
+			}
+			if attribute, _ := offer.GetOfferAttribute(o, data.Name); attribute != nil {
+				attributes = append(attributes, attribute)
+			}
+		}
+	}
+	return attributes
+}
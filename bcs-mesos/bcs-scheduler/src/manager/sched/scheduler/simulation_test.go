@@ -0,0 +1,153 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package scheduler
+
+import (
+	"testing"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/mesosproto/mesos"
+	types "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/offer"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// fakeOfferPool offer pool with fixed offers
+type fakeOfferPool struct {
+	offer.OfferPool
+	offers []*offer.Offer
+}
+
+func (p *fakeOfferPool) GetAllOffers() []*offer.Offer {
+	return p.offers
+}
+
+func newSimulationVersion(cpus float64, extended ...*commtypes.ExtendedResource) *types.Version {
+	return &types.Version{
+		ID:    "web",
+		RunAs: "ns",
+		Container: []*types.Container{{
+			DataClass: &types.DataClass{
+				Resources:         &types.Resource{Cpus: cpus, Mem: 64},
+				ExtendedResources: extended,
+			},
+		}},
+	}
+}
+
+func TestSimulateLaunch(t *testing.T) {
+	gpuOffer := newZoneOffer("host-b", "zone-1")
+	gpuOffer.Offer.Resources = append(gpuOffer.Offer.Resources, &mesos.Resource{
+		Name:   proto.String("gpu"),
+		Type:   mesos.Value_SCALAR.Enum(),
+		Scalar: &mesos.Value_Scalar{Value: proto.Float64(2)},
+	})
+	pool := &fakeOfferPool{offers: []*offer.Offer{newZoneOffer("host-a", "zone-1"), gpuOffer}}
+	s := &Scheduler{store: newFakeStore(), offerPool: pool}
+
+	unique := newSimulationVersion(1)
+	unique.Constraints = &commtypes.Constraint{
+		IntersectionItem: []*commtypes.ConstraintDataItem{{
+			UnionData: []*commtypes.ConstraintData{{
+				Name:    "hostname",
+				Operate: commtypes.Constraint_Type_UNIQUE,
+			}},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		version    *types.Version
+		replicas   int
+		placed     map[string]int
+		rejections map[string]string
+	}{
+		{
+			name:     "all placed",
+			version:  newSimulationVersion(1),
+			replicas: 6,
+			placed:   map[string]int{"host-a": 4, "host-b": 2},
+		},
+		{
+			name:     "insufficient resource",
+			version:  newSimulationVersion(3),
+			replicas: 3,
+			placed:   map[string]int{"host-a": 1, "host-b": 1},
+			rejections: map[string]string{
+				"host-a": types.SimulationReasonResource,
+				"host-b": types.SimulationReasonResource,
+			},
+		},
+		{
+			name:     "unique hostname",
+			version:  unique,
+			replicas: 3,
+			placed:   map[string]int{"host-a": 1, "host-b": 1},
+			rejections: map[string]string{
+				"host-a": types.SimulationReasonConstraints,
+				"host-b": types.SimulationReasonConstraints,
+			},
+		},
+		{
+			name:     "insufficient extended resource",
+			version:  newSimulationVersion(1, &commtypes.ExtendedResource{Name: "gpu", Value: 1}),
+			replicas: 3,
+			placed:   map[string]int{"host-b": 2},
+			rejections: map[string]string{
+				"host-a": types.SimulationReasonExtendedResource,
+				"host-b": types.SimulationReasonExtendedResource,
+			},
+		},
+	}
+	for _, test := range tests {
+		result := s.SimulateLaunch(test.version, test.replicas)
+		placed := 0
+		for _, n := range test.placed {
+			placed += n
+		}
+		if result.Replicas != test.replicas || result.Placed != placed {
+			t.Errorf("%s: expect %d of %d placed, got %d of %d",
+				test.name, placed, test.replicas, result.Placed, result.Replicas)
+		}
+		if len(result.Hosts) != len(test.placed) {
+			t.Errorf("%s: expect %d hosts, got %d", test.name, len(test.placed), len(result.Hosts))
+		}
+		for _, host := range result.Hosts {
+			if test.placed[host.Hostname] != host.Placed {
+				t.Errorf("%s: expect %d placed on %s, got %d",
+					test.name, test.placed[host.Hostname], host.Hostname, host.Placed)
+			}
+		}
+		if len(result.Rejections) != len(test.rejections) {
+			t.Errorf("%s: expect %d rejections, got %d", test.name, len(test.rejections), len(result.Rejections))
+		}
+		for _, rejection := range result.Rejections {
+			if test.rejections[rejection.Hostname] != rejection.Reason {
+				t.Errorf("%s: expect %s rejected by %s, got %s(%s)", test.name, rejection.Hostname,
+					test.rejections[rejection.Hostname], rejection.Reason, rejection.Message)
+			}
+		}
+	}
+
+	// offers in pool are not used by simulation
+	for _, o := range pool.offers {
+		if o.DeltaCPU != 0 || o.DeltaMem != 0 || o.DeltaDisk != 0 {
+			t.Errorf("offer of %s is changed by simulation", o.Offer.GetHostname())
+		}
+	}
+	if gpu := s.getNeedResourceOfOffer(gpuOffer.Offer, "gpu"); gpu.GetScalar().GetValue() != 2 {
+		t.Errorf("expect extended resource gpu 2 of offer not changed, got %f", gpu.GetScalar().GetValue())
+	}
+}
@@ -16,6 +16,7 @@ package scheduler
 import (
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 	"errors"
 
@@ -351,3 +352,55 @@ func (s *Scheduler) IsConstraintsFit(version *types.Version, offer *mesos.Offer,
 	isFit, _ := strategy.ConstraintsFit(version, offer, s.store, taskgroupID)
 	return isFit
 }
+
+// GetSortedOffers Get offers from pool for launching taskgroup of version.
+// If offer scoring is enabled by version, offers not fit are dropped and the others
+// are sorted by score from high to low, otherwise all offers are returned by first-fit order.
+// Offer should still be checked before used, taskgroups launched before may change its fitness
+func (s *Scheduler) GetSortedOffers(version *types.Version, needResource *types.Resource, taskgroupID string) []*offer.Offer {
+	return s.sortOffers(version, needResource, taskgroupID, s.offerPool.GetAllOffers())
+}
+
+// sortOffers filters and sorts offers like GetSortedOffers. Transactions placing several taskgroups
+// sort the offers left again after each placement, scores depending on taskgroups placed,
+// such as GROUPBY preferred constraints, are changed by the placement
+func (s *Scheduler) sortOffers(version *types.Version, needResource *types.Resource, taskgroupID string,
+	offers []*offer.Offer) []*offer.Offer {
+	if !strategy.IsOfferScoringEnabled(version) || len(offers) == 0 {
+		return offers
+	}
+
+	fitOffers := make([]*offer.Offer, 0, len(offers))
+	for _, o := range offers {
+		if s.IsOfferResourceFitLaunch(needResource, o) &&
+			s.IsConstraintsFit(version, o.Offer, taskgroupID) &&
+			s.IsOfferExtendedResourcesFitLaunch(version.GetExtendedResources(), o) {
+			fitOffers = append(fitOffers, o)
+		}
+	}
+	if len(fitOffers) <= 1 {
+		return fitOffers
+	}
+
+	scores, err := strategy.ScoreOffers(version, fitOffers, s.store)
+	if err != nil {
+		blog.Warnf("score offers for version(%s.%s) err: %s, use offers by first-fit",
+			version.RunAs, version.ID, err.Error())
+		return fitOffers
+	}
+	indexes := make([]int, len(fitOffers))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	// stable sort, so offers with the same score are still used by first-fit
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return scores[indexes[i]] > scores[indexes[j]]
+	})
+	sortedOffers := make([]*offer.Offer, 0, len(fitOffers))
+	for _, index := range indexes {
+		sortedOffers = append(sortedOffers, fitOffers[index])
+	}
+	blog.V(3).Infof("version(%s.%s) best offer %s with score %f in %d fit offers",
+		version.RunAs, version.ID, sortedOffers[0].Offer.GetHostname(), scores[indexes[0]], len(sortedOffers))
+	return sortedOffers
+}
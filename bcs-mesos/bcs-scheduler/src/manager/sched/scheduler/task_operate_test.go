@@ -0,0 +1,112 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package scheduler
+
+import (
+	"testing"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/mesosproto/mesos"
+	types "github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/offer"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/task"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func (f *fakeStore) LockApplication(appID string) {}
+
+func (f *fakeStore) UnLockApplication(appID string) {}
+
+func newZoneAttribute(zone string) *mesos.Attribute {
+	return &mesos.Attribute{
+		Name: proto.String("zone"),
+		Type: mesos.Value_TEXT.Enum(),
+		Text: &mesos.Value_Text{Value: proto.String(zone)},
+	}
+}
+
+func newZoneOffer(hostname, zone string) *offer.Offer {
+	scalar := func(name string, value float64) *mesos.Resource {
+		return &mesos.Resource{
+			Name:   proto.String(name),
+			Type:   mesos.Value_SCALAR.Enum(),
+			Scalar: &mesos.Value_Scalar{Value: proto.Float64(value)},
+		}
+	}
+	return &offer.Offer{
+		Offer: &mesos.Offer{
+			Id:         &mesos.OfferID{Value: proto.String(hostname + "-offer")},
+			AgentId:    &mesos.AgentID{Value: proto.String(hostname + "-agent")},
+			Hostname:   proto.String(hostname),
+			Resources:  []*mesos.Resource{scalar("cpus", 4), scalar("mem", 4096), scalar("disk", 4096)},
+			Attributes: []*mesos.Attribute{newZoneAttribute(zone)},
+		},
+	}
+}
+
+func TestSortOffersAfterPlacement(t *testing.T) {
+	fake := newFakeStore()
+	s := &Scheduler{store: fake}
+	version := &types.Version{
+		ID:    "web",
+		RunAs: "ns",
+		Container: []*types.Container{{
+			Type:      "DOCKER",
+			Docker:    &types.Docker{Image: "nginx", Network: "HOST"},
+			DataClass: &types.DataClass{Resources: &types.Resource{Cpus: 1, Mem: 1024}},
+		}},
+		Constraints: &commtypes.Constraint{
+			Preferred: []*commtypes.PreferredConstraint{{
+				Weight: 1,
+				Preference: &commtypes.ConstraintData{
+					Name:    "zone",
+					Operate: commtypes.Constraint_Type_GROUP_BY,
+					Type:    commtypes.ConstValueType_Set,
+					Set:     &commtypes.ConstraintValue_Set{Item: []string{"zone-1", "zone-2"}},
+				},
+			}},
+		},
+	}
+	need := &types.Resource{Cpus: 1, Mem: 1024}
+	offers := []*offer.Offer{
+		newZoneOffer("host-a", "zone-1"),
+		newZoneOffer("host-b", "zone-1"),
+		newZoneOffer("host-c", "zone-2"),
+	}
+
+	sorted := s.sortOffers(version, need, "", offers)
+	if len(sorted) != 3 || sorted[0].Offer.GetHostname() != "host-a" {
+		t.Fatalf("all zones are empty, expect first-fit order, got %d offers", len(sorted))
+	}
+
+	// taskgroup placed on host-a changes the spread of zones, attributes of preferred constraints
+	// are copied from offer when taskgroup is built
+	taskgroup, err := task.CreateTaskGroup(version, "", 0, "cluster", "test", fake)
+	if err != nil {
+		t.Fatalf("create taskgroup failed, %s", err)
+	}
+	if task.CreateTaskGroupInfo(sorted[0].Offer, version, sorted[0].Offer.GetResources(), taskgroup) == nil {
+		t.Fatalf("create taskgroup info with offer of %s failed", sorted[0].Offer.GetHostname())
+	}
+	fake.taskgroups["ns.web"] = append(fake.taskgroups["ns.web"], taskgroup)
+	sorted = s.sortOffers(version, need, "", sorted[1:])
+	var hostnames []string
+	for _, o := range sorted {
+		hostnames = append(hostnames, o.Offer.GetHostname())
+	}
+	if len(hostnames) != 2 || hostnames[0] != "host-c" || hostnames[1] != "host-b" {
+		t.Errorf("expect offers of zone-2 first after placement, got %v", hostnames)
+	}
+}
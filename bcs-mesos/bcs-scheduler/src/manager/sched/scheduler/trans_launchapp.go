@@ -45,15 +45,15 @@ func (s *Scheduler) RunLaunchApplication(transaction *types.Transaction) bool {
 	opData := transaction.CurOp.OpLaunchData
 	version := opData.Version
 
-	offerOut := s.GetFirstOffer()
-	for offerOut != nil {
-		offerIdx := offerOut.Id
-		offer := offerOut.Offer
+	offers := s.GetSortedOffers(version, opData.NeedResource, "")
+	for len(offers) > 0 {
+		curOffer := offers[0]
+		offers = offers[1:]
+		offerIdx := curOffer.Id
+		offer := curOffer.Offer
 		blog.V(3).Infof("transaction %s launch(%s.%s) get offer(%d) %s||%s ",
 			transaction.TransactionID, runAs, appID, offerIdx, offer.GetHostname(), *(offer.Id.Value))
 
-		curOffer := offerOut
-		offerOut = s.GetNextOffer(offerOut)
 		isFit := s.IsOfferResourceFitLaunch(opData.NeedResource, curOffer) &&
 			s.IsConstraintsFit(version, offer, "") &&
 			s.IsOfferExtendedResourcesFitLaunch(version.GetExtendedResources(), curOffer)
@@ -73,6 +73,8 @@ func (s *Scheduler) RunLaunchApplication(transaction *types.Transaction) bool {
 				}
 				if launchedNum < opData.LaunchedNum {
 					startedTaskgroup = time.Now()
+					// scores of offers left are changed by the taskgroup placed
+					offers = s.sortOffers(version, opData.NeedResource, "", offers)
 				}
 
 			} else {
@@ -82,6 +84,12 @@ func (s *Scheduler) RunLaunchApplication(transaction *types.Transaction) bool {
 		}
 	}
 
+	// no offer fits for several rounds, try to preempt taskgroups with lower priority
+	if opData.SchedulerNum >= PREEMPTION_SCHEDULER_NUM &&
+		s.PreemptForTransaction(transaction, version, opData.NeedResource, "") {
+		opData.SchedulerNum = 0
+	}
+
 	// when scheduler taskgroup number>=10, then report resources insufficient message
 	if transaction.CurOp.OpLaunchData.SchedulerNum >= 10 {
 		blog.Warn("transaction %s launch(%s.%s) timeout", transaction.TransactionID, runAs, appID)
@@ -85,15 +85,12 @@ func (s *Scheduler) RunRescheduleTaskgroup(transaction *types.Transaction) bool
 	}
 
 	isContinue := true
-	offerOut := s.GetFirstOffer()
-	for offerOut != nil {
-		offerIdx := offerOut.Id
-		offer := offerOut.Offer
+	for _, curOffer := range s.GetSortedOffers(version, opData.NeedResource, taskGroupID) {
+		offerIdx := curOffer.Id
+		offer := curOffer.Offer
 		blog.V(3).Infof("transaction %s get offer(%d) %s||%s ",
 			transaction.TransactionID, offerIdx, offer.GetHostname(), *(offer.Id.Value))
 
-		curOffer := offerOut
-		offerOut = s.GetNextOffer(offerOut)
 		if hostRetain == false || offer.GetHostname() == opData.HostRetain {
 			isFit := s.IsOfferResourceFitLaunch(opData.NeedResource, curOffer) &&
 				s.IsConstraintsFit(version, offer, taskGroupID) &&
@@ -120,6 +117,11 @@ func (s *Scheduler) RunRescheduleTaskgroup(transaction *types.Transaction) bool
 		return false
 	}
 
+	// no offer fits for several rounds, try to preempt taskgroups with lower priority
+	if !hostRetain && opData.SchedulerNum >= PREEMPTION_SCHEDULER_NUM {
+		s.PreemptForTransaction(transaction, version, opData.NeedResource, taskGroupID)
+	}
+
 	// when scheduler taskgroup number>=10, then report resources insufficient message
 	if transaction.CurOp.OpRescheduleData.SchedulerNum == 10 {
 		s.store.LockApplication(runAs + "." + appID)
@@ -56,12 +56,12 @@ func (s *Scheduler) RunScaleApplication(transaction *types.Transaction) bool {
 		}
 	} else {
 		transaction.CurOp.OpScaleData.SchedulerNum++
-		offerOut := s.GetFirstOffer()
-		for offerOut != nil {
-			offer := offerOut.Offer
+		offers := s.GetSortedOffers(version, opData.NeedResource, "")
+		for len(offers) > 0 {
+			curOffer := offers[0]
+			offers = offers[1:]
+			offer := curOffer.Offer
 
-			curOffer := offerOut
-			offerOut = s.GetNextOffer(offerOut)
 			blog.V(3).Infof("transaction %s get offer %s||%s ",
 				transaction.TransactionID, offer.GetHostname(), *(offer.Id.Value))
 			isFit := s.IsOfferResourceFitLaunch(opData.NeedResource, curOffer) &&
@@ -83,6 +83,8 @@ func (s *Scheduler) RunScaleApplication(transaction *types.Transaction) bool {
 					}
 					if launchedNum < opData.LaunchedNum {
 						startedTaskgroup = time.Now()
+						// scores of offers left are changed by the taskgroup placed
+						offers = s.sortOffers(version, opData.NeedResource, "", offers)
 					}
 
 				} else {
@@ -94,6 +96,12 @@ func (s *Scheduler) RunScaleApplication(transaction *types.Transaction) bool {
 		}
 	}
 
+	// no offer fits for several rounds, try to preempt taskgroups with lower priority
+	if !opData.IsDown && opData.SchedulerNum >= PREEMPTION_SCHEDULER_NUM &&
+		s.PreemptForTransaction(transaction, version, opData.NeedResource, "") {
+		opData.SchedulerNum = 0
+	}
+
 	// when scheduler taskgroup number>=10, then report resources insufficient message
 	if transaction.CurOp.OpScaleData.SchedulerNum >= 10 {
 		blog.Warn("transaction %s scale(%s.%s) timeout", transaction.TransactionID, runAs, appID)
@@ -131,12 +139,12 @@ func (s *Scheduler) RunInnerScaleApplication(transaction *types.Transaction) boo
 			return false
 		}
 	} else {
-		offerOut := s.GetFirstOffer()
-		for offerOut != nil {
-			offerIdx := offerOut.Id
-			offer := offerOut.Offer
-			curOffer := offerOut
-			offerOut = s.GetNextOffer(offerOut)
+		offers := s.GetSortedOffers(version, opData.NeedResource, "")
+		for len(offers) > 0 {
+			curOffer := offers[0]
+			offers = offers[1:]
+			offerIdx := curOffer.Id
+			offer := curOffer.Offer
 			blog.V(3).Infof("transaction %s get offer(%d) %s||%s ",
 				transaction.TransactionID, offerIdx, offer.GetHostname(), *(offer.Id.Value))
 			isFit := s.IsOfferResourceFitLaunch(opData.NeedResource, curOffer) &&
@@ -157,6 +165,8 @@ func (s *Scheduler) RunInnerScaleApplication(transaction *types.Transaction) boo
 					}
 					if launchedNum < opData.LaunchedNum {
 						startedTaskgroup = time.Now()
+						// scores of offers left are changed by the taskgroup placed
+						offers = s.sortOffers(version, opData.NeedResource, "", offers)
 					}
 				} else {
 					blog.Info("transaction %s use offer(%d) %s||%s fail",
@@ -51,15 +51,12 @@ func (s *Scheduler) RunUpdateApplication(transaction *types.Transaction) bool {
 	//check doing
 	opData := transaction.CurOp.OpUpdateData
 	version := opData.Version
-	offerOut := s.GetFirstOffer()
 
 	taskGroupID := opData.Taskgroups[opData.LaunchedNum].ID
-	for offerOut != nil {
-		offerIdx := offerOut.Id
-		offer := offerOut.Offer
+	for _, curOffer := range s.GetSortedOffers(version, opData.NeedResource, taskGroupID) {
+		offerIdx := curOffer.Id
+		offer := curOffer.Offer
 
-		curOffer := offerOut
-		offerOut = s.GetNextOffer(offerOut)
 		blog.V(3).Infof("transaction %s get offer(%d) %s||%s ",
 			transaction.TransactionID, offerIdx, offer.GetHostname(), *(offer.Id.Value))
 
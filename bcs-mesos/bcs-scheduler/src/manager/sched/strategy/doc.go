@@ -14,7 +14,7 @@
 /*
 Package strategy provides schedule constraints implements.
 
-Function ConstraintsFit is the only external interface
+Function ConstraintsFit checks whether an offer fits constraints of application.
 
 Currently, constraints include:
 LIKE
@@ -25,5 +25,11 @@ GROUPBY
 CLUSTER
 GREATER
 EXCLUDE
+
+Preferred constraints of application are the same operates as above, but they never filter offers.
+Function ScoreOffers scores fit offers by registered OfferScorer, built-in scorers include:
+preferred: percent of weights of fit preferred constraints
+binpack: prefer offer with less free cpu and memory
+spread: prefer offer with more free cpu and memory
 */
 package strategy
@@ -0,0 +1,224 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package strategy
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	offerP "github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/offer"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+)
+
+// MaxOfferScore max score of one offer given by an OfferScorer
+const MaxOfferScore = 100
+
+// OfferScorer scores offers which are already fit for version, offer with higher score is preferred
+type OfferScorer interface {
+	// Name is referred by ScorerPolicy of application
+	Name() string
+	// Score returns scores of offers in the same order, each score is in [0, MaxOfferScore]
+	Score(version *types.Version, offers []*offerP.Offer, store store.Store) ([]float64, error)
+}
+
+var (
+	scorerLock sync.RWMutex
+	scorers    = make(map[string]OfferScorer)
+)
+
+func init() {
+	RegisterOfferScorer(&preferredScorer{})
+	RegisterOfferScorer(&resourceScorer{name: commtypes.OfferScorer_BINPACK, binpack: true})
+	RegisterOfferScorer(&resourceScorer{name: commtypes.OfferScorer_SPREAD})
+}
+
+// RegisterOfferScorer register scorer, scorer with the same name is replaced
+func RegisterOfferScorer(scorer OfferScorer) {
+	scorerLock.Lock()
+	defer scorerLock.Unlock()
+	scorers[scorer.Name()] = scorer
+}
+
+// GetOfferScorer get registered scorer by name, return nil if not found
+func GetOfferScorer(name string) OfferScorer {
+	scorerLock.RLock()
+	defer scorerLock.RUnlock()
+	return scorers[name]
+}
+
+// IsOfferScoringEnabled check whether offers for version should be scored,
+// it is enabled by preferred constraints or scorers of application
+func IsOfferScoringEnabled(version *types.Version) bool {
+	constraints := version.Constraints
+	if constraints == nil {
+		return false
+	}
+	return len(constraints.Preferred) > 0 || len(constraints.Scorers) > 0
+}
+
+// CheckOfferScorers check whether all scorers of version are registered
+func CheckOfferScorers(version *types.Version) error {
+	if version.Constraints == nil {
+		return nil
+	}
+	for _, policy := range version.Constraints.Scorers {
+		if policy == nil {
+			continue
+		}
+		if GetOfferScorer(policy.Name) == nil {
+			return fmt.Errorf("offer scorer %s not supported", policy.Name)
+		}
+	}
+	return nil
+}
+
+// ScoreOffers returns weighted sum of scores of all scorers for offers in the same order
+func ScoreOffers(version *types.Version, offers []*offerP.Offer, store store.Store) ([]float64, error) {
+	policies := version.Constraints.Scorers
+	if len(policies) == 0 {
+		policies = []*commtypes.ScorerPolicy{{Name: commtypes.OfferScorer_PREFERRED, Weight: 1}}
+	}
+
+	totals := make([]float64, len(offers))
+	for _, policy := range policies {
+		if policy == nil {
+			continue
+		}
+		scorer := GetOfferScorer(policy.Name)
+		if scorer == nil {
+			return nil, fmt.Errorf("offer scorer %s not supported", policy.Name)
+		}
+		weight := policy.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		scores, err := scorer.Score(version, offers, store)
+		if err != nil {
+			return nil, fmt.Errorf("offer scorer %s failed: %s", policy.Name, err.Error())
+		}
+		for i, score := range scores {
+			totals[i] += float64(weight) * score
+		}
+	}
+	return totals, nil
+}
+
+// preferredScorer gives offer the percent of weights of fit preferred constraints
+type preferredScorer struct{}
+
+func (p *preferredScorer) Name() string {
+	return commtypes.OfferScorer_PREFERRED
+}
+
+func (p *preferredScorer) Score(version *types.Version, offers []*offerP.Offer, store store.Store) ([]float64, error) {
+	scores := make([]float64, len(offers))
+	var totalWeight int32
+	for _, preferred := range version.Constraints.Preferred {
+		if preferred == nil || preferred.Preference == nil {
+			continue
+		}
+		totalWeight += preferred.Weight
+	}
+	if totalWeight <= 0 {
+		return scores, nil
+	}
+
+	for i, o := range offers {
+		var fitWeight int32
+		for _, preferred := range version.Constraints.Preferred {
+			if preferred == nil || preferred.Preference == nil {
+				continue
+			}
+			isFit, _ := contraintDataFit(preferred.Preference, o.Offer, version, store)
+			if isFit {
+				fitWeight += preferred.Weight
+			}
+		}
+		scores[i] = float64(MaxOfferScore) * float64(fitWeight) / float64(totalWeight)
+		blog.V(3).Infof("offer %s of version(%s.%s) fit preferred weight %d/%d",
+			o.Offer.GetHostname(), version.RunAs, version.ID, fitWeight, totalWeight)
+	}
+	return scores, nil
+}
+
+// resourceScorer scores offer by its free cpu and memory relative to the max of all offers,
+// binpack prefers offer with less free resources, spread prefers the opposite
+type resourceScorer struct {
+	name    string
+	binpack bool
+}
+
+func (r *resourceScorer) Name() string {
+	return r.name
+}
+
+func (r *resourceScorer) Score(version *types.Version, offers []*offerP.Offer, store store.Store) ([]float64, error) {
+	cpus := make([]float64, len(offers))
+	mems := make([]float64, len(offers))
+	var maxCpu, maxMem float64
+	for i, o := range offers {
+		cpus[i], mems[i] = offerFreeResource(o)
+		if cpus[i] > maxCpu {
+			maxCpu = cpus[i]
+		}
+		if mems[i] > maxMem {
+			maxMem = mems[i]
+		}
+	}
+
+	scores := make([]float64, len(offers))
+	for i := range offers {
+		var free float64
+		if maxCpu > 0 {
+			free += cpus[i] / maxCpu
+		}
+		if maxMem > 0 {
+			free += mems[i] / maxMem
+		}
+		// free is in [0, 2]
+		scores[i] = float64(MaxOfferScore) * free / 2
+		if r.binpack {
+			scores[i] = float64(MaxOfferScore) - scores[i]
+		}
+	}
+	return scores, nil
+}
+
+// offerFreeResource returns cpu and memory of offer, resources being updated are excluded
+func offerFreeResource(o *offerP.Offer) (cpus, mem float64) {
+	for _, res := range o.Offer.GetResources() {
+		switch res.GetName() {
+		case "cpus":
+			cpus += res.GetScalar().GetValue()
+		case "mem":
+			mem += res.GetScalar().GetValue()
+		}
+	}
+	if o.DeltaCPU > 0 {
+		cpus -= o.DeltaCPU
+	}
+	if o.DeltaMem > 0 {
+		mem -= o.DeltaMem
+	}
+	if cpus < 0 {
+		cpus = 0
+	}
+	if mem < 0 {
+		mem = 0
+	}
+	return cpus, mem
+}
@@ -0,0 +1,107 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package strategy
+
+import (
+	"testing"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/mesosproto/mesos"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	offerP "github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/sched/offer"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func newTestOffer(hostname, zone string, cpus, mem float64) *offerP.Offer {
+	scalar := func(name string, value float64) *mesos.Resource {
+		return &mesos.Resource{
+			Name:   proto.String(name),
+			Type:   mesos.Value_SCALAR.Enum(),
+			Scalar: &mesos.Value_Scalar{Value: proto.Float64(value)},
+		}
+	}
+	return &offerP.Offer{
+		Offer: &mesos.Offer{
+			Hostname:  proto.String(hostname),
+			Resources: []*mesos.Resource{scalar("cpus", cpus), scalar("mem", mem)},
+			Attributes: []*mesos.Attribute{{
+				Name: proto.String("zone"),
+				Type: mesos.Value_TEXT.Enum(),
+				Text: &mesos.Value_Text{Value: proto.String(zone)},
+			}},
+		},
+	}
+}
+
+func TestScoreOffers(t *testing.T) {
+	offers := []*offerP.Offer{
+		newTestOffer("host-a", "zone-1", 4, 4096),
+		newTestOffer("host-b", "zone-2", 1, 1024),
+		newTestOffer("host-c", "zone-1", 2, 2048),
+	}
+	version := &types.Version{
+		ID:    "app",
+		RunAs: "ns",
+		Constraints: &commtypes.Constraint{
+			Preferred: []*commtypes.PreferredConstraint{{
+				Weight: 50,
+				Preference: &commtypes.ConstraintData{
+					Name:    "zone",
+					Operate: commtypes.Constraint_Type_LIKE,
+					Type:    commtypes.ConstValueType_Text,
+					Text:    &commtypes.ConstraintValue_Text{Value: "zone-1"},
+				},
+			}},
+		},
+	}
+	if !IsOfferScoringEnabled(version) {
+		t.Fatalf("offer scoring should be enabled by preferred constraints")
+	}
+
+	cases := []struct {
+		scorers []*commtypes.ScorerPolicy
+		expect  []float64
+	}{
+		// scorer preferred is default
+		{expect: []float64{100, 0, 100}},
+		{scorers: []*commtypes.ScorerPolicy{{Name: commtypes.OfferScorer_BINPACK}}, expect: []float64{0, 75, 50}},
+		{scorers: []*commtypes.ScorerPolicy{{Name: commtypes.OfferScorer_SPREAD}}, expect: []float64{100, 25, 50}},
+		{
+			scorers: []*commtypes.ScorerPolicy{
+				{Name: commtypes.OfferScorer_PREFERRED, Weight: 2},
+				{Name: commtypes.OfferScorer_BINPACK, Weight: 1},
+			},
+			expect: []float64{200, 75, 250},
+		},
+	}
+	for i, cs := range cases {
+		version.Constraints.Scorers = cs.scorers
+		scores, err := ScoreOffers(version, offers, nil)
+		if err != nil {
+			t.Fatalf("case %d: %s", i, err.Error())
+		}
+		for j := range scores {
+			if scores[j] != cs.expect[j] {
+				t.Errorf("case %d: expect scores %v, got %v", i, cs.expect, scores)
+				break
+			}
+		}
+	}
+
+	version.Constraints.Scorers = []*commtypes.ScorerPolicy{{Name: "unknown"}}
+	if err := CheckOfferScorers(version); err == nil {
+		t.Errorf("expect error of unknown scorer")
+	}
+}
@@ -41,6 +41,12 @@ var BcsCniDir string
 // NetImage default network image information
 var NetImage string
 
+// ContainerRuntime container runtime of container executor, docker or cri
+var ContainerRuntime string
+
+// CriEndpoint cri runtime endpoint of container executor
+var CriEndpoint string
+
 // Passwd registry pass
 var Passwd = static.BcsDefaultPasswd
 
@@ -48,7 +54,7 @@ var Passwd = static.BcsDefaultPasswd
 var User = static.BcsDefaultUser
 
 //InitExecutorInfo init mesos executor info
-func InitExecutorInfo(CExec, PExec, CniDir, netImage string) {
+func InitExecutorInfo(CExec, PExec, CniDir, netImage, containerRuntime, criEndpoint string) {
 	if CExec != "" {
 		BcsContainerExecutorPath = CExec
 	} else {
@@ -68,6 +74,14 @@ func InitExecutorInfo(CExec, PExec, CniDir, netImage string) {
 	if netImage != "" {
 		NetImage = netImage
 	}
+
+	if containerRuntime != "" {
+		ContainerRuntime = containerRuntime
+	}
+
+	if criEndpoint != "" {
+		CriEndpoint = criEndpoint
+	}
 }
 
 //CreateBcsExecutorInfo special the bcs executor
@@ -78,7 +92,7 @@ func CreateBcsExecutorInfo(offer *mesos.Offer, taskGroupID *string,
 	switch version.Kind {
 	case commtypes.BcsDataType_PROCESS:
 		cmdOrURI = BcsProcessExecutorPath
-	case commtypes.BcsDataType_APP, "", commtypes.BcsDataType_Daemonset:
+	case commtypes.BcsDataType_APP, "", commtypes.BcsDataType_Daemonset, commtypes.BcsDataType_Job:
 		cmdOrURI = BcsContainerExecutorPath
 	}
 
@@ -97,7 +111,7 @@ func CreateBcsExecutorInfo(offer *mesos.Offer, taskGroupID *string,
 	switch version.Kind {
 	case commtypes.BcsDataType_PROCESS:
 		execCommand = fmt.Sprintf("./%s", base)
-	case commtypes.BcsDataType_APP, "", commtypes.BcsDataType_Daemonset:
+	case commtypes.BcsDataType_APP, "", commtypes.BcsDataType_Daemonset, commtypes.BcsDataType_Job:
 		var user string
 		var passwd string
 		if version.Container[0].Docker.ImagePullUser != "" {
@@ -237,8 +251,19 @@ func CreateBcsExecutorInfo(offer *mesos.Offer, taskGroupID *string,
 			netImage = fmt.Sprintf(" --network-image %s", NetImage)
 		}
 
+		var runtime string
+		if ContainerRuntime != "" {
+			runtime = fmt.Sprintf(" --container-runtime %s", ContainerRuntime)
+		}
+
+		var criEndpoint string
+		if CriEndpoint != "" {
+			criEndpoint = fmt.Sprintf(" --cri-endpoint %s", CriEndpoint)
+		}
+
 		uuid, _ := encrypt.DesEncryptToBase([]byte(passwd))
-		execCommand = fmt.Sprintf("./%s --user %s --uuid %s %s %s %s", base, user, string(uuid), networkType, cniDir, netImage)
+		execCommand = fmt.Sprintf("./%s --user %s --uuid %s %s %s %s %s %s", base, user, string(uuid), networkType, cniDir, netImage,
+			runtime, criEndpoint)
 	}
 
 	var resources []*mesos.Resource
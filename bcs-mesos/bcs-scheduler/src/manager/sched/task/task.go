@@ -167,7 +167,7 @@ func CreateTaskGroup(version *types.Version, ID string, appInstances uint64, app
 
 			taskgroup.Taskgroup = append(taskgroup.Taskgroup, &task)
 		}
-	case commtypes.BcsDataType_APP, "", commtypes.BcsDataType_Daemonset:
+	case commtypes.BcsDataType_APP, "", commtypes.BcsDataType_Daemonset, commtypes.BcsDataType_Job:
 		// build container tasks
 		for index, container := range version.Container {
 			var task types.Task
@@ -308,6 +308,7 @@ func CreateTaskGroup(version *types.Version, ID string, appInstances uint64, app
 	}
 
 	taskgroup.RestartPolicy = version.RestartPolicy
+	taskgroup.Priority = version.Priority
 
 	return &taskgroup, nil
 }
@@ -1241,6 +1242,28 @@ func getTaskHealthCheckPort(task *types.Task, name string) (int32, error) {
 	}
 }
 
+// constraintAttributeData returns constraint data of hard and preferred constraints,
+// attributes named by them are copied from offer to taskgroup
+func constraintAttributeData(constraints *commtypes.Constraint) []*commtypes.ConstraintData {
+	var datas []*commtypes.ConstraintData
+	for _, oneConstraint := range constraints.IntersectionItem {
+		if oneConstraint == nil {
+			continue
+		}
+		for _, oneData := range oneConstraint.UnionData {
+			if oneData != nil {
+				datas = append(datas, oneData)
+			}
+		}
+	}
+	for _, preferred := range constraints.Preferred {
+		if preferred != nil && preferred.Preference != nil {
+			datas = append(datas, preferred.Preference)
+		}
+	}
+	return datas
+}
+
 // CreateTaskGroupInfo Create taskgroup information with offered resource
 // the information include: ports, slave attributions, health-check information etc.
 func CreateTaskGroupInfo(offer *mesos.Offer, version *types.Version,
@@ -1251,50 +1274,42 @@ func CreateTaskGroupInfo(offer *mesos.Offer, version *types.Version,
 	taskgroup.HostName = offer.GetHostname()
 	taskgroup.StartTime = time.Now().Unix()
 	// build taskgroup's attributes according to version and offer attributes,
-	// for UNIQUE and other constraints
+	// for UNIQUE and other constraints, and preferred constraints which score offers by placed taskgroups
 	if version.Constraints != nil {
-		for _, oneConstraint := range version.Constraints.IntersectionItem {
-			if oneConstraint == nil {
-				continue
-			}
-			for _, oneData := range oneConstraint.UnionData {
-				if oneData == nil {
-					continue
+		for _, oneData := range constraintAttributeData(version.Constraints) {
+			blog.V(3).Infof("version(RunAs:%s ID:%s), Constraint attribute(%s)",
+				version.RunAs, version.ID, oneData.Name)
+			// copy attribute from offer to taskgroup
+			isIn := false
+			for _, currAttribute := range taskgroup.Attributes {
+				if currAttribute.GetName() == oneData.Name {
+					isIn = true
+					blog.V(3).Infof("attribute(%s) is already in taskgroup", oneData.Name)
+					break
 				}
-				blog.V(3).Infof("version(RunAs:%s ID:%s), Constraint attribute(%s)",
-					version.RunAs, version.ID, oneData.Name)
-				// copy attribute from offer to taskgroup
-				isIn := false
-				for _, currAttribute := range taskgroup.Attributes {
-					if currAttribute.GetName() == oneData.Name {
-						isIn = true
-						blog.V(3).Infof("attribute(%s) is already in taskgroup", oneData.Name)
-						break
-					}
+			}
+			if isIn == false {
+				var attribute *mesos.Attribute
+				if oneData.Name == "hostname" {
+					blog.V(3).Infof("create attribute(%s) for taskgroup", oneData.Name)
+					var attr mesos.Attribute
+					var attrName = "hostname"
+					attr.Name = &attrName
+					var attrType mesos.Value_Type = mesos.Value_TEXT
+					attr.Type = &attrType
+					var attrValue mesos.Value_Text
+					var host string = offer.GetHostname()
+					attrValue.Value = &host
+					attr.Text = &attrValue
+					attribute = &attr
+				} else {
+					attribute, _ = offerP.GetOfferAttribute(offer, oneData.Name)
 				}
-				if isIn == false {
-					var attribute *mesos.Attribute
-					if oneData.Name == "hostname" {
-						blog.V(3).Infof("create attribute(%s) for taskgroup", oneData.Name)
-						var attr mesos.Attribute
-						var attrName = "hostname"
-						attr.Name = &attrName
-						var attrType mesos.Value_Type = mesos.Value_TEXT
-						attr.Type = &attrType
-						var attrValue mesos.Value_Text
-						var host string = offer.GetHostname()
-						attrValue.Value = &host
-						attr.Text = &attrValue
-						attribute = &attr
-					} else {
-						attribute, _ = offerP.GetOfferAttribute(offer, oneData.Name)
-					}
-					if attribute != nil {
-						blog.V(3).Infof("add attribute(%s) to taskgroup", oneData.Name)
-						taskgroup.Attributes = append(taskgroup.Attributes, attribute)
-					} else {
-						blog.Warn("get attribute(%s) for taskgroup return nil", oneData.Name)
-					}
+				if attribute != nil {
+					blog.V(3).Infof("add attribute(%s) to taskgroup", oneData.Name)
+					taskgroup.Attributes = append(taskgroup.Attributes, attribute)
+				} else {
+					blog.Warn("get attribute(%s) for taskgroup return nil", oneData.Name)
 				}
 			}
 		}
@@ -0,0 +1,111 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	schStore "github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+	v2 "github.com/Tencent/bk-bcs/bcs-mesos/kubebkbcsv2/apis/bkbcs/v2"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SaveDisruptionBudget save disruption budget into db
+func (store *managerStore) SaveDisruptionBudget(budget *commtypes.BcsDisruptionBudget) error {
+	err := store.checkNamespace(budget.NameSpace)
+	if err != nil {
+		return err
+	}
+
+	client := store.BkbcsClient.BcsDisruptionBudgets(budget.NameSpace)
+	v2Budget := &v2.BcsDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       CrdBcsDisruptionBudget,
+			APIVersion: ApiversionV2,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        budget.Name,
+			Namespace:   budget.NameSpace,
+			Labels:      store.filterSpecialLabels(budget.Labels),
+			Annotations: budget.Annotations,
+		},
+		Spec: v2.BcsDisruptionBudgetSpec{
+			BcsDisruptionBudget: *budget,
+		},
+	}
+
+	obj, err := client.Get(context.Background(), budget.Name, metav1.GetOptions{})
+	if err == nil {
+		v2Budget.ResourceVersion = obj.ResourceVersion
+		v2Budget, err = client.Update(context.Background(), v2Budget, metav1.UpdateOptions{})
+	} else if errors.IsNotFound(err) {
+		v2Budget, err = client.Create(context.Background(), v2Budget, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	budget.ResourceVersion = v2Budget.ResourceVersion
+	return nil
+}
+
+// FetchDisruptionBudget fetch disruption budget by namespace and name
+func (store *managerStore) FetchDisruptionBudget(ns, name string) (*commtypes.BcsDisruptionBudget, error) {
+	client := store.BkbcsClient.BcsDisruptionBudgets(ns)
+	v2Budget, err := client.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, schStore.ErrNoFound
+		}
+		return nil, err
+	}
+
+	obj := v2Budget.Spec.BcsDisruptionBudget
+	obj.ResourceVersion = v2Budget.ResourceVersion
+	return &obj, nil
+}
+
+// ListDisruptionBudgets list disruption budgets in one namespace
+func (store *managerStore) ListDisruptionBudgets(ns string) ([]*commtypes.BcsDisruptionBudget, error) {
+	client := store.BkbcsClient.BcsDisruptionBudgets(ns)
+	v2Budgets, err := client.List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	budgets := make([]*commtypes.BcsDisruptionBudget, 0, len(v2Budgets.Items))
+	for _, v2Budget := range v2Budgets.Items {
+		obj := v2Budget.Spec.BcsDisruptionBudget
+		obj.ResourceVersion = v2Budget.ResourceVersion
+		budgets = append(budgets, &obj)
+	}
+	return budgets, nil
+}
+
+// ListAllDisruptionBudgets list disruption budgets of all namespaces
+func (store *managerStore) ListAllDisruptionBudgets() ([]*commtypes.BcsDisruptionBudget, error) {
+	return store.ListDisruptionBudgets("")
+}
+
+// DeleteDisruptionBudget delete disruption budget by namespace and name
+func (store *managerStore) DeleteDisruptionBudget(ns, name string) error {
+	client := store.BkbcsClient.BcsDisruptionBudgets(ns)
+	err := client.Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	schStore "github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+	v2 "github.com/Tencent/bk-bcs/bcs-mesos/kubebkbcsv2/apis/bkbcs/v2"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SaveJob save job into db
+func (store *managerStore) SaveJob(job *types.BcsJob) error {
+	err := store.checkNamespace(job.NameSpace)
+	if err != nil {
+		return err
+	}
+
+	client := store.BkbcsClient.BcsJobs(job.NameSpace)
+	v2Job := &v2.BcsJob{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       CrdBcsJob,
+			APIVersion: ApiversionV2,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        job.Name,
+			Namespace:   job.NameSpace,
+			Labels:      store.filterSpecialLabels(job.Labels),
+			Annotations: job.Annotations,
+		},
+		Spec: v2.BcsJobSpec{
+			BcsJob: *job,
+		},
+	}
+
+	obj, err := client.Get(context.Background(), job.Name, metav1.GetOptions{})
+	if err == nil {
+		v2Job.ResourceVersion = obj.ResourceVersion
+		v2Job, err = client.Update(context.Background(), v2Job, metav1.UpdateOptions{})
+	} else if errors.IsNotFound(err) {
+		v2Job, err = client.Create(context.Background(), v2Job, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	job.ResourceVersion = v2Job.ResourceVersion
+	return nil
+}
+
+// FetchJob fetch job by namespace and name
+func (store *managerStore) FetchJob(ns, name string) (*types.BcsJob, error) {
+	client := store.BkbcsClient.BcsJobs(ns)
+	v2Job, err := client.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, schStore.ErrNoFound
+		}
+		return nil, err
+	}
+
+	obj := v2Job.Spec.BcsJob
+	obj.ResourceVersion = v2Job.ResourceVersion
+	return &obj, nil
+}
+
+// ListJobs list jobs in one namespace
+func (store *managerStore) ListJobs(ns string) ([]*types.BcsJob, error) {
+	client := store.BkbcsClient.BcsJobs(ns)
+	v2Jobs, err := client.List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*types.BcsJob, 0, len(v2Jobs.Items))
+	for _, v2Job := range v2Jobs.Items {
+		obj := v2Job.Spec.BcsJob
+		obj.ResourceVersion = v2Job.ResourceVersion
+		jobs = append(jobs, &obj)
+	}
+	return jobs, nil
+}
+
+// ListAllJobs list jobs of all namespaces
+func (store *managerStore) ListAllJobs() ([]*types.BcsJob, error) {
+	return store.ListJobs("")
+}
+
+// DeleteJob delete job by namespace and name
+func (store *managerStore) DeleteJob(ns, name string) error {
+	client := store.BkbcsClient.BcsJobs(ns)
+	err := client.Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// SaveCronJob save cronjob into db
+func (store *managerStore) SaveCronJob(cronJob *types.BcsCronJob) error {
+	err := store.checkNamespace(cronJob.NameSpace)
+	if err != nil {
+		return err
+	}
+
+	client := store.BkbcsClient.BcsCronJobs(cronJob.NameSpace)
+	v2CronJob := &v2.BcsCronJob{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       CrdBcsCronJob,
+			APIVersion: ApiversionV2,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cronJob.Name,
+			Namespace:   cronJob.NameSpace,
+			Labels:      store.filterSpecialLabels(cronJob.Labels),
+			Annotations: cronJob.Annotations,
+		},
+		Spec: v2.BcsCronJobSpec{
+			BcsCronJob: *cronJob,
+		},
+	}
+
+	obj, err := client.Get(context.Background(), cronJob.Name, metav1.GetOptions{})
+	if err == nil {
+		v2CronJob.ResourceVersion = obj.ResourceVersion
+		v2CronJob, err = client.Update(context.Background(), v2CronJob, metav1.UpdateOptions{})
+	} else if errors.IsNotFound(err) {
+		v2CronJob, err = client.Create(context.Background(), v2CronJob, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	cronJob.ResourceVersion = v2CronJob.ResourceVersion
+	return nil
+}
+
+// FetchCronJob fetch cronjob by namespace and name
+func (store *managerStore) FetchCronJob(ns, name string) (*types.BcsCronJob, error) {
+	client := store.BkbcsClient.BcsCronJobs(ns)
+	v2CronJob, err := client.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, schStore.ErrNoFound
+		}
+		return nil, err
+	}
+
+	obj := v2CronJob.Spec.BcsCronJob
+	obj.ResourceVersion = v2CronJob.ResourceVersion
+	return &obj, nil
+}
+
+// ListCronJobs list cronjobs in one namespace
+func (store *managerStore) ListCronJobs(ns string) ([]*types.BcsCronJob, error) {
+	client := store.BkbcsClient.BcsCronJobs(ns)
+	v2CronJobs, err := client.List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	cronJobs := make([]*types.BcsCronJob, 0, len(v2CronJobs.Items))
+	for _, v2CronJob := range v2CronJobs.Items {
+		obj := v2CronJob.Spec.BcsCronJob
+		obj.ResourceVersion = v2CronJob.ResourceVersion
+		cronJobs = append(cronJobs, &obj)
+	}
+	return cronJobs, nil
+}
+
+// ListAllCronJobs list cronjobs of all namespaces
+func (store *managerStore) ListAllCronJobs() ([]*types.BcsCronJob, error) {
+	return store.ListCronJobs("")
+}
+
+// DeleteCronJob delete cronjob by namespace and name
+func (store *managerStore) DeleteCronJob(ns, name string) error {
+	client := store.BkbcsClient.BcsCronJobs(ns)
+	err := client.Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	schStore "github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+	v2 "github.com/Tencent/bk-bcs/bcs-mesos/kubebkbcsv2/apis/bkbcs/v2"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// namespace quotas are saved in DefaultNamespace, named by the namespace they limit
+
+// SaveNamespaceQuota save namespace quota into db
+func (store *managerStore) SaveNamespaceQuota(quota *commtypes.BcsNamespaceQuota) error {
+	err := store.checkNamespace(DefaultNamespace)
+	if err != nil {
+		return err
+	}
+
+	client := store.BkbcsClient.BcsNamespaceQuotas(DefaultNamespace)
+	v2Quota := &v2.BcsNamespaceQuota{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       CrdBcsNamespaceQuota,
+			APIVersion: ApiversionV2,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        quota.NameSpace,
+			Namespace:   DefaultNamespace,
+			Labels:      store.filterSpecialLabels(quota.Labels),
+			Annotations: quota.Annotations,
+		},
+		Spec: v2.BcsNamespaceQuotaSpec{
+			BcsNamespaceQuota: *quota,
+		},
+	}
+
+	obj, err := client.Get(context.Background(), quota.NameSpace, metav1.GetOptions{})
+	if err == nil {
+		v2Quota.ResourceVersion = obj.ResourceVersion
+		_, err = client.Update(context.Background(), v2Quota, metav1.UpdateOptions{})
+		return err
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+	_, err = client.Create(context.Background(), v2Quota, metav1.CreateOptions{})
+	return err
+}
+
+// FetchNamespaceQuota fetch namespace quota by namespace
+func (store *managerStore) FetchNamespaceQuota(ns string) (*commtypes.BcsNamespaceQuota, error) {
+	client := store.BkbcsClient.BcsNamespaceQuotas(DefaultNamespace)
+	v2Quota, err := client.Get(context.Background(), ns, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, schStore.ErrNoFound
+		}
+		return nil, err
+	}
+
+	return &v2Quota.Spec.BcsNamespaceQuota, nil
+}
+
+// ListNamespaceQuotas list all namespace quotas
+func (store *managerStore) ListNamespaceQuotas() ([]*commtypes.BcsNamespaceQuota, error) {
+	client := store.BkbcsClient.BcsNamespaceQuotas(DefaultNamespace)
+	v2Quotas, err := client.List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	quotas := make([]*commtypes.BcsNamespaceQuota, 0, len(v2Quotas.Items))
+	for _, v2Quota := range v2Quotas.Items {
+		obj := v2Quota.Spec.BcsNamespaceQuota
+		quotas = append(quotas, &obj)
+	}
+	return quotas, nil
+}
+
+// DeleteNamespaceQuota delete namespace quota by namespace
+func (store *managerStore) DeleteNamespaceQuota(ns string) error {
+	client := store.BkbcsClient.BcsNamespaceQuotas(DefaultNamespace)
+	err := client.Delete(context.Background(), ns, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -90,6 +90,14 @@ const (
 	CrdBcsDaemonset = "BcsDaemonset"
 	// CrdBcsTransaction mesos transaction crd name
 	CrdBcsTransaction = "BcsTransaction"
+	// CrdBcsNamespaceQuota mesos namespace quota crd name
+	CrdBcsNamespaceQuota = "BcsNamespaceQuota"
+	// CrdBcsJob mesos job crd name
+	CrdBcsJob = "BcsJob"
+	// CrdBcsCronJob mesos cronjob crd name
+	CrdBcsCronJob = "BcsCronJob"
+	// CrdBcsDisruptionBudget mesos disruption budget crd name
+	CrdBcsDisruptionBudget = "BcsDisruptionBudget"
 )
 
 const (
@@ -139,6 +147,10 @@ func (s *managerStore) initKubeCrd() error {
 		CrdVersion,
 		CrdBcsDaemonset,
 		CrdBcsTransaction,
+		CrdBcsNamespaceQuota,
+		CrdBcsJob,
+		CrdBcsCronJob,
+		CrdBcsDisruptionBudget,
 	}
 
 	for _, crd := range crds {
@@ -263,6 +263,48 @@ type Store interface {
 	ListAllTransaction() ([]*types.Transaction, error)
 	// DeleteTransaction delete transaction
 	DeleteTransaction(namespace, name string) error
+
+	// SaveNamespaceQuota save resource quota of namespace
+	SaveNamespaceQuota(quota *commtypes.BcsNamespaceQuota) error
+	// FetchNamespaceQuota fetch resource quota of namespace, ErrNoFound if not exist
+	FetchNamespaceQuota(ns string) (*commtypes.BcsNamespaceQuota, error)
+	// ListNamespaceQuotas list resource quotas of all namespaces
+	ListNamespaceQuotas() ([]*commtypes.BcsNamespaceQuota, error)
+	// DeleteNamespaceQuota delete resource quota of namespace
+	DeleteNamespaceQuota(ns string) error
+
+	// SaveJob save job
+	SaveJob(job *types.BcsJob) error
+	// FetchJob fetch job by namespace and name, ErrNoFound if not exist
+	FetchJob(ns, name string) (*types.BcsJob, error)
+	// ListJobs list jobs in one namespace
+	ListJobs(ns string) ([]*types.BcsJob, error)
+	// ListAllJobs list jobs of all namespaces
+	ListAllJobs() ([]*types.BcsJob, error)
+	// DeleteJob delete job by namespace and name
+	DeleteJob(ns, name string) error
+
+	// SaveCronJob save cronjob
+	SaveCronJob(cronJob *types.BcsCronJob) error
+	// FetchCronJob fetch cronjob by namespace and name, ErrNoFound if not exist
+	FetchCronJob(ns, name string) (*types.BcsCronJob, error)
+	// ListCronJobs list cronjobs in one namespace
+	ListCronJobs(ns string) ([]*types.BcsCronJob, error)
+	// ListAllCronJobs list cronjobs of all namespaces
+	ListAllCronJobs() ([]*types.BcsCronJob, error)
+	// DeleteCronJob delete cronjob by namespace and name
+	DeleteCronJob(ns, name string) error
+
+	// SaveDisruptionBudget save disruption budget
+	SaveDisruptionBudget(budget *commtypes.BcsDisruptionBudget) error
+	// FetchDisruptionBudget fetch disruption budget by namespace and name, ErrNoFound if not exist
+	FetchDisruptionBudget(ns, name string) (*commtypes.BcsDisruptionBudget, error)
+	// ListDisruptionBudgets list disruption budgets in one namespace
+	ListDisruptionBudgets(ns string) ([]*commtypes.BcsDisruptionBudget, error)
+	// ListAllDisruptionBudgets list disruption budgets of all namespaces
+	ListAllDisruptionBudgets() ([]*commtypes.BcsDisruptionBudget, error)
+	// DeleteDisruptionBudget delete disruption budget by namespace and name
+	DeleteDisruptionBudget(ns, name string) error
 }
 
 // The interface for db operations
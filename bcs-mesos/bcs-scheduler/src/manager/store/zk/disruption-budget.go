@@ -0,0 +1,110 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zk
+
+import (
+	"encoding/json"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	schStore "github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+func getDisruptionBudgetRootPath() string {
+	return "/" + bcsRootNode + "/" + disruptionBudgetNode + "/"
+}
+
+// SaveDisruptionBudget save disruption budget to db
+func (store *managerStore) SaveDisruptionBudget(budget *commtypes.BcsDisruptionBudget) error {
+	data, err := json.Marshal(budget)
+	if err != nil {
+		return err
+	}
+
+	path := getDisruptionBudgetRootPath() + budget.NameSpace + "/" + budget.Name
+	return store.Db.Insert(path, string(data))
+}
+
+// FetchDisruptionBudget fetch disruption budget by namespace and name
+func (store *managerStore) FetchDisruptionBudget(ns, name string) (*commtypes.BcsDisruptionBudget, error) {
+	path := getDisruptionBudgetRootPath() + ns + "/" + name
+	data, err := store.Db.Fetch(path)
+	if err != nil {
+		if err == zk.ErrNoNode {
+			return nil, schStore.ErrNoFound
+		}
+		return nil, err
+	}
+
+	budget := &commtypes.BcsDisruptionBudget{}
+	if err := json.Unmarshal(data, budget); err != nil {
+		blog.Errorf("fail to unmarshal disruption budget(%s), err:%s", string(data), err.Error())
+		return nil, err
+	}
+	return budget, nil
+}
+
+// ListDisruptionBudgets list disruption budgets in one namespace
+func (store *managerStore) ListDisruptionBudgets(ns string) ([]*commtypes.BcsDisruptionBudget, error) {
+	path := getDisruptionBudgetRootPath() + ns
+	names, err := store.Db.List(path)
+	if err != nil {
+		blog.Errorf("fail to list disruption budgets(%s), err:%s", path, err.Error())
+		return nil, err
+	}
+
+	budgets := make([]*commtypes.BcsDisruptionBudget, 0, len(names))
+	for _, name := range names {
+		budget, err := store.FetchDisruptionBudget(ns, name)
+		if err != nil {
+			blog.Warnf("fail to fetch disruption budget(%s.%s), err:%s", ns, name, err.Error())
+			continue
+		}
+		budgets = append(budgets, budget)
+	}
+	return budgets, nil
+}
+
+// ListAllDisruptionBudgets list disruption budgets of all namespaces
+func (store *managerStore) ListAllDisruptionBudgets() ([]*commtypes.BcsDisruptionBudget, error) {
+	nss, err := store.ListObjectNamespaces(disruptionBudgetNode)
+	if err != nil {
+		return nil, err
+	}
+
+	var budgets []*commtypes.BcsDisruptionBudget
+	for _, ns := range nss {
+		objs, err := store.ListDisruptionBudgets(ns)
+		if err != nil {
+			blog.Errorf("fail to list disruption budgets by ns(%s)", ns)
+			continue
+		}
+		budgets = append(budgets, objs...)
+	}
+	return budgets, nil
+}
+
+// DeleteDisruptionBudget delete disruption budget by namespace and name
+func (store *managerStore) DeleteDisruptionBudget(ns, name string) error {
+	path := getDisruptionBudgetRootPath() + ns + "/" + name
+	if err := store.Db.Delete(path); err != nil {
+		if err == zk.ErrNoNode {
+			return nil
+		}
+		blog.Errorf("fail to delete disruption budget(%s), err:%s", path, err.Error())
+		return err
+	}
+	return nil
+}
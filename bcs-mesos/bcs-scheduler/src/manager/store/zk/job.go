@@ -0,0 +1,197 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zk
+
+import (
+	"encoding/json"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/scheduler/schetypes"
+	schStore "github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+func getJobRootPath() string {
+	return "/" + bcsRootNode + "/" + jobNode + "/"
+}
+
+// SaveJob save job to db
+func (store *managerStore) SaveJob(job *types.BcsJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	path := getJobRootPath() + job.NameSpace + "/" + job.Name
+	return store.Db.Insert(path, string(data))
+}
+
+// FetchJob fetch job by namespace and name
+func (store *managerStore) FetchJob(ns, name string) (*types.BcsJob, error) {
+	path := getJobRootPath() + ns + "/" + name
+	data, err := store.Db.Fetch(path)
+	if err != nil {
+		if err == zk.ErrNoNode {
+			return nil, schStore.ErrNoFound
+		}
+		return nil, err
+	}
+
+	job := &types.BcsJob{}
+	if err := json.Unmarshal(data, job); err != nil {
+		blog.Errorf("fail to unmarshal job(%s), err:%s", string(data), err.Error())
+		return nil, err
+	}
+	return job, nil
+}
+
+// ListJobs list jobs in one namespace
+func (store *managerStore) ListJobs(ns string) ([]*types.BcsJob, error) {
+	path := getJobRootPath() + ns
+	names, err := store.Db.List(path)
+	if err != nil {
+		blog.Errorf("fail to list jobs(%s), err:%s", path, err.Error())
+		return nil, err
+	}
+
+	jobs := make([]*types.BcsJob, 0, len(names))
+	for _, name := range names {
+		job, err := store.FetchJob(ns, name)
+		if err != nil {
+			blog.Warnf("fail to fetch job(%s.%s), err:%s", ns, name, err.Error())
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// ListAllJobs list jobs of all namespaces
+func (store *managerStore) ListAllJobs() ([]*types.BcsJob, error) {
+	nss, err := store.ListObjectNamespaces(jobNode)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*types.BcsJob
+	for _, ns := range nss {
+		objs, err := store.ListJobs(ns)
+		if err != nil {
+			blog.Errorf("fail to list jobs by ns(%s)", ns)
+			continue
+		}
+		jobs = append(jobs, objs...)
+	}
+	return jobs, nil
+}
+
+// DeleteJob delete job by namespace and name
+func (store *managerStore) DeleteJob(ns, name string) error {
+	path := getJobRootPath() + ns + "/" + name
+	if err := store.Db.Delete(path); err != nil {
+		if err == zk.ErrNoNode {
+			return nil
+		}
+		blog.Errorf("fail to delete job(%s), err:%s", path, err.Error())
+		return err
+	}
+	return nil
+}
+
+func getCronJobRootPath() string {
+	return "/" + bcsRootNode + "/" + cronJobNode + "/"
+}
+
+// SaveCronJob save cronjob to db
+func (store *managerStore) SaveCronJob(cronJob *types.BcsCronJob) error {
+	data, err := json.Marshal(cronJob)
+	if err != nil {
+		return err
+	}
+
+	path := getCronJobRootPath() + cronJob.NameSpace + "/" + cronJob.Name
+	return store.Db.Insert(path, string(data))
+}
+
+// FetchCronJob fetch cronjob by namespace and name
+func (store *managerStore) FetchCronJob(ns, name string) (*types.BcsCronJob, error) {
+	path := getCronJobRootPath() + ns + "/" + name
+	data, err := store.Db.Fetch(path)
+	if err != nil {
+		if err == zk.ErrNoNode {
+			return nil, schStore.ErrNoFound
+		}
+		return nil, err
+	}
+
+	cronJob := &types.BcsCronJob{}
+	if err := json.Unmarshal(data, cronJob); err != nil {
+		blog.Errorf("fail to unmarshal cronjob(%s), err:%s", string(data), err.Error())
+		return nil, err
+	}
+	return cronJob, nil
+}
+
+// ListCronJobs list cronjobs in one namespace
+func (store *managerStore) ListCronJobs(ns string) ([]*types.BcsCronJob, error) {
+	path := getCronJobRootPath() + ns
+	names, err := store.Db.List(path)
+	if err != nil {
+		blog.Errorf("fail to list cronjobs(%s), err:%s", path, err.Error())
+		return nil, err
+	}
+
+	cronJobs := make([]*types.BcsCronJob, 0, len(names))
+	for _, name := range names {
+		cronJob, err := store.FetchCronJob(ns, name)
+		if err != nil {
+			blog.Warnf("fail to fetch cronjob(%s.%s), err:%s", ns, name, err.Error())
+			continue
+		}
+		cronJobs = append(cronJobs, cronJob)
+	}
+	return cronJobs, nil
+}
+
+// ListAllCronJobs list cronjobs of all namespaces
+func (store *managerStore) ListAllCronJobs() ([]*types.BcsCronJob, error) {
+	nss, err := store.ListObjectNamespaces(cronJobNode)
+	if err != nil {
+		return nil, err
+	}
+
+	var cronJobs []*types.BcsCronJob
+	for _, ns := range nss {
+		objs, err := store.ListCronJobs(ns)
+		if err != nil {
+			blog.Errorf("fail to list cronjobs by ns(%s)", ns)
+			continue
+		}
+		cronJobs = append(cronJobs, objs...)
+	}
+	return cronJobs, nil
+}
+
+// DeleteCronJob delete cronjob by namespace and name
+func (store *managerStore) DeleteCronJob(ns, name string) error {
+	path := getCronJobRootPath() + ns + "/" + name
+	if err := store.Db.Delete(path); err != nil {
+		if err == zk.ErrNoNode {
+			return nil
+		}
+		blog.Errorf("fail to delete cronjob(%s), err:%s", path, err.Error())
+		return err
+	}
+	return nil
+}
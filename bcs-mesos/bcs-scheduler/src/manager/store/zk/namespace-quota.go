@@ -0,0 +1,92 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zk
+
+import (
+	"encoding/json"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	schStore "github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/manager/store"
+
+	"github.com/samuel/go-zookeeper/zk"
+)
+
+func getNamespaceQuotaRootPath() string {
+	return "/" + bcsRootNode + "/" + namespaceQuotaNode
+}
+
+// SaveNamespaceQuota save namespace quota to db
+func (store *managerStore) SaveNamespaceQuota(quota *commtypes.BcsNamespaceQuota) error {
+	data, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+
+	path := getNamespaceQuotaRootPath() + "/" + quota.NameSpace
+	return store.Db.Insert(path, string(data))
+}
+
+// FetchNamespaceQuota fetch namespace quota by namespace
+func (store *managerStore) FetchNamespaceQuota(ns string) (*commtypes.BcsNamespaceQuota, error) {
+	path := getNamespaceQuotaRootPath() + "/" + ns
+	data, err := store.Db.Fetch(path)
+	if err == zk.ErrNoNode {
+		blog.V(3).Infof("namespace quota(%s) not exist", path)
+		return nil, schStore.ErrNoFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	quota := &commtypes.BcsNamespaceQuota{}
+	if err := json.Unmarshal(data, quota); err != nil {
+		blog.Errorf("fail to unmarshal namespace quota(%s), err:%s", string(data), err.Error())
+		return nil, err
+	}
+	return quota, nil
+}
+
+// ListNamespaceQuotas list all namespace quotas
+func (store *managerStore) ListNamespaceQuotas() ([]*commtypes.BcsNamespaceQuota, error) {
+	path := getNamespaceQuotaRootPath()
+	namespaces, err := store.Db.List(path)
+	if err != nil {
+		blog.Errorf("fail to list namespace quotas(%s), err:%s", path, err.Error())
+		return nil, err
+	}
+
+	quotas := make([]*commtypes.BcsNamespaceQuota, 0, len(namespaces))
+	for _, ns := range namespaces {
+		quota, err := store.FetchNamespaceQuota(ns)
+		if err != nil {
+			blog.Warnf("fail to fetch namespace quota(%s), err:%s", ns, err.Error())
+			continue
+		}
+		quotas = append(quotas, quota)
+	}
+	return quotas, nil
+}
+
+// DeleteNamespaceQuota delete namespace quota by namespace
+func (store *managerStore) DeleteNamespaceQuota(ns string) error {
+	path := getNamespaceQuotaRootPath() + "/" + ns
+	if err := store.Db.Delete(path); err != nil {
+		if err == zk.ErrNoNode {
+			return nil
+		}
+		blog.Errorf("fail to delete namespace quota(%s), err:%s", path, err.Error())
+		return err
+	}
+	return nil
+}
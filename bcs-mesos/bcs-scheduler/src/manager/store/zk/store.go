@@ -261,4 +261,12 @@ const (
 	AdmissionWebhookNode string = "admissionwebhook"
 	// Transaction zk node
 	transactionNode string = "transaction"
+	// namespace quota zk node
+	namespaceQuotaNode string = "namespacequota"
+	// job zk node
+	jobNode string = "job"
+	// cronjob zk node
+	cronJobNode string = "cronjob"
+	// disruption budget zk node
+	disruptionBudgetNode string = "disruptionbudget"
 )
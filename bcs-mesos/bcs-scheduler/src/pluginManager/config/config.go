@@ -28,9 +28,13 @@ type PluginConfig struct {
 	Type         PluginType               `json:"type"`
 	DefaultAtrrs []*typesplugin.Attribute `json:"defaultAttrs"`
 	Timeout      int                      `json:"timeout"`
+	// Address of out-of-process plugin, grpc-plugin and http-plugin only
+	Address string `json:"address"`
+	// CacheTTL seconds to cache attributes of host, grpc-plugin and http-plugin only, 0 means no cache
+	CacheTTL int `json:"cacheTTL"`
 }
 
-// PluginType type of plugin, dynamic lib/executable-file
+// PluginType type of plugin, dynamic lib/executable-file/grpc/http
 type PluginType string
 
 const (
@@ -38,6 +42,10 @@ const (
 	DynamicPluginType PluginType = "dynamic-plugin"
 	// ExecutablePluginType executable file
 	ExecutablePluginType PluginType = "executable-plugin"
+	// GrpcPluginType out-of-process plugin serving grpc
+	GrpcPluginType PluginType = "grpc-plugin"
+	// HttpPluginType out-of-process plugin serving http
+	HttpPluginType PluginType = "http-plugin"
 	// DefaultTimeout default timeout for plugin invocation
 	DefaultTimeout int = 5
 )
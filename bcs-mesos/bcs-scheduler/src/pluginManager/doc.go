@@ -18,9 +18,14 @@ Scheduler support for the use of plugins to get mesos slave attributes.
 It is mainly applicable to the acquisition of dynamic attributes, example for container ip resources,
 net flow.
 
-The types of plugin are mainly including dynamic, executable.
+The types of plugin are mainly including dynamic, executable, grpc and http.
 User can implement specific plugin based on their own scenarios.
 
+Dynamic plugin is a go plugin loaded by plugin.Open, it must be built with the same
+go version and dependencies as scheduler. Grpc and http plugins run in another process
+and are called with timeout, attributes are cached cacheTTL seconds by ip, so they
+can be built and upgraded independently, see package remotePlugin/hostplugin.
+
 	//mesos slave attribute plugin's names
 	pluginsNames := []string{"ip-resources","net-flow"}
 
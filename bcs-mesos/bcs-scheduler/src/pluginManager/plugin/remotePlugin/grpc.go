@@ -0,0 +1,50 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package remotePlugin
+
+import (
+	"context"
+
+	typesplugin "github.com/Tencent/bk-bcs/bcs-common/common/plugin"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/pluginManager/plugin/remotePlugin/hostplugin"
+
+	"google.golang.org/grpc"
+)
+
+type grpcCaller struct {
+	conn   *grpc.ClientConn
+	client hostplugin.HostPluginClient
+}
+
+// newGrpcCaller dial is not blocked, connection is established in background
+// and re-established when plugin restarts
+func newGrpcCaller(address string) (*grpcCaller, error) {
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	return &grpcCaller{
+		conn:   conn,
+		client: hostplugin.NewHostPluginClient(conn),
+	}, nil
+}
+
+func (c *grpcCaller) getHostAttributes(ctx context.Context,
+	para *typesplugin.HostPluginParameter) (map[string]*typesplugin.HostAttributes, error) {
+	resp, err := c.client.GetHostAttributes(ctx, hostplugin.ParameterToProto(para))
+	if err != nil {
+		return nil, err
+	}
+	return hostplugin.AttributesFromProto(resp), nil
+}
@@ -0,0 +1,585 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.23.0-devel
+// 	protoc        (unknown)
+// source: hostplugin.proto
+
+package hostplugin
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// HostAttributesRequest mesos slaves to get attributes
+type HostAttributesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ips       []string `protobuf:"bytes,1,rep,name=ips,proto3" json:"ips,omitempty"`
+	ClusterId string   `protobuf:"bytes,2,opt,name=clusterId,proto3" json:"clusterId,omitempty"`
+}
+
+func (x *HostAttributesRequest) Reset() {
+	*x = HostAttributesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hostplugin_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostAttributesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostAttributesRequest) ProtoMessage() {}
+
+func (x *HostAttributesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hostplugin_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostAttributesRequest.ProtoReflect.Descriptor instead.
+func (*HostAttributesRequest) Descriptor() ([]byte, []int) {
+	return file_hostplugin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HostAttributesRequest) GetIps() []string {
+	if x != nil {
+		return x.Ips
+	}
+	return nil
+}
+
+func (x *HostAttributesRequest) GetClusterId() string {
+	if x != nil {
+		return x.ClusterId
+	}
+	return ""
+}
+
+// HostAttributesResponse attributes of mesos slaves
+type HostAttributesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hosts []*HostAttributes `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+}
+
+func (x *HostAttributesResponse) Reset() {
+	*x = HostAttributesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hostplugin_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostAttributesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostAttributesResponse) ProtoMessage() {}
+
+func (x *HostAttributesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hostplugin_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostAttributesResponse.ProtoReflect.Descriptor instead.
+func (*HostAttributesResponse) Descriptor() ([]byte, []int) {
+	return file_hostplugin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HostAttributesResponse) GetHosts() []*HostAttributes {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+// HostAttributes attributes of one mesos slave
+type HostAttributes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ip         string       `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Attributes []*Attribute `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty"`
+}
+
+func (x *HostAttributes) Reset() {
+	*x = HostAttributes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hostplugin_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostAttributes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostAttributes) ProtoMessage() {}
+
+func (x *HostAttributes) ProtoReflect() protoreflect.Message {
+	mi := &file_hostplugin_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostAttributes.ProtoReflect.Descriptor instead.
+func (*HostAttributes) Descriptor() ([]byte, []int) {
+	return file_hostplugin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HostAttributes) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *HostAttributes) GetAttributes() []*Attribute {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// Attribute mesos slave attribute, type 0 scalar, 1 ranges, 2 set, 3 text
+type Attribute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type   int32    `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Scalar float64  `protobuf:"fixed64,3,opt,name=scalar,proto3" json:"scalar,omitempty"`
+	Ranges []*Range `protobuf:"bytes,4,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	Set    []string `protobuf:"bytes,5,rep,name=set,proto3" json:"set,omitempty"`
+	Text   string   `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *Attribute) Reset() {
+	*x = Attribute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hostplugin_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Attribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attribute) ProtoMessage() {}
+
+func (x *Attribute) ProtoReflect() protoreflect.Message {
+	mi := &file_hostplugin_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attribute.ProtoReflect.Descriptor instead.
+func (*Attribute) Descriptor() ([]byte, []int) {
+	return file_hostplugin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Attribute) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Attribute) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Attribute) GetScalar() float64 {
+	if x != nil {
+		return x.Scalar
+	}
+	return 0
+}
+
+func (x *Attribute) GetRanges() []*Range {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *Attribute) GetSet() []string {
+	if x != nil {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *Attribute) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// Range range value of attribute
+type Range struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Begin int64 `protobuf:"varint,1,opt,name=begin,proto3" json:"begin,omitempty"`
+	End   int64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *Range) Reset() {
+	*x = Range{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hostplugin_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Range) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Range) ProtoMessage() {}
+
+func (x *Range) ProtoReflect() protoreflect.Message {
+	mi := &file_hostplugin_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Range.ProtoReflect.Descriptor instead.
+func (*Range) Descriptor() ([]byte, []int) {
+	return file_hostplugin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Range) GetBegin() int64 {
+	if x != nil {
+		return x.Begin
+	}
+	return 0
+}
+
+func (x *Range) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+var File_hostplugin_proto protoreflect.FileDescriptor
+
+var file_hostplugin_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x68, 0x6f, 0x73, 0x74, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0a, 0x68, 0x6f, 0x73, 0x74, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x22, 0x47,
+	0x0a, 0x15, 0x48, 0x6f, 0x73, 0x74, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x70, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x70, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x49, 0x64, 0x22, 0x4a, 0x0a, 0x16, 0x48, 0x6f, 0x73, 0x74, 0x41,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x30, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x68, 0x6f, 0x73, 0x74, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x48, 0x6f,
+	0x73, 0x74, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x52, 0x05, 0x68, 0x6f,
+	0x73, 0x74, 0x73, 0x22, 0x57, 0x0a, 0x0e, 0x48, 0x6f, 0x73, 0x74, 0x41, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x35, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x6f, 0x73, 0x74,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x22, 0x9c, 0x01, 0x0a,
+	0x09, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x12, 0x29, 0x0a, 0x06, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x73,
+	0x74, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x03, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22, 0x2f, 0x0a, 0x05, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x32, 0x68, 0x0a, 0x0a,
+	0x48, 0x6f, 0x73, 0x74, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x5a, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x48, 0x6f, 0x73, 0x74, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12,
+	0x21, 0x2e, 0x68, 0x6f, 0x73, 0x74, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x48, 0x6f, 0x73,
+	0x74, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x68, 0x6f, 0x73, 0x74, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e,
+	0x48, 0x6f, 0x73, 0x74, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x6f, 0x5a, 0x6d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x54, 0x65, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x2f, 0x62, 0x6b, 0x2d,
+	0x62, 0x63, 0x73, 0x2f, 0x62, 0x63, 0x73, 0x2d, 0x6d, 0x65, 0x73, 0x6f, 0x73, 0x2f, 0x62, 0x63,
+	0x73, 0x2d, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x2f, 0x73, 0x72, 0x63, 0x2f,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2f, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x2f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x2f, 0x68, 0x6f, 0x73, 0x74, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x3b, 0x68, 0x6f, 0x73,
+	0x74, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_hostplugin_proto_rawDescOnce sync.Once
+	file_hostplugin_proto_rawDescData = file_hostplugin_proto_rawDesc
+)
+
+func file_hostplugin_proto_rawDescGZIP() []byte {
+	file_hostplugin_proto_rawDescOnce.Do(func() {
+		file_hostplugin_proto_rawDescData = protoimpl.X.CompressGZIP(file_hostplugin_proto_rawDescData)
+	})
+	return file_hostplugin_proto_rawDescData
+}
+
+var file_hostplugin_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_hostplugin_proto_goTypes = []interface{}{
+	(*HostAttributesRequest)(nil),  // 0: hostplugin.HostAttributesRequest
+	(*HostAttributesResponse)(nil), // 1: hostplugin.HostAttributesResponse
+	(*HostAttributes)(nil),         // 2: hostplugin.HostAttributes
+	(*Attribute)(nil),              // 3: hostplugin.Attribute
+	(*Range)(nil),                  // 4: hostplugin.Range
+}
+var file_hostplugin_proto_depIdxs = []int32{
+	2, // 0: hostplugin.HostAttributesResponse.hosts:type_name -> hostplugin.HostAttributes
+	3, // 1: hostplugin.HostAttributes.attributes:type_name -> hostplugin.Attribute
+	4, // 2: hostplugin.Attribute.ranges:type_name -> hostplugin.Range
+	0, // 3: hostplugin.HostPlugin.GetHostAttributes:input_type -> hostplugin.HostAttributesRequest
+	1, // 4: hostplugin.HostPlugin.GetHostAttributes:output_type -> hostplugin.HostAttributesResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_hostplugin_proto_init() }
+func file_hostplugin_proto_init() {
+	if File_hostplugin_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_hostplugin_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostAttributesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hostplugin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostAttributesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hostplugin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostAttributes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hostplugin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Attribute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hostplugin_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Range); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_hostplugin_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hostplugin_proto_goTypes,
+		DependencyIndexes: file_hostplugin_proto_depIdxs,
+		MessageInfos:      file_hostplugin_proto_msgTypes,
+	}.Build()
+	File_hostplugin_proto = out.File
+	file_hostplugin_proto_rawDesc = nil
+	file_hostplugin_proto_goTypes = nil
+	file_hostplugin_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// HostPluginClient is the client API for HostPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HostPluginClient interface {
+	// GetHostAttributes get dynamic attributes of mesos slaves
+	GetHostAttributes(ctx context.Context, in *HostAttributesRequest, opts ...grpc.CallOption) (*HostAttributesResponse, error)
+}
+
+type hostPluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHostPluginClient(cc grpc.ClientConnInterface) HostPluginClient {
+	return &hostPluginClient{cc}
+}
+
+func (c *hostPluginClient) GetHostAttributes(ctx context.Context, in *HostAttributesRequest, opts ...grpc.CallOption) (*HostAttributesResponse, error) {
+	out := new(HostAttributesResponse)
+	err := c.cc.Invoke(ctx, "/hostplugin.HostPlugin/GetHostAttributes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HostPluginServer is the server API for HostPlugin service.
+type HostPluginServer interface {
+	// GetHostAttributes get dynamic attributes of mesos slaves
+	GetHostAttributes(context.Context, *HostAttributesRequest) (*HostAttributesResponse, error)
+}
+
+// UnimplementedHostPluginServer can be embedded to have forward compatible implementations.
+type UnimplementedHostPluginServer struct {
+}
+
+func (*UnimplementedHostPluginServer) GetHostAttributes(context.Context, *HostAttributesRequest) (*HostAttributesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostAttributes not implemented")
+}
+
+func RegisterHostPluginServer(s *grpc.Server, srv HostPluginServer) {
+	s.RegisterService(&_HostPlugin_serviceDesc, srv)
+}
+
+func _HostPlugin_GetHostAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HostAttributesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostPluginServer).GetHostAttributes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hostplugin.HostPlugin/GetHostAttributes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostPluginServer).GetHostAttributes(ctx, req.(*HostAttributesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _HostPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hostplugin.HostPlugin",
+	HandlerType: (*HostPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetHostAttributes",
+			Handler:    _HostPlugin_GetHostAttributes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hostplugin.proto",
+}
@@ -0,0 +1,138 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package hostplugin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	typesplugin "github.com/Tencent/bk-bcs/bcs-common/common/plugin"
+)
+
+// HTTPPath the path http plugin serves GetHostAttributes,
+// request body is json of HostPluginParameter, response body is json of map[ip]*HostAttributes
+const HTTPPath = "/v1/hostattributes"
+
+// GetHostAttributesFunc function implemented by host attributes plugin,
+// same as GetHostAttributes exported by dynamic plugin
+type GetHostAttributesFunc func(*typesplugin.HostPluginParameter) (map[string]*typesplugin.HostAttributes, error)
+
+// ParameterToProto convert plugin parameter to grpc request
+func ParameterToProto(para *typesplugin.HostPluginParameter) *HostAttributesRequest {
+	return &HostAttributesRequest{
+		Ips:       para.Ips,
+		ClusterId: para.ClusterId,
+	}
+}
+
+// ParameterFromProto convert grpc request to plugin parameter
+func ParameterFromProto(req *HostAttributesRequest) *typesplugin.HostPluginParameter {
+	return &typesplugin.HostPluginParameter{
+		Ips:       req.GetIps(),
+		ClusterId: req.GetClusterId(),
+	}
+}
+
+// AttributesToProto convert host attributes to grpc response
+func AttributesToProto(hosts map[string]*typesplugin.HostAttributes) *HostAttributesResponse {
+	resp := &HostAttributesResponse{}
+	for ip, host := range hosts {
+		if host == nil {
+			continue
+		}
+		pbHost := &HostAttributes{Ip: ip}
+		for _, attr := range host.Attributes {
+			if attr == nil {
+				continue
+			}
+			pbAttr := &Attribute{
+				Name:   attr.Name,
+				Type:   int32(attr.Type),
+				Scalar: attr.Scalar.Value,
+				Set:    attr.Set.Item,
+				Text:   attr.Text.Text,
+			}
+			for _, r := range attr.Ranges {
+				pbAttr.Ranges = append(pbAttr.Ranges, &Range{Begin: int64(r.Begin), End: int64(r.End)})
+			}
+			pbHost.Attributes = append(pbHost.Attributes, pbAttr)
+		}
+		resp.Hosts = append(resp.Hosts, pbHost)
+	}
+	return resp
+}
+
+// AttributesFromProto convert grpc response to host attributes
+func AttributesFromProto(resp *HostAttributesResponse) map[string]*typesplugin.HostAttributes {
+	hosts := make(map[string]*typesplugin.HostAttributes)
+	for _, pbHost := range resp.GetHosts() {
+		host := &typesplugin.HostAttributes{Ip: pbHost.GetIp()}
+		for _, pbAttr := range pbHost.GetAttributes() {
+			attr := &typesplugin.Attribute{
+				Name:   pbAttr.GetName(),
+				Type:   typesplugin.Value_Type(pbAttr.GetType()),
+				Scalar: typesplugin.Value_Scalar{Value: pbAttr.GetScalar()},
+				Set:    typesplugin.Value_Set{Item: pbAttr.GetSet()},
+				Text:   typesplugin.Value_Text{Text: pbAttr.GetText()},
+			}
+			for _, r := range pbAttr.GetRanges() {
+				attr.Ranges = append(attr.Ranges, typesplugin.Value_Ranges{Begin: int(r.GetBegin()), End: int(r.GetEnd())})
+			}
+			host.Attributes = append(host.Attributes, attr)
+		}
+		hosts[host.Ip] = host
+	}
+	return hosts
+}
+
+type grpcServer struct {
+	fn GetHostAttributesFunc
+}
+
+// NewGrpcServer wrap plugin function as grpc HostPluginServer, register it with RegisterHostPluginServer
+func NewGrpcServer(fn GetHostAttributesFunc) HostPluginServer {
+	return &grpcServer{fn: fn}
+}
+
+// GetHostAttributes HostPluginServer implementation
+func (s *grpcServer) GetHostAttributes(ctx context.Context, req *HostAttributesRequest) (*HostAttributesResponse, error) {
+	hosts, err := s.fn(ParameterFromProto(req))
+	if err != nil {
+		return nil, err
+	}
+	return AttributesToProto(hosts), nil
+}
+
+// NewHTTPHandler wrap plugin function as http handler, serve it on HTTPPath
+func NewHTTPHandler(fn GetHostAttributesFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		para := &typesplugin.HostPluginParameter{}
+		if err := json.NewDecoder(r.Body).Decode(para); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		hosts, err := fn(para)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(hosts)
+	})
+}
@@ -0,0 +1,75 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package remotePlugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	typesplugin "github.com/Tencent/bk-bcs/bcs-common/common/plugin"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/pluginManager/plugin/remotePlugin/hostplugin"
+)
+
+type httpCaller struct {
+	url    string
+	client *http.Client
+}
+
+// newHttpCaller address is like http://127.0.0.1:8080, scheme http is used by default
+func newHttpCaller(address string) *httpCaller {
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		address = "http://" + address
+	}
+	return &httpCaller{
+		url:    strings.TrimSuffix(address, "/") + hostplugin.HTTPPath,
+		client: &http.Client{},
+	}
+}
+
+func (c *httpCaller) getHostAttributes(ctx context.Context,
+	para *typesplugin.HostPluginParameter) (map[string]*typesplugin.HostAttributes, error) {
+	body, err := json.Marshal(para)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("post %s status %d, body %s", c.url, resp.StatusCode, string(data))
+	}
+
+	hosts := make(map[string]*typesplugin.HostAttributes)
+	if err := json.Unmarshal(data, &hosts); err != nil {
+		return nil, fmt.Errorf("unmarshal response %s error %s", string(data), err.Error())
+	}
+	return hosts, nil
+}
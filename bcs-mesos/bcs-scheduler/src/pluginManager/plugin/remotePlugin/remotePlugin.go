@@ -0,0 +1,150 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package remotePlugin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	typesplugin "github.com/Tencent/bk-bcs/bcs-common/common/plugin"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/pluginManager/config"
+	bcsplugin "github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/pluginManager/plugin"
+)
+
+// caller invoke out-of-process plugin
+type caller interface {
+	getHostAttributes(ctx context.Context, para *typesplugin.HostPluginParameter) (map[string]*typesplugin.HostAttributes, error)
+}
+
+type cachedAttributes struct {
+	attr   *typesplugin.HostAttributes
+	expire time.Time
+}
+
+// remotePlugin plugin running in another process, the plugin crash or upgrade
+// don't affect scheduler. Attributes are cached by ip for CacheTTL seconds.
+type remotePlugin struct {
+	name     string
+	timeout  time.Duration
+	cacheTTL time.Duration
+	caller   caller
+
+	lock  sync.Mutex
+	cache map[string]*cachedAttributes
+}
+
+func newRemotePlugin(conf *config.PluginConfig, c caller) *remotePlugin {
+	return &remotePlugin{
+		name:     conf.Name,
+		timeout:  time.Second * time.Duration(conf.Timeout),
+		cacheTTL: time.Second * time.Duration(conf.CacheTTL),
+		caller:   c,
+		cache:    make(map[string]*cachedAttributes),
+	}
+}
+
+// NewGrpcPlugin create plugin calling GetHostAttributes of HostPlugin grpc service on conf.Address
+func NewGrpcPlugin(conf *config.PluginConfig) (bcsplugin.Plugin, error) {
+	if conf.Address == "" {
+		return nil, fmt.Errorf("plugin %s address is empty", conf.Name)
+	}
+	c, err := newGrpcCaller(conf.Address)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s dial %s error %s", conf.Name, conf.Address, err.Error())
+	}
+	return newRemotePlugin(conf, c), nil
+}
+
+// NewHttpPlugin create plugin posting HostPluginParameter to conf.Address
+func NewHttpPlugin(conf *config.PluginConfig) (bcsplugin.Plugin, error) {
+	if conf.Address == "" {
+		return nil, fmt.Errorf("plugin %s address is empty", conf.Name)
+	}
+	return newRemotePlugin(conf, newHttpCaller(conf.Address)), nil
+}
+
+// GetHostAttributes interface implementation, only ips not in cache are requested
+func (p *remotePlugin) GetHostAttributes(para *typesplugin.HostPluginParameter) (map[string]*typesplugin.HostAttributes, error) {
+	hosts := make(map[string]*typesplugin.HostAttributes)
+	missing := p.getCache(para.Ips, hosts)
+	if len(missing) == 0 {
+		return hosts, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	attrs, err := p.caller.getHostAttributes(ctx, &typesplugin.HostPluginParameter{
+		Ips:       missing,
+		ClusterId: para.ClusterId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s GetHostAttributes error %s", p.name, err.Error())
+	}
+
+	p.setCache(attrs)
+	for ip, attr := range attrs {
+		hosts[ip] = attr
+	}
+	blog.V(3).Infof("plugin %s get %d hosts from cache, %d hosts from remote", p.name, len(para.Ips)-len(missing), len(attrs))
+	return hosts, nil
+}
+
+// getCache fill hosts with valid cached attributes, return ips not cached
+func (p *remotePlugin) getCache(ips []string, hosts map[string]*typesplugin.HostAttributes) []string {
+	if p.cacheTTL <= 0 {
+		return ips
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := time.Now()
+	missing := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		cached, ok := p.cache[ip]
+		if !ok || now.After(cached.expire) {
+			missing = append(missing, ip)
+			continue
+		}
+		hosts[ip] = cached.attr
+	}
+	return missing
+}
+
+func (p *remotePlugin) setCache(attrs map[string]*typesplugin.HostAttributes) {
+	if p.cacheTTL <= 0 {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := time.Now()
+	// clean expired hosts, agents may be removed from cluster
+	for ip, cached := range p.cache {
+		if now.After(cached.expire) {
+			delete(p.cache, ip)
+		}
+	}
+	for ip, attr := range attrs {
+		if attr == nil {
+			continue
+		}
+		p.cache[ip] = &cachedAttributes{
+			attr:   attr,
+			expire: now.Add(p.cacheTTL),
+		}
+	}
+}
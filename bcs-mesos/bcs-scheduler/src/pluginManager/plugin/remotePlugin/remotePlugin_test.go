@@ -0,0 +1,95 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package remotePlugin
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	typesplugin "github.com/Tencent/bk-bcs/bcs-common/common/plugin"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/pluginManager/config"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/pluginManager/plugin/remotePlugin/hostplugin"
+)
+
+func TestHttpPluginCache(t *testing.T) {
+	var requested []string
+	handler := hostplugin.NewHTTPHandler(func(para *typesplugin.HostPluginParameter) (map[string]*typesplugin.HostAttributes, error) {
+		requested = append(requested, para.Ips...)
+		hosts := make(map[string]*typesplugin.HostAttributes)
+		for _, ip := range para.Ips {
+			hosts[ip] = &typesplugin.HostAttributes{
+				Ip: ip,
+				Attributes: []*typesplugin.Attribute{{
+					Name:   typesplugin.SlaveAttributeIpResources,
+					Type:   typesplugin.ValueScalar,
+					Scalar: typesplugin.Value_Scalar{Value: 10},
+				}},
+			}
+		}
+		return hosts, nil
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	p, err := NewHttpPlugin(&config.PluginConfig{
+		Name:     "ip-resources",
+		Type:     config.HttpPluginType,
+		Address:  server.URL,
+		Timeout:  config.DefaultTimeout,
+		CacheTTL: 60,
+	})
+	if err != nil {
+		t.Fatalf("NewHttpPlugin failed: %s", err.Error())
+	}
+
+	hosts, err := p.GetHostAttributes(&typesplugin.HostPluginParameter{Ips: []string{"127.0.0.1", "127.0.0.2"}})
+	if err != nil {
+		t.Fatalf("GetHostAttributes failed: %s", err.Error())
+	}
+	if len(hosts) != 2 || hosts["127.0.0.1"].Attributes[0].Scalar.Value != 10 {
+		t.Errorf("unexpected hosts %+v", hosts)
+	}
+
+	// 127.0.0.1 is cached, only 127.0.0.3 is requested
+	hosts, err = p.GetHostAttributes(&typesplugin.HostPluginParameter{Ips: []string{"127.0.0.1", "127.0.0.3"}})
+	if err != nil {
+		t.Fatalf("GetHostAttributes failed: %s", err.Error())
+	}
+	if len(hosts) != 2 {
+		t.Errorf("unexpected hosts %+v", hosts)
+	}
+	sort.Strings(requested)
+	if !reflect.DeepEqual(requested, []string{"127.0.0.1", "127.0.0.2", "127.0.0.3"}) {
+		t.Errorf("unexpected requested ips %v", requested)
+	}
+}
+
+func TestAttributesProtoConvert(t *testing.T) {
+	hosts := map[string]*typesplugin.HostAttributes{
+		"127.0.0.1": {
+			Ip: "127.0.0.1",
+			Attributes: []*typesplugin.Attribute{
+				{Name: "ranges", Type: typesplugin.ValueRanges, Ranges: []typesplugin.Value_Ranges{{Begin: 1, End: 10}}},
+				{Name: "set", Type: typesplugin.ValueSet, Set: typesplugin.Value_Set{Item: []string{"a", "b"}}},
+				{Name: "text", Type: typesplugin.ValueText, Text: typesplugin.Value_Text{Text: "t"}},
+			},
+		},
+	}
+	converted := hostplugin.AttributesFromProto(hostplugin.AttributesToProto(hosts))
+	if !reflect.DeepEqual(hosts, converted) {
+		t.Errorf("expect %+v, got %+v", hosts["127.0.0.1"], converted["127.0.0.1"])
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/pluginManager/config"
 	bcsplugin "github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/pluginManager/plugin"
 	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/pluginManager/plugin/dynamicPlugin"
+	"github.com/Tencent/bk-bcs/bcs-mesos/bcs-scheduler/src/pluginManager/plugin/remotePlugin"
 )
 
 //PluginManager plugin manager
@@ -118,13 +119,17 @@ func (p *PluginManager) initPlugins() {
 		switch conf.Type {
 		case config.DynamicPluginType:
 			plugin, err = dynamicPlugin.NewDynamicPlugin(p.pluginDir, conf)
+		case config.GrpcPluginType:
+			plugin, err = remotePlugin.NewGrpcPlugin(conf)
+		case config.HttpPluginType:
+			plugin, err = remotePlugin.NewHttpPlugin(conf)
 
 		default:
 			err = fmt.Errorf("plugin type %s is invalid", conf.Type)
 		}
 
 		if err != nil {
-			blog.Errorf("NewDynamicPlugin error %s", err.Error())
+			blog.Errorf("new plugin %s type %s error %s", conf.Name, conf.Type, err.Error())
 			continue
 		}
 
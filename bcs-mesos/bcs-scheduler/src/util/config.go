@@ -50,6 +50,8 @@ type SchedulerOptions struct {
 	ProcessExecutor   string `json:"process_executor" value:"" usage:"the process executor path"`
 	CniDir            string `json:"cni_dir" value:"" usage:"the cni directory"`
 	NetImage          string `json:"net_image" value:"" usage:"the network image"`
+	ContainerRuntime  string `json:"container_runtime" value:"" usage:"container runtime of container executor, enum: docker, cri. default docker"`
+	CriEndpoint       string `json:"cri_endpoint" value:"" usage:"cri runtime endpoint of container executor, only for container_runtime cri"`
 	Kubeconfig        string `json:"kubeconfig" value:"" usage:"kubeconfig, when store_driver is etcd"`
 	StoreDriver       string `json:"store_driver" value:"zookeeper" usage:"the store driver, enum: zookeeper, etcd"`
 	DebugMode         bool   `json:"debug_mode" value:"false" usage:"Debug mode, use pprof."`
@@ -99,6 +101,8 @@ type Scheduler struct {
 	ProcessExecutor   string
 	CniDir            string
 	NetImage          string
+	ContainerRuntime  string
+	CriEndpoint       string
 
 	Kubeconfig  string
 	StoreDriver string
@@ -186,6 +190,8 @@ func SetSchedulerCfg(config *SchedConfig, op *SchedulerOptions) {
 	config.Scheduler.ProcessExecutor = op.ProcessExecutor
 	config.Scheduler.CniDir = op.CniDir
 	config.Scheduler.NetImage = op.NetImage
+	config.Scheduler.ContainerRuntime = op.ContainerRuntime
+	config.Scheduler.CriEndpoint = op.CriEndpoint
 
 	config.HttpListener.TCPAddr = op.ServiceConfig.Address + ":" + strconv.Itoa(int(op.Port))
 	//config.HttpListener.CertDir = op.ServerCertDir
@@ -651,6 +651,96 @@ func (in *BcsConfigMapStatus) DeepCopy() *BcsConfigMapStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsCronJob) DeepCopyInto(out *BcsCronJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsCronJob.
+func (in *BcsCronJob) DeepCopy() *BcsCronJob {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsCronJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BcsCronJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsCronJobList) DeepCopyInto(out *BcsCronJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BcsCronJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsCronJobList.
+func (in *BcsCronJobList) DeepCopy() *BcsCronJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsCronJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BcsCronJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsCronJobSpec) DeepCopyInto(out *BcsCronJobSpec) {
+	*out = *in
+	in.BcsCronJob.DeepCopyInto(&out.BcsCronJob)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsCronJobSpec.
+func (in *BcsCronJobSpec) DeepCopy() *BcsCronJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsCronJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsCronJobStatus) DeepCopyInto(out *BcsCronJobStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsCronJobStatus.
+func (in *BcsCronJobStatus) DeepCopy() *BcsCronJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsCronJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BcsDaemonset) DeepCopyInto(out *BcsDaemonset) {
 	*out = *in
@@ -741,6 +831,96 @@ func (in *BcsDaemonsetStatus) DeepCopy() *BcsDaemonsetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsDisruptionBudget) DeepCopyInto(out *BcsDisruptionBudget) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsDisruptionBudget.
+func (in *BcsDisruptionBudget) DeepCopy() *BcsDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BcsDisruptionBudget) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsDisruptionBudgetList) DeepCopyInto(out *BcsDisruptionBudgetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BcsDisruptionBudget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsDisruptionBudgetList.
+func (in *BcsDisruptionBudgetList) DeepCopy() *BcsDisruptionBudgetList {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsDisruptionBudgetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BcsDisruptionBudgetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsDisruptionBudgetSpec) DeepCopyInto(out *BcsDisruptionBudgetSpec) {
+	*out = *in
+	in.BcsDisruptionBudget.DeepCopyInto(&out.BcsDisruptionBudget)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsDisruptionBudgetSpec.
+func (in *BcsDisruptionBudgetSpec) DeepCopy() *BcsDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsDisruptionBudgetStatus) DeepCopyInto(out *BcsDisruptionBudgetStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsDisruptionBudgetStatus.
+func (in *BcsDisruptionBudgetStatus) DeepCopy() *BcsDisruptionBudgetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsDisruptionBudgetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BcsEndpoint) DeepCopyInto(out *BcsEndpoint) {
 	*out = *in
@@ -831,6 +1011,186 @@ func (in *BcsEndpointStatus) DeepCopy() *BcsEndpointStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsJob) DeepCopyInto(out *BcsJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsJob.
+func (in *BcsJob) DeepCopy() *BcsJob {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BcsJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsJobList) DeepCopyInto(out *BcsJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BcsJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsJobList.
+func (in *BcsJobList) DeepCopy() *BcsJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BcsJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsJobSpec) DeepCopyInto(out *BcsJobSpec) {
+	*out = *in
+	in.BcsJob.DeepCopyInto(&out.BcsJob)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsJobSpec.
+func (in *BcsJobSpec) DeepCopy() *BcsJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsJobStatus) DeepCopyInto(out *BcsJobStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsJobStatus.
+func (in *BcsJobStatus) DeepCopy() *BcsJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsNamespaceQuota) DeepCopyInto(out *BcsNamespaceQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsNamespaceQuota.
+func (in *BcsNamespaceQuota) DeepCopy() *BcsNamespaceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsNamespaceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BcsNamespaceQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsNamespaceQuotaList) DeepCopyInto(out *BcsNamespaceQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BcsNamespaceQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsNamespaceQuotaList.
+func (in *BcsNamespaceQuotaList) DeepCopy() *BcsNamespaceQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsNamespaceQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BcsNamespaceQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsNamespaceQuotaSpec) DeepCopyInto(out *BcsNamespaceQuotaSpec) {
+	*out = *in
+	in.BcsNamespaceQuota.DeepCopyInto(&out.BcsNamespaceQuota)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsNamespaceQuotaSpec.
+func (in *BcsNamespaceQuotaSpec) DeepCopy() *BcsNamespaceQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsNamespaceQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BcsNamespaceQuotaStatus) DeepCopyInto(out *BcsNamespaceQuotaStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BcsNamespaceQuotaStatus.
+func (in *BcsNamespaceQuotaStatus) DeepCopy() *BcsNamespaceQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BcsNamespaceQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BcsSecret) DeepCopyInto(out *BcsSecret) {
 	*out = *in
@@ -30,8 +30,12 @@ type BkbcsV2Interface interface {
 	BcsClusterAgentSettingsGetter
 	BcsCommandInfosGetter
 	BcsConfigMapsGetter
+	BcsCronJobsGetter
 	BcsDaemonsetsGetter
+	BcsDisruptionBudgetsGetter
 	BcsEndpointsGetter
+	BcsJobsGetter
+	BcsNamespaceQuotasGetter
 	BcsSecretsGetter
 	BcsServicesGetter
 	BcsTransactionsGetter
@@ -77,14 +81,30 @@ func (c *BkbcsV2Client) BcsConfigMaps(namespace string) BcsConfigMapInterface {
 	return newBcsConfigMaps(c, namespace)
 }
 
+func (c *BkbcsV2Client) BcsCronJobs(namespace string) BcsCronJobInterface {
+	return newBcsCronJobs(c, namespace)
+}
+
 func (c *BkbcsV2Client) BcsDaemonsets(namespace string) BcsDaemonsetInterface {
 	return newBcsDaemonsets(c, namespace)
 }
 
+func (c *BkbcsV2Client) BcsDisruptionBudgets(namespace string) BcsDisruptionBudgetInterface {
+	return newBcsDisruptionBudgets(c, namespace)
+}
+
 func (c *BkbcsV2Client) BcsEndpoints(namespace string) BcsEndpointInterface {
 	return newBcsEndpoints(c, namespace)
 }
 
+func (c *BkbcsV2Client) BcsJobs(namespace string) BcsJobInterface {
+	return newBcsJobs(c, namespace)
+}
+
+func (c *BkbcsV2Client) BcsNamespaceQuotas(namespace string) BcsNamespaceQuotaInterface {
+	return newBcsNamespaceQuotas(c, namespace)
+}
+
 func (c *BkbcsV2Client) BcsSecrets(namespace string) BcsSecretInterface {
 	return newBcsSecrets(c, namespace)
 }
@@ -53,14 +53,30 @@ func (c *FakeBkbcsV2) BcsConfigMaps(namespace string) v2.BcsConfigMapInterface {
 	return &FakeBcsConfigMaps{c, namespace}
 }
 
+func (c *FakeBkbcsV2) BcsCronJobs(namespace string) v2.BcsCronJobInterface {
+	return &FakeBcsCronJobs{c, namespace}
+}
+
 func (c *FakeBkbcsV2) BcsDaemonsets(namespace string) v2.BcsDaemonsetInterface {
 	return &FakeBcsDaemonsets{c, namespace}
 }
 
+func (c *FakeBkbcsV2) BcsDisruptionBudgets(namespace string) v2.BcsDisruptionBudgetInterface {
+	return &FakeBcsDisruptionBudgets{c, namespace}
+}
+
 func (c *FakeBkbcsV2) BcsEndpoints(namespace string) v2.BcsEndpointInterface {
 	return &FakeBcsEndpoints{c, namespace}
 }
 
+func (c *FakeBkbcsV2) BcsJobs(namespace string) v2.BcsJobInterface {
+	return &FakeBcsJobs{c, namespace}
+}
+
+func (c *FakeBkbcsV2) BcsNamespaceQuotas(namespace string) v2.BcsNamespaceQuotaInterface {
+	return &FakeBcsNamespaceQuotas{c, namespace}
+}
+
 func (c *FakeBkbcsV2) BcsSecrets(namespace string) v2.BcsSecretInterface {
 	return &FakeBcsSecrets{c, namespace}
 }
@@ -29,10 +29,18 @@ type BcsCommandInfoExpansion interface{}
 
 type BcsConfigMapExpansion interface{}
 
+type BcsCronJobExpansion interface{}
+
 type BcsDaemonsetExpansion interface{}
 
+type BcsDisruptionBudgetExpansion interface{}
+
 type BcsEndpointExpansion interface{}
 
+type BcsJobExpansion interface{}
+
+type BcsNamespaceQuotaExpansion interface{}
+
 type BcsSecretExpansion interface{}
 
 type BcsServiceExpansion interface{}
@@ -35,10 +35,18 @@ type Interface interface {
 	BcsCommandInfos() BcsCommandInfoInformer
 	// BcsConfigMaps returns a BcsConfigMapInformer.
 	BcsConfigMaps() BcsConfigMapInformer
+	// BcsCronJobs returns a BcsCronJobInformer.
+	BcsCronJobs() BcsCronJobInformer
 	// BcsDaemonsets returns a BcsDaemonsetInformer.
 	BcsDaemonsets() BcsDaemonsetInformer
+	// BcsDisruptionBudgets returns a BcsDisruptionBudgetInformer.
+	BcsDisruptionBudgets() BcsDisruptionBudgetInformer
 	// BcsEndpoints returns a BcsEndpointInformer.
 	BcsEndpoints() BcsEndpointInformer
+	// BcsJobs returns a BcsJobInformer.
+	BcsJobs() BcsJobInformer
+	// BcsNamespaceQuotas returns a BcsNamespaceQuotaInformer.
+	BcsNamespaceQuotas() BcsNamespaceQuotaInformer
 	// BcsSecrets returns a BcsSecretInformer.
 	BcsSecrets() BcsSecretInformer
 	// BcsServices returns a BcsServiceInformer.
@@ -107,16 +115,36 @@ func (v *version) BcsConfigMaps() BcsConfigMapInformer {
 	return &bcsConfigMapInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// BcsCronJobs returns a BcsCronJobInformer.
+func (v *version) BcsCronJobs() BcsCronJobInformer {
+	return &bcsCronJobInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // BcsDaemonsets returns a BcsDaemonsetInformer.
 func (v *version) BcsDaemonsets() BcsDaemonsetInformer {
 	return &bcsDaemonsetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// BcsDisruptionBudgets returns a BcsDisruptionBudgetInformer.
+func (v *version) BcsDisruptionBudgets() BcsDisruptionBudgetInformer {
+	return &bcsDisruptionBudgetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // BcsEndpoints returns a BcsEndpointInformer.
 func (v *version) BcsEndpoints() BcsEndpointInformer {
 	return &bcsEndpointInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// BcsJobs returns a BcsJobInformer.
+func (v *version) BcsJobs() BcsJobInformer {
+	return &bcsJobInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// BcsNamespaceQuotas returns a BcsNamespaceQuotaInformer.
+func (v *version) BcsNamespaceQuotas() BcsNamespaceQuotaInformer {
+	return &bcsNamespaceQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // BcsSecrets returns a BcsSecretInformer.
 func (v *version) BcsSecrets() BcsSecretInformer {
 	return &bcsSecretInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -65,10 +65,18 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Bkbcs().V2().BcsCommandInfos().Informer()}, nil
 	case v2.SchemeGroupVersion.WithResource("bcsconfigmaps"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Bkbcs().V2().BcsConfigMaps().Informer()}, nil
+	case v2.SchemeGroupVersion.WithResource("bcscronjobs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Bkbcs().V2().BcsCronJobs().Informer()}, nil
 	case v2.SchemeGroupVersion.WithResource("bcsdaemonsets"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Bkbcs().V2().BcsDaemonsets().Informer()}, nil
+	case v2.SchemeGroupVersion.WithResource("bcsdisruptionbudgets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Bkbcs().V2().BcsDisruptionBudgets().Informer()}, nil
 	case v2.SchemeGroupVersion.WithResource("bcsendpoints"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Bkbcs().V2().BcsEndpoints().Informer()}, nil
+	case v2.SchemeGroupVersion.WithResource("bcsjobs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Bkbcs().V2().BcsJobs().Informer()}, nil
+	case v2.SchemeGroupVersion.WithResource("bcsnamespacequotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Bkbcs().V2().BcsNamespaceQuotas().Informer()}, nil
 	case v2.SchemeGroupVersion.WithResource("bcssecrets"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Bkbcs().V2().BcsSecrets().Informer()}, nil
 	case v2.SchemeGroupVersion.WithResource("bcsservices"):
@@ -71,6 +71,14 @@ type BcsConfigMapListerExpansion interface{}
 // BcsConfigMapNamespaceLister.
 type BcsConfigMapNamespaceListerExpansion interface{}
 
+// BcsCronJobListerExpansion allows custom methods to be added to
+// BcsCronJobLister.
+type BcsCronJobListerExpansion interface{}
+
+// BcsCronJobNamespaceListerExpansion allows custom methods to be added to
+// BcsCronJobNamespaceLister.
+type BcsCronJobNamespaceListerExpansion interface{}
+
 // BcsDaemonsetListerExpansion allows custom methods to be added to
 // BcsDaemonsetLister.
 type BcsDaemonsetListerExpansion interface{}
@@ -79,6 +87,14 @@ type BcsDaemonsetListerExpansion interface{}
 // BcsDaemonsetNamespaceLister.
 type BcsDaemonsetNamespaceListerExpansion interface{}
 
+// BcsDisruptionBudgetListerExpansion allows custom methods to be added to
+// BcsDisruptionBudgetLister.
+type BcsDisruptionBudgetListerExpansion interface{}
+
+// BcsDisruptionBudgetNamespaceListerExpansion allows custom methods to be added to
+// BcsDisruptionBudgetNamespaceLister.
+type BcsDisruptionBudgetNamespaceListerExpansion interface{}
+
 // BcsEndpointListerExpansion allows custom methods to be added to
 // BcsEndpointLister.
 type BcsEndpointListerExpansion interface{}
@@ -87,6 +103,22 @@ type BcsEndpointListerExpansion interface{}
 // BcsEndpointNamespaceLister.
 type BcsEndpointNamespaceListerExpansion interface{}
 
+// BcsJobListerExpansion allows custom methods to be added to
+// BcsJobLister.
+type BcsJobListerExpansion interface{}
+
+// BcsJobNamespaceListerExpansion allows custom methods to be added to
+// BcsJobNamespaceLister.
+type BcsJobNamespaceListerExpansion interface{}
+
+// BcsNamespaceQuotaListerExpansion allows custom methods to be added to
+// BcsNamespaceQuotaLister.
+type BcsNamespaceQuotaListerExpansion interface{}
+
+// BcsNamespaceQuotaNamespaceListerExpansion allows custom methods to be added to
+// BcsNamespaceQuotaNamespaceLister.
+type BcsNamespaceQuotaNamespaceListerExpansion interface{}
+
 // BcsSecretListerExpansion allows custom methods to be added to
 // BcsSecretLister.
 type BcsSecretListerExpansion interface{}
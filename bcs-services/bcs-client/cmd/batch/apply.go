@@ -43,6 +43,8 @@ func NewApplyCommand() cli.Command {
 			or reading resource from file
 			> bcs-client apply -f myresource.json
 			> bcs-client apply -f anyyaml.yaml --format yaml
+			checking resources by bcs-scheduler and admission webhooks without saving
+			> bcs-client apply -f myresource.json --dry-run
 		`,
 		Flags: []cli.Flag{
 			cli.StringFlag{
@@ -58,6 +60,10 @@ func NewApplyCommand() cli.Command {
 				Usage: "resource format, like json or yaml",
 				Value: metastream.JSONFormat,
 			},
+			cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "only check resources by bcs-scheduler without saving, crd/permission/custom resources are not supported",
+			},
 		},
 		Action: func(c *cli.Context) error {
 			return apply(utils.NewClientContext(c))
@@ -76,13 +82,14 @@ type metaInfo struct {
 	namespace  string
 	name       string
 	rawJson    []byte
+	dryRun     bool
 }
 
-//apply multiple mesos json resources to bcs-scheduler
-func apply(cxt *utils.ClientContext) error {
+//readMetaStream reading json object from input(file or stdin)
+func readMetaStream(cxt *utils.ClientContext) (metastream.Stream, error) {
 	//step: check parameter from command line
 	if err := cxt.MustSpecified(utils.OptionClusterID); err != nil {
-		return err
+		return nil, err
 	}
 	var data []byte
 	var err error
@@ -93,16 +100,25 @@ func apply(cxt *utils.ClientContext) error {
 		data, err = cxt.FileData()
 	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(data) == 0 {
-		return fmt.Errorf("failed to apply: no available resource datas")
+		return nil, fmt.Errorf("no available resource datas")
 	}
-	//step: reading json object from input(file or stdin)
 	metaList := metastream.NewMetaStream(bytes.NewReader(data), cxt.String("format"))
 	if metaList.Length() == 0 {
-		return fmt.Errorf("failed to Apply: No correct format resource")
+		return nil, fmt.Errorf("no correct format resource")
+	}
+	return metaList, nil
+}
+
+//apply multiple mesos json resources to bcs-scheduler
+func apply(cxt *utils.ClientContext) error {
+	metaList, err := readMetaStream(cxt)
+	if err != nil {
+		return fmt.Errorf("failed to apply: %s", err.Error())
 	}
+	dryRun := cxt.Bool("dry-run")
 	//step: initialize storage client & scheduler client
 	storage := v1.NewBcsStorage(utils.GetClientOption())
 	scheduler := v4.NewBcsScheduler(utils.GetClientOption())
@@ -125,37 +141,46 @@ func apply(cxt *utils.ClientContext) error {
 		info.rawJson = metaList.GetRawJSON()
 		utils.DebugPrintf("debugInfo: %s\n", string(info.rawJson))
 		info.clusterID = cxt.ClusterID()
+		info.dryRun = dryRun
 		//step: inspect resource object from storage
 		//	if resource exist, update resource to bcs-scheduler, print object status from response
 		//	otherwise, create resources to bcs-scheduler and print object status from response
 		var inspectStatus error
 		var create createFunc
 		var update updateFunc
+		//resourceType of bcs-scheduler for dry run
+		var resourceType string
 		switch mesostype.BcsDataType(strings.ToLower(info.kind)) {
 		case mesostype.BcsDataType_APP:
 			_, inspectStatus = storage.InspectApplication(cxt.ClusterID(), info.namespace, info.name)
 			create = scheduler.CreateApplication
 			update = scheduler.UpdateApplication
+			resourceType = v4.BcsSchedulerResourceApplication
 		case mesostype.BcsDataType_PROCESS:
 			_, inspectStatus = storage.InspectProcess(cxt.ClusterID(), info.namespace, info.name)
 			create = scheduler.CreateProcess
 			update = scheduler.UpdateProcess
+			resourceType = v4.BcsSchedulerResourceProcess
 		case mesostype.BcsDataType_SECRET:
 			_, inspectStatus = storage.InspectSecret(cxt.ClusterID(), info.namespace, info.name)
 			create = scheduler.CreateSecret
 			update = scheduler.UpdateSecret
+			resourceType = v4.BcsSchedulerResourceSecret
 		case mesostype.BcsDataType_CONFIGMAP:
 			_, inspectStatus = storage.InspectConfigMap(cxt.ClusterID(), info.namespace, info.name)
 			create = scheduler.CreateConfigMap
 			update = scheduler.UpdateConfigMap
+			resourceType = v4.BcsSchedulerResourceConfigMap
 		case mesostype.BcsDataType_SERVICE:
 			_, inspectStatus = storage.InspectService(cxt.ClusterID(), info.namespace, info.name)
 			create = scheduler.CreateService
 			update = scheduler.UpdateService
+			resourceType = v4.BcsSchedulerResourceService
 		case mesostype.BcsDataType_DEPLOYMENT:
 			_, inspectStatus = storage.InspectDeployment(cxt.ClusterID(), info.namespace, info.name)
 			create = scheduler.CreateDeployment
 			update = scheduler.UpdateDeployment
+			resourceType = v4.BcsSchedulerResourceDeployment
 		case mesostype.BcsDataType_CRD:
 			_, inspectStatus = scheduler.GetCustomResourceDefinition(cxt.ClusterID(), info.name)
 			create = func(cluster string, ns string, data []byte) error {
@@ -184,6 +209,18 @@ func apply(cxt *utils.ClientContext) error {
 				return scheduler.UpdateCustomResource(cluster, crdapiVersion, plural, ns, info.name, data)
 			}
 		}
+		if dryRun {
+			if len(resourceType) == 0 {
+				fmt.Printf("resource %s/%s %s dry run is not supported, skip...\n", info.apiVersion, info.kind, info.name)
+				continue
+			}
+			create = func(cluster string, ns string, data []byte) error {
+				return scheduler.DryRunCreate(cluster, ns, resourceType, data)
+			}
+			update = func(cluster, ns string, data []byte, urlv url.Values) error {
+				return scheduler.DryRunUpdate(cluster, ns, resourceType, data, urlv)
+			}
+		}
 		applySpecifiedResource(inspectStatus, create, update, &info)
 	}
 	return nil
@@ -195,12 +232,16 @@ func isObjectNotExist(err error) bool {
 }
 
 func applySpecifiedResource(inspectStatus error, create createFunc, update updateFunc, info *metaInfo) {
+	suffix := ""
+	if info.dryRun {
+		suffix = " (dry run)"
+	}
 	if inspectStatus == nil {
 		//update object
 		if err := update(info.clusterID, info.namespace, info.rawJson, nil); err != nil {
-			fmt.Printf("resource %s/%s %s update successfully\n", info.apiVersion, info.kind, info.name)
+			fmt.Printf("resource %s/%s %s update failed%s, %s\n", info.apiVersion, info.kind, info.name, suffix, err.Error())
 		} else {
-			fmt.Printf("resource %s/%s %s update failed, %s\n", info.apiVersion, info.kind, info.name, err.Error())
+			fmt.Printf("resource %s/%s %s update successfully%s\n", info.apiVersion, info.kind, info.name, suffix)
 		}
 		return
 	}
@@ -211,8 +252,8 @@ func applySpecifiedResource(inspectStatus error, create createFunc, update updat
 	}
 	//create
 	if err := create(info.clusterID, info.namespace, info.rawJson); err != nil {
-		fmt.Printf("resource %s/%s %s create failed, %s\n", info.apiVersion, info.kind, info.name, err.Error())
+		fmt.Printf("resource %s/%s %s create failed%s, %s\n", info.apiVersion, info.kind, info.name, suffix, err.Error())
 	} else {
-		fmt.Printf("resource %s/%s %s create successfully\n", info.apiVersion, info.kind, info.name)
+		fmt.Printf("resource %s/%s %s create successfully%s\n", info.apiVersion, info.kind, info.name, suffix)
 	}
 }
@@ -0,0 +1,309 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	mesostype "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/utils"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/pkg/metastream"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/pkg/scheduler/v4"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/pkg/storage/v1"
+
+	"github.com/urfave/cli"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+)
+
+const (
+	diffAdd    = "+"
+	diffRemove = "-"
+	diffChange = "~"
+)
+
+//NewDiffCommand sub command diff registration
+func NewDiffCommand() cli.Command {
+	return cli.Command{
+		Name:  "diff",
+		Usage: "show differences between resources in file and live resources in cluster, like application/deployment/service/configmap/secret",
+		UsageText: `
+		example:
+			> bcs-client diff -f myresource.json
+			> bcs-client diff -f anyyaml.yaml --format yaml
+		output:
+			+ field only in file, it will be added by apply
+			- field only in cluster, it will be removed by apply
+			~ field changed, old value in cluster => new value in file
+		`,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "from-file, f",
+				Usage: "reading with configuration `FILE`",
+			},
+			cli.StringFlag{
+				Name:  "clusterid",
+				Usage: "Cluster ID",
+			},
+			cli.StringFlag{
+				Name:  "format",
+				Usage: "resource format, like json or yaml",
+				Value: metastream.JSONFormat,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return diff(utils.NewClientContext(c))
+		},
+	}
+}
+
+// fieldDiff difference of one field between live resource and resource in file
+type fieldDiff struct {
+	path     string
+	op       string
+	oldValue interface{}
+	newValue interface{}
+}
+
+//diff multiple mesos json resources with live resources in bcs-scheduler
+func diff(cxt *utils.ClientContext) error {
+	metaList, err := readMetaStream(cxt)
+	if err != nil {
+		return fmt.Errorf("failed to diff: %s", err.Error())
+	}
+	storage := v1.NewBcsStorage(utils.GetClientOption())
+	scheduler := v4.NewBcsScheduler(utils.GetClientOption())
+
+	for metaList.HasNext() {
+		info := metaInfo{}
+		info.apiVersion, info.kind, err = metaList.GetResourceKind()
+		if err != nil {
+			fmt.Printf("diff partial failed, %s, continue...\n", err.Error())
+			continue
+		}
+		info.namespace, info.name, err = metaList.GetResourceKey()
+		if err != nil {
+			fmt.Printf("diff partial failed, %s, continue...\n", err.Error())
+			continue
+		}
+		info.rawJson = metaList.GetRawJSON()
+		info.clusterID = cxt.ClusterID()
+
+		live, desired, err := getLiveResource(storage, scheduler, &info)
+		if err != nil {
+			if isObjectNotExist(err) {
+				fmt.Printf("resource %s/%s %s does not exist, it will be created\n", info.apiVersion, info.kind, info.name)
+			} else {
+				fmt.Printf("resource %s/%s %s diff failed, %s\n", info.apiVersion, info.kind, info.name, err.Error())
+			}
+			continue
+		}
+		diffs, err := diffResource(live, desired, info.rawJson)
+		if err != nil {
+			fmt.Printf("resource %s/%s %s diff failed, %s\n", info.apiVersion, info.kind, info.name, err.Error())
+			continue
+		}
+		printResourceDiff(&info, diffs)
+	}
+	return nil
+}
+
+//getLiveResource get live resource from cluster, and empty object of the same type for resource in file
+func getLiveResource(storage v1.Storage, scheduler v4.Scheduler, info *metaInfo) (interface{}, interface{}, error) {
+	switch mesostype.BcsDataType(strings.ToLower(info.kind)) {
+	case mesostype.BcsDataType_APP:
+		if _, err := storage.InspectApplication(info.clusterID, info.namespace, info.name); err != nil {
+			return nil, nil, err
+		}
+		live, err := scheduler.GetApplicationDefinition(info.clusterID, info.namespace, info.name)
+		return live, &mesostype.ReplicaController{}, err
+	case mesostype.BcsDataType_PROCESS:
+		if _, err := storage.InspectProcess(info.clusterID, info.namespace, info.name); err != nil {
+			return nil, nil, err
+		}
+		live, err := scheduler.GetProcessDefinition(info.clusterID, info.namespace, info.name)
+		return live, &mesostype.ReplicaController{}, err
+	case mesostype.BcsDataType_DEPLOYMENT:
+		if _, err := storage.InspectDeployment(info.clusterID, info.namespace, info.name); err != nil {
+			return nil, nil, err
+		}
+		live, err := scheduler.GetDeploymentDefinition(info.clusterID, info.namespace, info.name)
+		return live, &mesostype.BcsDeployment{}, err
+	case mesostype.BcsDataType_SECRET:
+		live, err := storage.InspectSecret(info.clusterID, info.namespace, info.name)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &live.Data, &mesostype.BcsSecret{}, nil
+	case mesostype.BcsDataType_CONFIGMAP:
+		live, err := storage.InspectConfigMap(info.clusterID, info.namespace, info.name)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &live.Data, &mesostype.BcsConfigMap{}, nil
+	case mesostype.BcsDataType_SERVICE:
+		live, err := storage.InspectService(info.clusterID, info.namespace, info.name)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &live.Data, &mesostype.BcsService{}, nil
+	case mesostype.BcsDataType_CRD:
+		live, err := scheduler.GetCustomResourceDefinition(info.clusterID, info.name)
+		return live, &v1beta1.CustomResourceDefinition{}, err
+	case mesostype.BcsDataType_PERMISSION:
+		return nil, nil, fmt.Errorf("diff of permission is not supported")
+	default:
+		//unkown type, try custom resource
+		crdapiVersion, plural, err := utils.GetCustomResourceTypeByKind(scheduler, info.clusterID, info.kind)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := scheduler.GetCustomResource(info.clusterID, crdapiVersion, plural, info.namespace, info.name)
+		if err != nil {
+			return nil, nil, err
+		}
+		live := make(map[string]interface{})
+		if err := json.Unmarshal(data, &live); err != nil {
+			return nil, nil, fmt.Errorf("decode custom resource failed, %s", err.Error())
+		}
+		return live, &map[string]interface{}{}, nil
+	}
+}
+
+//diffResource decodes rawJson into desired object, so that live and desired resource are compared by the same type
+func diffResource(live, desired interface{}, rawJson []byte) ([]fieldDiff, error) {
+	if err := json.Unmarshal(rawJson, desired); err != nil {
+		return nil, fmt.Errorf("decode resource in file failed, %s", err.Error())
+	}
+	liveObj, err := normalizeObject(live)
+	if err != nil {
+		return nil, err
+	}
+	desiredObj, err := normalizeObject(desired)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []fieldDiff
+	diffObject("", liveObj, desiredObj, &diffs)
+	return diffs, nil
+}
+
+//normalizeObject converts object into generic json value, fields maintained by server
+//like status and metadata.resourceVersion are dropped
+func normalizeObject(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("encode resource failed, %s", err.Error())
+	}
+	var result interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("decode resource failed, %s", err.Error())
+	}
+	if m, ok := result.(map[string]interface{}); ok {
+		delete(m, "status")
+		if meta, ok := m["metadata"].(map[string]interface{}); ok {
+			for _, key := range []string{"resourceVersion", "uid", "selfLink", "generation", "creationTimestamp"} {
+				delete(meta, key)
+			}
+		}
+	}
+	return result, nil
+}
+
+//diffObject compares json values recursively, map keys are compared in order
+func diffObject(path string, live, desired interface{}, diffs *[]fieldDiff) {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveValue, ok := live.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(liveValue)+len(desiredValue))
+		for key := range liveValue {
+			keys = append(keys, key)
+		}
+		for key := range desiredValue {
+			if _, ok := liveValue[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childPath := key
+			if len(path) != 0 {
+				childPath = path + "." + key
+			}
+			liveChild, inLive := liveValue[key]
+			desiredChild, inDesired := desiredValue[key]
+			switch {
+			case !inLive:
+				*diffs = append(*diffs, fieldDiff{path: childPath, op: diffAdd, newValue: desiredChild})
+			case !inDesired:
+				*diffs = append(*diffs, fieldDiff{path: childPath, op: diffRemove, oldValue: liveChild})
+			default:
+				diffObject(childPath, liveChild, desiredChild, diffs)
+			}
+		}
+		return
+	case []interface{}:
+		liveValue, ok := live.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(liveValue) || i < len(desiredValue); i++ {
+			childPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(liveValue):
+				*diffs = append(*diffs, fieldDiff{path: childPath, op: diffAdd, newValue: desiredValue[i]})
+			case i >= len(desiredValue):
+				*diffs = append(*diffs, fieldDiff{path: childPath, op: diffRemove, oldValue: liveValue[i]})
+			default:
+				diffObject(childPath, liveValue[i], desiredValue[i], diffs)
+			}
+		}
+		return
+	}
+	if !reflect.DeepEqual(live, desired) {
+		*diffs = append(*diffs, fieldDiff{path: path, op: diffChange, oldValue: live, newValue: desired})
+	}
+}
+
+func printResourceDiff(info *metaInfo, diffs []fieldDiff) {
+	if len(diffs) == 0 {
+		fmt.Printf("resource %s/%s %s no changes\n", info.apiVersion, info.kind, info.name)
+		return
+	}
+	fmt.Printf("resource %s/%s %s has %d changes:\n", info.apiVersion, info.kind, info.name, len(diffs))
+	for _, d := range diffs {
+		switch d.op {
+		case diffAdd:
+			fmt.Printf("  %s %s: %s\n", d.op, d.path, diffValueString(d.newValue))
+		case diffRemove:
+			fmt.Printf("  %s %s: %s\n", d.op, d.path, diffValueString(d.oldValue))
+		default:
+			fmt.Printf("  %s %s: %s => %s\n", d.op, d.path, diffValueString(d.oldValue), diffValueString(d.newValue))
+		}
+	}
+}
+
+func diffValueString(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
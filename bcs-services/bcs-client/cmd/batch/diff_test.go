@@ -0,0 +1,77 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package batch
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestDiffResource test field differences between live resource and resource in file
+func TestDiffResource(t *testing.T) {
+	live := map[string]interface{}{
+		"kind": "deployment",
+		"metadata": map[string]interface{}{
+			"name":            "web",
+			"resourceVersion": "100",
+			"labels":          map[string]interface{}{"app": "web", "old": "true"},
+		},
+		"spec": map[string]interface{}{
+			"instance":   2,
+			"containers": []interface{}{"nginx:1.0"},
+		},
+		"status": map[string]interface{}{"ready": 2},
+	}
+	rawJson := []byte(`{
+		"kind": "deployment",
+		"metadata": {"name": "web", "labels": {"app": "web", "new": "true"}},
+		"spec": {"instance": 3, "containers": ["nginx:1.1", "sidecar:1.0"]}
+	}`)
+
+	diffs, err := diffResource(live, &map[string]interface{}{}, rawJson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expects := []string{
+		`+ metadata.labels.new: <nil> => "true"`,
+		`- metadata.labels.old: "true" => <nil>`,
+		`~ spec.containers[0]: "nginx:1.0" => "nginx:1.1"`,
+		`+ spec.containers[1]: <nil> => "sidecar:1.0"`,
+		`~ spec.instance: 2 => 3`,
+	}
+	if len(diffs) != len(expects) {
+		t.Fatalf("expect %d diffs, got %+v", len(expects), diffs)
+	}
+	for i, d := range diffs {
+		oldValue, newValue := "<nil>", "<nil>"
+		if d.op != diffAdd {
+			oldValue = diffValueString(d.oldValue)
+		}
+		if d.op != diffRemove {
+			newValue = diffValueString(d.newValue)
+		}
+		got := fmt.Sprintf("%s %s: %s => %s", d.op, d.path, oldValue, newValue)
+		if got != expects[i] {
+			t.Errorf("diff %d expect %s, got %s", i, expects[i], got)
+		}
+	}
+
+	diffs, err = diffResource(live, &map[string]interface{}{}, []byte(`{
+		"kind": "deployment",
+		"metadata": {"name": "web", "labels": {"app": "web", "old": "true"}},
+		"spec": {"instance": 2, "containers": ["nginx:1.0"]}
+	}`))
+	if err != nil || len(diffs) != 0 {
+		t.Errorf("expect no changes, got %+v, err %v", diffs, err)
+	}
+}
@@ -58,6 +58,7 @@ func NewGetCommand() cli.Command {
 				Name:  "resourcetype",
 				Usage: "resource type, value can be cluster/storage/network-detection...",
 			},
+			utils.OutputFlag(),
 		},
 		Action: func(c *cli.Context) error {
 			if err := get(utils.NewClientContext(c)); err != nil {
@@ -104,7 +105,7 @@ func getApplication(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to get application definition: %v", err)
 	}
 
-	return printGet(result)
+	return printGet(c, result)
 }
 
 func getProcess(c *utils.ClientContext) error {
@@ -118,7 +119,7 @@ func getProcess(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to get process definition: %v", err)
 	}
 
-	return printGet(result)
+	return printGet(c, result)
 }
 
 func getDeployment(c *utils.ClientContext) error {
@@ -132,7 +133,7 @@ func getDeployment(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to get deployment definition: %v", err)
 	}
 
-	return printGet(result)
+	return printGet(c, result)
 }
 
 func getIPPoolStatic(c *utils.ClientContext) error {
@@ -151,7 +152,7 @@ func getIPPoolStatic(c *utils.ClientContext) error {
 		fmt.Println("Resource Not Found.")
 		return nil
 	}
-	return printGet(result[0].Data)
+	return printGet(c, result[0].Data)
 }
 
 func getIPPoolStaticDetail(c *utils.ClientContext) error {
@@ -170,7 +171,7 @@ func getIPPoolStaticDetail(c *utils.ClientContext) error {
 		fmt.Println("Resource Not Found.")
 		return nil
 	}
-	return printGet(result[0].Data)
+	return printGet(c, result[0].Data)
 }
 
 func getUser(c *utils.ClientContext) error {
@@ -184,10 +185,13 @@ func getUser(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to create user: %v", err)
 	}
 
-	return printGet(user)
+	return printGet(c, user)
 }
 
-func printGet(single interface{}) error {
-	fmt.Printf("%s\n", utils.TryIndent(single))
-	return nil
+func printGet(c *utils.ClientContext, single interface{}) error {
+	printer, err := utils.NewResourcePrinter(c)
+	if err != nil {
+		return err
+	}
+	return printer.PrintSingle(single)
 }
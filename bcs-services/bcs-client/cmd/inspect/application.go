@@ -31,5 +31,5 @@ func inspectApplication(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to inspect application: %v", err)
 	}
 
-	return printInspect(single)
+	return printInspect(c, single)
 }
@@ -31,5 +31,5 @@ func inspectConfigMap(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to inspect configmap: %v", err)
 	}
 
-	return printInspect(single)
+	return printInspect(c, single)
 }
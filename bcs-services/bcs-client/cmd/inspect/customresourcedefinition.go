@@ -30,7 +30,7 @@ func inspectCustomResourceDefinition(c *utils.ClientContext) error {
 	if err != nil {
 		return fmt.Errorf("failed to Get CustomResourceDefinition: %s", err.Error())
 	}
-	return printInspect(crd)
+	return printInspect(c, crd)
 }
 
 func inspectCustomResource(c *utils.ClientContext) error {
@@ -50,6 +50,13 @@ func inspectCustomResource(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to Get %s: %v", plural, err)
 	}
 	utils.DebugPrintf("original CustomResource: %s", string(crd))
+	if len(c.String(utils.OptionOutput)) != 0 {
+		var single interface{}
+		if err := json.Unmarshal(crd, &single); err != nil {
+			return fmt.Errorf("decode CustomResource failed, %s", err.Error())
+		}
+		return printInspect(c, single)
+	}
 	var buffer bytes.Buffer
 	if err := json.Indent(&buffer, crd, "", "  "); err != nil {
 		return fmt.Errorf("pretty print CustomResource failed, %s", err.Error())
@@ -31,5 +31,5 @@ func inspectDeployment(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to inspect deployment: %v", err)
 	}
 
-	return printInspect(single)
+	return printInspect(c, single)
 }
@@ -31,5 +31,5 @@ func inspectEndpoint(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to inspect endpoint: %v", err)
 	}
 
-	return printInspect(single)
+	return printInspect(c, single)
 }
@@ -14,8 +14,6 @@
 package inspect
 
 import (
-	"fmt"
-
 	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/utils"
 
 	"github.com/urfave/cli"
@@ -44,6 +42,7 @@ func NewInspectCommand() cli.Command {
 				Name:  "name, n",
 				Usage: "Inspect name according to type",
 			},
+			utils.OutputFlag(),
 		},
 		Action: func(c *cli.Context) error {
 			return inspect(utils.NewClientContext(c))
@@ -85,7 +84,10 @@ func inspect(c *utils.ClientContext) error {
 	}
 }
 
-func printInspect(single interface{}) error {
-	fmt.Printf("%s\n", utils.TryIndent(single))
-	return nil
+func printInspect(c *utils.ClientContext, single interface{}) error {
+	printer, err := utils.NewResourcePrinter(c)
+	if err != nil {
+		return err
+	}
+	return printer.PrintSingle(single)
 }
@@ -36,5 +36,5 @@ func inspectMeshCluster(c *utils.ClientContext) error {
 	if len(resp.MeshClusters) == 0 {
 		return fmt.Errorf("Not found cluster(%s) meshcluster", c.ClusterID())
 	}
-	return printInspect(resp.MeshClusters[0])
+	return printInspect(c, resp.MeshClusters[0])
 }
@@ -31,5 +31,5 @@ func inspectProcess(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to inspect process: %v", err)
 	}
 
-	return printInspect(single)
+	return printInspect(c, single)
 }
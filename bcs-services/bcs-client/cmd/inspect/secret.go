@@ -31,5 +31,5 @@ func inspectSecret(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to inspect secret: %v", err)
 	}
 
-	return printInspect(single)
+	return printInspect(c, single)
 }
@@ -31,5 +31,5 @@ func inspectService(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to inspect service: %v", err)
 	}
 
-	return printInspect(single)
+	return printInspect(c, single)
 }
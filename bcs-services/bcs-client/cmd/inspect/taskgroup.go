@@ -31,5 +31,5 @@ func inspectTaskGroup(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to inspect taskgroup: %v", err)
 	}
 
-	return printInspect(single)
+	return printInspect(c, single)
 }
@@ -33,13 +33,25 @@ func listAgent(c *utils.ClientContext) error {
 
 	ipList := utils.GetIPList(c.String(utils.OptionIP))
 
+	printer, err := utils.NewResourcePrinter(c)
+	if err != nil {
+		return err
+	}
+
 	scheduler := v4.NewBcsScheduler(utils.GetClientOption())
 	list, err := scheduler.ListAgentInfo(c.ClusterID(), ipList)
 	if err != nil {
 		return fmt.Errorf("failed to list agent: %v", err)
 	}
 
-	return printListAgent(list)
+	filtered, err := printer.Filter(list)
+	if err != nil {
+		return err
+	}
+	list = filtered.([]*commonTypes.BcsClusterAgentInfo)
+	return printer.PrintList(list, func() error {
+		return printListAgent(list)
+	})
 }
 
 func printListAgent(list []*commonTypes.BcsClusterAgentInfo) error {
@@ -14,12 +14,13 @@
 package list
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/utils"
 	v4 "github.com/Tencent/bk-bcs/bcs-services/bcs-client/pkg/scheduler/v4"
 
-	simplejson "github.com/bitly/go-simplejson"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 )
 
 //listCustomResourceDefinition list all CRDs from mesos-driver
@@ -27,31 +28,48 @@ func listCustomResourceDefinition(c *utils.ClientContext) error {
 	if err := c.MustSpecified(utils.OptionClusterID); err != nil {
 		return err
 	}
+	printer, err := utils.NewResourcePrinter(c)
+	if err != nil {
+		return err
+	}
 	scheduler := v4.NewBcsScheduler(utils.GetClientOption())
 	crdList, err := scheduler.ListCustomResourceDefinition(c.ClusterID())
 	if err != nil {
 		return fmt.Errorf("failed to List all CustomResourceDefinition: %v", err)
 	}
+	filtered, err := printer.Filter(crdList.Items)
+	if err != nil {
+		return err
+	}
+	items := filtered.([]v1beta1.CustomResourceDefinition)
+	return printer.PrintList(items, func() error {
+		return printListCustomResourceDefinition(items, printer.IsWide())
+	})
+}
+
+func printListCustomResourceDefinition(items []v1beta1.CustomResourceDefinition, wide bool) error {
 	//print all datas
 	//Name - ShortName - apiVersion - Kind - CreatedTime
 	fmt.Printf(
-		"%-50s %-20s %-25s %-20s %-21s\n",
+		"%-50s %-20s %-25s %-20s %-21s",
 		"NAME",
 		"CMDTYPE",
 		"APIVERSION",
 		"KIND",
 		"CRAETEDTIME",
 	)
-	for _, item := range crdList.Items {
+	printLineEnd(wide, "LABELS")
+	for _, item := range items {
 		apiVersion := item.Spec.Group + "/" + item.Spec.Version
 		fmt.Printf(
-			"%-50s %-20s %-25s %-20s %-21s\n",
+			"%-50s %-20s %-25s %-20s %-21s",
 			item.GetName(),
 			item.Spec.Names.Singular,
 			apiVersion,
 			item.Spec.Names.Kind,
 			item.GetCreationTimestamp(),
 		)
+		printLineEnd(wide, utils.FormatLabels(item.GetLabels()))
 	}
 	return nil
 }
@@ -68,6 +86,10 @@ func listCustomResource(c *utils.ClientContext) error {
 	if allNamespaces {
 		namespace = v4.AllNamespace
 	}
+	printer, err := utils.NewResourcePrinter(c)
+	if err != nil {
+		return err
+	}
 	scheduler := v4.NewBcsScheduler(utils.GetClientOption())
 	//validate command line option type
 	apiVersion, plural, err := utils.GetCustomResourceType(scheduler, c.ClusterID(), c.String(utils.OptionType))
@@ -79,38 +101,55 @@ func listCustomResource(c *utils.ClientContext) error {
 		return fmt.Errorf("failed to List %s, %v", plural, err)
 	}
 	//parse item list formation
-	json, err := simplejson.NewJson(allBytes)
-	if err != nil {
+	resp := make(map[string]interface{})
+	if err := json.Unmarshal(allBytes, &resp); err != nil {
 		return fmt.Errorf("list %s failed, response is not expected json format: %s", plural, err.Error())
 	}
-	items := json.Get("items")
-	dataList, err := items.Array()
+	dataList, ok := resp["items"].([]interface{})
+	if !ok {
+		return fmt.Errorf("list %s failed, No items array response", plural)
+	}
+	filtered, err := printer.Filter(dataList)
 	if err != nil {
-		return fmt.Errorf("list %s failed, No items array response, %s", plural, err.Error())
+		return err
 	}
-	len := len(dataList)
-	if len == 0 {
+	dataList = filtered.([]interface{})
+	return printer.PrintList(dataList, func() error {
+		return printListCustomResource(dataList, printer.IsWide())
+	})
+}
+
+func printListCustomResource(dataList []interface{}, wide bool) error {
+	if len(dataList) == 0 {
 		fmt.Printf("Found No Resources\n")
 		return nil
 	}
 	//print simple information
 	fmt.Printf(
-		"%-30s %-20s %-25s\n",
+		"%-30s %-20s %-25s",
 		"NAME",
 		"NAMESPACE",
 		"CRAETEDTIME",
 	)
-	for i := 0; i < len; i++ {
-		meta := items.GetIndex(i).Get("metadata")
-		destName, _ := meta.Get("name").String()
-		destNS, _ := meta.Get("namespace").String()
-		createdTime, _ := meta.Get("creationTimestamp").String()
+	printLineEnd(wide, "LABELS")
+	for _, data := range dataList {
+		item, _ := data.(map[string]interface{})
+		meta, _ := item["metadata"].(map[string]interface{})
+		destName, _ := meta["name"].(string)
+		destNS, _ := meta["namespace"].(string)
+		createdTime, _ := meta["creationTimestamp"].(string)
 		fmt.Printf(
-			"%-30s %-20s %-25s\n",
+			"%-30s %-20s %-25s",
 			destName,
 			destNS,
 			createdTime,
 		)
+		labels := make(map[string]string)
+		metaLabels, _ := meta["labels"].(map[string]interface{})
+		for k, v := range metaLabels {
+			labels[k] = fmt.Sprintf("%v", v)
+		}
+		printLineEnd(wide, utils.FormatLabels(labels))
 	}
 	return nil
 }
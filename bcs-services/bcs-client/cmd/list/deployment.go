@@ -27,6 +27,11 @@ func listDeployment(c *utils.ClientContext) error {
 		return err
 	}
 
+	printer, err := utils.NewResourcePrinter(c)
+	if err != nil {
+		return err
+	}
+
 	storage := v1.NewBcsStorage(utils.GetClientOption())
 
 	// get namespace
@@ -34,7 +39,6 @@ func listDeployment(c *utils.ClientContext) error {
 	condition.Add(filterNamespaceTag, c.Namespace())
 
 	if c.IsAllNamespace() {
-		var err error
 		if condition, err = getNamespaceFilter(storage, c.ClusterID()); err != nil {
 			return err
 		}
@@ -46,21 +50,29 @@ func listDeployment(c *utils.ClientContext) error {
 	}
 
 	sort.Sort(list)
-	return printListDeployment(list)
+	filtered, err := printer.Filter(list)
+	if err != nil {
+		return err
+	}
+	list = filtered.(v1.DeploymentList)
+	return printer.PrintList(list, func() error {
+		return printListDeployment(list, printer.IsWide())
+	})
 }
 
-func printListDeployment(list v1.DeploymentList) error {
+func printListDeployment(list v1.DeploymentList, wide bool) error {
 	if len(list) == 0 {
 		fmt.Printf("Found no deployment\n")
 		return nil
 	}
 
-	fmt.Printf("%-50s  %-15s  %-30s  %-30s %-30s\n",
+	fmt.Printf("%-50s  %-15s  %-30s  %-30s %-30s",
 		"NAME",
 		"STATUS",
 		"NAMESPACE",
 		"APP_NAME",
 		"APPEXT_NAME")
+	printLineEnd(wide, "LABELS")
 	for _, status := range list {
 		appName := ""
 		appExtName := ""
@@ -70,12 +82,13 @@ func printListDeployment(list v1.DeploymentList) error {
 		if status.Data.ApplicationExt != nil {
 			appExtName = status.Data.ApplicationExt.ApplicationName
 		}
-		fmt.Printf("%-50s  %-15s  %-30s  %-30s %-30s\n",
+		fmt.Printf("%-50s  %-15s  %-30s  %-30s %-30s",
 			status.Data.ObjectMeta.Name,
 			status.Data.Status,
 			status.Data.ObjectMeta.NameSpace,
 			appName,
 			appExtName)
+		printLineEnd(wide, utils.FormatLabels(status.Data.ObjectMeta.Labels))
 	}
 	return nil
 }
@@ -27,6 +27,11 @@ func listEndpoint(c *utils.ClientContext) error {
 		return err
 	}
 
+	printer, err := utils.NewResourcePrinter(c)
+	if err != nil {
+		return err
+	}
+
 	storage := v1.NewBcsStorage(utils.GetClientOption())
 
 	// get namespace
@@ -34,7 +39,6 @@ func listEndpoint(c *utils.ClientContext) error {
 	condition.Add(filterNamespaceTag, c.Namespace())
 
 	if c.IsAllNamespace() {
-		var err error
 		if condition, err = getNamespaceFilter(storage, c.ClusterID()); err != nil {
 			return err
 		}
@@ -46,22 +50,31 @@ func listEndpoint(c *utils.ClientContext) error {
 	}
 
 	sort.Sort(list)
-	return printListEndpoint(list)
+	filtered, err := printer.Filter(list)
+	if err != nil {
+		return err
+	}
+	list = filtered.(v1.EndpointList)
+	return printer.PrintList(list, func() error {
+		return printListEndpoint(list, printer.IsWide())
+	})
 }
 
-func printListEndpoint(list v1.EndpointList) error {
+func printListEndpoint(list v1.EndpointList, wide bool) error {
 	if len(list) == 0 {
 		fmt.Printf("Found no endpoint\n")
 		return nil
 	}
 
-	fmt.Printf("%-50s  %-30s\n",
+	fmt.Printf("%-50s  %-30s",
 		"NAME",
 		"NAMESPACE")
+	printLineEnd(wide, "LABELS")
 	for _, status := range list {
-		fmt.Printf("%-50s %-30s\n",
+		fmt.Printf("%-50s %-30s",
 			status.Data.ObjectMeta.Name,
 			status.Data.ObjectMeta.NameSpace)
+		printLineEnd(wide, utils.FormatLabels(status.Data.ObjectMeta.Labels))
 	}
 	return nil
 }
@@ -14,6 +14,8 @@
 package list
 
 import (
+	"fmt"
+
 	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/utils"
 
 	"github.com/urfave/cli"
@@ -24,7 +26,7 @@ func NewListCommand() cli.Command {
 	return cli.Command{
 		Name:  "list",
 		Usage: "list brief information of application, taskgroup, agent, cluster, customresource, meshcluster and etc.",
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			cli.StringFlag{
 				Name:  "type, t",
 				Usage: "List type, ns/application(app)/process/taskgroup/service/configmap/secret/deployment/endpoint/agent/customresourcedefintion(crd)/meshcluster/logcollectiontask",
@@ -46,7 +48,8 @@ func NewListCommand() cli.Command {
 				Name:  "ip",
 				Usage: "The ip of taskgroup. Split by ,",
 			},
-		},
+			utils.OutputFlag(),
+		}, utils.SelectorFlags()...),
 		Action: func(c *cli.Context) error {
 			return list(utils.NewClientContext(c))
 		},
@@ -96,3 +99,11 @@ func list(c *utils.ClientContext) error {
 const (
 	filterNamespaceTag = "namespace"
 )
+
+//printLineEnd ends the line of table, labels column is appended in wide output
+func printLineEnd(wide bool, labels string) {
+	if wide {
+		fmt.Printf(" %s", labels)
+	}
+	fmt.Printf("\n")
+}
@@ -23,6 +23,10 @@ import (
 )
 
 func listMeshCluster(c *utils.ClientContext) error {
+	printer, err := utils.NewResourcePrinter(c)
+	if err != nil {
+		return err
+	}
 	meshManager := v1.NewMeshManager(utils.GetClientOption())
 	resp, err := meshManager.ListMeshCluster(&meshmanager.ListMeshClusterReq{})
 	if err != nil {
@@ -35,13 +39,23 @@ func listMeshCluster(c *utils.ClientContext) error {
 	if len(resp.MeshClusters) == 0 {
 		return fmt.Errorf("Found no meshclusters")
 	}
+	filtered, err := printer.Filter(resp.MeshClusters)
+	if err != nil {
+		return err
+	}
+	return printer.PrintList(filtered, func() error {
+		return printListMeshCluster(filtered.([]*meshmanager.MeshCluster))
+	})
+}
+
+func printListMeshCluster(list []*meshmanager.MeshCluster) error {
 	fmt.Printf("%-15s %-10s %-10s %-25s\n",
 		"CLUSTERID",
 		"VERSION",
 		"STATUS",
 		"MESSAGE")
 
-	for _, mCluster := range resp.MeshClusters {
+	for _, mCluster := range list {
 		var status, message string
 		if mCluster.Deletion {
 			status = "DELETING"
@@ -25,13 +25,25 @@ func listNamespace(c *utils.ClientContext) error {
 		return err
 	}
 
+	printer, err := utils.NewResourcePrinter(c)
+	if err != nil {
+		return err
+	}
+
 	storage := v1.NewBcsStorage(utils.GetClientOption())
 	list, err := storage.ListNamespace(c.ClusterID(), nil)
 	if err != nil {
 		return fmt.Errorf("failed to list namespace: %v", err)
 	}
 
-	return printListNamespace(list)
+	filtered, err := printer.Filter(list)
+	if err != nil {
+		return err
+	}
+	list = filtered.([]string)
+	return printer.PrintList(list, func() error {
+		return printListNamespace(list)
+	})
 }
 
 func printListNamespace(list []string) error {
@@ -28,6 +28,11 @@ func listProcess(c *utils.ClientContext) error {
 		return err
 	}
 
+	printer, err := utils.NewResourcePrinter(c)
+	if err != nil {
+		return err
+	}
+
 	storage := v1.NewBcsStorage(utils.GetClientOption())
 
 	// get namespace
@@ -35,7 +40,6 @@ func listProcess(c *utils.ClientContext) error {
 	condition.Add(filterNamespaceTag, c.Namespace())
 
 	if c.IsAllNamespace() {
-		var err error
 		if condition, err = getNamespaceFilter(storage, c.ClusterID()); err != nil {
 			return err
 		}
@@ -47,16 +51,23 @@ func listProcess(c *utils.ClientContext) error {
 	}
 
 	sort.Sort(list)
-	return printListProcess(list)
+	filtered, err := printer.Filter(list)
+	if err != nil {
+		return err
+	}
+	list = filtered.(v1.ProcessList)
+	return printer.PrintList(list, func() error {
+		return printListProcess(list, printer.IsWide())
+	})
 }
 
-func printListProcess(list v1.ProcessList) error {
+func printListProcess(list v1.ProcessList, wide bool) error {
 	if len(list) == 0 {
 		fmt.Printf("Found no process\n")
 		return nil
 	}
 
-	fmt.Printf("%-50s %-10s %-10s %-17s %-25s %-35s %-30s\n",
+	fmt.Printf("%-50s %-10s %-10s %-17s %-25s %-35s %-30s",
 		"NAME",
 		"STATUS",
 		"INSTANCE",
@@ -64,6 +75,7 @@ func printListProcess(list v1.ProcessList) error {
 		"MESSAGE",
 		"CREATETIME",
 		"NAMESPACE")
+	printLineEnd(wide, "LABELS")
 	for _, status := range list {
 		if status.Data.Kind != commonTypes.BcsDataType_PROCESS {
 			continue
@@ -71,7 +83,7 @@ func printListProcess(list v1.ProcessList) error {
 		if len(status.Data.Message) > 22 {
 			status.Data.Message = status.Data.Message[:22] + "..."
 		}
-		fmt.Printf("%-50s %-10s %-10d %-17d %-25s %-35s %-30s\n",
+		fmt.Printf("%-50s %-10s %-10d %-17d %-25s %-35s %-30s",
 			status.Data.Name,
 			status.Data.Status,
 			status.Data.Instance,
@@ -79,6 +91,7 @@ func printListProcess(list v1.ProcessList) error {
 			status.Data.Message,
 			status.Data.CreateTime,
 			status.Data.ObjectMeta.NameSpace)
+		printLineEnd(wide, utils.FormatLabels(status.Data.ObjectMeta.Labels))
 	}
 
 	return nil
@@ -27,6 +27,11 @@ func listService(c *utils.ClientContext) error {
 		return err
 	}
 
+	printer, err := utils.NewResourcePrinter(c)
+	if err != nil {
+		return err
+	}
+
 	storage := v1.NewBcsStorage(utils.GetClientOption())
 
 	// get namespace
@@ -34,7 +39,6 @@ func listService(c *utils.ClientContext) error {
 	condition.Add(filterNamespaceTag, c.Namespace())
 
 	if c.IsAllNamespace() {
-		var err error
 		if condition, err = getNamespaceFilter(storage, c.ClusterID()); err != nil {
 			return err
 		}
@@ -46,26 +50,35 @@ func listService(c *utils.ClientContext) error {
 	}
 
 	sort.Sort(list)
-	return printListService(list)
+	filtered, err := printer.Filter(list)
+	if err != nil {
+		return err
+	}
+	list = filtered.(v1.ServiceList)
+	return printer.PrintList(list, func() error {
+		return printListService(list, printer.IsWide())
+	})
 }
 
-func printListService(list v1.ServiceList) error {
+func printListService(list v1.ServiceList, wide bool) error {
 	if len(list) == 0 {
 		fmt.Printf("Found no service\n")
 		return nil
 	}
 
-	fmt.Printf("%-50s  %-30s  %-30s  %-30s\n",
+	fmt.Printf("%-50s  %-30s  %-30s  %-30s",
 		"NAME",
 		"NAMESPACE",
 		"CLUSTER",
 		"BCSGROUP")
+	printLineEnd(wide, "LABELS")
 	for _, status := range list {
-		fmt.Printf("%-50s  %-30s  %-30s  %-30s\n",
+		fmt.Printf("%-50s  %-30s  %-30s  %-30s",
 			status.Data.ObjectMeta.Name,
 			status.Data.ObjectMeta.NameSpace,
 			status.Data.ObjectMeta.Labels["io.tencent.bcs.cluster"],
 			status.Data.ObjectMeta.Labels["BCSGROUP"])
+		printLineEnd(wide, utils.FormatLabels(status.Data.ObjectMeta.Labels))
 	}
 	return nil
 }
@@ -27,6 +27,11 @@ func listTaskGroup(c *utils.ClientContext) error {
 		return err
 	}
 
+	printer, err := utils.NewResourcePrinter(c)
+	if err != nil {
+		return err
+	}
+
 	storage := v1.NewBcsStorage(utils.GetClientOption())
 
 	// get namespace
@@ -34,7 +39,6 @@ func listTaskGroup(c *utils.ClientContext) error {
 	condition.Add(filterNamespaceTag, c.Namespace())
 
 	if c.IsAllNamespace() {
-		var err error
 		if condition, err = getNamespaceFilter(storage, c.ClusterID()); err != nil {
 			return err
 		}
@@ -51,16 +55,23 @@ func listTaskGroup(c *utils.ClientContext) error {
 	}
 
 	sort.Sort(list)
-	return printListTaskGroup(list)
+	filtered, err := printer.Filter(list)
+	if err != nil {
+		return err
+	}
+	list = filtered.(v1.TaskGroupList)
+	return printer.PrintList(list, func() error {
+		return printListTaskGroup(list, printer.IsWide())
+	})
 }
 
-func printListTaskGroup(list v1.TaskGroupList) error {
+func printListTaskGroup(list v1.TaskGroupList, wide bool) error {
 	if len(list) == 0 {
 		fmt.Printf("Found no taskgroup\n")
 		return nil
 	}
 
-	fmt.Printf("%-50s  %-25s  %-15s  %-15s  %-30s  %-15s %-15s %-15s %-30s %-30s\n",
+	fmt.Printf("%-50s  %-25s  %-15s  %-15s  %-30s  %-15s %-15s %-15s %-30s %-30s",
 		"NAME",
 		"RC_NAME",
 		"STATUS",
@@ -71,9 +82,10 @@ func printListTaskGroup(list v1.TaskGroupList) error {
 		"PODIP",
 		"NAMESPACE",
 		"MESSAGE")
+	printLineEnd(wide, "LABELS")
 
 	for _, status := range list {
-		fmt.Printf("%-50s  %-25s  %-15s  %-15d  %-30s  %-15s %-15s %-15s %-30s %-30s\n",
+		fmt.Printf("%-50s  %-25s  %-15s  %-15d  %-30s  %-15s %-15s %-15s %-30s %-30s",
 			status.Data.ObjectMeta.Name,
 			status.Data.RcName,
 			status.Data.Status,
@@ -85,6 +97,7 @@ func printListTaskGroup(list v1.TaskGroupList) error {
 			status.Data.ObjectMeta.NameSpace,
 			status.Data.Message,
 		)
+		printLineEnd(wide, utils.FormatLabels(status.Data.ObjectMeta.Labels))
 	}
 	return nil
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/list"
 	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/offer"
 	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/permission"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/quota"
 	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/refresh"
 	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/template"
 	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/transaction"
@@ -70,11 +71,13 @@ func main() {
 		template.NewTemplateCommand(),
 		batch.NewApplyCommand(),
 		batch.NewCleanCommand(),
+		batch.NewDiffCommand(),
 		refresh.NewRefreshCommand(),
 		add.NewAddCommand(),
 		exec.NewExecCommand(),
 		permission.NewPermissionCommand(),
 		transaction.NewTransactionCommand(),
+		quota.NewQuotaCommand(),
 	}
 
 	if err := utils.InitCfg(); err != nil {
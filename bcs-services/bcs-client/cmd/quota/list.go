@@ -0,0 +1,67 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package quota
+
+import (
+	"fmt"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/utils"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/pkg/scheduler/v4"
+)
+
+func listQuota(c *utils.ClientContext) error {
+	if err := c.MustSpecified(utils.OptionClusterID); err != nil {
+		return err
+	}
+
+	scheduler := v4.NewBcsScheduler(utils.GetClientOption())
+
+	// namespace from env is ignored, only the option limits the list
+	if c.IsSet(utils.OptionNamespace) {
+		quota, err := scheduler.GetNamespaceQuota(c.ClusterID(), c.String(utils.OptionNamespace))
+		if err != nil {
+			return fmt.Errorf("failed to get namespace quota: %v", err)
+		}
+		return printListQuota([]*commtypes.BcsNamespaceQuota{quota})
+	}
+
+	quotas, err := scheduler.ListNamespaceQuotas(c.ClusterID())
+	if err != nil {
+		return fmt.Errorf("failed to list namespace quotas: %v", err)
+	}
+	return printListQuota(quotas)
+}
+
+func printListQuota(quotas []*commtypes.BcsNamespaceQuota) error {
+	base := "%-30s %-20s %-25s %-25s %-15s\n"
+	fmt.Printf(base, "NAMESPACE", "CPU(USED/HARD)", "MEM(USED/HARD)", "DISK(USED/HARD)", "TASKGROUPS(USED/HARD)")
+	for _, quota := range quotas {
+		used := quota.Used
+		if used == nil {
+			used = &commtypes.NamespaceQuotaResource{}
+		}
+		fmt.Printf(base, quota.NameSpace,
+			fmt.Sprintf("%.2f/%s", used.Cpu, formatHard(quota.Hard.Cpu)),
+			fmt.Sprintf("%.2f/%s", used.Mem, formatHard(quota.Hard.Mem)),
+			fmt.Sprintf("%.2f/%s", used.Disk, formatHard(quota.Hard.Disk)),
+			fmt.Sprintf("%d/%s", used.Taskgroups, formatHard(float64(quota.Hard.Taskgroups))))
+	}
+	return nil
+}
+
+func formatHard(hard float64) string {
+	if hard == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%g", hard)
+}
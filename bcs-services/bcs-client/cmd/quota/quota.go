@@ -0,0 +1,87 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package quota
+
+import (
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/utils"
+	"github.com/urfave/cli"
+)
+
+// NewQuotaCommand create command struct for namespace quota
+func NewQuotaCommand() cli.Command {
+	return cli.Command{
+		Name:  "quota",
+		Usage: "manage the resource quotas of mesos namespaces",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "list, l",
+				Usage: "For get operation, list quotas of all namespaces if namespace is not specified",
+			},
+			cli.BoolFlag{
+				Name:  "set, s",
+				Usage: "For set operation, create or replace the quota of namespace",
+			},
+			cli.BoolFlag{
+				Name:  "delete, d",
+				Usage: "For delete operation, delete the quota of namespace",
+			},
+			cli.StringFlag{
+				Name:  "clusterid",
+				Usage: "Cluster ID",
+			},
+			cli.StringFlag{
+				Name:  "namespace, ns",
+				Usage: "Namespace of quota",
+			},
+			cli.Float64Flag{
+				Name:  "cpu",
+				Usage: "For set operation, the limit of cpu cores, 0 means no limit",
+			},
+			cli.Float64Flag{
+				Name:  "mem",
+				Usage: "For set operation, the limit of memory(MB), 0 means no limit",
+			},
+			cli.Float64Flag{
+				Name:  "disk",
+				Usage: "For set operation, the limit of disk(MB), 0 means no limit",
+			},
+			cli.IntFlag{
+				Name:  "taskgroups",
+				Usage: "For set operation, the limit of taskgroup number, 0 means no limit",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if err := quota(utils.NewClientContext(c)); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+func quota(c *utils.ClientContext) error {
+	// get basic command
+	isList := c.Bool(utils.OptionList)
+	isSet := c.Bool(utils.OptionSet)
+	isDelete := c.Bool(utils.OptionDelete)
+
+	if isList {
+		return listQuota(c)
+	}
+	if isSet {
+		return setQuota(c)
+	}
+	if isDelete {
+		return deleteQuota(c)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package quota
+
+import (
+	"fmt"
+
+	commtypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/cmd/utils"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-client/pkg/scheduler/v4"
+)
+
+func setQuota(c *utils.ClientContext) error {
+	if err := c.MustSpecified(utils.OptionClusterID, utils.OptionNamespace); err != nil {
+		return err
+	}
+
+	quota := &commtypes.BcsNamespaceQuota{
+		Hard: commtypes.NamespaceQuotaResource{
+			Cpu:        c.Float64("cpu"),
+			Mem:        c.Float64("mem"),
+			Disk:       c.Float64("disk"),
+			Taskgroups: c.Int("taskgroups"),
+		},
+	}
+	quota.NameSpace = c.Namespace()
+	if err := quota.Validate(); err != nil {
+		return err
+	}
+
+	scheduler := v4.NewBcsScheduler(utils.GetClientOption())
+	if err := scheduler.SetNamespaceQuota(c.ClusterID(), quota); err != nil {
+		return fmt.Errorf("failed to set namespace quota: %v", err)
+	}
+
+	fmt.Printf("success to set quota of namespace %s\n", quota.NameSpace)
+	return nil
+}
+
+func deleteQuota(c *utils.ClientContext) error {
+	if err := c.MustSpecified(utils.OptionClusterID, utils.OptionNamespace); err != nil {
+		return err
+	}
+
+	scheduler := v4.NewBcsScheduler(utils.GetClientOption())
+	if err := scheduler.DeleteNamespaceQuota(c.ClusterID(), c.Namespace()); err != nil {
+		return fmt.Errorf("failed to delete namespace quota: %v", err)
+	}
+
+	fmt.Printf("success to delete quota of namespace %s\n", c.Namespace())
+	return nil
+}
@@ -0,0 +1,440 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ghodss/yaml"
+	"github.com/urfave/cli"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+/*
+output format of list/get/inspect
+*/
+const (
+	OutputJSON          = "json"
+	OutputYAML          = "yaml"
+	OutputWide          = "wide"
+	OutputName          = "name"
+	OutputJSONPath      = "jsonpath="
+	OutputCustomColumns = "custom-columns="
+
+	noneValue = "<none>"
+)
+
+//OutputFlag command line option -o for list/get/inspect
+func OutputFlag() cli.Flag {
+	return cli.StringFlag{
+		Name: "output, o",
+		Usage: "Output format, json/yaml/wide/name/jsonpath=TEMPLATE/custom-columns=HEADER:PATH,..., " +
+			"resource is printed without storage wrapper except default format",
+	}
+}
+
+//SelectorFlags command line option -l and --sort-by for list
+func SelectorFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "selector, l",
+			Usage: "Label selector to filter resources, supports '=', '==', '!=', 'in', 'notin' and exists, like app=web,tier!=db",
+		},
+		cli.StringFlag{
+			Name:  "sort-by",
+			Usage: "Sort resources by JSONPath of resource, like .metadata.name",
+		},
+	}
+}
+
+type printColumn struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+//ResourcePrinter prints resources by output format from command line
+type ResourcePrinter struct {
+	writer   io.Writer
+	output   string
+	jsonPath *jsonpath.JSONPath
+	columns  []printColumn
+	selector labels.Selector
+	sortBy   *jsonpath.JSONPath
+}
+
+//NewResourcePrinter create printer by output, selector and sort-by options of command line
+func NewResourcePrinter(c *ClientContext) (*ResourcePrinter, error) {
+	return newResourcePrinter(os.Stdout, c.String(OptionOutput), c.String(OptionSelector), c.String(OptionSortBy))
+}
+
+func newResourcePrinter(writer io.Writer, output, selector, sortBy string) (*ResourcePrinter, error) {
+	p := &ResourcePrinter{
+		writer: writer,
+		output: output,
+	}
+	var err error
+	switch {
+	case output == "", output == OutputJSON, output == OutputYAML, output == OutputWide, output == OutputName:
+	case strings.HasPrefix(output, OutputJSONPath):
+		template := strings.TrimPrefix(output, OutputJSONPath)
+		if p.jsonPath, err = parseJSONPath("jsonpath", template); err != nil {
+			return nil, err
+		}
+		p.output = OutputJSONPath
+	case strings.HasPrefix(output, OutputCustomColumns):
+		spec := strings.TrimPrefix(output, OutputCustomColumns)
+		if p.columns, err = parseCustomColumns(spec); err != nil {
+			return nil, err
+		}
+		p.output = OutputCustomColumns
+	default:
+		return nil, fmt.Errorf("invalid output format %s, must be one of json/yaml/wide/name/jsonpath=/custom-columns=", output)
+	}
+	if len(selector) != 0 {
+		if p.selector, err = labels.Parse(selector); err != nil {
+			return nil, fmt.Errorf("invalid label selector %s: %v", selector, err)
+		}
+	}
+	if len(sortBy) != 0 {
+		if p.sortBy, err = parseJSONPath("sort-by", sortBy); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+//parseJSONPath parse template like kubectl, {} and leading dot can be omitted, like metadata.name
+func parseJSONPath(name, template string) (*jsonpath.JSONPath, error) {
+	template = strings.TrimSpace(template)
+	if len(template) == 0 {
+		return nil, fmt.Errorf("%s template cannot be empty", name)
+	}
+	if !strings.Contains(template, "{") {
+		if !strings.HasPrefix(template, ".") {
+			template = "." + template
+		}
+		template = "{" + template + "}"
+	}
+	path := jsonpath.New(name)
+	if err := path.Parse(template); err != nil {
+		return nil, fmt.Errorf("invalid %s template %s: %v", name, template, err)
+	}
+	path.AllowMissingKeys(true)
+	return path, nil
+}
+
+//parseCustomColumns parse columns like NAME:.metadata.name,NAMESPACE:.metadata.namespace
+func parseCustomColumns(spec string) ([]printColumn, error) {
+	var columns []printColumn
+	for _, part := range strings.Split(spec, ",") {
+		items := strings.SplitN(part, ":", 2)
+		if len(items) != 2 || len(items[0]) == 0 {
+			return nil, fmt.Errorf("invalid custom column %s, must be HEADER:PATH", part)
+		}
+		path, err := parseJSONPath(items[0], items[1])
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, printColumn{header: items[0], path: path})
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("custom columns cannot be empty")
+	}
+	return columns, nil
+}
+
+//IsWide check if output is wide table
+func (p *ResourcePrinter) IsWide() bool {
+	return p.output == OutputWide
+}
+
+//Filter selects resources of list by label selector, and sorts them by sort-by path.
+//list must be a slice whose element is resource, or storage record holding resource in field Data.
+//returned value is the same type of list
+func (p *ResourcePrinter) Filter(list interface{}) (interface{}, error) {
+	if p.selector == nil && p.sortBy == nil {
+		return list, nil
+	}
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("cannot filter %s, it is not list", value.Type())
+	}
+
+	type sortItem struct {
+		index int
+		key   interface{}
+	}
+	var items []sortItem
+	for i := 0; i < value.Len(); i++ {
+		obj, err := toGenericObject(resourceOf(value.Index(i)))
+		if err != nil {
+			return nil, err
+		}
+		if p.selector != nil && !p.selector.Matches(objectLabels(obj)) {
+			continue
+		}
+		item := sortItem{index: i}
+		if p.sortBy != nil {
+			if item.key, err = findFirstValue(p.sortBy, obj); err != nil {
+				return nil, fmt.Errorf("sort by failed: %v", err)
+			}
+		}
+		items = append(items, item)
+	}
+	if p.sortBy != nil {
+		sort.SliceStable(items, func(i, j int) bool {
+			return lessValue(items[i].key, items[j].key)
+		})
+	}
+
+	result := reflect.MakeSlice(value.Type(), 0, len(items))
+	for _, item := range items {
+		result = reflect.Append(result, value.Index(item.index))
+	}
+	return result.Interface(), nil
+}
+
+//PrintList prints resources of list, table is called for default and wide output
+func (p *ResourcePrinter) PrintList(list interface{}, table func() error) error {
+	if p.output == "" || p.output == OutputWide {
+		return table()
+	}
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice {
+		return fmt.Errorf("cannot print %s, it is not list", value.Type())
+	}
+	objs := make([]interface{}, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		obj, err := toGenericObject(resourceOf(value.Index(i)))
+		if err != nil {
+			return err
+		}
+		objs = append(objs, obj)
+	}
+
+	switch p.output {
+	case OutputName:
+		for _, obj := range objs {
+			fmt.Fprintln(p.writer, objectName(obj))
+		}
+		return nil
+	case OutputJSONPath:
+		return p.printJSONPath(map[string]interface{}{"items": objs})
+	case OutputCustomColumns:
+		return p.printColumns(objs)
+	default:
+		return p.printData(objs)
+	}
+}
+
+//PrintSingle prints single resource, default output is indented json of the whole response
+func (p *ResourcePrinter) PrintSingle(single interface{}) error {
+	if p.output == "" || p.output == OutputWide {
+		fmt.Fprintf(p.writer, "%s\n", TryIndent(single))
+		return nil
+	}
+	obj, err := toGenericObject(resourceOf(reflect.ValueOf(single)))
+	if err != nil {
+		return err
+	}
+	switch p.output {
+	case OutputName:
+		fmt.Fprintln(p.writer, objectName(obj))
+		return nil
+	case OutputJSONPath:
+		return p.printJSONPath(obj)
+	case OutputCustomColumns:
+		return p.printColumns([]interface{}{obj})
+	default:
+		return p.printData(obj)
+	}
+}
+
+func (p *ResourcePrinter) printData(obj interface{}) error {
+	var data []byte
+	var err error
+	if p.output == OutputYAML {
+		data, err = yaml.Marshal(obj)
+	} else {
+		data, err = json.MarshalIndent(obj, "", "    ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return fmt.Errorf("encode resource to %s failed: %v", p.output, err)
+	}
+	_, err = p.writer.Write(data)
+	return err
+}
+
+func (p *ResourcePrinter) printJSONPath(obj interface{}) error {
+	if err := p.jsonPath.Execute(p.writer, obj); err != nil {
+		return fmt.Errorf("execute jsonpath failed: %v", err)
+	}
+	fmt.Fprintln(p.writer)
+	return nil
+}
+
+func (p *ResourcePrinter) printColumns(objs []interface{}) error {
+	w := tabwriter.NewWriter(p.writer, 10, 4, 3, ' ', 0)
+	headers := make([]string, 0, len(p.columns))
+	for _, column := range p.columns {
+		headers = append(headers, column.header)
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, obj := range objs {
+		values := make([]string, 0, len(p.columns))
+		for _, column := range p.columns {
+			results, err := column.path.FindResults(obj)
+			if err != nil {
+				return fmt.Errorf("find column %s failed: %v", column.header, err)
+			}
+			var fields []string
+			for _, result := range results {
+				for _, r := range result {
+					fields = append(fields, valueString(r.Interface()))
+				}
+			}
+			if len(fields) == 0 {
+				fields = append(fields, noneValue)
+			}
+			values = append(values, strings.Join(fields, ","))
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	return w.Flush()
+}
+
+//FormatLabels formats labels like app=web,tier=db in key order for wide output
+func FormatLabels(objLabels map[string]string) string {
+	if len(objLabels) == 0 {
+		return noneValue
+	}
+	return labels.Set(objLabels).String()
+}
+
+//resourceOf returns resource of list element, storage record holding only field Data is unwrapped
+func resourceOf(value reflect.Value) interface{} {
+	elem := value
+	for elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface {
+		if elem.IsNil() {
+			return nil
+		}
+		elem = elem.Elem()
+	}
+	if elem.Kind() == reflect.Struct && elem.NumField() == 1 && elem.Type().Field(0).Name == "Data" {
+		return elem.Field(0).Interface()
+	}
+	return value.Interface()
+}
+
+//toGenericObject converts resource into json value, so that it can be evaluated by jsonpath
+func toGenericObject(resource interface{}) (interface{}, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, fmt.Errorf("encode resource failed: %v", err)
+	}
+	var obj interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("decode resource failed: %v", err)
+	}
+	return obj, nil
+}
+
+func objectLabels(obj interface{}) labels.Set {
+	set := labels.Set{}
+	m, _ := obj.(map[string]interface{})
+	meta, _ := m["metadata"].(map[string]interface{})
+	objLabels, _ := meta["labels"].(map[string]interface{})
+	for k, v := range objLabels {
+		set[k] = valueString(v)
+	}
+	return set
+}
+
+//objectName returns kind/name of resource, kind is omitted when it is empty
+func objectName(obj interface{}) string {
+	m, ok := obj.(map[string]interface{})
+	if !ok {
+		return valueString(obj)
+	}
+	meta, _ := m["metadata"].(map[string]interface{})
+	name, _ := meta["name"].(string)
+	if len(name) == 0 {
+		name, _ = m["name"].(string)
+	}
+	if kind, _ := m["kind"].(string); len(kind) != 0 {
+		return strings.ToLower(kind) + "/" + name
+	}
+	return name
+}
+
+func findFirstValue(path *jsonpath.JSONPath, obj interface{}) (interface{}, error) {
+	results, err := path.FindResults(obj)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if len(result) != 0 {
+			return result[0].Interface(), nil
+		}
+	}
+	return nil, nil
+}
+
+//lessValue compares numbers by value and others by string, missing value is the smallest
+func lessValue(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	numA, errA := numberOf(a)
+	numB, errB := numberOf(b)
+	if errA == nil && errB == nil {
+		return numA < numB
+	}
+	return valueString(a) < valueString(b)
+}
+
+func numberOf(v interface{}) (float64, error) {
+	if n, ok := v.(json.Number); ok {
+		return n.Float64()
+	}
+	return 0, fmt.Errorf("%v is not number", v)
+}
+
+func valueString(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return noneValue
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(value)
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
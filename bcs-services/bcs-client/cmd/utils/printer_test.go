@@ -0,0 +1,102 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package utils
+
+import (
+	"bytes"
+	"testing"
+)
+
+type testMeta struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type testResource struct {
+	Kind     string   `json:"kind"`
+	Meta     testMeta `json:"metadata"`
+	Instance int      `json:"instance"`
+}
+
+type testResourceSet struct {
+	Data testResource `json:"data"`
+}
+
+type testResourceList []*testResourceSet
+
+func newTestResourceList() testResourceList {
+	return testResourceList{
+		{Data: testResource{Kind: "Deployment", Meta: testMeta{Name: "web", Labels: map[string]string{"app": "web"}}, Instance: 10}},
+		{Data: testResource{Kind: "Deployment", Meta: testMeta{Name: "db", Labels: map[string]string{"app": "db"}}, Instance: 2}},
+		{Data: testResource{Kind: "Deployment", Meta: testMeta{Name: "api", Labels: map[string]string{"app": "web"}}, Instance: 3}},
+	}
+}
+
+// TestResourcePrinterFilter test label selector and sort-by of list
+func TestResourcePrinterFilter(t *testing.T) {
+	p, err := newResourcePrinter(&bytes.Buffer{}, OutputName, "app=web", "metadata.name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filtered, err := p.Filter(newTestResourceList())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// storage record is unwrapped, so sort-by path is evaluated on resource
+	list := filtered.(testResourceList)
+	if len(list) != 2 || list[0].Data.Meta.Name != "api" || list[1].Data.Meta.Name != "web" {
+		t.Fatalf("unexpected filter result %+v", list)
+	}
+
+	p, _ = newResourcePrinter(&bytes.Buffer{}, OutputName, "", ".instance")
+	filtered, _ = p.Filter(newTestResourceList())
+	list = filtered.(testResourceList)
+	if list[0].Data.Meta.Name != "db" || list[1].Data.Meta.Name != "api" || list[2].Data.Meta.Name != "web" {
+		t.Errorf("expect sorted by instance as number, got %s,%s,%s",
+			list[0].Data.Meta.Name, list[1].Data.Meta.Name, list[2].Data.Meta.Name)
+	}
+}
+
+// TestResourcePrinterOutput test structured output formats of list
+func TestResourcePrinterOutput(t *testing.T) {
+	cases := []struct {
+		output string
+		expect string
+	}{
+		{output: OutputName, expect: "deployment/web\ndeployment/db\ndeployment/api\n"},
+		{output: "jsonpath={.items[*].metadata.name}", expect: "web db api\n"},
+		{output: "custom-columns=NAME:.metadata.name,TIER:.metadata.labels.tier",
+			expect: "NAME      TIER\nweb       <none>\ndb        <none>\napi       <none>\n"},
+	}
+	for _, cs := range cases {
+		buffer := &bytes.Buffer{}
+		p, err := newResourcePrinter(buffer, cs.output, "", "")
+		if err != nil {
+			t.Fatalf("output %s: %v", cs.output, err)
+		}
+		if err := p.PrintList(newTestResourceList(), func() error {
+			t.Errorf("output %s should not print table", cs.output)
+			return nil
+		}); err != nil {
+			t.Fatalf("output %s: %v", cs.output, err)
+		}
+		if buffer.String() != cs.expect {
+			t.Errorf("output %s expect %q, got %q", cs.output, cs.expect, buffer.String())
+		}
+	}
+
+	if _, err := newResourcePrinter(&bytes.Buffer{}, "xml", "", ""); err == nil {
+		t.Errorf("expect error of invalid output format")
+	}
+}
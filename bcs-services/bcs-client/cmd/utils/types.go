@@ -48,6 +48,9 @@ const (
 	OptionResourceType       = "resourcetype"
 	OptionVpc                = "vpcid"
 	OptionOnlyUpdateResource = "only-update-resource"
+	OptionOutput             = "output"
+	OptionSelector           = "selector"
+	OptionSortBy             = "sort-by"
 )
 
 //ValidateCustomResourceType check if speicifed CustomResource was registered before.
@@ -89,6 +89,8 @@ require (
 	istio.io/client-go v0.0.0-20200812230733-f5504d568313 // indirect
 	istio.io/gogo-genproto v0.0.0-20200720193312-b523a30fe746 // indirect
 	k8s.io/apiextensions-apiserver v0.18.6
+	k8s.io/apimachinery v0.18.6
+	k8s.io/client-go v0.18.6
 	k8s.io/code-generator v0.19.0 // indirect
 	sigs.k8s.io/service-apis v0.0.0-20200731055707-56154e7bfde5 // indirect
 )
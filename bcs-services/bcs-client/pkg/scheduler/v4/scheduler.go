@@ -42,6 +42,11 @@ type Scheduler interface {
 	UpdateService(clusterID, namespace string, data []byte, extraValue url.Values) error
 	UpdateDeployment(clusterID, namespace string, data []byte, extraValue url.Values) error
 
+	//DryRunCreate checks creation of resource by bcs-scheduler and admission webhooks without saving it
+	DryRunCreate(clusterID, namespace, resourceType string, data []byte) error
+	//DryRunUpdate checks update of resource by bcs-scheduler and admission webhooks without saving it
+	DryRunUpdate(clusterID, namespace, resourceType string, data []byte, extraValue url.Values) error
+
 	DeleteApplication(clusterID, namespace, name string, enforce bool) error
 	DeleteProcess(clusterID, namespace, name string, enforce bool) error
 	DeleteConfigMap(clusterID, namespace, name string, enforce bool) error
@@ -111,6 +116,11 @@ type Scheduler interface {
 
 	ListTransaction(clusterID, objKind, objNs, objName string) ([]*schetypes.Transaction, error)
 	DeleteTransaction(clusterID, ns, name string) error
+
+	ListNamespaceQuotas(clusterID string) ([]*commonTypes.BcsNamespaceQuota, error)
+	GetNamespaceQuota(clusterID, namespace string) (*commonTypes.BcsNamespaceQuota, error)
+	SetNamespaceQuota(clusterID string, quota *commonTypes.BcsNamespaceQuota) error
+	DeleteNamespaceQuota(clusterID, namespace string) error
 }
 
 const (
@@ -138,6 +148,8 @@ const (
 	bcsSchedulerResizeExecUri               = "%s/bcsapi/v4/scheduler/mesos/webconsole/resize_exec?host_ip=%s"
 	bcsSchedulerTransactionListUri          = "%s/bcsapi/v4/scheduler/mesos/transactions/%s?objKind=%s&objName=%s"
 	bcsSchedulerTransactionDeleteUri        = "%s/bcsapi/v4/scheduler/mesos/transactions/%s/%s"
+	bcsSchedulerNamespaceQuotaListUri       = "%s/bcsapi/v4/scheduler/mesos/namespacequotas"
+	bcsSchedulerNamespaceQuotaUri           = "%s/bcsapi/v4/scheduler/mesos/namespacequota/%s"
 )
 
 type bcsScheduler struct {
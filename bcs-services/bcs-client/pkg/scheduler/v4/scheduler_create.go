@@ -16,6 +16,7 @@ package v4
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 func (bs *bcsScheduler) CreateApplication(clusterID, namespace string, data []byte) error {
@@ -47,8 +48,13 @@ func (bs *bcsScheduler) CreateDaemonset(clusterID, namespace string, data []byte
 }
 
 func (bs *bcsScheduler) createResource(clusterID, namespace, resourceType string, data []byte) error {
+	return bs.createResourceWithValues(clusterID, namespace, resourceType, data, nil)
+}
+
+func (bs *bcsScheduler) createResourceWithValues(clusterID, namespace, resourceType string, data []byte,
+	extraValue url.Values) error {
 	resp, err := bs.requester.Do(
-		fmt.Sprintf(bcsSchedulerResourceURI, bs.bcsAPIAddress, namespace, resourceType, ""),
+		fmt.Sprintf(bcsSchedulerResourceURI, bs.bcsAPIAddress, namespace, resourceType, extraValue.Encode()),
 		http.MethodPost,
 		data,
 		getClusterIDHeader(clusterID),
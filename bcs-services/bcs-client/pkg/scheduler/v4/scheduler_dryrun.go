@@ -0,0 +1,36 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package v4
+
+import (
+	"net/url"
+)
+
+func (bs *bcsScheduler) DryRunCreate(clusterID, namespace, resourceType string, data []byte) error {
+	return bs.createResourceWithValues(clusterID, namespace, resourceType, data, dryRunValues(nil))
+}
+
+func (bs *bcsScheduler) DryRunUpdate(clusterID, namespace, resourceType string, data []byte, extraValue url.Values) error {
+	return bs.updateResource(clusterID, namespace, resourceType, data, dryRunValues(extraValue))
+}
+
+// dryRunValues copies extraValue with dryRun query
+func dryRunValues(extraValue url.Values) url.Values {
+	values := make(url.Values)
+	for k, v := range extraValue {
+		values[k] = v
+	}
+	values.Set(DryRunQuery, "true")
+	return values
+}
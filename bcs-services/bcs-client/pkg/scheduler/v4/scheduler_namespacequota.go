@@ -0,0 +1,128 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v4
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/codec"
+	commonTypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
+)
+
+// ListNamespaceQuotas list quotas of all namespaces with current usage
+func (bs *bcsScheduler) ListNamespaceQuotas(clusterID string) ([]*commonTypes.BcsNamespaceQuota, error) {
+	resp, err := bs.requester.Do(
+		fmt.Sprintf(bcsSchedulerNamespaceQuotaListUri, bs.bcsAPIAddress),
+		http.MethodGet,
+		nil,
+		getClusterIDHeader(clusterID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	code, msg, data, err := parseResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if code != 0 {
+		return nil, fmt.Errorf("list namespace quotas failed: %s", msg)
+	}
+
+	result := make([]*commonTypes.BcsNamespaceQuota, 0)
+	if err = codec.DecJson(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetNamespaceQuota get quota of namespace with current usage
+func (bs *bcsScheduler) GetNamespaceQuota(clusterID, namespace string) (*commonTypes.BcsNamespaceQuota, error) {
+	resp, err := bs.requester.Do(
+		fmt.Sprintf(bcsSchedulerNamespaceQuotaUri, bs.bcsAPIAddress, namespace),
+		http.MethodGet,
+		nil,
+		getClusterIDHeader(clusterID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	code, msg, data, err := parseResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if code != 0 {
+		return nil, fmt.Errorf("get namespace quota failed: %s", msg)
+	}
+
+	result := new(commonTypes.BcsNamespaceQuota)
+	if err = codec.DecJson(data, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetNamespaceQuota create or update quota of namespace
+func (bs *bcsScheduler) SetNamespaceQuota(clusterID string, quota *commonTypes.BcsNamespaceQuota) error {
+	var data []byte
+	if err := codec.EncJson(quota, &data); err != nil {
+		return err
+	}
+
+	resp, err := bs.requester.Do(
+		fmt.Sprintf(bcsSchedulerNamespaceQuotaUri, bs.bcsAPIAddress, quota.NameSpace),
+		http.MethodPut,
+		data,
+		getClusterIDHeader(clusterID),
+	)
+	if err != nil {
+		return err
+	}
+
+	code, msg, _, err := parseResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		return fmt.Errorf("set namespace quota failed: %s", msg)
+	}
+	return nil
+}
+
+// DeleteNamespaceQuota delete quota of namespace
+func (bs *bcsScheduler) DeleteNamespaceQuota(clusterID, namespace string) error {
+	resp, err := bs.requester.Do(
+		fmt.Sprintf(bcsSchedulerNamespaceQuotaUri, bs.bcsAPIAddress, namespace),
+		http.MethodDelete,
+		nil,
+		getClusterIDHeader(clusterID),
+	)
+	if err != nil {
+		return err
+	}
+
+	code, msg, _, err := parseResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		return fmt.Errorf("delete namespace quota failed: %s", msg)
+	}
+	return nil
+}
@@ -26,4 +26,6 @@ const (
 	AllNamespace = ""
 	//StatusKind for CRD error message
 	StatusKind = "Status"
+	//DryRunQuery query of create/update request which is only checked by bcs-scheduler without saving
+	DryRunQuery = "dryRun"
 )
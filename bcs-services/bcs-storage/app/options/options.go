@@ -55,6 +55,11 @@ type StorageOptions struct {
 	PrintBody    bool   `json:"print_body" value:"false" usage:"Print body every request."`
 	PrintManager bool   `json:"print_manager" value:"false" usage:"Print manager."`
 	DebugMode    bool   `json:"debug_mode" value:"false" usage:"Debug mode, use pprof."`
+
+	RetentionCheckInterval int64 `json:"retention_check_interval" value:"300" usage:"Interval seconds for cleaning data by retention policies."`
+
+	HistoryResourceTypes string `json:"history_resource_types" value:"" usage:"Dynamic resource types keeping history versions, split by comma."`
+	HistoryMaxTime       int64  `json:"history_max_day" value:"7" usage:"Max day for holding history versions of dynamic resources."`
 }
 
 //NewStorageOptions create StorageOptions object
@@ -0,0 +1,216 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.,
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lib
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/tracing/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// AggregationGroupKey key of group fields in aggregation result
+	AggregationGroupKey = "group"
+	// AggregationCountKey key of document count in aggregation result
+	AggregationCountKey = "count"
+	// AggregationSumKey key of sum results in aggregation result
+	AggregationSumKey = "sum"
+
+	// field names are not allowed to contain dots in $group stage, so use placeholders instead
+	aggregationGroupFieldPrefix = "g"
+	aggregationSumFieldPrefix   = "s"
+)
+
+// StoreAggregateSort sort key of aggregation result
+type StoreAggregateSort struct {
+	// Key count or name of sum result
+	Key string
+	// Order 1 for ascending and -1 for descending
+	Order int
+}
+
+// StoreAggregateOption option for aggregate action
+type StoreAggregateOption struct {
+	Cond *operator.Condition
+	// GroupBy fields to group by
+	GroupBy []string
+	// Unwind array fields to be unwound before grouping
+	Unwind []string
+	// Sum name of sum result -> field to sum
+	Sum map[string]string
+	// Sort keys in priority order, groups are finally sorted by group fields to keep limit stable
+	Sort []StoreAggregateSort
+	// Limit max number of groups
+	Limit int64
+}
+
+// Aggregate count and sum documents by groups
+func (a *Store) Aggregate(ctx context.Context, resourceType string, opt *StoreAggregateOption) ([]operator.M, error) {
+	const (
+		OperationName   = "storage-Aggregate"
+		OperationMethod = "Aggregate"
+	)
+	span, ctx := utils.StartSpanFromContext(ctx, OperationName)
+	defer span.Finish()
+	setDBSpanTags(a, span, resourceType, OperationMethod)
+
+	if opt == nil {
+		err := fmt.Errorf("StoreAggregateOption cannot be empty")
+		utils.SetSpanLogTagError(span, err)
+		return nil, err
+	}
+	if opt.Cond == nil {
+		err := fmt.Errorf("Cond in StoreAggregateOption cannot be empty")
+		utils.SetSpanLogTagError(span, err)
+		return nil, err
+	}
+	matchCond := opt.Cond
+	if a.doSoftDelete {
+		// aggregate data which is not marked deleted
+		delFlagCond := operator.NewLeafCondition(operator.Ne, operator.M{databaseFieldNameForDeletionFlag: true})
+		matchCond = operator.NewBranchCondition(operator.And, opt.Cond, delFlagCond)
+	}
+	pipeline, sumNames, err := buildAggregationPipeline(matchCond, opt)
+	if err != nil {
+		utils.SetSpanLogTagError(span, err)
+		return nil, err
+	}
+
+	mList := make([]operator.M, 0)
+	if err := a.mDriver.Table(resourceType).Aggregation(ctx, pipeline, &mList); err != nil {
+		blog.Errorf("failed to aggregate, err %s", err.Error())
+		utils.SetSpanLogTagError(span, err)
+		return nil, fmt.Errorf("failed to aggregate, err %s", err.Error())
+	}
+	return formatAggregationResult(mList, opt.GroupBy, sumNames), nil
+}
+
+// buildAggregationPipeline build pipeline of $match, $unwind, $group, $sort and $limit stages,
+// it returns the names of sum results in the order of their placeholders
+func buildAggregationPipeline(matchCond *operator.Condition, opt *StoreAggregateOption) (
+	[]interface{}, []string, error) {
+	pipeline := []interface{}{
+		operator.NewBranchCondition(operator.Mat, matchCond),
+	}
+	for _, field := range opt.Unwind {
+		if err := checkAggregationField(field); err != nil {
+			return nil, nil, err
+		}
+		pipeline = append(pipeline, operator.M{"$unwind": "$" + field})
+	}
+
+	groupID := operator.M{}
+	for i, field := range opt.GroupBy {
+		if err := checkAggregationField(field); err != nil {
+			return nil, nil, err
+		}
+		groupID[fmt.Sprintf("%s%d", aggregationGroupFieldPrefix, i)] = "$" + field
+	}
+	group := operator.M{
+		"_id":               groupID,
+		AggregationCountKey: operator.M{"$sum": 1},
+	}
+	sumNames := make([]string, 0, len(opt.Sum))
+	for name := range opt.Sum {
+		sumNames = append(sumNames, name)
+	}
+	// keep placeholders stable
+	sort.Strings(sumNames)
+	sumFields := make(map[string]string)
+	for i, name := range sumNames {
+		field := opt.Sum[name]
+		if strings.ContainsAny(name, ".$") || len(name) == 0 {
+			return nil, nil, fmt.Errorf("invalid sum name %s", name)
+		}
+		if err := checkAggregationField(field); err != nil {
+			return nil, nil, err
+		}
+		placeholder := fmt.Sprintf("%s%d", aggregationSumFieldPrefix, i)
+		group[placeholder] = operator.M{"$sum": "$" + field}
+		sumFields[name] = placeholder
+	}
+	pipeline = append(pipeline, operator.M{"$group": group})
+
+	// $sort document must keep key order, so use primitive.D instead of map
+	sortStage := primitive.D{}
+	sortedKeys := make(map[string]bool)
+	for _, s := range opt.Sort {
+		if s.Order != 1 && s.Order != -1 {
+			return nil, nil, fmt.Errorf("invalid order %d of sort key %s", s.Order, s.Key)
+		}
+		field := AggregationCountKey
+		if s.Key != AggregationCountKey {
+			placeholder, ok := sumFields[s.Key]
+			if !ok {
+				return nil, nil, fmt.Errorf("sort key %s is neither count nor sum name", s.Key)
+			}
+			field = placeholder
+		}
+		if sortedKeys[field] {
+			return nil, nil, fmt.Errorf("duplicated sort key %s", s.Key)
+		}
+		sortedKeys[field] = true
+		sortStage = append(sortStage, primitive.E{Key: field, Value: s.Order})
+	}
+	// _id is unique after grouping, sort by it at last so that $limit returns the same groups every time
+	sortStage = append(sortStage, primitive.E{Key: "_id", Value: 1})
+	pipeline = append(pipeline, operator.M{"$sort": sortStage})
+	limit := opt.Limit
+	if limit <= 0 {
+		limit = storeActionDefaultLimit
+	}
+	pipeline = append(pipeline, operator.M{"$limit": limit})
+	return pipeline, sumNames, nil
+}
+
+// formatAggregationResult convert placeholders in aggregation result back to field names
+func formatAggregationResult(mList []operator.M, groupBy, sumNames []string) []operator.M {
+	retList := make([]operator.M, 0, len(mList))
+	for _, m := range mList {
+		group := operator.M{}
+		var groupID map[string]interface{}
+		switch id := m["_id"].(type) {
+		case operator.M:
+			groupID = id
+		case map[string]interface{}:
+			groupID = id
+		}
+		for i, field := range groupBy {
+			group[field] = groupID[fmt.Sprintf("%s%d", aggregationGroupFieldPrefix, i)]
+		}
+		sum := operator.M{}
+		for i, name := range sumNames {
+			sum[name] = m[fmt.Sprintf("%s%d", aggregationSumFieldPrefix, i)]
+		}
+		retList = append(retList, operator.M{
+			AggregationGroupKey: group,
+			AggregationCountKey: m[AggregationCountKey],
+			AggregationSumKey:   sum,
+		})
+	}
+	return retList
+}
+
+func checkAggregationField(field string) error {
+	if len(field) == 0 || strings.HasPrefix(field, "$") {
+		return fmt.Errorf("invalid aggregation field %s", field)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.,
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lib
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBuildAggregationPipeline(t *testing.T) {
+	cond := operator.NewLeafCondition(operator.Eq, operator.M{"clusterId": "BCS-K8S-00000"})
+	opt := &StoreAggregateOption{
+		Cond:    cond,
+		GroupBy: []string{"namespace", "data.metadata.labels.app"},
+		Unwind:  []string{"data.spec.containers"},
+		Sum:     map[string]string{"replicas": "data.spec.replicas"},
+		Sort:    []StoreAggregateSort{{Key: "replicas", Order: -1}, {Key: "count", Order: 1}},
+	}
+	pipeline, sumNames, err := buildAggregationPipeline(cond, opt)
+	if err != nil {
+		t.Fatalf("build pipeline failed, err %s", err.Error())
+	}
+	if len(pipeline) != 5 {
+		t.Fatalf("expect 5 stages, got %d", len(pipeline))
+	}
+	expectSort := operator.M{"$sort": primitive.D{
+		{Key: "s0", Value: -1}, {Key: "count", Value: 1}, {Key: "_id", Value: 1}}}
+	if !reflect.DeepEqual(pipeline[3], expectSort) {
+		t.Errorf("unexpected sort stage %v", pipeline[3])
+	}
+	if !reflect.DeepEqual(sumNames, []string{"replicas"}) {
+		t.Errorf("unexpected sum names %v", sumNames)
+	}
+	expectGroup := operator.M{"$group": operator.M{
+		"_id":   operator.M{"g0": "$namespace", "g1": "$data.metadata.labels.app"},
+		"count": operator.M{"$sum": 1},
+		"s0":    operator.M{"$sum": "$data.spec.replicas"},
+	}}
+	if !reflect.DeepEqual(pipeline[2], expectGroup) {
+		t.Errorf("unexpected group stage %v", pipeline[2])
+	}
+	if !reflect.DeepEqual(pipeline[4], operator.M{"$limit": int64(storeActionDefaultLimit)}) {
+		t.Errorf("unexpected limit stage %v", pipeline[4])
+	}
+
+	opt.Sort = []StoreAggregateSort{{Key: "unknown", Order: 1}}
+	if _, _, err := buildAggregationPipeline(cond, opt); err == nil {
+		t.Errorf("expect error for unknown sort key")
+	}
+	opt.Sort = []StoreAggregateSort{{Key: "count", Order: 0}}
+	if _, _, err := buildAggregationPipeline(cond, opt); err == nil {
+		t.Errorf("expect error for invalid sort order")
+	}
+
+	// limit without sort keys is still applied on a stable order
+	opt.Sort = nil
+	pipeline, _, err = buildAggregationPipeline(cond, opt)
+	if err != nil {
+		t.Fatalf("build pipeline failed, err %s", err.Error())
+	}
+	if !reflect.DeepEqual(pipeline[3], operator.M{"$sort": primitive.D{{Key: "_id", Value: 1}}}) {
+		t.Errorf("unexpected sort stage %v", pipeline[3])
+	}
+	opt.Sum = map[string]string{"a.b": "data.spec.replicas"}
+	if _, _, err := buildAggregationPipeline(cond, opt); err == nil {
+		t.Errorf("expect error for invalid sum name")
+	}
+}
+
+func TestFormatAggregationResult(t *testing.T) {
+	mList := []operator.M{
+		{"_id": operator.M{"g0": "default"}, "count": 3, "s0": 6},
+	}
+	ret := formatAggregationResult(mList, []string{"namespace"}, []string{"replicas"})
+	expect := []operator.M{
+		{"group": operator.M{"namespace": "default"}, "count": 3, "sum": operator.M{"replicas": 6}},
+	}
+	if !reflect.DeepEqual(ret, expect) {
+		t.Errorf("unexpected result %v", ret)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.,
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lib
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/drivers"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/tracing/utils"
+)
+
+// StoreBulkOperation operation for bulk write action
+type StoreBulkOperation struct {
+	Cond *operator.Condition
+	// Data data to upsert, not used by removing
+	Data operator.M
+	// Remove remove data matching Cond instead of upserting
+	Remove bool
+}
+
+// StoreBulkOption option for bulk write action
+type StoreBulkOption struct {
+	UniqueKey     []string
+	CreateTimeKey string
+	UpdateTimeKey string
+}
+
+// BulkWrite upsert or remove data in one request, it returns error of each operation,
+// and removing data which does not exist is not taken as error
+func (a *Store) BulkWrite(ctx context.Context, resourceType string, ops []*StoreBulkOperation,
+	opt *StoreBulkOption) ([]error, error) {
+	const (
+		OperationName   = "storage-BulkWrite"
+		OperationMethod = "BulkWrite"
+	)
+	span, ctx := utils.StartSpanFromContext(ctx, OperationName)
+	defer span.Finish()
+	setDBSpanTags(a, span, resourceType, OperationMethod)
+
+	if opt == nil {
+		err := fmt.Errorf("StoreBulkOption cannot be empty")
+		utils.SetSpanLogTagError(span, err)
+		return nil, err
+	}
+
+	var index drivers.Index
+	if len(opt.UniqueKey) != 0 {
+		index.Name = resourceType + "_idx"
+		index.Unique = true
+		index.Key = make(map[string]int32)
+		for _, key := range opt.UniqueKey {
+			index.Key[key] = 1
+		}
+	}
+	// ensure table index
+	if err := a.ensureTable(ctx, resourceType, index); err != nil {
+		utils.SetSpanLogTagError(span, err)
+		return nil, err
+	}
+
+	timeNow := time.Now()
+	operations := make([]*drivers.BulkOperation, 0, len(ops))
+	for _, op := range ops {
+		if op.Cond == nil {
+			err := fmt.Errorf("Cond in StoreBulkOperation cannot be empty")
+			utils.SetSpanLogTagError(span, err)
+			return nil, err
+		}
+		if op.Remove {
+			operations = append(operations, a.bulkRemoveOperation(op))
+			continue
+		}
+		operations = append(operations, a.bulkUpsertOperation(op, opt, timeNow))
+	}
+
+	ret, err := a.mDriver.Table(resourceType).BulkWrite(ctx, operations)
+	if err != nil {
+		utils.SetSpanLogTagError(span, err)
+		return nil, err
+	}
+	errs := make([]error, len(ops))
+	for i, opErr := range ret.Errors {
+		if i >= 0 && i < len(errs) {
+			errs[i] = opErr
+		}
+	}
+	return errs, nil
+}
+
+func (a *Store) bulkUpsertOperation(op *StoreBulkOperation, opt *StoreBulkOption,
+	timeNow time.Time) *drivers.BulkOperation {
+	data := dollarHandler(op.Data)
+	// resource version is maintained by database, never by caller
+	delete(data, databaseFieldNameForRevision)
+	update := operator.M{
+		"$currentDate": revisionUpdate(),
+	}
+	if len(opt.CreateTimeKey) != 0 {
+		delete(data, opt.CreateTimeKey)
+		update["$setOnInsert"] = operator.M{opt.CreateTimeKey: timeNow}
+	}
+	if len(opt.UpdateTimeKey) != 0 {
+		data[opt.UpdateTimeKey] = timeNow
+	}
+	if a.doSoftDelete {
+		// overriding the deletion flag value
+		data[databaseFieldNameForDeletionFlag] = false
+	}
+	update["$set"] = data
+	return &drivers.BulkOperation{
+		Type:      drivers.BulkUpsert,
+		Condition: op.Cond,
+		Data:      update,
+	}
+}
+
+func (a *Store) bulkRemoveOperation(op *StoreBulkOperation) *drivers.BulkOperation {
+	if !a.doSoftDelete {
+		return &drivers.BulkOperation{
+			Type:      drivers.BulkDeleteMany,
+			Condition: op.Cond,
+		}
+	}
+	delFlagCond := operator.NewLeafCondition(operator.Ne, operator.M{databaseFieldNameForDeletionFlag: true})
+	return &drivers.BulkOperation{
+		Type:      drivers.BulkUpdateMany,
+		Condition: operator.NewBranchCondition(operator.And, op.Cond, delFlagCond),
+		Data: operator.M{
+			"$set": operator.M{
+				databaseFieldNameForDeletionFlag: true,
+			},
+			"$currentDate": revisionUpdate(),
+		},
+	}
+}
@@ -0,0 +1,308 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.,
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lib
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/drivers"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/tracing/utils"
+)
+
+const (
+	// HistoryDeletedKey key of flag in history version, true means resource was deleted
+	HistoryDeletedKey = "deleted"
+	// HistoryVersionKey key of version number in history version, it increases by one for each version of a resource
+	HistoryVersionKey = "version"
+
+	historyTableSuffix = "_history"
+	// max times of putting history when version number is taken by concurrent put
+	historyPutMaxRetry = 3
+
+	// DiffOpAdd field is added
+	DiffOpAdd = "add"
+	// DiffOpRemove field is removed
+	DiffOpRemove = "remove"
+	// DiffOpReplace value of field is replaced
+	DiffOpReplace = "replace"
+)
+
+// HistoryTableName returns name of table keeping history versions of resources in resourceType table
+func HistoryTableName(resourceType string) string {
+	return resourceType + historyTableSuffix
+}
+
+// StoreHistoryOption option for put history action
+type StoreHistoryOption struct {
+	// Cond condition of the resource
+	Cond *operator.Condition
+	// IndexKey keys identifying a resource, history table is indexed by IndexKey and TimeKey
+	IndexKey []string
+	// TimeKey key of version time
+	TimeKey string
+	// DataKey key of resource data, version is skipped when data is the same as the latest version
+	DataKey string
+}
+
+// PutHistory appends a version of resource into history table of resourceType,
+// it returns false when the version is skipped for nothing changed
+func (a *Store) PutHistory(ctx context.Context, resourceType string, data operator.M, deleted bool,
+	opt *StoreHistoryOption) (bool, error) {
+	const (
+		OperationName   = "storage-PutHistory"
+		OperationMethod = "PutHistory"
+	)
+	span, ctx := utils.StartSpanFromContext(ctx, OperationName)
+	defer span.Finish()
+	tableName := HistoryTableName(resourceType)
+	setDBSpanTags(a, span, tableName, OperationMethod)
+
+	if opt == nil || opt.Cond == nil {
+		err := fmt.Errorf("StoreHistoryOption and its Cond cannot be empty")
+		utils.SetSpanLogTagError(span, err)
+		return false, err
+	}
+
+	index := drivers.Index{
+		Name: tableName + "_idx",
+		Key:  make(map[string]int32),
+	}
+	// version number of a resource is unique, so that concurrent puts cannot take the same version
+	versionIndex := drivers.Index{
+		Name:   tableName + "_version_idx",
+		Key:    make(map[string]int32),
+		Unique: true,
+	}
+	for _, key := range opt.IndexKey {
+		index.Key[key] = 1
+		versionIndex.Key[key] = 1
+	}
+	index.Key[opt.TimeKey] = -1
+	versionIndex.Key[HistoryVersionKey] = -1
+	if err := a.ensureTable(ctx, tableName, index, versionIndex); err != nil {
+		utils.SetSpanLogTagError(span, err)
+		return false, err
+	}
+
+	for i := 0; i < historyPutMaxRetry; i++ {
+		put, err := a.putHistoryVersion(ctx, tableName, data, deleted, opt)
+		if err == drivers.ErrTableRecordDuplicateKey {
+			// version is taken by another put, compare with the new latest version and try again
+			continue
+		}
+		if err != nil {
+			utils.SetSpanLogTagError(span, err)
+		}
+		return put, err
+	}
+	err := fmt.Errorf("put history conflicted for %d times", historyPutMaxRetry)
+	utils.SetSpanLogTagError(span, err)
+	return false, err
+}
+
+// putHistoryVersion inserts data as the next version of the latest one,
+// it returns drivers.ErrTableRecordDuplicateKey when the version is taken by others
+func (a *Store) putHistoryVersion(ctx context.Context, tableName string, data operator.M, deleted bool,
+	opt *StoreHistoryOption) (bool, error) {
+	latest, err := a.Get(ctx, tableName, &StoreGetOption{
+		Cond:  opt.Cond,
+		Sort:  map[string]int{HistoryVersionKey: -1},
+		Limit: 1,
+	})
+	if err != nil {
+		return false, err
+	}
+	var latestVersion int64
+	if len(latest) != 0 {
+		latestDeleted, _ := latest[0][HistoryDeletedKey].(bool)
+		if latestDeleted == deleted && (deleted || isSameData(latest[0][opt.DataKey], data[opt.DataKey])) {
+			return false, nil
+		}
+		latestVersion = historyVersion(latest[0])
+	} else if deleted {
+		// nothing to mark as deleted
+		return false, nil
+	}
+
+	version := CopyMap(data)
+	delete(version, databaseFieldNameForRevision)
+	delete(version, databaseFieldNameForDeletionFlag)
+	delete(version, ResourceVersionKey)
+	if deleted {
+		delete(version, opt.DataKey)
+	}
+	version[opt.TimeKey] = time.Now()
+	version[HistoryDeletedKey] = deleted
+	version[HistoryVersionKey] = latestVersion + 1
+	if _, err := a.mDriver.Table(tableName).Insert(ctx, []interface{}{dollarHandler(version)}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// historyVersion returns version number of history version, number decoded from database may be of any numeric type
+func historyVersion(m operator.M) int64 {
+	switch v := m[HistoryVersionKey].(type) {
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case uint64:
+		return int64(v)
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
+
+// isSameData compare data after json normalization, data decoded from request and from database
+// may be of different go types even if they are the same in json. Keys with "$" are replaced
+// when data is written into database and recovered when read, so both are compared in recovered form
+func isSameData(a, b interface{}) bool {
+	na, errA := normalizeRecoveredData(a)
+	nb, errB := normalizeRecoveredData(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return reflect.DeepEqual(na, nb)
+}
+
+func normalizeRecoveredData(data interface{}) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalData(bytes.Replace(raw, []byte(dollarReplacement), []byte("$"), -1))
+}
+
+func normalizeData(data interface{}) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalData(raw)
+}
+
+func unmarshalData(raw []byte) (interface{}, error) {
+	var normalized interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// FieldDiff difference of a field between two versions of data
+type FieldDiff struct {
+	// Path path of field, joined by dot, array index is used as path element
+	Path     string      `json:"path"`
+	Op       string      `json:"op"`
+	OldValue interface{} `json:"oldValue,omitempty"`
+	NewValue interface{} `json:"newValue,omitempty"`
+}
+
+// DiffData returns differences from oldData to newData, objects and arrays are compared element by element
+func DiffData(oldData, newData interface{}) ([]FieldDiff, error) {
+	oldNormalized, err := normalizeData(oldData)
+	if err != nil {
+		return nil, err
+	}
+	newNormalized, err := normalizeData(newData)
+	if err != nil {
+		return nil, err
+	}
+	diffs := make([]FieldDiff, 0)
+	diffValue("", oldNormalized, newNormalized, &diffs)
+	return diffs, nil
+}
+
+func joinPath(prefix, key string) string {
+	if len(prefix) == 0 {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func diffValue(path string, oldValue, newValue interface{}, diffs *[]FieldDiff) {
+	switch o := oldValue.(type) {
+	case map[string]interface{}:
+		if n, ok := newValue.(map[string]interface{}); ok {
+			diffMap(path, o, n, diffs)
+			return
+		}
+	case []interface{}:
+		if n, ok := newValue.([]interface{}); ok {
+			diffArray(path, o, n, diffs)
+			return
+		}
+	}
+	if reflect.DeepEqual(oldValue, newValue) {
+		return
+	}
+	switch {
+	case oldValue == nil:
+		*diffs = append(*diffs, FieldDiff{Path: path, Op: DiffOpAdd, NewValue: newValue})
+	case newValue == nil:
+		*diffs = append(*diffs, FieldDiff{Path: path, Op: DiffOpRemove, OldValue: oldValue})
+	default:
+		*diffs = append(*diffs, FieldDiff{Path: path, Op: DiffOpReplace, OldValue: oldValue, NewValue: newValue})
+	}
+}
+
+func diffMap(path string, oldMap, newMap map[string]interface{}, diffs *[]FieldDiff) {
+	keys := make([]string, 0, len(oldMap)+len(newMap))
+	for key := range oldMap {
+		keys = append(keys, key)
+	}
+	for key := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		oldValue, oldOk := oldMap[key]
+		newValue, newOk := newMap[key]
+		switch {
+		case !oldOk:
+			*diffs = append(*diffs, FieldDiff{Path: joinPath(path, key), Op: DiffOpAdd, NewValue: newValue})
+		case !newOk:
+			*diffs = append(*diffs, FieldDiff{Path: joinPath(path, key), Op: DiffOpRemove, OldValue: oldValue})
+		default:
+			diffValue(joinPath(path, key), oldValue, newValue, diffs)
+		}
+	}
+}
+
+func diffArray(path string, oldArray, newArray []interface{}, diffs *[]FieldDiff) {
+	for i := 0; i < len(oldArray) || i < len(newArray); i++ {
+		elemPath := joinPath(path, strconv.Itoa(i))
+		switch {
+		case i >= len(oldArray):
+			*diffs = append(*diffs, FieldDiff{Path: elemPath, Op: DiffOpAdd, NewValue: newArray[i]})
+		case i >= len(newArray):
+			*diffs = append(*diffs, FieldDiff{Path: elemPath, Op: DiffOpRemove, OldValue: oldArray[i]})
+		default:
+			diffValue(elemPath, oldArray[i], newArray[i], diffs)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.,
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lib
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/drivers"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+)
+
+func TestDiffData(t *testing.T) {
+	oldData := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "nginx", "labels": map[string]interface{}{"app": "nginx"}},
+		"spec": map[string]interface{}{
+			"replicas":   2,
+			"containers": []interface{}{map[string]interface{}{"image": "nginx:1.18"}},
+		},
+	}
+	newData := operator.M{
+		"metadata": operator.M{"name": "nginx"},
+		"spec": operator.M{
+			"replicas": float64(3),
+			"containers": []interface{}{
+				operator.M{"image": "nginx:1.19"},
+				operator.M{"image": "sidecar"},
+			},
+		},
+		"status": "Running",
+	}
+	diffs, err := DiffData(oldData, newData)
+	if err != nil {
+		t.Fatalf("diff data failed, err %s", err.Error())
+	}
+	expect := []FieldDiff{
+		{Path: "metadata.labels", Op: DiffOpRemove, OldValue: map[string]interface{}{"app": "nginx"}},
+		{Path: "spec.containers.0.image", Op: DiffOpReplace, OldValue: "nginx:1.18", NewValue: "nginx:1.19"},
+		{Path: "spec.containers.1", Op: DiffOpAdd, NewValue: map[string]interface{}{"image": "sidecar"}},
+		{Path: "spec.replicas", Op: DiffOpReplace, OldValue: float64(2), NewValue: float64(3)},
+		{Path: "status", Op: DiffOpAdd, NewValue: "Running"},
+	}
+	if !reflect.DeepEqual(diffs, expect) {
+		t.Errorf("expect diffs %+v, got %+v", expect, diffs)
+	}
+
+	diffs, err = DiffData(newData, newData)
+	if err != nil || len(diffs) != 0 {
+		t.Errorf("expect no diff for the same data, got %+v, err %v", diffs, err)
+	}
+}
+
+func TestIsSameData(t *testing.T) {
+	if !isSameData(map[string]interface{}{"replicas": 1}, operator.M{"replicas": float64(1)}) {
+		t.Errorf("expect the same data after normalization")
+	}
+	if isSameData(operator.M{"replicas": 1}, operator.M{"replicas": 2}) {
+		t.Errorf("expect different data")
+	}
+}
+
+func TestIsSameDataWithDollarKey(t *testing.T) {
+	stored := dollarRecover(dollarHandler(operator.M{"data": operator.M{"$ref": "a"}}))
+	raw := dollarHandler(operator.M{"data": operator.M{"$ref": "a"}})
+	if !isSameData(stored["data"], raw["data"]) {
+		t.Errorf("expect the same data for keys with dollar, stored %+v, raw %+v", stored, raw)
+	}
+}
+
+// historyDB keeps history table in memory
+type historyDB struct {
+	drivers.DB
+	table *historyTable
+}
+
+func (db *historyDB) DataBase() string {
+	return "test"
+}
+
+func (db *historyDB) HasTable(ctx context.Context, name string) (bool, error) {
+	return true, nil
+}
+
+func (db *historyDB) Table(name string) drivers.Table {
+	return db.table
+}
+
+// historyTable keeps history versions of one resource, and fails inserting
+// with duplicate key error for the first conflicts times
+type historyTable struct {
+	drivers.Table
+	docs      []operator.M
+	conflicts int
+	indexes   map[string]drivers.Index
+}
+
+func (t *historyTable) HasIndex(ctx context.Context, name string) (bool, error) {
+	_, ok := t.indexes[name]
+	return ok, nil
+}
+
+func (t *historyTable) CreateIndex(ctx context.Context, index drivers.Index) error {
+	t.indexes[index.Name] = index
+	return nil
+}
+
+func (t *historyTable) Find(condition *operator.Condition) drivers.Find {
+	return &historyFinder{table: t}
+}
+
+func (t *historyTable) Insert(ctx context.Context, docs []interface{}) (int, error) {
+	doc := docs[0].(operator.M)
+	if t.conflicts > 0 {
+		t.conflicts--
+		// another put takes the version with different data
+		t.docs = append(t.docs, operator.M{
+			"data":            operator.M{"replicas": 0},
+			HistoryVersionKey: doc[HistoryVersionKey],
+		})
+		return 0, drivers.ErrTableRecordDuplicateKey
+	}
+	for _, d := range t.docs {
+		if historyVersion(d) == historyVersion(doc) {
+			return 0, drivers.ErrTableRecordDuplicateKey
+		}
+	}
+	t.docs = append(t.docs, doc)
+	return 1, nil
+}
+
+// historyFinder finds the version with max version number
+type historyFinder struct {
+	drivers.Find
+	table *historyTable
+}
+
+func (f *historyFinder) WithSort(sort map[string]interface{}) drivers.Find {
+	return f
+}
+
+func (f *historyFinder) WithLimit(limit int64) drivers.Find {
+	return f
+}
+
+func (f *historyFinder) All(ctx context.Context, result interface{}) error {
+	docs := make([]operator.M, len(f.table.docs))
+	copy(docs, f.table.docs)
+	sort.Slice(docs, func(i, j int) bool {
+		return historyVersion(docs[i]) > historyVersion(docs[j])
+	})
+	if len(docs) > 1 {
+		docs = docs[:1]
+	}
+	*(result.(*[]operator.M)) = docs
+	return nil
+}
+
+func TestPutHistory(t *testing.T) {
+	opt := &StoreHistoryOption{
+		Cond:     operator.NewLeafCondition(operator.Eq, operator.M{"resourceName": "nginx"}),
+		IndexKey: []string{"resourceName"},
+		TimeKey:  "updateTime",
+		DataKey:  "data",
+	}
+	newData := func(replicas int) operator.M {
+		return operator.M{
+			"resourceName": "nginx",
+			"data":         operator.M{"replicas": replicas, "$ref": "nginx"},
+		}
+	}
+	tests := []struct {
+		name      string
+		conflicts int
+		puts      []operator.M
+		expectPut []bool
+		expectErr bool
+		versions  []int64
+	}{
+		{
+			name:      "same data with dollar key is skipped",
+			puts:      []operator.M{newData(1), newData(1), newData(2)},
+			expectPut: []bool{true, false, true},
+			versions:  []int64{1, 2},
+		},
+		{
+			name:      "retry after version is taken",
+			conflicts: 1,
+			puts:      []operator.M{newData(1)},
+			expectPut: []bool{true},
+			versions:  []int64{1, 2},
+		},
+		{
+			name:      "too many conflicts",
+			conflicts: historyPutMaxRetry,
+			puts:      []operator.M{newData(1)},
+			expectPut: []bool{false},
+			expectErr: true,
+			versions:  []int64{1, 2, 3},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			table := &historyTable{conflicts: test.conflicts, indexes: make(map[string]drivers.Index)}
+			store := NewStore(&historyDB{table: table}, nil)
+			for i, data := range test.puts {
+				put, err := store.PutHistory(context.TODO(), "Deployment", data, false, opt)
+				if (err != nil) != test.expectErr {
+					t.Fatalf("put %d expect error %v, got %v", i, test.expectErr, err)
+				}
+				if put != test.expectPut[i] {
+					t.Errorf("put %d expect %v, got %v", i, test.expectPut[i], put)
+				}
+			}
+			versions := make([]int64, 0, len(table.docs))
+			for _, doc := range table.docs {
+				versions = append(versions, historyVersion(doc))
+			}
+			if !reflect.DeepEqual(versions, test.versions) {
+				t.Errorf("expect versions %v, got %v", test.versions, versions)
+			}
+			if !table.indexes["Deployment_history_version_idx"].Unique {
+				t.Errorf("expect unique version index, got %+v", table.indexes)
+			}
+		})
+	}
+}
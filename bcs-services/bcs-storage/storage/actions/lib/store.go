@@ -22,6 +22,7 @@ import (
 	mapset "github.com/deckarep/golang-set"
 	"github.com/google/uuid"
 	"github.com/opentracing/opentracing-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
 	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/drivers"
@@ -39,8 +40,17 @@ const (
 	databaseFieldNameForDeletionFlag = "_isBcsObjectDeleted"
 	databaseIndexNameForDeletionFlag = "bcs_object_deletion_flag_idx"
 
+	// database key for resource version
+	// the value is set by database timestamp when data is written, so it increases monotonically
+	databaseFieldNameForRevision = "_bcsResourceVersion"
+	// ResourceVersionKey key of resource version in returned data
+	ResourceVersionKey = "resourceVersion"
+
 	// set span dbType
 	dbType = "mongo"
+
+	// interval of sending bookmark event
+	watchBookmarkInterval = 30 * time.Second
 )
 
 // StoreGetOption option for get action
@@ -164,11 +174,27 @@ func (a *Store) Get(ctx context.Context, resourceType string, opt *StoreGetOptio
 				delete(tmpM, databaseFieldNameForDeletionFlag)
 			}
 		}
+		formatRevision(tmpM)
 		retList = append(retList, tmpM)
 	}
 	return retList, nil
 }
 
+// List get data like Get, and returns resource version of the list, watch from the resource version
+// gets all changes after the list. Changes during the list may be both in the list and in the watch
+func (a *Store) List(ctx context.Context, resourceType string, opt *StoreGetOption) ([]operator.M, string, error) {
+	// take the timestamp before query, so that no change is lost between list and watch
+	ts, err := a.mDriver.CurrentTimestamp(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get current timestamp, err %s", err.Error())
+	}
+	mList, err := a.Get(ctx, resourceType, opt)
+	if err != nil {
+		return nil, "", err
+	}
+	return mList, watchbus.FormatRevision(watchbus.TimestampToRevision(ts)), nil
+}
+
 // GetIndex get indexes of table
 func (a *Store) GetIndex(ctx context.Context, resourceType string) (*drivers.Index, error) {
 	const (
@@ -300,7 +326,7 @@ func (a *Store) Count(ctx context.Context, resourceType string, opt *StoreGetOpt
 	return count, nil
 }
 
-func (a *Store) ensureTable(ctx context.Context, tableName string, index drivers.Index) error {
+func (a *Store) ensureTable(ctx context.Context, tableName string, indexes ...drivers.Index) error {
 	// find from cache
 	if a.tableCache.Contains(tableName) {
 		return nil
@@ -316,8 +342,11 @@ func (a *Store) ensureTable(ctx context.Context, tableName string, index drivers
 			return tErr
 		}
 	}
-	// only ensure index when index name is not empty
-	if len(index.Name) != 0 {
+	for _, index := range indexes {
+		// only ensure index when index name is not empty
+		if len(index.Name) == 0 {
+			continue
+		}
 		// find index from db
 		hasIndex, err := a.mDriver.Table(tableName).HasIndex(ctx, index.Name)
 		if err != nil {
@@ -388,12 +417,19 @@ func (a *Store) Put(ctx context.Context, resourceType string, data operator.M, o
 	}
 
 	data = dollarHandler(data)
+	// resource version is maintained by database, never by caller
+	delete(data, databaseFieldNameForRevision)
 
 	timeNow := time.Now()
 	if opt.Cond == nil {
 		data[opt.CreateTimeKey] = timeNow
 		data[databaseFieldNameForDeletionFlag] = false
-		if _, err := a.mDriver.Table(resourceType).Insert(ctx, []interface{}{data}); err != nil {
+		// insert by upserting a new id, so that resource version is stamped by $currentDate as well
+		idCond := operator.NewLeafCondition(operator.Eq, operator.M{"_id": primitive.NewObjectID()})
+		if err := a.mDriver.Table(resourceType).Upsert(ctx, idCond, operator.M{
+			"$set":         data,
+			"$currentDate": revisionUpdate(),
+		}); err != nil {
 			utils.SetSpanLogTagError(span, err)
 			return err
 		}
@@ -419,7 +455,10 @@ func (a *Store) Put(ctx context.Context, resourceType string, data operator.M, o
 	if len(opt.UpdateTimeKey) != 0 {
 		data[opt.UpdateTimeKey] = timeNow
 	}
-	if err := a.mDriver.Table(resourceType).Upsert(ctx, opt.Cond, operator.M{"$set": data}); err != nil {
+	if err := a.mDriver.Table(resourceType).Upsert(ctx, opt.Cond, operator.M{
+		"$set":         data,
+		"$currentDate": revisionUpdate(),
+	}); err != nil {
 		utils.SetSpanLogTagError(span, err)
 		return err
 	}
@@ -472,9 +511,12 @@ func (a *Store) doDelete(ctx context.Context, resourceType string, opt *StoreRem
 func (a *Store) doDeleteSoft(ctx context.Context, resourceType string, opt *StoreRemoveOption) error {
 	delFlagCond := operator.NewLeafCondition(operator.Ne, operator.M{databaseFieldNameForDeletionFlag: true})
 	delCond := operator.NewBranchCondition(operator.And, opt.Cond, delFlagCond)
-	deleteCounter, err := a.mDriver.Table(resourceType).UpdateMany(ctx, delCond, operator.M{"$set": operator.M{
-		databaseFieldNameForDeletionFlag: true,
-	}})
+	deleteCounter, err := a.mDriver.Table(resourceType).UpdateMany(ctx, delCond, operator.M{
+		"$set": operator.M{
+			databaseFieldNameForDeletionFlag: true,
+		},
+		"$currentDate": revisionUpdate(),
+	})
 	if err != nil {
 		return err
 	}
@@ -506,6 +548,8 @@ const (
 	Chg
 	// SChg self change event
 	SChg
+	// Bmk bookmark event, it only carries the latest resource version
+	Bmk
 	// Brk event
 	Brk EventType = -1
 )
@@ -517,6 +561,7 @@ var (
 		Del:  "EventDelete",
 		Chg:  "EventChange",
 		SChg: "EventSelfChange",
+		Bmk:  "EventBookmark",
 		Brk:  "EventWatchBreak",
 	}
 )
@@ -530,8 +575,9 @@ var (
 
 // Event event of watch
 type Event struct {
-	Type  EventType  `json:"type"`
-	Value operator.M `json:"value"`
+	Type            EventType  `json:"type"`
+	Value           operator.M `json:"value"`
+	ResourceVersion string     `json:"resourceVersion,omitempty"`
 }
 
 // StoreWatchOption option for watch action
@@ -541,6 +587,10 @@ type StoreWatchOption struct {
 	MaxEvents uint
 	Timeout   time.Duration
 	MustDiff  string
+	// ResourceVersion resume watch from this revision, 0 for watching from now
+	ResourceVersion uint64
+	// AllowBookmarks send bookmark events periodically
+	AllowBookmarks bool
 }
 
 func watchMatch(data, cond operator.M) bool {
@@ -569,7 +619,14 @@ func (a *Store) Watch(ctx context.Context, resourceType string, opt *StoreWatchO
 	utils.SetSpanCommonTag(span, "uuid", id)
 
 	dbEvent := make(chan *drivers.WatchEvent, 100)
-	err := a.eventBus.Subscribe(resourceType, id, dbEvent)
+	// events in history after resource version, they are sent before events from dbEvent
+	var backlog []*drivers.WatchEvent
+	var err error
+	if opt.ResourceVersion != 0 {
+		backlog, err = a.eventBus.SubscribeFrom(resourceType, id, opt.ResourceVersion, dbEvent)
+	} else {
+		err = a.eventBus.Subscribe(resourceType, id, dbEvent)
+	}
 	if err != nil {
 		utils.SetSpanLogTagError(span, err)
 		return nil, err
@@ -580,76 +637,100 @@ func (a *Store) Watch(ctx context.Context, resourceType string, opt *StoreWatchO
 		defer span.Finish()
 		defer a.eventBus.Unsubscribe(resourceType, id)
 		eventCounter := 0
-		for {
-			select {
-			case e := <-dbEvent:
-				if len(opt.MustDiff) != 0 {
-					if e.Type == drivers.EventUpdate && len(e.UpdatedFields) == 0 && len(e.RemovedFields) == 0 {
-						blog.V(5).Infof("watcher %s of topic %s ignore no-diff update event %+v",
-							id, resourceType, e)
-						continue
-					}
+		// the latest revision seen by this watcher and the latest one sent to client
+		lastRevision := opt.ResourceVersion
+		sentRevision := opt.ResourceVersion
+
+		send := func(t EventType, value operator.M) {
+			event := &Event{Type: t, Value: value}
+			if lastRevision != 0 {
+				event.ResourceVersion = watchbus.FormatRevision(lastRevision)
+				sentRevision = lastRevision
+			}
+			retEvent <- event
+		}
+		// handle returns false when watch should be stopped
+		handle := func(e *drivers.WatchEvent) bool {
+			if rev := watchbus.Revision(e); rev != 0 {
+				lastRevision = rev
+			}
+			if len(opt.MustDiff) != 0 {
+				if e.Type == drivers.EventUpdate && len(e.UpdatedFields) == 0 && len(e.RemovedFields) == 0 {
+					blog.V(5).Infof("watcher %s of topic %s ignore no-diff update event %+v",
+						id, resourceType, e)
+					return true
 				}
-				if len(opt.Cond) != 0 {
-					if e.Type == drivers.EventAdd || e.Type == drivers.EventUpdate || e.Type == drivers.EventDelete {
-						if !watchMatch(e.Data, opt.Cond) {
-							continue
-						}
+			}
+			if len(opt.Cond) != 0 {
+				if e.Type == drivers.EventAdd || e.Type == drivers.EventUpdate || e.Type == drivers.EventDelete {
+					if !watchMatch(e.Data, opt.Cond) {
+						return true
 					}
 				}
-				switch e.Type {
-				case drivers.EventAdd:
-					retEvent <- &Event{
-						Type:  Add,
-						Value: e.Data,
-					}
-				case drivers.EventUpdate:
-					// send delete event when doing soft delete and meeting delete flag
-					if a.doSoftDelete {
-						if deleteFlagValue, ok := e.UpdatedFields[databaseFieldNameForDeletionFlag]; ok {
-							deleteFlag, assertOk := deleteFlagValue.(bool)
-							if assertOk && deleteFlag {
-								retEvent <- &Event{
-									Type:  Del,
-									Value: e.Data,
-								}
-							}
+			}
+			switch e.Type {
+			case drivers.EventAdd:
+				send(Add, e.Data)
+			case drivers.EventUpdate:
+				// send delete event when doing soft delete and meeting delete flag
+				if a.doSoftDelete {
+					if deleteFlagValue, ok := e.UpdatedFields[databaseFieldNameForDeletionFlag]; ok {
+						deleteFlag, assertOk := deleteFlagValue.(bool)
+						if assertOk && deleteFlag {
+							send(Del, e.Data)
 						}
 					}
+				}
+				send(Chg, e.Data)
+			case drivers.EventDelete:
+				// ignore delete event when doing soft delete
+				if a.doSoftDelete {
+					return true
+				}
+				send(Del, e.Data)
+			case drivers.EventError, drivers.EventClose:
+				retEvent <- &Event{
+					Type:  Brk,
+					Value: e.Data,
+				}
+				return false
+			default:
+				send(Nop, e.Data)
+			}
+			eventCounter++
+			if opt.MaxEvents != 0 {
+				if uint(eventCounter) >= opt.MaxEvents {
+					blog.Infof("watcher %s for topic %s exceeds max event %d", id, resourceType, opt.MaxEvents)
 					retEvent <- &Event{
-						Type:  Chg,
-						Value: e.Data,
-					}
-				case drivers.EventDelete:
-					// ignore delete event when doing soft delete
-					if a.doSoftDelete {
-						continue
-					}
-					retEvent <- &Event{
-						Type:  Del,
-						Value: e.Data,
-					}
-				case drivers.EventError, drivers.EventClose:
-					retEvent <- &Event{
-						Type:  Brk,
-						Value: e.Data,
+						Type: Brk,
 					}
+					return false
+				}
+			}
+			return true
+		}
+
+		for _, e := range backlog {
+			if !handle(e) {
+				return
+			}
+		}
+		var bookmarkCh <-chan time.Time
+		if opt.AllowBookmarks {
+			ticker := time.NewTicker(watchBookmarkInterval)
+			defer ticker.Stop()
+			bookmarkCh = ticker.C
+		}
+		for {
+			select {
+			case e := <-dbEvent:
+				if !handle(e) {
 					return
-				default:
-					retEvent <- &Event{
-						Type:  Nop,
-						Value: e.Data,
-					}
 				}
-				eventCounter++
-				if opt.MaxEvents != 0 {
-					if uint(eventCounter) >= opt.MaxEvents {
-						blog.Infof("watcher %s for topic %s exceeds max event %d", id, resourceType, opt.MaxEvents)
-						retEvent <- &Event{
-							Type: Brk,
-						}
-						return
-					}
+			case <-bookmarkCh:
+				// events filtered by condition still move the resume point forward
+				if lastRevision > sentRevision {
+					send(Bmk, nil)
 				}
 			}
 		}
@@ -657,6 +738,28 @@ func (a *Store) Watch(ctx context.Context, resourceType string, opt *StoreWatchO
 	return retEvent, nil
 }
 
+// revisionUpdate returns $currentDate operation to refresh resource version
+func revisionUpdate() operator.M {
+	return operator.M{
+		databaseFieldNameForRevision: operator.M{"$type": "timestamp"},
+	}
+}
+
+// formatRevision replace database resource version field with readable resource version
+func formatRevision(m operator.M) {
+	value, ok := m[databaseFieldNameForRevision]
+	if !ok {
+		return
+	}
+	delete(m, databaseFieldNameForRevision)
+	if ts, ok := value.(primitive.Timestamp); ok {
+		m[ResourceVersionKey] = watchbus.FormatRevision(watchbus.TimestampToRevision(drivers.Timestamp{
+			Second: ts.T,
+			Index:  ts.I,
+		}))
+	}
+}
+
 func setDBSpanTags(s *Store, span opentracing.Span, table string, operation string) {
 	utils.SetSpanDBTag(span, utils.DBType, dbType)
 	utils.SetSpanDBTag(span, utils.DBDatabase, s.mDriver.DataBase())
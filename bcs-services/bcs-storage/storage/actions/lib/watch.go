@@ -18,11 +18,14 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/Tencent/bk-bcs/bcs-common/common"
 	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
 	"github.com/Tencent/bk-bcs/bcs-common/common/codec"
 	bcstypes "github.com/Tencent/bk-bcs/bcs-common/common/types"
 	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
 	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions/utils/metrics"
+	storageErr "github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/errors"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/watchbus"
 
 	restful "github.com/emicklei/go-restful"
 )
@@ -88,6 +91,29 @@ func (ws *WatchServer) Go(ctx context.Context) {
 		ws.opts = &bcstypes.WatchOptions{}
 	}
 
+	revision, err := watchbus.ParseRevision(ws.opts.ResourceVersion)
+	if err != nil {
+		blog.Errorf(ws.sprint(fmt.Sprintf("parse resource version %s failed", ws.opts.ResourceVersion)))
+		ws.returnError(err)
+		return
+	}
+	watchOption := &StoreWatchOption{
+		Cond:            ws.cond,
+		SelfOnly:        ws.opts.SelfOnly,
+		MaxEvents:       ws.opts.MaxEvents,
+		Timeout:         ws.opts.Timeout,
+		MustDiff:        ws.opts.MustDiff,
+		ResourceVersion: revision,
+		AllowBookmarks:  ws.opts.AllowBookmarks,
+	}
+	// subscribe before writing header, so that client can tell the resource version is too old
+	event, err := ws.store.Watch(ctx, ws.tableName, watchOption)
+	if err != nil {
+		blog.Errorf(ws.sprint(fmt.Sprintf("watch failed, err %s", err.Error())))
+		ws.returnError(err)
+		return
+	}
+
 	// metrics
 	metrics.ReportWatchRequestInc(ws.req.SelectedRoutePath(), ws.tableName)
 
@@ -97,25 +123,12 @@ func (ws *WatchServer) Go(ctx context.Context) {
 
 	blog.Infof(ws.sprint("begin to watch"))
 
-	watchOption := &StoreWatchOption{
-		Cond:      ws.cond,
-		SelfOnly:  ws.opts.SelfOnly,
-		MaxEvents: ws.opts.MaxEvents,
-		Timeout:   ws.opts.Timeout,
-		MustDiff:  ws.opts.MustDiff,
-	}
-
 	defer func() {
 		blog.Infof(ws.sprint("watch end"))
 		metrics.ReportWatchRequestDec(ws.req.SelectedRoutePath(), ws.tableName)
 		ws.Writer(ws.resp, EventWatchBreak)
 		ws.resp.ResponseWriter.(http.Flusher).Flush()
 	}()
-	event, err := ws.store.Watch(ctx, ws.tableName, watchOption)
-	if err != nil {
-		blog.Errorf("watch failed, err %s", err.Error())
-		return
-	}
 
 	for {
 		select {
@@ -139,6 +152,24 @@ func (ws *WatchServer) Go(ctx context.Context) {
 	}
 }
 
+// returnError return error before watch starts
+func (ws *WatchServer) returnError(err error) {
+	resp := &RestResponse{
+		Resp:     ws.resp,
+		HTTPCode: http.StatusInternalServerError,
+		ErrCode:  common.BcsErrStorageGetResourceFail,
+		Message:  err.Error(),
+	}
+	if se, ok := err.(*storageErr.StorageError); ok {
+		resp.HTTPCode = http.StatusBadRequest
+		resp.ErrCode = se.Code
+		if se == storageErr.ResourceVersionTooOld {
+			resp.HTTPCode = http.StatusGone
+		}
+	}
+	ReturnRest(resp)
+}
+
 func (ws *WatchServer) sprint(s string) string {
 	return fmt.Sprintf("watch server %s | %s", ws.req.Request.URL, s)
 }
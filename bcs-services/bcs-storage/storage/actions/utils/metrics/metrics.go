@@ -57,6 +57,18 @@ var (
 		Help:      "BCS storage queue push operation latency statistic.",
 		Buckets:   []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.0, 3.0},
 	}, []string{"name", "status"})
+
+	// retention policy metrics
+	retentionPrunedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: BkBcsStorage,
+		Name:      "retention_pruned_total",
+		Help:      "The total number of documents pruned by retention policy",
+	}, []string{"database", "policy"})
+	retentionCleanTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: BkBcsStorage,
+		Name:      "retention_clean_total",
+		Help:      "The total number of retention policy cleanings",
+	}, []string{"database", "policy", "status"})
 )
 
 func init() {
@@ -66,6 +78,9 @@ func init() {
 	// queue
 	prometheus.MustRegister(queuePushTotal)
 	prometheus.MustRegister(queuePushLatency)
+	// retention
+	prometheus.MustRegister(retentionPrunedTotal)
+	prometheus.MustRegister(retentionCleanTotal)
 }
 
 // ReportWatchRequestInc report watch connection inc
@@ -92,3 +107,13 @@ func ReportQueuePushMetrics(name string, err error, started time.Time) {
 	queuePushTotal.WithLabelValues(name, status).Inc()
 	queuePushLatency.WithLabelValues(name, status).Observe(time.Since(started).Seconds())
 }
+
+// ReportRetentionCleanMetrics report documents pruned by retention policy
+func ReportRetentionCleanMetrics(database, policy string, pruned int64, err error) {
+	status := pushStatusSuccess
+	if err != nil {
+		status = pushStatusFail
+	}
+	retentionCleanTotal.WithLabelValues(database, policy, status).Inc()
+	retentionPrunedTotal.WithLabelValues(database, policy).Add(float64(pruned))
+}
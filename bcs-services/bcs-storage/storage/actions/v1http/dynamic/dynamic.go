@@ -14,7 +14,10 @@
 package dynamic
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Tencent/bk-bcs/bcs-common/common"
 	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
@@ -22,6 +25,8 @@ import (
 	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions"
 	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions/lib"
 	v1http "github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions/v1http/utils"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/apiserver"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/clean"
 
 	"github.com/emicklei/go-restful"
 )
@@ -177,7 +182,7 @@ func ListNamespaceResources(req *restful.Request, resp *restful.Response) {
 	span := v1http.SetHTTPSpanContextInfo(req, handler)
 	defer span.Finish()
 
-	r, err := listNamespaceResources(req)
+	r, extra, err := listNamespaceResources(req)
 	if err != nil {
 		utils.SetSpanLogTagError(span, err)
 		blog.Errorf("%s | err: %v", common.BcsErrStorageListResourceFailStr, err)
@@ -188,7 +193,7 @@ func ListNamespaceResources(req *restful.Request, resp *restful.Response) {
 			Message: common.BcsErrStorageListResourceFailStr})
 		return
 	}
-	lib.ReturnRest(&lib.RestResponse{Resp: resp, Data: r})
+	lib.ReturnRest(&lib.RestResponse{Resp: resp, Data: r, Extra: extra})
 }
 
 // ListClusterResources list cluster resources
@@ -199,7 +204,7 @@ func ListClusterResources(req *restful.Request, resp *restful.Response) {
 	span := v1http.SetHTTPSpanContextInfo(req, handler)
 	defer span.Finish()
 
-	r, err := listClusterResources(req)
+	r, extra, err := listClusterResources(req)
 	if err != nil {
 		utils.SetSpanLogTagError(span, err)
 		blog.Errorf("%s | err: %v", common.BcsErrStorageListResourceFailStr, err)
@@ -210,9 +215,181 @@ func ListClusterResources(req *restful.Request, resp *restful.Response) {
 			Message: common.BcsErrStorageListResourceFailStr})
 		return
 	}
+	lib.ReturnRest(&lib.RestResponse{Resp: resp, Data: r, Extra: extra})
+}
+
+// AggregateNamespaceResources count and sum namespaced resources by groups
+func AggregateNamespaceResources(req *restful.Request, resp *restful.Response) {
+	const (
+		handler = "AggregateNamespaceResources"
+	)
+	span := v1http.SetHTTPSpanContextInfo(req, handler)
+	defer span.Finish()
+
+	r, err := aggregateNamespaceResources(req)
+	if err != nil {
+		utils.SetSpanLogTagError(span, err)
+		blog.Errorf("%s | err: %v", common.BcsErrStorageListResourceFailStr, err)
+		lib.ReturnRest(&lib.RestResponse{
+			Resp:    resp,
+			Data:    []string{},
+			ErrCode: common.BcsErrStorageListResourceFail,
+			Message: common.BcsErrStorageListResourceFailStr})
+		return
+	}
+	lib.ReturnRest(&lib.RestResponse{Resp: resp, Data: r})
+}
+
+// AggregateClusterResources count and sum cluster resources by groups
+func AggregateClusterResources(req *restful.Request, resp *restful.Response) {
+	const (
+		handler = "AggregateClusterResources"
+	)
+	span := v1http.SetHTTPSpanContextInfo(req, handler)
+	defer span.Finish()
+
+	r, err := aggregateClusterResources(req)
+	if err != nil {
+		utils.SetSpanLogTagError(span, err)
+		blog.Errorf("%s | err: %v", common.BcsErrStorageListResourceFailStr, err)
+		lib.ReturnRest(&lib.RestResponse{
+			Resp:    resp,
+			Data:    []string{},
+			ErrCode: common.BcsErrStorageListResourceFail,
+			Message: common.BcsErrStorageListResourceFailStr})
+		return
+	}
+	lib.ReturnRest(&lib.RestResponse{Resp: resp, Data: r})
+}
+
+// BulkWriteResources upsert or delete multiple resources of cluster in one request
+func BulkWriteResources(req *restful.Request, resp *restful.Response) {
+	const (
+		handler = "BulkWriteResources"
+	)
+	span := v1http.SetHTTPSpanContextInfo(req, handler)
+	defer span.Finish()
+
+	r, err := bulkWriteResources(req)
+	if err != nil {
+		utils.SetSpanLogTagError(span, err)
+		blog.Errorf("%s | err: %v", common.BcsErrStoragePutResourceFailStr, err)
+		lib.ReturnRest(&lib.RestResponse{
+			Resp:    resp,
+			Data:    []string{},
+			ErrCode: common.BcsErrStoragePutResourceFail,
+			Message: common.BcsErrStoragePutResourceFailStr})
+		return
+	}
 	lib.ReturnRest(&lib.RestResponse{Resp: resp, Data: r})
 }
 
+// GetNamespaceResourceHistory get version of namespaced resource as of the time
+func GetNamespaceResourceHistory(req *restful.Request, resp *restful.Response) {
+	const (
+		handler = "GetNamespaceResourceHistory"
+	)
+	span := v1http.SetHTTPSpanContextInfo(req, handler)
+	defer span.Finish()
+
+	r, err := getNamespaceResourceHistory(req)
+	if err != nil {
+		utils.SetSpanLogTagError(span, err)
+		blog.Errorf("%s | err: %v", common.BcsErrStorageGetResourceFailStr, err)
+		lib.ReturnRest(&lib.RestResponse{
+			Resp:    resp,
+			Data:    []string{},
+			ErrCode: common.BcsErrStorageGetResourceFail,
+			Message: common.BcsErrStorageGetResourceFailStr})
+		return
+	}
+	lib.ReturnRest(&lib.RestResponse{Resp: resp, Data: r})
+}
+
+// GetClusterResourceHistory get version of cluster resource as of the time
+func GetClusterResourceHistory(req *restful.Request, resp *restful.Response) {
+	const (
+		handler = "GetClusterResourceHistory"
+	)
+	span := v1http.SetHTTPSpanContextInfo(req, handler)
+	defer span.Finish()
+
+	r, err := getClusterResourceHistory(req)
+	if err != nil {
+		utils.SetSpanLogTagError(span, err)
+		blog.Errorf("%s | err: %v", common.BcsErrStorageGetResourceFailStr, err)
+		lib.ReturnRest(&lib.RestResponse{
+			Resp:    resp,
+			Data:    []string{},
+			ErrCode: common.BcsErrStorageGetResourceFail,
+			Message: common.BcsErrStorageGetResourceFailStr})
+		return
+	}
+	lib.ReturnRest(&lib.RestResponse{Resp: resp, Data: r})
+}
+
+// ListNamespaceResourceRevisions list versions of namespaced resource with diffs
+func ListNamespaceResourceRevisions(req *restful.Request, resp *restful.Response) {
+	const (
+		handler = "ListNamespaceResourceRevisions"
+	)
+	span := v1http.SetHTTPSpanContextInfo(req, handler)
+	defer span.Finish()
+
+	r, err := listNamespaceResourceRevisions(req)
+	if err != nil {
+		utils.SetSpanLogTagError(span, err)
+		blog.Errorf("%s | err: %v", common.BcsErrStorageGetResourceFailStr, err)
+		lib.ReturnRest(&lib.RestResponse{
+			Resp:    resp,
+			Data:    []string{},
+			ErrCode: common.BcsErrStorageGetResourceFail,
+			Message: common.BcsErrStorageGetResourceFailStr})
+		return
+	}
+	lib.ReturnRest(&lib.RestResponse{Resp: resp, Data: r})
+}
+
+// ListClusterResourceRevisions list versions of cluster resource with diffs
+func ListClusterResourceRevisions(req *restful.Request, resp *restful.Response) {
+	const (
+		handler = "ListClusterResourceRevisions"
+	)
+	span := v1http.SetHTTPSpanContextInfo(req, handler)
+	defer span.Finish()
+
+	r, err := listClusterResourceRevisions(req)
+	if err != nil {
+		utils.SetSpanLogTagError(span, err)
+		blog.Errorf("%s | err: %v", common.BcsErrStorageGetResourceFailStr, err)
+		lib.ReturnRest(&lib.RestResponse{
+			Resp:    resp,
+			Data:    []string{},
+			ErrCode: common.BcsErrStorageGetResourceFail,
+			Message: common.BcsErrStorageGetResourceFailStr})
+		return
+	}
+	lib.ReturnRest(&lib.RestResponse{Resp: resp, Data: r})
+}
+
+// CleanHistory clean expired history versions of dynamic resources
+func CleanHistory() {
+	maxTime := apiserver.GetAPIResource().Conf.HistoryMaxTime
+	if maxTime <= 0 {
+		return
+	}
+	for _, table := range strings.Split(apiserver.GetAPIResource().Conf.HistoryResourceTypes, ",") {
+		table = strings.TrimSpace(table)
+		if len(table) == 0 {
+			continue
+		}
+		cleaner := clean.NewDBCleaner(apiserver.GetAPIResource().GetDBClient(dbConfig),
+			lib.HistoryTableName(table), time.Hour)
+		cleaner.WithMaxDuration(time.Duration(maxTime*24)*time.Hour, updateTimeTag)
+		go cleaner.Run(context.TODO())
+	}
+}
+
 // DeleteBatchNamespaceResource delete multiple namespaced resources
 func DeleteBatchNamespaceResource(req *restful.Request, resp *restful.Response) {
 	const (
@@ -422,6 +599,57 @@ func init() {
 		Params:  nil,
 		Handler: lib.MarkProcess(DeleteBatchClusterResource)})
 
+	// Bulk write.
+	k8sBulkResourcesPath := urlPathK8S("/dynamic/bulk/clusters/{clusterId}")
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "POST",
+		Path:    k8sBulkResourcesPath,
+		Params:  nil,
+		Handler: lib.MarkProcess(BulkWriteResources)})
+
+	// Aggregation.
+	k8sAggregateNamespaceResourcesPath := urlPathK8S(
+		"/dynamic/aggregation/namespace_resources/clusters/{clusterId}/namespaces/{namespace}/{resourceType}")
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "POST",
+		Path:    k8sAggregateNamespaceResourcesPath,
+		Params:  nil,
+		Handler: lib.MarkProcess(AggregateNamespaceResources)})
+	k8sAggregateClusterResourcesPath := urlPathK8S(
+		"/dynamic/aggregation/cluster_resources/clusters/{clusterId}/{resourceType}")
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "POST",
+		Path:    k8sAggregateClusterResourcesPath,
+		Params:  nil,
+		Handler: lib.MarkProcess(AggregateClusterResources)})
+
+	// History.
+	k8sNamespaceResourceHistoryPath := urlPathK8S(
+		"/dynamic/history/namespace_resources/clusters/{clusterId}/namespaces/{namespace}/{resourceType}/{resourceName}")
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "GET",
+		Path:    k8sNamespaceResourceHistoryPath,
+		Params:  nil,
+		Handler: lib.MarkProcess(GetNamespaceResourceHistory)})
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "GET",
+		Path:    k8sNamespaceResourceHistoryPath + "/revisions",
+		Params:  nil,
+		Handler: lib.MarkProcess(ListNamespaceResourceRevisions)})
+
+	k8sClusterResourceHistoryPath := urlPathK8S(
+		"/dynamic/history/cluster_resources/clusters/{clusterId}/{resourceType}/{resourceName}")
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "GET",
+		Path:    k8sClusterResourceHistoryPath,
+		Params:  nil,
+		Handler: lib.MarkProcess(GetClusterResourceHistory)})
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "GET",
+		Path:    k8sClusterResourceHistoryPath + "/revisions",
+		Params:  nil,
+		Handler: lib.MarkProcess(ListClusterResourceRevisions)})
+
 	// All Ops.
 	k8sAllResourcesPath := urlPathK8S(
 		"/dynamic/all_resources/clusters/{clusterId}/{resourceType}")
@@ -501,6 +729,57 @@ func init() {
 		Params:  nil,
 		Handler: lib.MarkProcess(DeleteBatchClusterResource)})
 
+	// Bulk write.
+	mesosBulkResourcesPath := urlPathMesos("/dynamic/bulk/clusters/{clusterId}")
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "POST",
+		Path:    mesosBulkResourcesPath,
+		Params:  nil,
+		Handler: lib.MarkProcess(BulkWriteResources)})
+
+	// Aggregation.
+	mesosAggregateNamespaceResourcesPath := urlPathMesos(
+		"/dynamic/aggregation/namespace_resources/clusters/{clusterId}/namespaces/{namespace}/{resourceType}")
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "POST",
+		Path:    mesosAggregateNamespaceResourcesPath,
+		Params:  nil,
+		Handler: lib.MarkProcess(AggregateNamespaceResources)})
+	mesosAggregateClusterResourcesPath := urlPathMesos(
+		"/dynamic/aggregation/cluster_resources/clusters/{clusterId}/{resourceType}")
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "POST",
+		Path:    mesosAggregateClusterResourcesPath,
+		Params:  nil,
+		Handler: lib.MarkProcess(AggregateClusterResources)})
+
+	// History.
+	mesosNamespaceResourceHistoryPath := urlPathMesos(
+		"/dynamic/history/namespace_resources/clusters/{clusterId}/namespaces/{namespace}/{resourceType}/{resourceName}")
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "GET",
+		Path:    mesosNamespaceResourceHistoryPath,
+		Params:  nil,
+		Handler: lib.MarkProcess(GetNamespaceResourceHistory)})
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "GET",
+		Path:    mesosNamespaceResourceHistoryPath + "/revisions",
+		Params:  nil,
+		Handler: lib.MarkProcess(ListNamespaceResourceRevisions)})
+
+	mesosClusterResourceHistoryPath := urlPathMesos(
+		"/dynamic/history/cluster_resources/clusters/{clusterId}/{resourceType}/{resourceName}")
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "GET",
+		Path:    mesosClusterResourceHistoryPath,
+		Params:  nil,
+		Handler: lib.MarkProcess(GetClusterResourceHistory)})
+	actions.RegisterV1Action(actions.Action{
+		Verb:    "GET",
+		Path:    mesosClusterResourceHistoryPath + "/revisions",
+		Params:  nil,
+		Handler: lib.MarkProcess(ListClusterResourceRevisions)})
+
 	// All Ops.
 	mesosAllResourcesPath := urlPathMesos(
 		"/dynamic/all_resources/clusters/{clusterId}/{resourceType}")
@@ -545,4 +824,6 @@ func init() {
 		Path:    customResourceIndexPath,
 		Params:  nil,
 		Handler: lib.MarkProcess(DeleteCustomResourcesIndex)})
-}
\ No newline at end of file
+
+	actions.RegisterDaemonFunc(CleanHistory)
+}
@@ -14,7 +14,10 @@
 package dynamic
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/emicklei/go-restful"
@@ -49,6 +52,15 @@ const (
 	updateTimeTag = "updateTime"
 	createTimeTag = "createTime"
 
+	// max number of operations in one bulk request
+	maxBulkOperations = 1000
+
+	historyTimeTag = "time"
+	timeBeginTag   = "timeBegin"
+	timeEndTag     = "timeEnd"
+	withDataTag    = "withData"
+	diffTag        = "diff"
+
 	applicationTypeName = "application"
 	processTypeName     = "process"
 	kindTag             = "data.kind"
@@ -146,12 +158,12 @@ func getClusterResources(req *restful.Request) ([]operator.M, error) {
 	return getResources(req, csFeatTags)
 }
 
-func listNamespaceResources(req *restful.Request) ([]operator.M, error) {
-	return getResources(req, nsListFeatTags)
+func listNamespaceResources(req *restful.Request) ([]operator.M, operator.M, error) {
+	return listResources(req, nsListFeatTags)
 }
 
-func listClusterResources(req *restful.Request) ([]operator.M, error) {
-	return getResources(req, csListFeatTags)
+func listClusterResources(req *restful.Request) ([]operator.M, operator.M, error) {
+	return listResources(req, csListFeatTags)
 }
 
 func getCustomResources(req *restful.Request) ([]operator.M, operator.M, error) {
@@ -193,6 +205,24 @@ func getResources(req *restful.Request, resourceFeatList []string) ([]operator.M
 	return mList, err
 }
 
+// listResources list resources with resourceVersion of the list in extra, which can be used to start watch
+func listResources(req *restful.Request, resourceFeatList []string) ([]operator.M, operator.M, error) {
+	getOption, err := getStoreOption(req, resourceFeatList)
+	if err != nil {
+		return nil, nil, err
+	}
+	store := lib.NewStore(
+		apiserver.GetAPIResource().GetDBClient(dbConfig),
+		apiserver.GetAPIResource().GetEventBus(dbConfig))
+	store.SetSoftDeletion(true)
+	mList, resourceVersion, err := store.List(req.Request.Context(), getTable(req), getOption)
+	if err != nil {
+		return nil, nil, err
+	}
+	lib.FormatTime(mList, needTimeFormatList)
+	return mList, operator.M{lib.ResourceVersionKey: resourceVersion}, nil
+}
+
 func getResourcesWithPageInfo(req *restful.Request, resourceFeatList []string) (data []operator.M, extra operator.M, err error) {
 	getOption, err := getStoreOption(req, resourceFeatList)
 	if err != nil {
@@ -220,6 +250,38 @@ func getResourcesWithPageInfo(req *restful.Request, resourceFeatList []string) (
 	return mList, extra, err
 }
 
+func aggregateNamespaceResources(req *restful.Request) ([]operator.M, error) {
+	return aggregateResources(req, nsListFeatTags)
+}
+
+func aggregateClusterResources(req *restful.Request) ([]operator.M, error) {
+	return aggregateResources(req, csListFeatTags)
+}
+
+func aggregateResources(req *restful.Request, resourceFeatList []string) ([]operator.M, error) {
+	var data types.BcsStorageDynamicAggregationIf
+	if err := codec.DecJsonReader(req.Request.Body, &data); err != nil {
+		return nil, err
+	}
+	sortKeys := make([]lib.StoreAggregateSort, 0, len(data.Sort))
+	for _, s := range data.Sort {
+		sortKeys = append(sortKeys, lib.StoreAggregateSort{Key: s.Key, Order: s.Order})
+	}
+	aggOption := &lib.StoreAggregateOption{
+		Cond:    getCondition(req, resourceFeatList),
+		GroupBy: data.GroupBy,
+		Unwind:  data.Unwind,
+		Sum:     data.Sum,
+		Sort:    sortKeys,
+		Limit:   data.Limit,
+	}
+	store := lib.NewStore(
+		apiserver.GetAPIResource().GetDBClient(dbConfig),
+		apiserver.GetAPIResource().GetEventBus(dbConfig))
+	store.SetSoftDeletion(true)
+	return store.Aggregate(req.Request.Context(), getTable(req), aggOption)
+}
+
 func getReqData(req *restful.Request, features operator.M) (operator.M, error) {
 	var tmp types.BcsStorageDynamicIf
 	if err := codec.DecJsonReader(req.Request.Body, &tmp); err != nil {
@@ -323,10 +385,142 @@ func putResources(req *restful.Request, resourceFeatList []string) (operator.M,
 	if err != nil {
 		return nil, err
 	}
+	putHistory(req.Request.Context(), getTable(req), data, false)
 
 	return data, nil
 }
 
+// bulkTableOperations operations of bulk request for one table
+type bulkTableOperations struct {
+	featTags []string
+	indexes  []int
+	ops      []*lib.StoreBulkOperation
+	features []operator.M
+}
+
+// bulkWriteResources upsert or delete resources of a cluster in one request, and returns result of each operation
+func bulkWriteResources(req *restful.Request) ([]types.BcsStorageDynamicBulkResultIf, error) {
+	var bulk types.BcsStorageDynamicBulkIf
+	if err := codec.DecJsonReader(req.Request.Body, &bulk); err != nil {
+		return nil, err
+	}
+	if len(bulk.Operations) > maxBulkOperations {
+		return nil, fmt.Errorf("too many operations in bulk request, max %d", maxBulkOperations)
+	}
+	clusterID := req.PathParameter(clusterIDTag)
+	results := make([]types.BcsStorageDynamicBulkResultIf, len(bulk.Operations))
+
+	// group operations by table, operations in each table are executed without order,
+	// so one resource can only be operated once in a request
+	tables := make([]string, 0)
+	tableOps := make(map[string]*bulkTableOperations)
+	resources := make(map[string]int)
+	for i, bulkOp := range bulk.Operations {
+		if len(bulkOp.ResourceType) == 0 || len(bulkOp.ResourceName) == 0 {
+			results[i].Message = "resourceType and resourceName cannot be empty"
+			continue
+		}
+		if bulkOp.Action != types.BcsStorageBulkActionUpsert && bulkOp.Action != types.BcsStorageBulkActionDelete {
+			results[i].Message = fmt.Sprintf("unknown action %s", bulkOp.Action)
+			continue
+		}
+		table := bulkOp.ResourceType
+		// for mesos
+		if table == processTypeName {
+			table = applicationTypeName
+		}
+		resourceKey := table + "/" + bulkOp.Namespace + "/" + bulkOp.ResourceName
+		if j, ok := resources[resourceKey]; ok {
+			results[i].Message = fmt.Sprintf("resource is duplicated with operation %d in bulk request", j)
+			continue
+		}
+		resources[resourceKey] = i
+		featTags := csFeatTags
+		features := operator.M{
+			clusterIDTag:    clusterID,
+			resourceTypeTag: bulkOp.ResourceType,
+			resourceNameTag: bulkOp.ResourceName,
+		}
+		if len(bulkOp.Namespace) != 0 {
+			featTags = nsFeatTags
+			features[namespaceTag] = bulkOp.Namespace
+		}
+		storeOp := &lib.StoreBulkOperation{
+			Cond:   operator.NewLeafCondition(operator.Eq, features),
+			Remove: bulkOp.Action == types.BcsStorageBulkActionDelete,
+		}
+		data := lib.CopyMap(features)
+		data[dataTag] = bulkOp.Data
+		if !storeOp.Remove {
+			storeOp.Data = data
+		}
+
+		t, ok := tableOps[table]
+		if !ok {
+			t = &bulkTableOperations{featTags: featTags}
+			tableOps[table] = t
+			tables = append(tables, table)
+		}
+		t.indexes = append(t.indexes, i)
+		t.ops = append(t.ops, storeOp)
+		t.features = append(t.features, data)
+	}
+
+	store := lib.NewStore(
+		apiserver.GetAPIResource().GetDBClient(dbConfig),
+		apiserver.GetAPIResource().GetEventBus(dbConfig))
+	store.SetSoftDeletion(true)
+	for _, table := range tables {
+		t := tableOps[table]
+		errs, err := store.BulkWrite(req.Request.Context(), table, t.ops, &lib.StoreBulkOption{
+			UniqueKey:     t.featTags,
+			CreateTimeKey: createTimeTag,
+			UpdateTimeKey: updateTimeTag,
+		})
+		for j, i := range t.indexes {
+			switch {
+			case err != nil:
+				results[i].Message = err.Error()
+			case errs[j] != nil:
+				results[i].Message = errs[j].Error()
+			default:
+				results[i].Result = true
+			}
+		}
+		if err != nil {
+			blog.Errorf("bulk write table %s failed, err %s", table, err.Error())
+			continue
+		}
+		for j, data := range t.features {
+			if errs[j] == nil {
+				putHistory(req.Request.Context(), table, data, t.ops[j].Remove)
+			}
+		}
+
+		// queueFlag true
+		if apiserver.GetAPIResource().GetMsgQueue().QueueFlag {
+			go publishBulkResourcesToQueue(t, errs)
+		}
+	}
+	return results, nil
+}
+
+// publishBulkResourcesToQueue publish succeeded operations, deletions without data are skipped
+func publishBulkResourcesToQueue(t *bulkTableOperations, errs []error) {
+	for j, data := range t.features {
+		if errs[j] != nil || data[dataTag] == nil {
+			continue
+		}
+		event := msgqueue.EventTypeUpdate
+		if t.ops[j].Remove {
+			event = msgqueue.EventTypeDelete
+		}
+		if err := publishDynamicResourceToQueue(data, t.featTags, event); err != nil {
+			blog.Errorf("func[%s] call publishDynamicResourceToQueue failed: err[%v]", "bulkWriteResources", err)
+		}
+	}
+}
+
 func deleteNamespaceResources(req *restful.Request) error {
 	mList, err := deleteResources(req, nsFeatTags)
 	if err != nil {
@@ -404,6 +598,9 @@ func deleteResources(req *restful.Request, resourceFeatList []string) ([]operato
 	if err != nil {
 		return nil, err
 	}
+	for _, data := range mList {
+		putHistory(req.Request.Context(), getTable(req), data, true)
+	}
 
 	return mList, nil
 }
@@ -498,10 +695,188 @@ func deleteBatchResources(req *restful.Request, resourceFeatList []string) ([]op
 	if err != nil {
 		return nil, err
 	}
+	for _, data := range mList {
+		putHistory(req.Request.Context(), getTable(req), data, true)
+	}
 
 	return mList, nil
 }
 
+// isHistoryEnabled check whether history versions of resources in table are kept
+func isHistoryEnabled(table string) bool {
+	for _, t := range strings.Split(apiserver.GetAPIResource().Conf.HistoryResourceTypes, ",") {
+		if strings.TrimSpace(t) == table {
+			return true
+		}
+	}
+	return false
+}
+
+func getHistoryStore() *lib.Store {
+	// history versions are appended only, soft deletion is not needed
+	return lib.NewStore(
+		apiserver.GetAPIResource().GetDBClient(dbConfig),
+		apiserver.GetAPIResource().GetEventBus(dbConfig))
+}
+
+// putHistory record version of resource when history of table is enabled,
+// failure is only logged because the resource itself has been written
+func putHistory(ctx context.Context, table string, data operator.M, deleted bool) {
+	if !isHistoryEnabled(table) {
+		return
+	}
+	featTags := csFeatTags
+	if _, ok := data[namespaceTag]; ok {
+		featTags = nsFeatTags
+	}
+	features := make(operator.M)
+	for _, key := range featTags {
+		features[key] = data[key]
+	}
+	version := lib.CopyMap(features)
+	version[dataTag] = data[dataTag]
+	if _, err := getHistoryStore().PutHistory(ctx, table, version, deleted, &lib.StoreHistoryOption{
+		Cond:     operator.NewLeafCondition(operator.Eq, features),
+		IndexKey: featTags,
+		TimeKey:  updateTimeTag,
+		DataKey:  dataTag,
+	}); err != nil {
+		blog.Errorf("put history of table %s for %v failed, err %s", table, features, err.Error())
+	}
+}
+
+func getNamespaceResourceHistory(req *restful.Request) ([]operator.M, error) {
+	return getResourceHistory(req, nsFeatTags)
+}
+
+func getClusterResourceHistory(req *restful.Request) ([]operator.M, error) {
+	return getResourceHistory(req, csFeatTags)
+}
+
+// getResourceHistory returns version of resource as of the time in query, or the latest version when time is not set.
+// Result is empty when resource did not exist at that time.
+func getResourceHistory(req *restful.Request, resourceFeatList []string) ([]operator.M, error) {
+	if !isHistoryEnabled(getTable(req)) {
+		return nil, fmt.Errorf("history of %s is not enabled", getTable(req))
+	}
+	asOf, err := lib.GetQueryParamInt64(req, historyTimeTag, 0)
+	if err != nil {
+		return nil, err
+	}
+	condition := operator.NewLeafCondition(operator.Eq, getFeatures(req, resourceFeatList))
+	if asOf > 0 {
+		condition = operator.NewBranchCondition(operator.And, condition,
+			operator.NewLeafCondition(operator.Lte, operator.M{updateTimeTag: time.Unix(asOf, 0)}))
+	}
+	mList, err := getHistoryStore().Get(req.Request.Context(), lib.HistoryTableName(getTable(req)),
+		&lib.StoreGetOption{
+			Cond:  condition,
+			Sort:  map[string]int{updateTimeTag: -1},
+			Limit: 1,
+		})
+	if err != nil {
+		return nil, err
+	}
+	if len(mList) == 0 {
+		return mList, nil
+	}
+	if deleted, _ := mList[0][lib.HistoryDeletedKey].(bool); deleted {
+		return []operator.M{}, nil
+	}
+	lib.FormatTime(mList, needTimeFormatList)
+	return mList, nil
+}
+
+func listNamespaceResourceRevisions(req *restful.Request) ([]operator.M, error) {
+	return listResourceRevisions(req, nsFeatTags)
+}
+
+func listClusterResourceRevisions(req *restful.Request) ([]operator.M, error) {
+	return listResourceRevisions(req, csFeatTags)
+}
+
+// listResourceRevisions returns versions of resource in time range in ascending order, with diff
+// from the previous version. The first version without previous one carries its whole data instead.
+func listResourceRevisions(req *restful.Request, resourceFeatList []string) ([]operator.M, error) {
+	if !isHistoryEnabled(getTable(req)) {
+		return nil, fmt.Errorf("history of %s is not enabled", getTable(req))
+	}
+	timeBegin, err := lib.GetQueryParamInt64(req, timeBeginTag, 0)
+	if err != nil {
+		return nil, err
+	}
+	timeEnd, err := lib.GetQueryParamInt64(req, timeEndTag, 0)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := lib.GetQueryParamInt64(req, limitTag, 0)
+	if err != nil {
+		return nil, err
+	}
+	withData := req.QueryParameter(withDataTag) == "true"
+
+	store := getHistoryStore()
+	table := lib.HistoryTableName(getTable(req))
+	featCondition := operator.NewLeafCondition(operator.Eq, getFeatures(req, resourceFeatList))
+	condList := []*operator.Condition{featCondition}
+	if timeBegin > 0 {
+		condList = append(condList, operator.NewLeafCondition(operator.Gte, operator.M{
+			updateTimeTag: time.Unix(timeBegin, 0)}))
+	}
+	if timeEnd > 0 {
+		condList = append(condList, operator.NewLeafCondition(operator.Lte, operator.M{
+			updateTimeTag: time.Unix(timeEnd, 0)}))
+	}
+	mList, err := store.Get(req.Request.Context(), table, &lib.StoreGetOption{
+		Cond:  operator.NewBranchCondition(operator.And, condList...),
+		Sort:  map[string]int{updateTimeTag: 1},
+		Limit: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// version before the time range is the base of the first diff
+	var previous operator.M
+	if timeBegin > 0 && len(mList) != 0 {
+		prevList, err := store.Get(req.Request.Context(), table, &lib.StoreGetOption{
+			Cond: operator.NewBranchCondition(operator.And, featCondition,
+				operator.NewLeafCondition(operator.Lt, operator.M{updateTimeTag: time.Unix(timeBegin, 0)})),
+			Sort:  map[string]int{updateTimeTag: -1},
+			Limit: 1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(prevList) != 0 {
+			previous = prevList[0]
+		}
+	}
+
+	revisions := make([]operator.M, 0, len(mList))
+	for _, version := range mList {
+		revision := operator.M{
+			updateTimeTag:         version[updateTimeTag],
+			lib.HistoryDeletedKey: version[lib.HistoryDeletedKey],
+			lib.HistoryVersionKey: version[lib.HistoryVersionKey],
+		}
+		if previous == nil || withData {
+			revision[dataTag] = version[dataTag]
+		}
+		if previous != nil {
+			diffs, err := lib.DiffData(previous[dataTag], version[dataTag])
+			if err != nil {
+				return nil, err
+			}
+			revision[diffTag] = diffs
+		}
+		revisions = append(revisions, revision)
+		previous = version
+	}
+	lib.FormatTime(revisions, needTimeFormatList)
+	return revisions, nil
+}
+
 func createCustomResourcesIndex(req *restful.Request) error {
 	index := drivers.Index{
 		Unique: true,
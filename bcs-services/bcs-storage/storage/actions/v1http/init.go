@@ -24,5 +24,6 @@ import (
 	_ "github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions/v1http/hostconfig"
 	_ "github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions/v1http/metric"
 	_ "github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions/v1http/metricwatch"
+	_ "github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions/v1http/retention"
 	_ "github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions/v1http/watchk8smesos"
 )
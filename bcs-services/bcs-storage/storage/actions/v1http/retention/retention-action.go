@@ -0,0 +1,160 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.,
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retention
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/emicklei/go-restful"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common"
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/tracing/utils"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions/lib"
+	v1http "github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions/v1http/utils"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/apiserver"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/clean"
+)
+
+const (
+	databaseTag   = "database"
+	tableTag      = "table"
+	clusterIDTag  = "clusterId"
+	createTimeTag = "createTime"
+	updateTimeTag = "updateTime"
+
+	// policies are only supported by mongodb
+	dbConfigPrefix = "mongodb/"
+)
+
+var needTimeFormatList = []string{createTimeTag, updateTimeTag}
+
+// ListPolicies list retention policies of database or table
+func ListPolicies(req *restful.Request, resp *restful.Response) {
+	const (
+		handler = "ListRetentionPolicies"
+	)
+	span := v1http.SetHTTPSpanContextInfo(req, handler)
+	defer span.Finish()
+
+	r, err := listPolicies(req)
+	if err != nil {
+		utils.SetSpanLogTagError(span, err)
+		blog.Errorf("%s | err: %v", common.BcsErrStorageListResourceFailStr, err)
+		lib.ReturnRest(&lib.RestResponse{
+			Resp:    resp,
+			Data:    []string{},
+			ErrCode: common.BcsErrStorageListResourceFail,
+			Message: common.BcsErrStorageListResourceFailStr})
+		return
+	}
+	lib.ReturnRest(&lib.RestResponse{Resp: resp, Data: r})
+}
+
+// CreatePolicy create retention policy
+func CreatePolicy(req *restful.Request, resp *restful.Response) {
+	const (
+		handler = "CreateRetentionPolicy"
+	)
+	span := v1http.SetHTTPSpanContextInfo(req, handler)
+	defer span.Finish()
+
+	if err := putPolicy(req, false); err != nil {
+		utils.SetSpanLogTagError(span, err)
+		blog.Errorf("%s | err: %v", common.BcsErrStoragePutResourceFailStr, err)
+		lib.ReturnRest(&lib.RestResponse{
+			Resp:    resp,
+			ErrCode: common.BcsErrStoragePutResourceFail,
+			Message: common.BcsErrStoragePutResourceFailStr + " " + err.Error()})
+		return
+	}
+	lib.ReturnRest(&lib.RestResponse{Resp: resp})
+}
+
+// UpdatePolicy update retention policy
+func UpdatePolicy(req *restful.Request, resp *restful.Response) {
+	const (
+		handler = "UpdateRetentionPolicy"
+	)
+	span := v1http.SetHTTPSpanContextInfo(req, handler)
+	defer span.Finish()
+
+	if err := putPolicy(req, true); err != nil {
+		utils.SetSpanLogTagError(span, err)
+		blog.Errorf("%s | err: %v", common.BcsErrStoragePutResourceFailStr, err)
+		lib.ReturnRest(&lib.RestResponse{
+			Resp:    resp,
+			ErrCode: common.BcsErrStoragePutResourceFail,
+			Message: common.BcsErrStoragePutResourceFailStr + " " + err.Error()})
+		return
+	}
+	lib.ReturnRest(&lib.RestResponse{Resp: resp})
+}
+
+// DeletePolicy delete retention policy
+func DeletePolicy(req *restful.Request, resp *restful.Response) {
+	const (
+		handler = "DeleteRetentionPolicy"
+	)
+	span := v1http.SetHTTPSpanContextInfo(req, handler)
+	defer span.Finish()
+
+	if err := deletePolicy(req); err != nil {
+		utils.SetSpanLogTagError(span, err)
+		blog.Errorf("%s | err: %v", common.BcsErrStorageDeleteResourceFailStr, err)
+		lib.ReturnRest(&lib.RestResponse{
+			Resp:    resp,
+			ErrCode: common.BcsErrStorageDeleteResourceFail,
+			Message: common.BcsErrStorageDeleteResourceFailStr})
+		return
+	}
+	lib.ReturnRest(&lib.RestResponse{Resp: resp})
+}
+
+// CleanByPolicies run policy cleaner for each mongodb database
+func CleanByPolicies() {
+	interval := time.Duration(apiserver.GetAPIResource().Conf.RetentionCheckInterval) * time.Second
+	if interval <= 0 {
+		blog.Infof("retention check interval is not set, retention policies are disabled")
+		return
+	}
+	for _, key := range apiserver.GetAPIResource().GetDBClientKeys() {
+		if !strings.HasPrefix(key, dbConfigPrefix) {
+			continue
+		}
+		cleaner := clean.NewPolicyCleaner(apiserver.GetAPIResource().GetDBClient(key),
+			strings.TrimPrefix(key, dbConfigPrefix), interval)
+		go cleaner.Run(context.TODO())
+	}
+}
+
+func init() {
+	databasePath := "/retention_policies/{database}"
+	actions.RegisterV1Action(actions.Action{
+		Verb: "GET", Path: databasePath, Params: nil, Handler: lib.MarkProcess(ListPolicies)})
+
+	tablePath := "/retention_policies/{database}/{table}"
+	actions.RegisterV1Action(actions.Action{
+		Verb: "GET", Path: tablePath, Params: nil, Handler: lib.MarkProcess(ListPolicies)})
+	actions.RegisterV1Action(actions.Action{
+		Verb: "POST", Path: tablePath, Params: nil, Handler: lib.MarkProcess(CreatePolicy)})
+	actions.RegisterV1Action(actions.Action{
+		Verb: "PUT", Path: tablePath, Params: nil, Handler: lib.MarkProcess(UpdatePolicy)})
+	actions.RegisterV1Action(actions.Action{
+		Verb: "DELETE", Path: tablePath, Params: nil, Handler: lib.MarkProcess(DeletePolicy)})
+
+	actions.RegisterDaemonFunc(CleanByPolicies)
+}
@@ -0,0 +1,128 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.,
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package retention
+
+import (
+	"fmt"
+
+	"github.com/emicklei/go-restful"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/codec"
+	"github.com/Tencent/bk-bcs/bcs-common/common/types"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions/lib"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/apiserver"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/clean"
+)
+
+func getStore(req *restful.Request) (*lib.Store, error) {
+	dbConfig := dbConfigPrefix + req.PathParameter(databaseTag)
+	db := apiserver.GetAPIResource().GetDBClient(dbConfig)
+	if db == nil {
+		return nil, fmt.Errorf("database %s does not exist", req.PathParameter(databaseTag))
+	}
+	return lib.NewStore(db, apiserver.GetAPIResource().GetEventBus(dbConfig)), nil
+}
+
+func getPolicy(req *restful.Request) *clean.RetentionPolicy {
+	return &clean.RetentionPolicy{
+		Table:     req.PathParameter(tableTag),
+		ClusterID: req.QueryParameter(clusterIDTag),
+	}
+}
+
+func listPolicies(req *restful.Request) ([]operator.M, error) {
+	store, err := getStore(req)
+	if err != nil {
+		return nil, err
+	}
+	features := make(operator.M)
+	if table := req.PathParameter(tableTag); len(table) != 0 {
+		features[tableTag] = table
+	}
+	if clusterID := req.QueryParameter(clusterIDTag); len(clusterID) != 0 {
+		features[clusterIDTag] = clusterID
+	}
+	condition := operator.EmptyCondition
+	if len(features) != 0 {
+		condition = operator.NewLeafCondition(operator.Eq, features)
+	}
+	mList, err := store.Get(req.Request.Context(), clean.PolicyTableName, &lib.StoreGetOption{
+		Cond:           condition,
+		IsAllDocuments: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	lib.FormatTime(mList, needTimeFormatList)
+	return mList, nil
+}
+
+// putPolicy create policy when it does not exist, or update policy when mustExist is true
+func putPolicy(req *restful.Request, mustExist bool) error {
+	var data types.BcsStorageRetentionPolicyIf
+	if err := codec.DecJsonReader(req.Request.Body, &data); err != nil {
+		return err
+	}
+	policy := getPolicy(req)
+	policy.TTL = data.TTL
+	policy.MaxCount = data.MaxCount
+	policy.KeepLastPerName = data.KeepLastPerName
+	policy.TimeTag = data.TimeTag
+	policy.NameTag = data.NameTag
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	store, err := getStore(req)
+	if err != nil {
+		return err
+	}
+	mList, err := store.Get(req.Request.Context(), clean.PolicyTableName, &lib.StoreGetOption{
+		Cond: policy.Condition(),
+	})
+	if err != nil {
+		return err
+	}
+	if mustExist && len(mList) == 0 {
+		return fmt.Errorf("retention policy %s does not exist", policy.Name())
+	}
+	if !mustExist && len(mList) != 0 {
+		return fmt.Errorf("retention policy %s already exists", policy.Name())
+	}
+
+	return store.Put(req.Request.Context(), clean.PolicyTableName, operator.M{
+		tableTag:          policy.Table,
+		clusterIDTag:      policy.ClusterID,
+		"ttl":             policy.TTL,
+		"maxCount":        policy.MaxCount,
+		"keepLastPerName": policy.KeepLastPerName,
+		"timeTag":         policy.TimeTag,
+		"nameTag":         policy.NameTag,
+	}, &lib.StorePutOption{
+		UniqueKey:     []string{tableTag, clusterIDTag},
+		Cond:          policy.Condition(),
+		CreateTimeKey: createTimeTag,
+		UpdateTimeKey: updateTimeTag,
+	})
+}
+
+func deletePolicy(req *restful.Request) error {
+	store, err := getStore(req)
+	if err != nil {
+		return err
+	}
+	return store.Remove(req.Request.Context(), clean.PolicyTableName, &lib.StoreRemoveOption{
+		Cond: getPolicy(req).Condition(),
+	})
+}
@@ -16,6 +16,7 @@ package apiserver
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -254,6 +255,16 @@ func (a *APIResource) GetDBClient(key string) drivers.DB {
 	return a.dbMap[key]
 }
 
+// GetDBClientKeys get keys of all db clients
+func (a *APIResource) GetDBClientKeys() []string {
+	keys := make([]string, 0, len(a.dbMap))
+	for key := range a.dbMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // GetStoreClient get store client by keys
 func (a *APIResource) GetStoreClient(key string) store.Store {
 	return a.storeMap[key]
@@ -319,6 +330,15 @@ func (a *APIResource) parseMongodb(key string, dbConf *conf.Config) error {
 		ebus.SetCondition(operator.NewBranchCondition(operator.Mat,
 			operator.NewLeafCondition(operator.Ne, operator.M{"operationType": "delete"})))
 	}
+	// number of events kept for each table to resume watch
+	watchHistorySizeRaw := dbConf.Read(key, "WatchHistorySize")
+	if len(watchHistorySizeRaw) != 0 {
+		watchHistorySize, err := strconv.Atoi(watchHistorySizeRaw)
+		if err != nil {
+			return err
+		}
+		ebus.SetHistorySize(watchHistorySize)
+	}
 	a.dbMap[key] = mongoDB
 	a.ebusMap[key] = ebus
 	blog.Infof("init mongo db with key %s successfully", key)
@@ -54,36 +54,11 @@ func (dbc *DBCleaner) WithMaxDuration(maxDuration time.Duration, timeTagName str
 
 func (dbc *DBCleaner) doNumClean() error {
 	if dbc.maxEntryNum != 0 {
-		total, err := dbc.db.Table(dbc.tableName).Find(operator.EmptyCondition).Count(context.TODO())
+		deleteCounter, err := cleanByNum(dbc.db, dbc.tableName, operator.EmptyCondition, dbc.timeTagName, dbc.maxEntryNum)
 		if err != nil {
-			return fmt.Errorf("count table %s failed, err %s", dbc.tableName, err.Error())
+			return err
 		}
-		if total > dbc.maxEntryNum {
-			var toDelete operator.M
-			if err := dbc.db.Table(dbc.tableName).Find(operator.EmptyCondition).
-				WithSort(map[string]interface{}{
-					dbc.timeTagName: -1,
-				}).WithStart(dbc.maxEntryNum-1).
-				WithLimit(1).
-				One(context.TODO(), &toDelete); err != nil {
-				return fmt.Errorf("find delete edge failed, err %s", err.Error())
-			}
-
-			timeObj, ok := toDelete[dbc.timeTagName]
-			if !ok {
-				return fmt.Errorf("data %+v does not have time tag %s", toDelete, dbc.timeTagName)
-			}
-			timeEdge, asok := timeObj.(time.Time)
-			if !asok {
-				return fmt.Errorf("field %+v with time tag %s is not time.Time", timeObj, dbc.timeTagName)
-			}
-			deleteCounter, err := dbc.db.Table(dbc.tableName).Delete(context.TODO(),
-				operator.NewLeafCondition(operator.Lt, operator.M{
-					dbc.timeTagName: timeEdge,
-				}))
-			if err != nil {
-				return fmt.Errorf("delete entry with time less than %s", timeEdge.String())
-			}
+		if deleteCounter != 0 {
 			blog.Infof("cleaned %d entry of table %s", deleteCounter, dbc.tableName)
 		}
 	}
@@ -92,20 +67,69 @@ func (dbc *DBCleaner) doNumClean() error {
 
 func (dbc *DBCleaner) doTimeClean() error {
 	if dbc.maxDuration != 0 {
-		now := time.Now()
-		timeEdge := now.Add(-dbc.maxDuration)
-		deleteCounter, err := dbc.db.Table(dbc.tableName).Delete(context.TODO(),
-			operator.NewLeafCondition(operator.Lt, operator.M{
-				dbc.timeTagName: timeEdge,
-			}))
+		deleteCounter, err := cleanByTime(dbc.db, dbc.tableName, operator.EmptyCondition, dbc.timeTagName, dbc.maxDuration)
 		if err != nil {
-			return fmt.Errorf("delete entry with time less than %s", timeEdge.String())
+			return err
 		}
 		blog.Infof("cleaned %d entry of table %s", deleteCounter, dbc.tableName)
 	}
 	return nil
 }
 
+// cleanByNum keep the latest maxEntryNum entries matching cond, and delete the others
+func cleanByNum(db drivers.DB, tableName string, cond *operator.Condition, timeTagName string,
+	maxEntryNum int64) (int64, error) {
+	total, err := db.Table(tableName).Find(cond).Count(context.TODO())
+	if err != nil {
+		return 0, fmt.Errorf("count table %s failed, err %s", tableName, err.Error())
+	}
+	if total <= maxEntryNum {
+		return 0, nil
+	}
+	var toDelete operator.M
+	if err := db.Table(tableName).Find(cond).
+		WithSort(map[string]interface{}{
+			timeTagName: -1,
+		}).WithStart(maxEntryNum-1).
+		WithLimit(1).
+		One(context.TODO(), &toDelete); err != nil {
+		return 0, fmt.Errorf("find delete edge failed, err %s", err.Error())
+	}
+
+	timeObj, ok := toDelete[timeTagName]
+	if !ok {
+		return 0, fmt.Errorf("data %+v does not have time tag %s", toDelete, timeTagName)
+	}
+	timeEdge, asok := timeObj.(time.Time)
+	if !asok {
+		return 0, fmt.Errorf("field %+v with time tag %s is not time.Time", timeObj, timeTagName)
+	}
+	deleteCounter, err := db.Table(tableName).Delete(context.TODO(),
+		operator.NewBranchCondition(operator.And, cond,
+			operator.NewLeafCondition(operator.Lt, operator.M{
+				timeTagName: timeEdge,
+			})))
+	if err != nil {
+		return 0, fmt.Errorf("delete entry with time less than %s", timeEdge.String())
+	}
+	return deleteCounter, nil
+}
+
+// cleanByTime delete entries matching cond which are older than maxDuration
+func cleanByTime(db drivers.DB, tableName string, cond *operator.Condition, timeTagName string,
+	maxDuration time.Duration) (int64, error) {
+	timeEdge := time.Now().Add(-maxDuration)
+	deleteCounter, err := db.Table(tableName).Delete(context.TODO(),
+		operator.NewBranchCondition(operator.And, cond,
+			operator.NewLeafCondition(operator.Lt, operator.M{
+				timeTagName: timeEdge,
+			})))
+	if err != nil {
+		return 0, fmt.Errorf("delete entry with time less than %s", timeEdge.String())
+	}
+	return deleteCounter, nil
+}
+
 // Run run cleaner
 func (dbc *DBCleaner) Run(ctx context.Context) {
 	ticker := time.NewTicker(dbc.checkInterval)
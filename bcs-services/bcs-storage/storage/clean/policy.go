@@ -0,0 +1,252 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.,
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clean
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/common/blog"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/drivers"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+	"github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/actions/utils/metrics"
+)
+
+const (
+	// PolicyTableName table for retention policies, each database keeps policies of its own tables
+	PolicyTableName = "retentionPolicy"
+
+	// DefaultTimeTag default time field to decide which documents are older
+	DefaultTimeTag = "updateTime"
+	// DefaultNameTag default name field for keeping last N documents per resource name
+	DefaultNameTag = "resourceName"
+
+	policyTableTag = "table"
+	clusterIDTag   = "clusterId"
+	namespaceTag   = "namespace"
+	groupNameTag   = "name"
+	groupCountTag  = "count"
+)
+
+// RetentionPolicy retention policy of a table, or of a cluster in the table when ClusterID is set.
+// Policy of cluster takes precedence over policy of the whole table.
+type RetentionPolicy struct {
+	Table     string `json:"table" bson:"table"`
+	ClusterID string `json:"clusterId" bson:"clusterId"`
+	// TTL seconds to keep documents
+	TTL int64 `json:"ttl" bson:"ttl"`
+	// MaxCount max number of documents to keep
+	MaxCount int64 `json:"maxCount" bson:"maxCount"`
+	// KeepLastPerName max number of documents to keep for each resource name
+	KeepLastPerName int64 `json:"keepLastPerName" bson:"keepLastPerName"`
+	// TimeTag time field of documents, default updateTime
+	TimeTag string `json:"timeTag" bson:"timeTag"`
+	// NameTag name field of documents for KeepLastPerName, default resourceName
+	NameTag string `json:"nameTag" bson:"nameTag"`
+}
+
+// Name name of policy used in logs and metrics
+func (p *RetentionPolicy) Name() string {
+	if len(p.ClusterID) == 0 {
+		return p.Table
+	}
+	return p.Table + "/" + p.ClusterID
+}
+
+// Validate check whether policy is valid
+func (p *RetentionPolicy) Validate() error {
+	if len(p.Table) == 0 {
+		return fmt.Errorf("table of retention policy cannot be empty")
+	}
+	if p.Table == PolicyTableName {
+		return fmt.Errorf("table %s is reserved for retention policies", PolicyTableName)
+	}
+	if p.TTL < 0 || p.MaxCount < 0 || p.KeepLastPerName < 0 {
+		return fmt.Errorf("ttl, maxCount and keepLastPerName cannot be negative")
+	}
+	if p.TTL == 0 && p.MaxCount == 0 && p.KeepLastPerName == 0 {
+		return fmt.Errorf("at least one of ttl, maxCount and keepLastPerName should be set")
+	}
+	return nil
+}
+
+// Condition returns condition for finding the policy
+func (p *RetentionPolicy) Condition() *operator.Condition {
+	return operator.NewLeafCondition(operator.Eq, operator.M{
+		policyTableTag: p.Table,
+		clusterIDTag:   p.ClusterID,
+	})
+}
+
+func (p *RetentionPolicy) timeTag() string {
+	if len(p.TimeTag) == 0 {
+		return DefaultTimeTag
+	}
+	return p.TimeTag
+}
+
+func (p *RetentionPolicy) nameTag() string {
+	if len(p.NameTag) == 0 {
+		return DefaultNameTag
+	}
+	return p.NameTag
+}
+
+// scopeCondition returns condition of documents governed by the policy,
+// clusters which have their own policies are excluded from the policy of whole table
+func (p *RetentionPolicy) scopeCondition(excludedClusters []string) *operator.Condition {
+	if len(p.ClusterID) != 0 {
+		return operator.NewLeafCondition(operator.Eq, operator.M{clusterIDTag: p.ClusterID})
+	}
+	if len(excludedClusters) == 0 {
+		return operator.EmptyCondition
+	}
+	return operator.NewLeafCondition(operator.Nin, operator.M{clusterIDTag: excludedClusters})
+}
+
+// PolicyCleaner cleans tables of a database by the retention policies stored in it.
+// Policies are reloaded in every round, so changes take effect without restart.
+type PolicyCleaner struct {
+	db            drivers.DB
+	dbName        string
+	checkInterval time.Duration
+}
+
+// NewPolicyCleaner create policy cleaner
+func NewPolicyCleaner(db drivers.DB, dbName string, checkInterval time.Duration) *PolicyCleaner {
+	return &PolicyCleaner{
+		db:            db,
+		dbName:        dbName,
+		checkInterval: checkInterval,
+	}
+}
+
+// Run run policy cleaner
+func (pc *PolicyCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(pc.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := pc.doClean(); err != nil {
+				blog.Warnf("do retention policy clean of %s failed, err %s", pc.dbName, err.Error())
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (pc *PolicyCleaner) doClean() error {
+	policies := make([]*RetentionPolicy, 0)
+	if err := pc.db.Table(PolicyTableName).Find(operator.EmptyCondition).
+		All(context.TODO(), &policies); err != nil {
+		return fmt.Errorf("list retention policies failed, err %s", err.Error())
+	}
+	clusterPolicies := make(map[string][]string)
+	for _, p := range policies {
+		if len(p.ClusterID) != 0 {
+			clusterPolicies[p.Table] = append(clusterPolicies[p.Table], p.ClusterID)
+		}
+	}
+	for _, p := range policies {
+		if err := p.Validate(); err != nil {
+			blog.Warnf("skip invalid retention policy %s of %s, err %s", p.Name(), pc.dbName, err.Error())
+			continue
+		}
+		deleteCounter, err := pc.cleanByPolicy(p, p.scopeCondition(clusterPolicies[p.Table]))
+		metrics.ReportRetentionCleanMetrics(pc.dbName, p.Name(), deleteCounter, err)
+		if err != nil {
+			blog.Warnf("clean by retention policy %s of %s failed, err %s", p.Name(), pc.dbName, err.Error())
+			continue
+		}
+		if deleteCounter != 0 {
+			blog.Infof("cleaned %d entry of table %s by retention policy %s", deleteCounter, p.Table, p.Name())
+		}
+	}
+	return nil
+}
+
+func (pc *PolicyCleaner) cleanByPolicy(p *RetentionPolicy, cond *operator.Condition) (int64, error) {
+	var total int64
+	if p.TTL != 0 {
+		deleteCounter, err := cleanByTime(pc.db, p.Table, cond, p.timeTag(), time.Duration(p.TTL)*time.Second)
+		if err != nil {
+			return total, err
+		}
+		total += deleteCounter
+	}
+	if p.KeepLastPerName != 0 {
+		deleteCounter, err := pc.cleanByNameNum(p, cond)
+		total += deleteCounter
+		if err != nil {
+			return total, err
+		}
+	}
+	if p.MaxCount != 0 {
+		deleteCounter, err := cleanByNum(pc.db, p.Table, cond, p.timeTag(), p.MaxCount)
+		if err != nil {
+			return total, err
+		}
+		total += deleteCounter
+	}
+	return total, nil
+}
+
+// cleanByNameNum keep the latest KeepLastPerName documents for each resource name
+func (pc *PolicyCleaner) cleanByNameNum(p *RetentionPolicy, cond *operator.Condition) (int64, error) {
+	// group documents by cluster, namespace and name, only groups with more than
+	// KeepLastPerName documents need to be cleaned
+	pipeline := []interface{}{
+		operator.NewBranchCondition(operator.Mat, cond),
+		operator.M{"$group": operator.M{
+			"_id": operator.M{
+				clusterIDTag: "$" + clusterIDTag,
+				namespaceTag: "$" + namespaceTag,
+				groupNameTag: "$" + p.nameTag(),
+			},
+			groupCountTag: operator.M{"$sum": 1},
+		}},
+		operator.M{"$match": operator.M{groupCountTag: operator.M{"$gt": p.KeepLastPerName}}},
+	}
+	groups := make([]operator.M, 0)
+	if err := pc.db.Table(p.Table).Aggregation(context.TODO(), pipeline, &groups); err != nil {
+		return 0, fmt.Errorf("group table %s by %s failed, err %s", p.Table, p.nameTag(), err.Error())
+	}
+
+	var total int64
+	for _, group := range groups {
+		var groupID map[string]interface{}
+		switch id := group["_id"].(type) {
+		case operator.M:
+			groupID = id
+		case map[string]interface{}:
+			groupID = id
+		default:
+			continue
+		}
+		groupCond := operator.NewBranchCondition(operator.And, cond,
+			operator.NewLeafCondition(operator.Eq, operator.M{
+				clusterIDTag: groupID[clusterIDTag],
+				namespaceTag: groupID[namespaceTag],
+				p.nameTag():  groupID[groupNameTag],
+			}))
+		deleteCounter, err := cleanByNum(pc.db, p.Table, groupCond, p.timeTag(), p.KeepLastPerName)
+		if err != nil {
+			return total, err
+		}
+		total += deleteCounter
+	}
+	return total, nil
+}
@@ -0,0 +1,60 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.,
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clean
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+)
+
+func TestRetentionPolicyValidate(t *testing.T) {
+	testCases := []struct {
+		policy  RetentionPolicy
+		isValid bool
+	}{
+		{RetentionPolicy{Table: "Pod", TTL: 3600}, true},
+		{RetentionPolicy{Table: "Pod", ClusterID: "BCS-K8S-00000", KeepLastPerName: 3}, true},
+		{RetentionPolicy{Table: "Pod"}, false},
+		{RetentionPolicy{TTL: 3600}, false},
+		{RetentionPolicy{Table: "Pod", MaxCount: -1}, false},
+		{RetentionPolicy{Table: PolicyTableName, TTL: 3600}, false},
+	}
+	for i, testCase := range testCases {
+		if err := testCase.policy.Validate(); (err == nil) != testCase.isValid {
+			t.Errorf("case %d: expect valid %v, got err %v", i, testCase.isValid, err)
+		}
+	}
+}
+
+func TestRetentionPolicyScopeCondition(t *testing.T) {
+	tablePolicy := &RetentionPolicy{Table: "Pod", TTL: 3600}
+	if tablePolicy.scopeCondition(nil) != operator.EmptyCondition {
+		t.Errorf("table policy without cluster policies should match all")
+	}
+	excluded := []string{"BCS-K8S-00000"}
+	expect := operator.NewLeafCondition(operator.Nin, operator.M{clusterIDTag: excluded})
+	if !reflect.DeepEqual(tablePolicy.scopeCondition(excluded), expect) {
+		t.Errorf("table policy should exclude clusters with their own policies")
+	}
+
+	clusterPolicy := &RetentionPolicy{Table: "Pod", ClusterID: "BCS-K8S-00000", TTL: 3600}
+	expect = operator.NewLeafCondition(operator.Eq, operator.M{clusterIDTag: "BCS-K8S-00000"})
+	if !reflect.DeepEqual(clusterPolicy.scopeCondition(excluded), expect) {
+		t.Errorf("cluster policy should match only its cluster")
+	}
+	if clusterPolicy.Name() != "Pod/BCS-K8S-00000" {
+		t.Errorf("unexpected policy name %s", clusterPolicy.Name())
+	}
+}
@@ -52,4 +52,6 @@ var (
 	RemoveLessThanMatch          = &StorageError{Code: common.AdditionErrorCode + 6320, Message: "remove less than match"}
 	UpdateLessThanMatch          = &StorageError{Code: common.AdditionErrorCode + 6321, Message: "update less than match"}
 	QueueConfigUnknown           = &StorageError{Code: common.AdditionErrorCode + 6307, Message: "queue config unknown"}
+	ResourceVersionTooOld        = &StorageError{Code: common.AdditionErrorCode + 6322, Message: "resource version is too old, please list again"}
+	ResourceVersionInvalid       = &StorageError{Code: common.AdditionErrorCode + 6323, Message: "resource version is invalid"}
 )
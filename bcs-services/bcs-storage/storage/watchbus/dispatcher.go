@@ -19,6 +19,7 @@ import (
 
 	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/drivers"
 	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+	storageErr "github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/errors"
 )
 
 // EventBus dispatch event
@@ -27,7 +28,11 @@ type EventBus struct {
 	subscribers    map[string]map[string]chan *drivers.WatchEvent
 	sublock        sync.RWMutex
 	topicListeners map[string]chan *drivers.WatchEvent
+	histories      map[string]*eventHistory
+	historySize    int
 	db             drivers.DB
+	// resumers cancel functions of change streams opened for subscribers resuming from database
+	resumers map[string]map[string]context.CancelFunc
 }
 
 // NewEventBus create event bus
@@ -35,6 +40,9 @@ func NewEventBus(db drivers.DB) *EventBus {
 	return &EventBus{
 		subscribers:    make(map[string]map[string]chan *drivers.WatchEvent),
 		topicListeners: make(map[string]chan *drivers.WatchEvent),
+		histories:      make(map[string]*eventHistory),
+		resumers:       make(map[string]map[string]context.CancelFunc),
+		historySize:    DefaultHistorySize,
 		db:             db,
 	}
 }
@@ -44,6 +52,11 @@ func (eb *EventBus) SetCondition(cond *operator.Condition) {
 	eb.cond = cond
 }
 
+// SetHistorySize set number of events kept for each topic to resume watch
+func (eb *EventBus) SetHistorySize(size int) {
+	eb.historySize = size
+}
+
 // create listener of topic
 func (eb *EventBus) createListener(topic string) (chan *drivers.WatchEvent, error) {
 	var conditionList []*operator.Condition
@@ -56,6 +69,8 @@ func (eb *EventBus) createListener(topic string) (chan *drivers.WatchEvent, erro
 	if err != nil {
 		return nil, fmt.Errorf("start listener of topic %s failed, err %s", topic, err.Error())
 	}
+	// events may be lost between the old listener and the new one, so history starts over
+	eb.histories[topic] = newEventHistory(eb.historySize)
 	go func() {
 		for {
 			select {
@@ -64,6 +79,7 @@ func (eb *EventBus) createListener(topic string) (chan *drivers.WatchEvent, erro
 				if event.Type == drivers.EventError || event.Type == drivers.EventClose {
 					eb.sublock.Lock()
 					delete(eb.topicListeners, topic)
+					delete(eb.histories, topic)
 					eb.sublock.Unlock()
 					return
 				}
@@ -97,10 +113,113 @@ func (eb *EventBus) Subscribe(topic, uuid string, ch chan *drivers.WatchEvent) e
 	return nil
 }
 
+// SubscribeFrom subscribe event for certain topic from revision,
+// it returns events after revision in history which should be consumed before events sent to ch.
+// When the revision is not covered by history in memory, e.g. after restart or on another replica,
+// a change stream of database starting at the revision is opened for this subscriber instead
+func (eb *EventBus) SubscribeFrom(topic, uuid string, revision uint64, ch chan *drivers.WatchEvent) (
+	[]*drivers.WatchEvent, error) {
+	eb.sublock.Lock()
+	if history, ok := eb.histories[topic]; ok {
+		if backlog, err := history.since(revision); err == nil {
+			defer eb.sublock.Unlock()
+			if err := eb.addSubscriber(topic, uuid, ch); err != nil {
+				return nil, err
+			}
+			return backlog, nil
+		}
+	}
+	eb.sublock.Unlock()
+	return nil, eb.resumeFromDB(topic, uuid, revision, ch)
+}
+
+// addSubscriber add subscriber channel of topic, caller must hold sublock
+func (eb *EventBus) addSubscriber(topic, uuid string, ch chan *drivers.WatchEvent) error {
+	topicChanMap, ok := eb.subscribers[topic]
+	if !ok {
+		topicChanMap = make(map[string]chan *drivers.WatchEvent)
+		eb.subscribers[topic] = topicChanMap
+	}
+	if _, idDup := topicChanMap[uuid]; idDup {
+		return fmt.Errorf("uuid %s of topic %s is duplicated", uuid, topic)
+	}
+	if _, idDup := eb.resumers[topic][uuid]; idDup {
+		return fmt.Errorf("uuid %s of topic %s is duplicated", uuid, topic)
+	}
+	topicChanMap[uuid] = ch
+	return nil
+}
+
+// resumeFromDB open change stream of database starting at revision for one subscriber,
+// the change stream is stopped when subscriber unsubscribes
+func (eb *EventBus) resumeFromDB(topic, uuid string, revision uint64, ch chan *drivers.WatchEvent) error {
+	var conditionList []*operator.Condition
+	if eb.cond != nil {
+		conditionList = append(conditionList, eb.cond)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	listenerCh, err := eb.db.Table(topic).Watch(conditionList).
+		WithFullContent(true).
+		WithStartTimestamp(uint32(revision>>32), uint32(revision)).
+		DoWatch(ctx)
+	if err != nil {
+		cancel()
+		if err == drivers.ErrWatchHistoryLost {
+			return storageErr.ResourceVersionTooOld
+		}
+		return fmt.Errorf("resume listener of topic %s from %d failed, err %s", topic, revision, err.Error())
+	}
+
+	eb.sublock.Lock()
+	if _, idDup := eb.subscribers[topic][uuid]; idDup {
+		eb.sublock.Unlock()
+		cancel()
+		return fmt.Errorf("uuid %s of topic %s is duplicated", uuid, topic)
+	}
+	if _, idDup := eb.resumers[topic][uuid]; idDup {
+		eb.sublock.Unlock()
+		cancel()
+		return fmt.Errorf("uuid %s of topic %s is duplicated", uuid, topic)
+	}
+	if _, ok := eb.resumers[topic]; !ok {
+		eb.resumers[topic] = make(map[string]context.CancelFunc)
+	}
+	eb.resumers[topic][uuid] = cancel
+	eb.sublock.Unlock()
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-listenerCh:
+				// change stream starts at the revision inclusively
+				if event.Type != drivers.EventError && event.Type != drivers.EventClose && Revision(event) <= revision {
+					continue
+				}
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+					return
+				}
+				if event.Type == drivers.EventError || event.Type == drivers.EventClose {
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
 // Unsubscribe unsubscribe topic
 func (eb *EventBus) Unsubscribe(topic, uuid string) error {
 	eb.sublock.Lock()
 	defer eb.sublock.Unlock()
+	if cancel, ok := eb.resumers[topic][uuid]; ok {
+		cancel()
+		delete(eb.resumers[topic], uuid)
+		return nil
+	}
 	if topicChanMap, ok := eb.subscribers[topic]; ok {
 		if _, idFound := topicChanMap[uuid]; !idFound {
 			return fmt.Errorf("no uuid %s of topic %s to unsubscribe", uuid, topic)
@@ -113,8 +232,13 @@ func (eb *EventBus) Unsubscribe(topic, uuid string) error {
 
 // dispatch event to subscribers
 func (eb *EventBus) dispatch(topic string, e *drivers.WatchEvent) {
-	eb.sublock.RLock()
-	defer eb.sublock.RUnlock()
+	// recording history and copying subscribers must be atomic,
+	// so that a new subscriber gets every event either from history or from its channel
+	eb.sublock.Lock()
+	defer eb.sublock.Unlock()
+	if history, ok := eb.histories[topic]; ok && e.Type != drivers.EventError && e.Type != drivers.EventClose {
+		history.add(e)
+	}
 	if topicChanMap, ok := eb.subscribers[topic]; ok {
 		// copy the slice, because we send event to chan slice in another goroutine
 		var chanList []chan *drivers.WatchEvent
@@ -0,0 +1,108 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.,
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package watchbus
+
+import (
+	"strconv"
+
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/drivers"
+	storageErr "github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/errors"
+)
+
+const (
+	// DefaultHistorySize default number of events kept for each topic to resume watch
+	DefaultHistorySize = 2000
+)
+
+// Revision returns the revision of watch event, revision is built from operation timestamp of database,
+// the higher 32 bits are seconds and the lower 32 bits are ordinal within that second
+func Revision(e *drivers.WatchEvent) uint64 {
+	return TimestampToRevision(e.OperationTime)
+}
+
+// TimestampToRevision convert database timestamp to revision
+func TimestampToRevision(ts drivers.Timestamp) uint64 {
+	return uint64(ts.Second)<<32 | uint64(ts.Index)
+}
+
+// FormatRevision format revision to resource version string
+func FormatRevision(rev uint64) string {
+	return strconv.FormatUint(rev, 10)
+}
+
+// ParseRevision parse resource version string to revision, empty string means no revision
+func ParseRevision(rv string) (uint64, error) {
+	if len(rv) == 0 {
+		return 0, nil
+	}
+	rev, err := strconv.ParseUint(rv, 10, 64)
+	if err != nil {
+		return 0, storageErr.ResourceVersionInvalid
+	}
+	return rev, nil
+}
+
+// eventHistory ring buffer of recent events of one topic
+type eventHistory struct {
+	events []*drivers.WatchEvent
+	start  int
+	size   int
+	// floor all events with revision greater than floor are kept in history
+	floor uint64
+	// ready history is ready after receiving the first event,
+	// before that we don't know which events are missed
+	ready bool
+}
+
+func newEventHistory(capacity int) *eventHistory {
+	if capacity <= 0 {
+		capacity = DefaultHistorySize
+	}
+	return &eventHistory{
+		events: make([]*drivers.WatchEvent, capacity),
+	}
+}
+
+// add add event into history, the oldest event is trimmed when history is full
+func (h *eventHistory) add(e *drivers.WatchEvent) {
+	rev := Revision(e)
+	if !h.ready {
+		h.floor = rev
+		h.ready = true
+	}
+	capacity := len(h.events)
+	if h.size == capacity {
+		h.floor = Revision(h.events[h.start])
+		h.events[h.start] = e
+		h.start = (h.start + 1) % capacity
+		return
+	}
+	h.events[(h.start+h.size)%capacity] = e
+	h.size++
+}
+
+// since returns events with revision greater than rev
+func (h *eventHistory) since(rev uint64) ([]*drivers.WatchEvent, error) {
+	if !h.ready || rev < h.floor {
+		return nil, storageErr.ResourceVersionTooOld
+	}
+	var ret []*drivers.WatchEvent
+	capacity := len(h.events)
+	for i := 0; i < h.size; i++ {
+		e := h.events[(h.start+i)%capacity]
+		if Revision(e) > rev {
+			ret = append(ret, e)
+		}
+	}
+	return ret, nil
+}
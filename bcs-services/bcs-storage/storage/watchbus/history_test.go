@@ -0,0 +1,163 @@
+/*
+ * Tencent is pleased to support the open source community by making Blueking Container Service available.,
+ * Copyright (C) 2019 THL A29 Limited, a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ * http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing, software distributed under,
+ * the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package watchbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/drivers"
+	"github.com/Tencent/bk-bcs/bcs-common/pkg/odm/operator"
+	storageErr "github.com/Tencent/bk-bcs/bcs-services/bcs-storage/storage/errors"
+)
+
+func newTestEvent(sec, index uint32) *drivers.WatchEvent {
+	return &drivers.WatchEvent{
+		Type:          drivers.EventUpdate,
+		OperationTime: drivers.Timestamp{Second: sec, Index: index},
+	}
+}
+
+// TestEventHistory test resuming events from history
+func TestEventHistory(t *testing.T) {
+	h := newEventHistory(3)
+	if _, err := h.since(0); err != storageErr.ResourceVersionTooOld {
+		t.Errorf("empty history should be too old, got %v", err)
+	}
+
+	h.add(newTestEvent(100, 1))
+	h.add(newTestEvent(100, 2))
+	h.add(newTestEvent(101, 1))
+
+	events, err := h.since(TimestampToRevision(drivers.Timestamp{Second: 100, Index: 1}))
+	if err != nil {
+		t.Fatalf("since failed, err %s", err.Error())
+	}
+	if len(events) != 2 {
+		t.Errorf("expect 2 events, got %d", len(events))
+	}
+
+	// trim the oldest event
+	h.add(newTestEvent(102, 1))
+	if _, err := h.since(TimestampToRevision(drivers.Timestamp{Second: 99, Index: 1})); err !=
+		storageErr.ResourceVersionTooOld {
+		t.Errorf("trimmed revision should be too old, got %v", err)
+	}
+	events, err = h.since(TimestampToRevision(drivers.Timestamp{Second: 100, Index: 1}))
+	if err != nil {
+		t.Fatalf("since failed, err %s", err.Error())
+	}
+	if len(events) != 3 {
+		t.Errorf("expect 3 events, got %d", len(events))
+	}
+	if Revision(events[2]) != TimestampToRevision(drivers.Timestamp{Second: 102, Index: 1}) {
+		t.Errorf("unexpected last event %+v", events[2])
+	}
+}
+
+// TestParseRevision test parsing resource version
+func TestParseRevision(t *testing.T) {
+	rev, err := ParseRevision(FormatRevision(429496729601))
+	if err != nil || rev != 429496729601 {
+		t.Errorf("parse revision failed, rev %d, err %v", rev, err)
+	}
+	if _, err := ParseRevision("abc"); err != storageErr.ResourceVersionInvalid {
+		t.Errorf("expect invalid error, got %v", err)
+	}
+}
+
+// fakeDB db whose change stream replays events from start timestamp
+type fakeDB struct {
+	drivers.DB
+	events []*drivers.WatchEvent
+	// lostBefore change history before it is trimmed
+	lostBefore uint64
+}
+
+func (db *fakeDB) Table(name string) drivers.Table {
+	return &fakeTable{db: db}
+}
+
+type fakeTable struct {
+	drivers.Table
+	db *fakeDB
+}
+
+func (t *fakeTable) Watch(conditions []*operator.Condition) drivers.Watch {
+	return &fakeWatch{db: t.db}
+}
+
+type fakeWatch struct {
+	drivers.Watch
+	db    *fakeDB
+	start uint64
+}
+
+func (w *fakeWatch) WithFullContent(isFull bool) drivers.Watch {
+	return w
+}
+
+func (w *fakeWatch) WithStartTimestamp(sec uint32, index uint32) drivers.Watch {
+	w.start = TimestampToRevision(drivers.Timestamp{Second: sec, Index: index})
+	return w
+}
+
+func (w *fakeWatch) DoWatch(ctx context.Context) (chan *drivers.WatchEvent, error) {
+	if w.start < w.db.lostBefore {
+		return nil, drivers.ErrWatchHistoryLost
+	}
+	ch := make(chan *drivers.WatchEvent, len(w.db.events))
+	for _, e := range w.db.events {
+		if Revision(e) >= w.start {
+			ch <- e
+		}
+	}
+	return ch, nil
+}
+
+// TestSubscribeFromDB test resuming from database when there is no history in memory
+func TestSubscribeFromDB(t *testing.T) {
+	db := &fakeDB{
+		events:     []*drivers.WatchEvent{newTestEvent(100, 1), newTestEvent(100, 2), newTestEvent(101, 1)},
+		lostBefore: TimestampToRevision(drivers.Timestamp{Second: 90}),
+	}
+	eb := NewEventBus(db)
+
+	ch := make(chan *drivers.WatchEvent, 10)
+	backlog, err := eb.SubscribeFrom("test", "1", TimestampToRevision(drivers.Timestamp{Second: 100, Index: 1}), ch)
+	if err != nil {
+		t.Fatalf("subscribe from db failed, err %s", err.Error())
+	}
+	if len(backlog) != 0 {
+		t.Errorf("expect no backlog, got %d", len(backlog))
+	}
+	for _, expect := range []uint32{2, 1} {
+		select {
+		case e := <-ch:
+			if e.OperationTime.Index != expect {
+				t.Errorf("unexpected event %+v", e)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("wait for event timeout")
+		}
+	}
+	if err := eb.Unsubscribe("test", "1"); err != nil {
+		t.Errorf("unsubscribe failed, err %s", err.Error())
+	}
+
+	if _, err := eb.SubscribeFrom("test", "2", TimestampToRevision(drivers.Timestamp{Second: 80}), ch); err !=
+		storageErr.ResourceVersionTooOld {
+		t.Errorf("trimmed revision in db should be too old, got %v", err)
+	}
+}